@@ -9,13 +9,35 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 
+	"kovra/internal/audit"
 	"kovra/internal/cache"
+	"kovra/internal/compliance"
 	"kovra/internal/config"
 	"kovra/internal/db"
+	"kovra/internal/export"
+	"kovra/internal/health"
+	"kovra/internal/jobs"
+	"kovra/internal/kyc"
 	"kovra/internal/ledger"
+	"kovra/internal/liquidity"
+	"kovra/internal/matching"
+	"kovra/internal/metrics"
+	"kovra/internal/models"
+	"kovra/internal/monitoring"
+	"kovra/internal/outbox"
+	"kovra/internal/rail"
+	"kovra/internal/reconciliation"
+	"kovra/internal/refund"
+	"kovra/internal/repository"
 	"kovra/internal/server"
+	"kovra/internal/statement"
+	"kovra/internal/tracing"
+	"kovra/internal/treasury"
+	"kovra/internal/webhook"
+	"kovra/migrations"
 )
 
 func main() {
@@ -52,6 +74,19 @@ func run() error {
 		zap.Int("port", cfg.Server.Port),
 	)
 
+	// Export traces to the OTLP collector, if one is configured
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer func() {
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer flushCancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Warn("failed to flush traces", zap.Error(err))
+		}
+	}()
+
 	// Connect to PostgreSQL
 	database, err := db.New(ctx, cfg.Database)
 	if err != nil {
@@ -66,6 +101,9 @@ func run() error {
 		return fmt.Errorf("ledger unavailable: %w", err)
 	}
 	defer ledgerClient.Close()
+	if err := ledgerClient.EnsureSystemAccounts(ctx); err != nil {
+		return fmt.Errorf("create system accounts: %w", err)
+	}
 
 	// Connect to Redis
 	cacheClient, err := cache.NewClient(ctx, cfg.Redis.URL)
@@ -74,21 +112,294 @@ func run() error {
 	}
 	defer cacheClient.Close()
 
+	// Load sanctions lists; screening fails closed if none are configured
+	screener := compliance.NewScreener(compliance.ScreenerConfig{
+		OFACPath:       cfg.Sanctions.OFACPath,
+		OFACAltPath:    cfg.Sanctions.OFACAltPath,
+		EUPath:         cfg.Sanctions.EUPath,
+		UKPath:         cfg.Sanctions.UKPath,
+		Threshold:      cfg.Sanctions.Threshold,
+		AliasThreshold: cfg.Sanctions.AliasThreshold,
+		MatchAliases:   cfg.Sanctions.MatchAliases,
+		AllowEmpty:     cfg.Sanctions.AllowEmpty,
+	}, logger)
+	if err := screener.Load(); err != nil {
+		return fmt.Errorf("load sanctions lists: %w", err)
+	}
+
+	// Transaction monitoring rules; the embedded defaults unless a file is configured
+	rules, err := monitoring.LoadRules(cfg.Monitoring.RulesPath)
+	if err != nil {
+		return fmt.Errorf("load monitoring rules: %w", err)
+	}
+	monitor := monitoring.NewMonitor(
+		monitoring.NewEngine(rules),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		repository.NewMonitoringAlertRepository(database.Pool()),
+	)
+
+	// Webhook signing keys are sealed in the database; seal any stored
+	// before they were
+	webhookKeys, err := webhook.NewKeyCipher(cfg.Webhook.KeyEncryptionKey)
+	if err != nil {
+		return fmt.Errorf("webhook key encryption key: %w", err)
+	}
+	if err := webhook.SealStoredKeys(ctx, repository.NewTenantRepository(database.Pool()), webhookKeys, logger); err != nil {
+		return err
+	}
+
+	// Audit trail of every mutating API call
+	trail := audit.NewTrail(repository.NewAuditRepository(database.Pool()))
+
+	// Settlement liquidity; payouts that would breach a minimum balance are held
+	liquidityService := liquidity.NewService(
+		database,
+		repository.NewLiquidityRepository(database.Pool()),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		repository.NewLegalEntityRepository(database.Pool()),
+		ledgerClient,
+		trail,
+		logger,
+	)
+
+	// Manual review of held transfers
+	cases := compliance.NewCaseService(
+		database,
+		repository.NewComplianceCaseRepository(database.Pool()),
+		repository.NewComplianceLogRepository(database.Pool()),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		liquidityService,
+		trail,
+		compliance.CaseConfig{
+			FourEyesThreshold: cfg.Compliance.FourEyesThreshold,
+			SLA:               cfg.Compliance.CaseSLA,
+			HighPrioritySLA:   cfg.Compliance.HighRiskCaseSLA,
+		},
+	)
+
+	// Daily reconciliation of the ledger against the bank and PostgreSQL
+	reconciler := reconciliation.NewService(
+		database,
+		repository.NewReconciliationRepository(database.Pool()),
+		repository.NewLegalEntityRepository(database.Pool()),
+		ledgerClient,
+		trail,
+	)
+
+	// Background jobs
+	workers := jobs.NewWorkers()
+	jobs.AddWorker(workers, outbox.NewPruneWorker(repository.NewOutboxRepository(database.Pool()), logger))
+	jobs.AddWorker(workers, compliance.NewScreenTransferWorker(
+		database,
+		screener,
+		monitor,
+		cases,
+		liquidityService,
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewTenantRepository(database.Pool()),
+		repository.NewRecipientRepository(database.Pool()),
+		repository.NewComplianceLogRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		logger,
+	))
+	jobs.AddWorker(workers, compliance.NewCaseSLAWorker(cases, logger))
+	jobs.AddWorker(workers, kyc.NewSyncWalletsWorker(
+		repository.NewTenantRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		ledgerClient,
+		logger,
+	))
+	jobs.AddWorker(workers, reconciliation.NewRunWorker(reconciler, logger))
+	jobs.AddWorker(workers, liquidity.NewCheckWorker(liquidityService, logger))
+
+	jobsConfig := jobs.Config{
+		Queues: map[string]jobs.QueueConfig{
+			jobs.DefaultQueue: {MaxWorkers: cfg.Jobs.MaxWorkers},
+			"compliance":      {MaxWorkers: cfg.Jobs.MaxWorkers},
+			"maintenance":     {MaxWorkers: 1},
+		},
+		PollInterval: cfg.Jobs.PollInterval,
+		JobTimeout:   cfg.Jobs.JobTimeout,
+		Periodic: []jobs.PeriodicJob{
+			{Interval: time.Hour, Args: outbox.PruneArgs{Retention: cfg.Jobs.OutboxRetention}},
+			{Interval: time.Minute, Args: compliance.CaseSLAArgs{}},
+			{Interval: 24 * time.Hour, Args: reconciliation.RunArgs{}},
+			{Interval: time.Hour, Args: matching.RunArgs{}},
+			{Interval: 5 * time.Minute, Args: liquidity.CheckArgs{}},
+			{Interval: time.Hour, Args: treasury.SnapshotArgs{}},
+		},
+	}
+	jobClient := jobs.NewClient(repository.NewJobRepository(database.Pool()), workers, jobsConfig, logger)
+
+	// Jobs enqueued with a transfer live in its regional database, and run
+	// there. Of the periodic jobs only those tending what each database keeps
+	// on its own, its outbox and review cases, run there too; the others span
+	// every database from the primary.
+	regionalJobsConfig := jobsConfig
+	regionalJobsConfig.Periodic = []jobs.PeriodicJob{
+		{Interval: time.Hour, Args: outbox.PruneArgs{Retention: cfg.Jobs.OutboxRetention}},
+		{Interval: time.Minute, Args: compliance.CaseSLAArgs{}},
+	}
+	regionalJobClients := make(map[models.ComplianceRegion]*jobs.Client)
+	for _, region := range database.Regions() {
+		regionalJobClients[region] = jobs.NewClient(repository.NewJobRepository(database.Pool()), workers, regionalJobsConfig, logger)
+	}
+
+	// Bank statement import; the embedded CSV layouts unless a file is configured
+	layouts, err := statement.LoadLayouts(cfg.Statements.CSVLayoutsPath)
+	if err != nil {
+		return fmt.Errorf("load statement csv layouts: %w", err)
+	}
+	importer := statement.NewImporter(
+		database,
+		repository.NewBankStatementRepository(database.Pool()),
+		repository.NewReconciliationRepository(database.Pool()),
+		repository.NewLegalEntityRepository(database.Pool()),
+		layouts,
+		jobClient,
+		trail,
+	)
+
+	// Matching of statement entries to transfers and expected deposits. Its
+	// workers need the job client, so they are added once it exists.
+	matcher := matching.NewService(
+		database,
+		repository.NewBankStatementRepository(database.Pool()),
+		repository.NewExpectedDepositRepository(database.Pool()),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewTenantRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		repository.NewLegalEntityRepository(database.Pool()),
+		jobClient,
+		trail,
+		matching.Rules{
+			DateWindow:      cfg.Statements.MatchDateWindow,
+			AmountTolerance: decimal.New(int64(cfg.Statements.MatchToleranceMinor), -2),
+		},
+	)
+	jobs.AddWorker(workers, matching.NewRunWorker(matcher, logger))
+	jobs.AddWorker(workers, matching.NewBookEntryWorker(
+		database,
+		repository.NewBankStatementRepository(database.Pool()),
+		repository.NewExpectedDepositRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		ledgerClient,
+		logger,
+	))
+
+	// Treasury: FX exposure of the FX_SETTLEMENT accounts, snapshotted
+	// hourly, and movements between system accounts
+	treasuryService := treasury.NewService(
+		database,
+		repository.NewFXExposureRepository(database.Pool()),
+		repository.NewTreasuryRepository(database.Pool()),
+		ledgerClient,
+		jobClient,
+		trail,
+		logger,
+	)
+	jobs.AddWorker(workers, treasury.NewSnapshotWorker(treasuryService, logger))
+	jobs.AddWorker(workers, treasury.NewPostMovementWorker(treasuryService, logger))
+
+	// Refunds of completed transfers, posted to the ledger in the background
+	refundService := refund.NewService(
+		database,
+		repository.NewRefundRepository(database.Pool()),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		ledgerClient,
+		jobClient,
+		trail,
+	)
+	jobs.AddWorker(workers, refund.NewPostWorker(refundService, logger))
+
+	// Payout status callbacks from the rails
+	railSecrets := make(rail.Secrets, len(cfg.Rails.CallbackSecrets))
+	for name, secret := range cfg.Rails.CallbackSecrets {
+		railSecrets[models.Rail(name)] = secret
+	}
+	railService := rail.NewService(
+		database,
+		repository.NewRailCallbackRepository(database.Pool()),
+		repository.NewTransferRepository(database.Pool()),
+		repository.NewWalletRepository(database.Pool()),
+		repository.NewOutboxRepository(database.Pool()),
+		refundService,
+		ledgerClient,
+		railSecrets,
+		cfg.Rails.CallbackTolerance,
+		logger,
+	)
+	jobs.AddWorker(workers, rail.NewVoidHoldWorker(railService, logger))
+
+	// KYC review, tenant lifecycle and transaction limits
+	kycService := kyc.NewService(
+		database,
+		repository.NewTenantRepository(database.Pool()),
+		repository.NewKYCRepository(database.Pool()),
+		repository.NewLimitRepository(database.Pool()),
+		jobClient,
+		trail,
+	)
+
+	// Readiness checks; the TigerBeetle and schema version results are cached
+	schemaVersion, err := migrations.Latest()
+	if err != nil {
+		return fmt.Errorf("read migrations: %w", err)
+	}
+	checks := append(health.Postgres(database),
+		health.Migrations(database, schemaVersion, cfg.Health.CacheTTL),
+		health.TigerBeetle(ledgerClient, cfg.Health.CacheTTL),
+		health.Redis(cacheClient),
+	)
+
 	// Create and start HTTP server
 	srv := server.New(server.Config{
-		Port:         cfg.Server.Port,
-		Pool:         database.Pool(),
-		LedgerClient: ledgerClient,
-		CacheClient:  cacheClient,
-		Logger:       logger,
+		Port:           cfg.Server.Port,
+		DB:             database,
+		Pool:           database.Pool(),
+		LedgerClient:   ledgerClient,
+		CacheClient:    cacheClient,
+		Screener:       screener,
+		Cases:          cases,
+		KYC:            kycService,
+		Trail:          trail,
+		Reconciliation: reconciler,
+		Statements:     importer,
+		Matching:       matcher,
+		Liquidity:      liquidityService,
+		Treasury:       treasuryService,
+		Refunds:        refundService,
+		Rails:          railService,
+		Exporter:       export.NewExporter(database),
+		WebhookKeys:    webhookKeys,
+		Jobs:           jobClient,
+		Health:         health.NewChecker(cfg.Health.CheckTimeout, checks...),
+		Logger:         logger,
 	})
 
+	// Metrics read on each scrape: connection pools and queue depths
+	if err := metrics.Register(
+		metrics.NewPoolCollector(database),
+		metrics.NewQueueCollector(database,
+			repository.NewJobRepository(database.Pool()),
+			repository.NewWebhookDeliveryRepository(database.Pool()),
+		),
+	); err != nil {
+		return fmt.Errorf("register metrics: %w", err)
+	}
+
 	// Start server in goroutine
 	// Start HTTP server in a separate goroutine because ListenAndServe() is BLOCKING.
 	// If run directly, it would halt the main control flow and prevent graceful shutdown.
-	errChan := make(chan error, 1)
-	// Buffered channel (size=1) to avoid goroutine deadlock
-	// if the server fails before the receiver is ready.
+	errChan := make(chan error, 2)
+	// Buffered channel (one per server) to avoid goroutine deadlock
+	// if the servers fail before the receiver is ready.
 
 	go func() {
 		// Start the server; this call blocks until the server stops or fails.
@@ -101,6 +412,80 @@ func run() error {
 		}
 	}()
 
+	// Start the admin server, serving the metrics, on its own port
+	var admin *server.Admin
+	if cfg.Server.AdminPort != 0 {
+		admin = server.NewAdmin(cfg.Server.AdminPort, logger)
+		go func() {
+			if err := admin.Start(); err != nil {
+				errChan <- fmt.Errorf("admin: %w", err)
+			}
+		}()
+	}
+
+	// Start outbox relay, on the primary and each regional database; it
+	// stops when ctx is cancelled
+	if cfg.Outbox.Enabled {
+		inProcess := outbox.NewInProcessSink()
+		inProcess.Subscribe(string(models.WebhookEventTransferStatusChanged), compliance.EnqueueOnCreate(jobClient))
+		inProcess.Subscribe(string(models.WebhookEventTransferStatusChanged), metrics.ObserveTransferEvent)
+
+		relay := outbox.NewRelay(
+			database,
+			repository.NewOutboxRepository(database.Pool()),
+			[]outbox.Sink{
+				inProcess,
+				webhook.NewSink(
+					repository.NewWebhookDeliveryRepository(database.Pool()),
+					repository.NewTenantRepository(database.Pool()),
+				),
+			},
+			outbox.Config{
+				BatchSize:    cfg.Outbox.BatchSize,
+				PollInterval: cfg.Outbox.PollInterval,
+			},
+			logger,
+		)
+		for _, dbCtx := range database.Databases(ctx) {
+			go relay.Run(dbCtx)
+		}
+	}
+
+	// Start webhook dispatcher, on the primary and each regional database;
+	// it stops when ctx is cancelled
+	if cfg.Webhook.Enabled {
+		dispatcher := webhook.NewDispatcher(
+			repository.NewWebhookDeliveryRepository(database.Pool()),
+			webhook.TenantKeyLookup(repository.NewTenantRepository(database.Pool()), webhookKeys),
+			webhook.Config{
+				MaxAttempts:    cfg.Webhook.MaxAttempts,
+				BatchSize:      cfg.Webhook.BatchSize,
+				PollInterval:   cfg.Webhook.PollInterval,
+				RequestTimeout: cfg.Webhook.RequestTimeout,
+			},
+			logger,
+		)
+		for _, dbCtx := range database.Databases(ctx) {
+			go dispatcher.Run(dbCtx)
+		}
+	}
+
+	// Reload sanctions lists when the files change
+	go screener.Watch(ctx, cfg.Sanctions.ReloadInterval)
+
+	// Start job workers; fetching stops when ctx is cancelled,
+	// running jobs are drained in jobClient.Stop below
+	if cfg.Jobs.Enabled {
+		if err := jobClient.Start(ctx); err != nil {
+			return fmt.Errorf("start job workers: %w", err)
+		}
+		for region, client := range regionalJobClients {
+			if err := client.Start(database.InRegion(ctx, region)); err != nil {
+				return fmt.Errorf("start %s job workers: %w", region, err)
+			}
+		}
+	}
+
 	logger.Info("kovra ready",
 		zap.Int("port", cfg.Server.Port),
 	)
@@ -108,6 +493,12 @@ func run() error {
 	// Wait for shutdown signal or error
 	select {
 	case err := <-errChan:
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer stopCancel()
+		jobClient.Stop(stopCtx)
+		for _, client := range regionalJobClients {
+			client.Stop(stopCtx)
+		}
 		return fmt.Errorf("server error: %w", err)
 	case <-ctx.Done():
 		logger.Info("shutdown signal received")
@@ -120,6 +511,20 @@ func run() error {
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("shutdown server: %w", err)
 	}
+	if admin != nil {
+		if err := admin.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("shutdown admin server: %w", err)
+		}
+	}
+
+	if err := jobClient.Stop(shutdownCtx); err != nil {
+		return err
+	}
+	for _, client := range regionalJobClients {
+		if err := client.Stop(shutdownCtx); err != nil {
+			return err
+		}
+	}
 
 	logger.Info("shutdown complete")
 	return nil
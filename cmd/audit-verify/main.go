@@ -0,0 +1,91 @@
+// Command audit-verify walks the audit trail's hash chains in the primary and
+// every regional database and reports any entry that was modified or deleted.
+// It exits with status 1 if a chain is broken.
+//
+//	audit-verify            verify every region
+//	audit-verify -region EU verify one region
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"kovra/internal/audit"
+	"kovra/internal/config"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+func main() {
+	ok, err := run()
+	if err != nil {
+		log.Fatalf("failed to run: %v", err)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// run verifies the requested chains and returns false if any is broken.
+func run() (bool, error) {
+	region := flag.String("region", "", "compliance region to verify (default: all)")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return false, fmt.Errorf("load config: %w", err)
+	}
+
+	database, err := db.New(ctx, cfg.Database)
+	if err != nil {
+		return false, fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	repo := repository.NewAuditRepository(database.Pool())
+
+	// Each database continues its own chains
+	ok := true
+	for _, dbCtx := range database.Databases(ctx) {
+		name := database.Name(dbCtx)
+
+		heads, err := repo.ListChainHeads(dbCtx)
+		if err != nil {
+			return false, fmt.Errorf("list audit chains of %s: %w", name, err)
+		}
+
+		for _, h := range heads {
+			if *region != "" && h.ComplianceRegion != models.ComplianceRegion(strings.ToUpper(*region)) {
+				continue
+			}
+
+			report, err := audit.Verify(dbCtx, repo, h.ComplianceRegion)
+			if err != nil {
+				return false, fmt.Errorf("verify %s in %s: %w", h.ComplianceRegion, name, err)
+			}
+
+			fmt.Printf("%s/%s: %d entries, head %d %s\n", name, report.Region, report.Entries, report.HeadSeq, hex.EncodeToString(report.HeadHash))
+			for _, p := range report.Problems {
+				fmt.Printf("  seq %d: %s: %s\n", p.Seq, p.Kind, p.Detail)
+			}
+			if !report.OK() {
+				ok = false
+			}
+		}
+	}
+
+	if ok {
+		fmt.Println("all audit chains intact")
+	} else {
+		fmt.Println("audit trail has been tampered with")
+	}
+	return ok, nil
+}
@@ -0,0 +1,87 @@
+// Command region-setup subscribes each regional database configured in
+// DATABASE_REGION_URLS to the reference data published by the primary, so
+// that transfers written there can read their tenants, wallets and
+// recipients. Run it once the migrations have been applied to every
+// database; regions already subscribed are left alone.
+//
+//	region-setup
+//	region-setup -publisher "host=postgres dbname=kovra user=kovra password=..."
+//
+// The publisher connection string is used by the regional Postgres servers,
+// not by this command, so it must name the primary as they reach it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"kovra/internal/config"
+	"kovra/internal/db"
+)
+
+// publication is the publication of the reference data on the primary,
+// created by migration 00032.
+const publication = "kovra_reference"
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("failed to run: %v", err)
+	}
+}
+
+func run() error {
+	publisher := flag.String("publisher", "", "connection string of the primary as the regional databases reach it (default: DATABASE_URL)")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if *publisher == "" {
+		*publisher = cfg.Database.URL
+	}
+
+	database, err := db.New(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	regions := database.Regions()
+	if len(regions) == 0 {
+		return fmt.Errorf("no regional databases configured in DATABASE_REGION_URLS")
+	}
+
+	for _, region := range regions {
+		pool := database.PoolFor(database.InRegion(ctx, region))
+		name := publication + "_" + strings.ToLower(string(region))
+
+		var exists bool
+		if err := pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_subscription WHERE subname = $1)", name).Scan(&exists); err != nil {
+			return fmt.Errorf("%s: check subscription: %w", region, err)
+		}
+		if exists {
+			fmt.Printf("%s: already subscribed as %s\n", region, name)
+			continue
+		}
+
+		// CREATE SUBSCRIPTION takes no parameters, and cannot run in a
+		// transaction block
+		create := fmt.Sprintf("CREATE SUBSCRIPTION %s CONNECTION %s PUBLICATION %s", name, quoteLiteral(*publisher), publication)
+		if _, err := pool.Exec(ctx, create); err != nil {
+			return fmt.Errorf("%s: create subscription: %w", region, err)
+		}
+		fmt.Printf("%s: subscribed as %s\n", region, name)
+	}
+	return nil
+}
+
+// quoteLiteral quotes s as an SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,105 @@
+// Command statement-import imports bank statement files of the FBO and
+// Nostro accounts for reconciliation.
+//
+//	statement-import -format camt053 -by ops@kovra statement.xml
+//	statement-import -format csv -layout id-fbo -account 1234567890 -by ops@kovra export.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/auth"
+	"kovra/internal/config"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/repository"
+	"kovra/internal/statement"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalf("failed to run: %v", err)
+	}
+}
+
+func run() error {
+	format := flag.String("format", "", "statement format: camt053, mt940 or csv")
+	layout := flag.String("layout", statement.DefaultLayout, "csv layout")
+	account := flag.String("account", "", "IBAN or account number, for csv layouts without an account column")
+	importedBy := flag.String("by", "", "who is importing the statements")
+	flag.Parse()
+
+	if !statement.Format(*format).IsValid() {
+		return fmt.Errorf("-format must be camt053, mt940 or csv")
+	}
+	if *importedBy == "" {
+		return fmt.Errorf("-by is required")
+	}
+	if flag.NArg() == 0 {
+		return fmt.Errorf("no statement files given")
+	}
+
+	ctx := auth.WithActor(context.Background(), auth.Actor{Type: auth.ActorTypeOperator, ID: *importedBy})
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	layouts, err := statement.LoadLayouts(cfg.Statements.CSVLayoutsPath)
+	if err != nil {
+		return err
+	}
+
+	database, err := db.New(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer database.Close()
+
+	importer := statement.NewImporter(
+		database,
+		repository.NewBankStatementRepository(database.Pool()),
+		repository.NewReconciliationRepository(database.Pool()),
+		repository.NewLegalEntityRepository(database.Pool()),
+		layouts,
+		// Insert-only: the API's job workers run the matching this enqueues
+		jobs.NewClient(repository.NewJobRepository(database.Pool()), nil, jobs.Config{}, zap.NewNop()),
+		audit.NewTrail(repository.NewAuditRepository(database.Pool())),
+	)
+
+	for _, path := range flag.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		statements, err := importer.Import(ctx, statement.ImportParams{
+			Format:     statement.Format(*format),
+			Layout:     *layout,
+			Account:    *account,
+			ImportedBy: *importedBy,
+			Data:       data,
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		for _, s := range statements {
+			closing := "-"
+			if s.ClosingBalance != nil {
+				closing = s.ClosingBalance.StringFixed(2)
+			}
+			fmt.Printf("%s: %s %s %s to %s, %d entries, closing balance %s\n",
+				path, s.AccountKind, s.AccountIdentifier, s.Currency, s.ToDate.Format("2006-01-02"), s.EntryCount, closing)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/compliance"
+	"kovra/internal/db"
+	"kovra/internal/liquidity"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// TestComplianceCaseDecide checks the decisions on a review case: the
+// four-eyes approval of a high-risk case, rejection and escalation.
+func TestComplianceCaseDecide(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	database := db.FromPool(tc.pool)
+	transferRepo := repository.NewTransferRepository(tc.pool)
+	outboxRepo := repository.NewOutboxRepository(tc.pool)
+	trail := audit.NewTrail(repository.NewAuditRepository(tc.pool))
+	cases := compliance.NewCaseService(
+		database,
+		repository.NewComplianceCaseRepository(tc.pool),
+		repository.NewComplianceLogRepository(tc.pool),
+		transferRepo,
+		outboxRepo,
+		liquidity.NewService(database, repository.NewLiquidityRepository(tc.pool), transferRepo, outboxRepo,
+			repository.NewLegalEntityRepository(tc.pool), nil, trail, zap.NewNop()),
+		trail,
+		compliance.CaseConfig{FourEyesThreshold: 80, SLA: 24 * time.Hour, HighPrioritySLA: time.Hour},
+	)
+
+	// openCase holds a new transfer for review with the given risk score
+	openCase := func(t *testing.T, riskScore int) *models.ComplianceCase {
+		t.Helper()
+		key := "case-" + uuid.NewString()
+		transfer, err := transferRepo.Create(ctx, models.CreateTransferParams{
+			TenantID:       EuroFintechTenantID,
+			IdempotencyKey: &key,
+			FromCurrency:   "EUR",
+			ToCurrency:     "EUR",
+			FromAmount:     decimal.NewFromInt(100),
+			ToAmount:       decimal.NewFromInt(100),
+			FXRate:         decimal.NewFromInt(1),
+			TotalFee:       decimal.Zero,
+		})
+		require.NoError(t, err)
+		require.NoError(t, transferRepo.UpdateStatus(ctx, transfer.ID, models.TransferStatusValidating, nil))
+
+		c, err := db.WithTxResult(ctx, database, func(tx pgx.Tx) (*models.ComplianceCase, error) {
+			return cases.OpenTx(ctx, tx, transfer, models.CaseReasonSanctionsHit, riskScore)
+		})
+		require.NoError(t, err)
+		return c
+	}
+
+	transferStatus := func(t *testing.T, c *models.ComplianceCase) models.TransferStatus {
+		t.Helper()
+		transfer, err := transferRepo.GetByID(ctx, c.TransferID)
+		require.NoError(t, err)
+		return transfer.Status
+	}
+
+	approve := func(reviewer string) compliance.Decision {
+		return compliance.Decision{Decision: models.CaseDecisionApprove, Reviewer: reviewer}
+	}
+
+	t.Run("four-eyes approval", func(t *testing.T) {
+		c := openCase(t, 90)
+		require.True(t, c.RequiresSecondApproval)
+
+		got, err := cases.Decide(ctx, c.ID, approve("reviewer-1"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusPendingApproval, got.Status, "the first approval awaits a second reviewer")
+		assert.Equal(t, models.TransferStatusValidating, transferStatus(t, c))
+
+		_, err = cases.Decide(ctx, c.ID, approve("reviewer-1"))
+		assert.ErrorIs(t, err, compliance.ErrSecondReviewerRequired)
+
+		got, err = cases.Decide(ctx, c.ID, approve("reviewer-2"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusApproved, got.Status)
+		require.NotNil(t, got.DecidedBy)
+		assert.Equal(t, "reviewer-1", *got.DecidedBy)
+		require.NotNil(t, got.ApprovedBy)
+		assert.Equal(t, "reviewer-2", *got.ApprovedBy)
+		assert.Equal(t, models.TransferStatusProcessing, transferStatus(t, c))
+	})
+
+	t.Run("single approval", func(t *testing.T) {
+		c := openCase(t, 10)
+		require.False(t, c.RequiresSecondApproval)
+
+		got, err := cases.Decide(ctx, c.ID, approve("reviewer-1"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusApproved, got.Status)
+		assert.Equal(t, models.TransferStatusProcessing, transferStatus(t, c))
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		c := openCase(t, 10)
+
+		_, err := cases.Decide(ctx, c.ID, compliance.Decision{Decision: models.CaseDecisionReject, Reviewer: "reviewer-1"})
+		assert.ErrorIs(t, err, compliance.ErrReasonRequired)
+
+		reason := "confirmed sanctions match"
+		got, err := cases.Decide(ctx, c.ID, compliance.Decision{Decision: models.CaseDecisionReject, Reason: &reason, Reviewer: "reviewer-1"})
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusRejected, got.Status)
+		assert.Equal(t, models.TransferStatusRejected, transferStatus(t, c))
+
+		_, err = cases.Decide(ctx, c.ID, approve("reviewer-2"))
+		assert.ErrorIs(t, err, compliance.ErrCaseClosed)
+	})
+
+	t.Run("escalate", func(t *testing.T) {
+		c := openCase(t, 10)
+		require.Equal(t, models.CasePriorityNormal, c.Priority)
+
+		got, err := cases.Decide(ctx, c.ID, compliance.Decision{Decision: models.CaseDecisionEscalate, Reviewer: "reviewer-1"})
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusEscalated, got.Status)
+		assert.Equal(t, models.CasePriorityHigh, got.Priority)
+		assert.True(t, got.RequiresSecondApproval, "an escalated case needs four eyes")
+		assert.True(t, got.DueAt.Before(c.DueAt), "an escalated case is due sooner")
+		assert.Equal(t, models.TransferStatusValidating, transferStatus(t, c))
+
+		got, err = cases.Decide(ctx, c.ID, approve("reviewer-1"))
+		require.NoError(t, err)
+		assert.Equal(t, models.CaseStatusPendingApproval, got.Status)
+	})
+}
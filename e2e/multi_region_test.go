@@ -0,0 +1,167 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/auth"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/server"
+)
+
+// TestMultiRegionRouting checks that a transfer written in its region's
+// database is found there, and only there, by the reads that route to it.
+// It needs the regional databases of "make setup-regions", migrated and
+// subscribed to the primary's reference data, listed in
+// TEST_REGION_DATABASE_URLS as REGION=url pairs.
+func TestMultiRegionRouting(t *testing.T) {
+	urls := os.Getenv("TEST_REGION_DATABASE_URLS")
+	if urls == "" {
+		t.Skip("TEST_REGION_DATABASE_URLS not set")
+	}
+
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	regions := make(map[models.ComplianceRegion]*pgxpool.Pool)
+	for _, pair := range strings.Split(urls, ",") {
+		name, url, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		require.True(t, ok, "invalid region database %q", pair)
+		pool, err := pgxpool.New(ctx, url)
+		require.NoError(t, err)
+		defer pool.Close()
+		regions[models.ComplianceRegion(strings.ToUpper(name))] = pool
+	}
+	require.Contains(t, regions, models.ComplianceRegionEU, "an EU database is required")
+
+	database := db.FromPool(tc.pool).WithRegions(regions)
+	transferRepo := repository.NewTransferRepository(tc.pool)
+
+	key := "multi-region-" + uuid.NewString()
+	euCtx := database.InRegion(ctx, models.ComplianceRegionEU)
+	transfer, err := transferRepo.Create(euCtx, models.CreateTransferParams{
+		TenantID:       EuroFintechTenantID,
+		IdempotencyKey: &key,
+		FromCurrency:   "EUR",
+		ToCurrency:     "EUR",
+		FromAmount:     decimal.NewFromInt(100),
+		ToAmount:       decimal.NewFromInt(100),
+		FXRate:         decimal.NewFromInt(1),
+		TotalFee:       decimal.Zero,
+	})
+	require.NoError(t, err)
+
+	t.Run("written in its region", func(t *testing.T) {
+		got, err := transferRepo.GetByID(ctx, transfer.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got, "EU transfer written to the primary")
+
+		got, err = transferRepo.GetByID(euCtx, transfer.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+
+	t.Run("located", func(t *testing.T) {
+		located, err := database.Locate(ctx, func(ctx context.Context) (bool, error) {
+			region, err := transferRepo.GetRegion(ctx, transfer.ID)
+			return region != nil, err
+		})
+		require.NoError(t, err)
+		assert.Same(t, regions[models.ComplianceRegionEU], database.PoolFor(located))
+	})
+
+	t.Run("listed across regions", func(t *testing.T) {
+		var pages []models.Page[*models.Transfer]
+		for _, c := range database.Databases(ctx) {
+			page, err := transferRepo.ListByTenant(c, EuroFintechTenantID, models.TransferFilter{})
+			require.NoError(t, err)
+			pages = append(pages, page)
+		}
+		merged := repository.MergePages(pages, 0, func(t *models.Transfer) uuid.UUID { return t.ID })
+		require.NotEmpty(t, merged.Items)
+		assert.Equal(t, transfer.ID, merged.Items[0].ID, "newest transfer first")
+	})
+
+	t.Run("API", func(t *testing.T) {
+		key := "op-" + uuid.NewString()
+		_, err := repository.NewOperatorRepository(tc.pool).Create(ctx, models.CreateOperatorParams{
+			Name:       "Global operations",
+			APIKeyHash: auth.HashAPIKey(key),
+		})
+		require.NoError(t, err)
+
+		logger, _ := zap.NewDevelopment()
+		srv := server.New(server.Config{
+			DB:     database,
+			Pool:   tc.pool,
+			Logger: logger,
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transfers/"+transfer.ID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		w := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// A regional operator reads the transfers of their region on its
+		// database, and writes reference data on the primary
+		euKey := "op-eu-" + uuid.NewString()
+		eu := models.ComplianceRegionEU
+		_, err = repository.NewOperatorRepository(tc.pool).Create(ctx, models.CreateOperatorParams{
+			Name:             "EU operations",
+			APIKeyHash:       auth.HashAPIKey(euKey),
+			ComplianceRegion: &eu,
+		})
+		require.NoError(t, err)
+
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/transfers/"+transfer.ID.String(), nil)
+		req.Header.Set("Authorization", "Bearer "+euKey)
+		w = httptest.NewRecorder()
+		srv.Handler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		body := `{"tenant_id":"` + EuroFintechTenantID.String() + `","name":"Regional write","country":"DE"}`
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/recipients", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+euKey)
+		w = httptest.NewRecorder()
+		srv.Handler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+		var recipient models.Recipient
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &recipient))
+		got, err := repository.NewRecipientRepository(tc.pool).GetByID(ctx, recipient.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got, "recipient written on the primary")
+
+		tenantKey := "tenant-" + uuid.NewString()
+		_, err = tc.pool.Exec(ctx, `UPDATE tenants SET api_key_hash = $1 WHERE id = $2`, auth.HashAPIKey(tenantKey), BritPayTenantID)
+		require.NoError(t, err)
+
+		for path, want := range map[string]int{
+			"/api/v1/transfers/" + transfer.ID.String():       http.StatusForbidden,
+			"/api/v1/tenants/" + EuroFintechTenantID.String(): http.StatusForbidden,
+			"/api/v1/tenants/" + BritPayTenantID.String():     http.StatusOK,
+			"/api/v1/admin/outbox/stats":                      http.StatusForbidden,
+		} {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", "Bearer "+tenantKey)
+			w := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(w, req)
+			assert.Equal(t, want, w.Code, "a tenant key on %s", path)
+		}
+	})
+}
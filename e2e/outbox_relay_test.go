@@ -0,0 +1,173 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/repository"
+)
+
+// recordingSink records the events of the aggregates it watches in the order
+// it is handed them, and fails those of the aggregates in failing.
+type recordingSink struct {
+	mu        sync.Mutex
+	watched   map[uuid.UUID]bool
+	failing   map[uuid.UUID]bool
+	published []string
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Publish(_ context.Context, _ pgx.Tx, event *models.OutboxEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.watched[event.AggregateID] {
+		return nil
+	}
+	if s.failing[event.AggregateID] {
+		return errors.New("sink unavailable")
+	}
+	s.published = append(s.published, event.EventType)
+	return nil
+}
+
+func (s *recordingSink) events() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.published...)
+}
+
+// relayedEvent is the delivery state of an outbox event.
+type relayedEvent struct {
+	attempts  int
+	lastError *string
+	published bool
+}
+
+// TestOutboxRelay checks that the relay publishes the events of an aggregate
+// in order, holds back the events behind one that failed, and retries it.
+func TestOutboxRelay(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	repo := repository.NewOutboxRepository(tc.pool)
+
+	// appendEvents appends events of the given types to a new aggregate
+	appendEvents := func(t *testing.T, types ...string) uuid.UUID {
+		t.Helper()
+		aggregateID := uuid.New()
+		for _, eventType := range types {
+			params, err := outbox.NewEvent(models.AggregateTransfer, aggregateID, nil, eventType, map[string]string{"type": eventType})
+			require.NoError(t, err)
+			require.NoError(t, repo.Append(ctx, params))
+		}
+		return aggregateID
+	}
+
+	newRelay := func(sink *recordingSink) *outbox.Relay {
+		return outbox.NewRelay(db.FromPool(tc.pool), repo, []outbox.Sink{sink}, outbox.Config{}, zap.NewNop())
+	}
+
+	// drain relays until a batch claims nothing
+	drain := func(t *testing.T, relay *outbox.Relay) {
+		t.Helper()
+		for i := 0; i < 10; i++ {
+			n, err := relay.RelayOnce(ctx)
+			require.NoError(t, err)
+			if n == 0 {
+				return
+			}
+		}
+	}
+
+	eventsOf := func(t *testing.T, aggregateID uuid.UUID) []relayedEvent {
+		t.Helper()
+		rows, err := tc.pool.Query(ctx,
+			`SELECT attempts, last_error, published_at IS NOT NULL FROM outbox WHERE aggregate_id = $1 ORDER BY seq`, aggregateID)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var out []relayedEvent
+		for rows.Next() {
+			var e relayedEvent
+			require.NoError(t, rows.Scan(&e.attempts, &e.lastError, &e.published))
+			out = append(out, e)
+		}
+		require.NoError(t, rows.Err())
+		return out
+	}
+
+	t.Run("ordered within an aggregate", func(t *testing.T) {
+		aggregateID := appendEvents(t, "first", "second", "third")
+		sink := &recordingSink{watched: map[uuid.UUID]bool{aggregateID: true}}
+
+		drain(t, newRelay(sink))
+
+		assert.Equal(t, []string{"first", "second", "third"}, sink.events())
+		for _, e := range eventsOf(t, aggregateID) {
+			assert.True(t, e.published)
+		}
+	})
+
+	t.Run("failure holds back later events", func(t *testing.T) {
+		blocked := appendEvents(t, "blocked-first", "blocked-second")
+		other := appendEvents(t, "other")
+		sink := &recordingSink{
+			watched: map[uuid.UUID]bool{blocked: true, other: true},
+			failing: map[uuid.UUID]bool{blocked: true},
+		}
+
+		drain(t, newRelay(sink))
+
+		assert.Equal(t, []string{"other"}, sink.events(), "other aggregates are not held back")
+		events := eventsOf(t, blocked)
+		require.Len(t, events, 2)
+		assert.False(t, events[0].published)
+		assert.Equal(t, 1, events[0].attempts)
+		require.NotNil(t, events[0].lastError)
+		assert.Contains(t, *events[0].lastError, "sink unavailable")
+		assert.False(t, events[1].published, "an event behind a failed one is not published")
+		assert.Zero(t, events[1].attempts, "an event behind a failed one is not attempted")
+	})
+
+	t.Run("retried after the delay", func(t *testing.T) {
+		aggregateID := appendEvents(t, "retried-first", "retried-second")
+		sink := &recordingSink{
+			watched: map[uuid.UUID]bool{aggregateID: true},
+			failing: map[uuid.UUID]bool{aggregateID: true},
+		}
+		relay := newRelay(sink)
+		drain(t, relay)
+		require.Empty(t, sink.events())
+
+		// Not retried before its delay has passed
+		sink.mu.Lock()
+		sink.failing = nil
+		sink.mu.Unlock()
+		drain(t, relay)
+		assert.Empty(t, sink.events())
+
+		_, err := tc.pool.Exec(ctx, `UPDATE outbox SET available_at = NOW() WHERE aggregate_id = $1`, aggregateID)
+		require.NoError(t, err)
+		drain(t, relay)
+
+		assert.Equal(t, []string{"retried-first", "retried-second"}, sink.events())
+		events := eventsOf(t, aggregateID)
+		require.Len(t, events, 2)
+		assert.True(t, events[0].published)
+		assert.Equal(t, 1, events[0].attempts, "attempts count the failures")
+		assert.True(t, events[1].published)
+	})
+}
@@ -20,9 +20,14 @@ import (
 
 	"github.com/shopspring/decimal"
 
+	"kovra/internal/audit"
+	"kovra/internal/auth"
 	"kovra/internal/cache"
 	"kovra/internal/config"
+	"kovra/internal/db"
 	"kovra/internal/handler"
+	"kovra/internal/jobs"
+	"kovra/internal/kyc"
 	"kovra/internal/ledger"
 	"kovra/internal/repository"
 )
@@ -113,13 +118,29 @@ func setupRouter(tc *testContext) chi.Router {
 	walletRepo := repository.NewWalletRepository(tc.pool)
 	transferRepo := repository.NewTransferRepository(tc.pool)
 
+	database := db.FromPool(tc.pool)
+	trail := audit.NewTrail(repository.NewAuditRepository(tc.pool))
+
 	legalEntityHandler := handler.NewLegalEntityHandler(legalEntityRepo)
-	tenantHandler := handler.NewTenantHandler(tenantRepo)
-	walletHandler := handler.NewWalletHandler(walletRepo, tc.ledgerClient)
-	transferHandler := handler.NewTransferHandler(transferRepo, walletRepo)
+	tenantHandler := handler.NewTenantHandler(database, tenantRepo, trail)
+	jobClient := jobs.NewClient(repository.NewJobRepository(tc.pool), nil, jobs.Config{}, logger)
+	kycService := kyc.NewService(database, tenantRepo,
+		repository.NewKYCRepository(tc.pool), repository.NewLimitRepository(tc.pool), jobClient, trail)
+	walletHandler := handler.NewWalletHandler(database, walletRepo, tc.ledgerClient, kycService, trail)
+	outboxRepo := repository.NewOutboxRepository(tc.pool)
+	transferHandler := handler.NewTransferHandler(database, transferRepo, walletRepo, outboxRepo,
+		repository.NewLiquidityRepository(tc.pool), jobClient, kycService, trail)
 
 	r := chi.NewRouter()
 
+	// The demo calls the API as an operator, which acts for any tenant
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := auth.WithActor(r.Context(), auth.Actor{Type: auth.ActorTypeOperator, ID: "e2e-demo"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Get("/legal-entities", legalEntityHandler.List)
 		r.Get("/legal-entities/{id}", legalEntityHandler.Get)
@@ -138,6 +159,7 @@ func setupRouter(tc *testContext) chi.Router {
 
 		r.Post("/transfers", transferHandler.Create)
 		r.Get("/transfers/{id}", transferHandler.Get)
+		r.Post("/transfers/{id}/cancel", transferHandler.Cancel)
 	})
 
 	_ = logger // suppress unused warning
@@ -570,12 +592,14 @@ func testMultiCurrencyAccounts(t *testing.T, tc *testContext) {
 
 	require.Equal(t, http.StatusOK, w.Code)
 
-	var wallets []map[string]any
-	err := json.Unmarshal(w.Body.Bytes(), &wallets)
+	var page struct {
+		Items []map[string]any `json:"items"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &page)
 	require.NoError(t, err)
 
-	t.Logf("✓ EuroFintech has %d wallets", len(wallets))
-	for _, w := range wallets {
+	t.Logf("✓ EuroFintech has %d wallets", len(page.Items))
+	for _, w := range page.Items {
 		t.Logf("  - %s wallet: %s", w["currency"], w["id"])
 	}
 }
@@ -0,0 +1,139 @@
+package e2e
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/refund"
+	"kovra/internal/repository"
+)
+
+// refundLedger accepts every refund posted to it.
+type refundLedger struct{}
+
+func (refundLedger) PostRefund(context.Context, ledger.Refund) error { return nil }
+
+// TestRefundLimits checks that a refund request repeated with its
+// idempotency key is recorded once, and that the refunds of a transfer do
+// not exceed its payout and fee.
+func TestRefundLimits(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	transferRepo := repository.NewTransferRepository(tc.pool)
+	walletRepo := repository.NewWalletRepository(tc.pool)
+	refundRepo := repository.NewRefundRepository(tc.pool)
+	service := refund.NewService(
+		db.FromPool(tc.pool),
+		refundRepo,
+		transferRepo,
+		walletRepo,
+		repository.NewOutboxRepository(tc.pool),
+		refundLedger{},
+		jobs.NewClient(repository.NewJobRepository(tc.pool), nil, jobs.Config{}, zap.NewNop()),
+		audit.NewTrail(repository.NewAuditRepository(tc.pool)),
+	)
+
+	// Refunds are credited to the tenant's wallet in the source currency
+	wallet, err := walletRepo.GetByTenantAndCurrency(ctx, EuroFintechTenantID, "EUR")
+	require.NoError(t, err)
+	if wallet == nil {
+		_, err = walletRepo.Create(ctx, models.CreateWalletParams{
+			TenantID:    EuroFintechTenantID,
+			Currency:    "EUR",
+			TBAccountID: ledger.NewAccountID(binary.BigEndian.Uint64(EuroFintechTenantID[8:]), ledger.AccountTypeTenantWallet, ledger.CurrencyFromString("EUR")).ToBigInt(),
+		})
+		require.NoError(t, err)
+	}
+
+	// completedTransfer creates a transfer of 100 EUR with a fee of 2 EUR
+	completedTransfer := func(t *testing.T) *models.Transfer {
+		t.Helper()
+		key := "refund-" + uuid.NewString()
+		transfer, err := transferRepo.Create(ctx, models.CreateTransferParams{
+			TenantID:       EuroFintechTenantID,
+			IdempotencyKey: &key,
+			FromCurrency:   "EUR",
+			ToCurrency:     "EUR",
+			FromAmount:     decimal.NewFromInt(100),
+			ToAmount:       decimal.NewFromInt(98),
+			FXRate:         decimal.NewFromInt(1),
+			TotalFee:       decimal.NewFromInt(2),
+		})
+		require.NoError(t, err)
+		require.NoError(t, transferRepo.UpdateStatus(ctx, transfer.ID, models.TransferStatusCompleted, nil))
+		return transfer
+	}
+
+	amount := func(v int64) *decimal.Decimal {
+		d := decimal.NewFromInt(v)
+		return &d
+	}
+
+	refunds := func(t *testing.T, transferID uuid.UUID) []*models.Refund {
+		t.Helper()
+		list, err := refundRepo.ListByTransfer(ctx, transferID)
+		require.NoError(t, err)
+		return list
+	}
+
+	t.Run("idempotent", func(t *testing.T) {
+		transfer := completedTransfer(t)
+		key := "refund-request-1"
+		req := refund.Request{Amount: amount(40), Reason: "customer request", IdempotencyKey: &key}
+
+		first, err := service.Refund(ctx, transfer.ID, req)
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(40).Equal(first.Amount))
+		assert.True(t, first.FeeAmount.IsZero())
+
+		second, err := service.Refund(ctx, transfer.ID, req)
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID, "a repeated request returns the first refund")
+		assert.Len(t, refunds(t, transfer.ID), 1)
+	})
+
+	t.Run("exceeds refundable", func(t *testing.T) {
+		transfer := completedTransfer(t)
+
+		_, err := service.Refund(ctx, transfer.ID, refund.Request{Amount: amount(99), Reason: "customer request"})
+		assert.ErrorIs(t, err, refund.ErrExceedsRefundable, "the fee is not part of the refundable payout")
+
+		_, err = service.Refund(ctx, transfer.ID, refund.Request{Amount: amount(60), Reason: "customer request"})
+		require.NoError(t, err)
+		_, err = service.Refund(ctx, transfer.ID, refund.Request{Amount: amount(39), Reason: "customer request"})
+		assert.ErrorIs(t, err, refund.ErrExceedsRefundable)
+
+		// The remainder and the fee are still refundable
+		last, err := service.Refund(ctx, transfer.ID, refund.Request{ReverseFee: true, Reason: "customer request"})
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(38).Equal(last.Amount))
+		assert.True(t, decimal.NewFromInt(2).Equal(last.FeeAmount))
+
+		_, err = service.Refund(ctx, transfer.ID, refund.Request{ReverseFee: true, Reason: "customer request"})
+		assert.ErrorIs(t, err, refund.ErrExceedsRefundable, "a fully refunded transfer has nothing left")
+		assert.Len(t, refunds(t, transfer.ID), 2)
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		transfer := completedTransfer(t)
+		require.NoError(t, transferRepo.UpdateStatus(ctx, transfer.ID, models.TransferStatusProcessing, nil))
+
+		_, err := service.Refund(ctx, transfer.ID, refund.Request{Reason: "customer request"})
+		assert.ErrorIs(t, err, refund.ErrTransferNotCompleted)
+		assert.Empty(t, refunds(t, transfer.ID))
+	})
+}
@@ -0,0 +1,176 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/auth"
+	"kovra/internal/db"
+	"kovra/internal/export"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/server"
+)
+
+// TestRegionIsolation checks that the queries of a regional operator run as
+// the region's database role, so that transfers of other regions are
+// invisible to them however they are read.
+func TestRegionIsolation(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	database := db.FromPool(tc.pool)
+	transferRepo := repository.NewTransferRepository(tc.pool)
+
+	createTransfer := func(currency string) *models.Transfer {
+		key := "region-isolation-" + uuid.NewString()
+		transfer, err := transferRepo.Create(ctx, models.CreateTransferParams{
+			TenantID:       EuroFintechTenantID,
+			IdempotencyKey: &key,
+			FromCurrency:   currency,
+			ToCurrency:     currency,
+			FromAmount:     decimal.NewFromInt(100),
+			ToAmount:       decimal.NewFromInt(100),
+			FXRate:         decimal.NewFromInt(1),
+			TotalFee:       decimal.Zero,
+		})
+		require.NoError(t, err)
+		return transfer
+	}
+	euTransfer := createTransfer("EUR")
+	idTransfer := createTransfer("IDR")
+	require.Equal(t, models.ComplianceRegionEU, euTransfer.ComplianceRegion)
+	require.Equal(t, models.ComplianceRegionID, idTransfer.ComplianceRegion)
+
+	euCtx := db.Confine(ctx, models.ComplianceRegionEU)
+
+	t.Run("repository reads", func(t *testing.T) {
+		got, err := transferRepo.GetByID(euCtx, euTransfer.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+
+		got, err = transferRepo.GetByID(euCtx, idTransfer.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got, "EU context read an ID transfer")
+
+		page, err := transferRepo.ListByTenant(euCtx, EuroFintechTenantID, models.TransferFilter{})
+		require.NoError(t, err)
+		for _, tr := range page.Items {
+			assert.Equal(t, models.ComplianceRegionEU, tr.ComplianceRegion)
+		}
+
+		// The unconfined app user still sees every region
+		got, err = transferRepo.GetByID(ctx, idTransfer.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got)
+	})
+
+	t.Run("transaction reads", func(t *testing.T) {
+		err := database.WithTx(euCtx, func(tx pgx.Tx) error {
+			got, err := transferRepo.WithTx(tx).GetByID(euCtx, idTransfer.ID)
+			require.NoError(t, err)
+			assert.Nil(t, got, "EU transaction read an ID transfer")
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("role privileges", func(t *testing.T) {
+		// Region-scoped tables other than transfers are hidden by region too
+		err := database.WithTx(euCtx, func(tx pgx.Tx) error {
+			var others int
+			require.NoError(t, tx.QueryRow(euCtx,
+				`SELECT COUNT(*) FROM compliance_logs WHERE compliance_region <> 'EU'`).Scan(&others))
+			assert.Zero(t, others)
+			return nil
+		})
+		require.NoError(t, err)
+
+		// The audit chain heads are only advanced by appending an entry
+		err = database.WithTx(euCtx, func(tx pgx.Tx) error {
+			_, err := tx.Exec(euCtx, `UPDATE audit_chain_heads SET seq = seq WHERE compliance_region = 'EU'`)
+			return err
+		})
+		assert.ErrorContains(t, err, "permission denied")
+
+		auditRepo := repository.NewAuditRepository(tc.pool)
+		before, err := auditRepo.GetChainHead(ctx, models.ComplianceRegionEU)
+		require.NoError(t, err)
+
+		trail := audit.NewTrail(auditRepo)
+		err = database.WithTx(euCtx, func(tx pgx.Tx) error {
+			return trail.RecordTx(euCtx, tx, audit.Entry{
+				Region:       models.ComplianceRegionEU,
+				ResourceType: audit.ResourceTransfer,
+				ResourceID:   euTransfer.ID.String(),
+				Action:       audit.ActionUpdate,
+			})
+		})
+		require.NoError(t, err)
+
+		after, err := auditRepo.GetChainHead(ctx, models.ComplianceRegionEU)
+		require.NoError(t, err)
+		assert.Equal(t, before.Seq+1, after.Seq)
+	})
+
+	t.Run("operator API", func(t *testing.T) {
+		key := "op-" + uuid.NewString()
+		eu := models.ComplianceRegionEU
+		_, err := repository.NewOperatorRepository(tc.pool).Create(ctx, models.CreateOperatorParams{
+			Name:             "EU operations",
+			APIKeyHash:       auth.HashAPIKey(key),
+			ComplianceRegion: &eu,
+		})
+		require.NoError(t, err)
+
+		logger, _ := zap.NewDevelopment()
+		srv := server.New(server.Config{
+			DB:       database,
+			Pool:     tc.pool,
+			Exporter: export.NewExporter(database),
+			Logger:   logger,
+		})
+
+		get := func(path string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("Authorization", "Bearer "+key)
+			w := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(w, req)
+			return w
+		}
+
+		// Requests without credentials are not served unconfined
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transfers/"+idTransfer.ID.String(), nil)
+		w := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		assert.Equal(t, http.StatusOK, get("/api/v1/transfers/"+euTransfer.ID.String()).Code)
+		assert.Equal(t, http.StatusNotFound, get("/api/v1/transfers/"+idTransfer.ID.String()).Code)
+
+		from := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		to := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		assert.Equal(t, http.StatusForbidden, get("/api/v1/transfers/export?region=ID&from="+from+"&to="+to).Code)
+
+		w = get("/api/v1/transfers/export?columns=id,compliance_region&from=" + from + "&to=" + to)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), euTransfer.ID.String())
+		assert.NotContains(t, w.Body.String(), idTransfer.ID.String())
+		for _, line := range strings.Split(strings.TrimSpace(w.Body.String()), "\n")[1:] {
+			assert.True(t, strings.HasSuffix(line, ",EU"), "exported row %q", line)
+		}
+	})
+}
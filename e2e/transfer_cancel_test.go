@@ -0,0 +1,153 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+	"kovra/internal/rail"
+	"kovra/internal/repository"
+)
+
+// TestTransferCancel checks which transfers can be cancelled, and that a
+// cancellation releases what the transfer held and voids its payout hold
+// once committed.
+func TestTransferCancel(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	transferRepo := repository.NewTransferRepository(tc.pool)
+
+	// newTransfer creates a transfer and takes it to status, acknowledged by
+	// the rail if acknowledged is set.
+	newTransfer := func(t *testing.T, status models.TransferStatus, acknowledged bool) *models.Transfer {
+		t.Helper()
+		key := "cancel-" + uuid.NewString()
+		sepa := models.RailSEPAInstant
+		transfer, err := transferRepo.Create(ctx, models.CreateTransferParams{
+			TenantID:       EuroFintechTenantID,
+			IdempotencyKey: &key,
+			FromCurrency:   "EUR",
+			ToCurrency:     "GBP",
+			FromAmount:     decimal.NewFromInt(100),
+			ToAmount:       decimal.NewFromInt(85),
+			FXRate:         decimal.RequireFromString("0.85"),
+			TotalFee:       decimal.Zero,
+			Rail:           &sepa,
+		})
+		require.NoError(t, err)
+		if status != models.TransferStatusCreated {
+			require.NoError(t, transferRepo.UpdateStatus(ctx, transfer.ID, status, nil))
+		}
+		if acknowledged {
+			require.NoError(t, transferRepo.AcknowledgeRail(ctx, transfer.ID))
+		}
+		return transfer
+	}
+
+	cancel := func(t *testing.T, id uuid.UUID) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/transfers/%s/cancel", id), nil)
+		w := httptest.NewRecorder()
+		tc.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("cancellable states", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			status       models.TransferStatus
+			acknowledged bool
+			want         int
+		}{
+			{"created", models.TransferStatusCreated, false, http.StatusOK},
+			{"validating", models.TransferStatusValidating, false, http.StatusOK},
+			{"processing before the rail accepted it", models.TransferStatusProcessing, false, http.StatusOK},
+			{"processing after the rail accepted it", models.TransferStatusProcessing, true, http.StatusConflict},
+			{"completed", models.TransferStatusCompleted, false, http.StatusConflict},
+			{"rolled back", models.TransferStatusRolledBack, false, http.StatusConflict},
+			{"cancelled", models.TransferStatusCancelled, false, http.StatusConflict},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				transfer := newTransfer(t, tt.status, tt.acknowledged)
+				assert.Equal(t, tt.want, cancel(t, transfer.ID))
+
+				got, err := transferRepo.GetByID(ctx, transfer.ID)
+				require.NoError(t, err)
+				if tt.want == http.StatusOK {
+					assert.Equal(t, models.TransferStatusCancelled, got.Status)
+				} else {
+					assert.Equal(t, tt.status, got.Status, "a refused cancellation leaves the transfer as it was")
+				}
+			})
+		}
+	})
+
+	t.Run("unknown transfer", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, cancel(t, uuid.New()))
+	})
+
+	t.Run("releases holds and voids the payout hold", func(t *testing.T) {
+		transfer := newTransfer(t, models.TransferStatusProcessing, false)
+
+		tenant, err := repository.NewTenantRepository(tc.pool).GetByID(ctx, EuroFintechTenantID)
+		require.NoError(t, err)
+		liquidityRepo := repository.NewLiquidityRepository(tc.pool)
+		settlement, err := liquidityRepo.GetSettlementByEntity(ctx, tenant.LegalEntityID, "GBP")
+		require.NoError(t, err)
+		if settlement == nil {
+			settlement, err = liquidityRepo.UpsertSettlement(ctx, models.UpsertRegionalSettlementParams{
+				LegalEntityID:  tenant.LegalEntityID,
+				Currency:       "GBP",
+				TBAccountID:    new(big.Int).SetBytes(transfer.ID[:]),
+				MinBalance:     decimal.Zero,
+				TargetBalance:  decimal.Zero,
+				AlertThreshold: decimal.Zero,
+			})
+			require.NoError(t, err)
+		}
+		_, err = liquidityRepo.CreateHold(ctx, settlement.ID, transfer.ID, transfer.ToAmount)
+		require.NoError(t, err)
+		require.NoError(t, transferRepo.RecordQuoteMargin(ctx, transfer.ID))
+
+		require.Equal(t, http.StatusOK, cancel(t, transfer.ID))
+
+		var holdStatus string
+		require.NoError(t, tc.pool.QueryRow(ctx,
+			`SELECT status FROM liquidity_holds WHERE transfer_id = $1`, transfer.ID).Scan(&holdStatus))
+		assert.Equal(t, string(models.LiquidityHoldCancelled), holdStatus)
+
+		margin, err := repository.NewFXExposureRepository(tc.pool).GetQuoteMargin(ctx, transfer.ID)
+		require.NoError(t, err)
+		assert.Nil(t, margin, "the quote margin is released")
+
+		var voids int
+		require.NoError(t, tc.pool.QueryRow(ctx,
+			`SELECT COUNT(*) FROM jobs WHERE kind = $1 AND args->>'transfer_id' = $2`,
+			rail.VoidHoldArgs{}.Kind(), transfer.ID.String()).Scan(&voids))
+		assert.Equal(t, 1, voids, "the payout hold is voided by a job enqueued with the cancellation")
+	})
+
+	t.Run("refused cancellation enqueues nothing", func(t *testing.T) {
+		transfer := newTransfer(t, models.TransferStatusCompleted, false)
+		require.Equal(t, http.StatusConflict, cancel(t, transfer.ID))
+
+		var voids int
+		require.NoError(t, tc.pool.QueryRow(ctx,
+			`SELECT COUNT(*) FROM jobs WHERE kind = $1 AND args->>'transfer_id' = $2`,
+			rail.VoidHoldArgs{}.Kind(), transfer.ID.String()).Scan(&voids))
+		assert.Zero(t, voids)
+	})
+}
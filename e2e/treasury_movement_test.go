@@ -0,0 +1,132 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/treasury"
+)
+
+// treasuryLedger counts the movements posted to it.
+type treasuryLedger struct {
+	mu     sync.Mutex
+	posted map[uuid.UUID]int
+}
+
+func (l *treasuryLedger) GetBalances(context.Context, []ledger.AccountID) (map[ledger.AccountID]ledger.Balance, error) {
+	return map[ledger.AccountID]ledger.Balance{}, nil
+}
+
+func (l *treasuryLedger) PostTreasuryMovement(_ context.Context, movementID uuid.UUID, _ ledger.TransferCode, _ []ledger.TreasuryLeg) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.posted[movementID]++
+	return nil
+}
+
+func (l *treasuryLedger) postings(movementID uuid.UUID) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.posted[movementID]
+}
+
+// TestTreasuryMovementDecide checks the approval of treasury movements by a
+// second operator, and that an approved movement is posted once.
+func TestTreasuryMovementDecide(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	ctx := context.Background()
+	logger := zap.NewNop()
+	ledgerClient := &treasuryLedger{posted: make(map[uuid.UUID]int)}
+	service := treasury.NewService(
+		db.FromPool(tc.pool),
+		repository.NewFXExposureRepository(tc.pool),
+		repository.NewTreasuryRepository(tc.pool),
+		ledgerClient,
+		jobs.NewClient(repository.NewJobRepository(tc.pool), nil, jobs.Config{}, logger),
+		audit.NewTrail(repository.NewAuditRepository(tc.pool)),
+		logger,
+	)
+
+	request := func(t *testing.T) *models.TreasuryMovement {
+		t.Helper()
+		movement, err := service.RequestRebalance(ctx, "top up EUR settlement", "requester", []models.TreasuryMovementLeg{{
+			Currency:      "EUR",
+			DebitAccount:  ledger.AccountTypeFXSettlement.String(),
+			CreditAccount: ledger.AccountTypeRegionalSettlement.String(),
+			Amount:        decimal.NewFromInt(1000),
+		}})
+		require.NoError(t, err)
+		return movement
+	}
+
+	postJobs := func(t *testing.T, id uuid.UUID) int {
+		t.Helper()
+		var n int
+		require.NoError(t, tc.pool.QueryRow(ctx,
+			`SELECT COUNT(*) FROM jobs WHERE kind = $1 AND args->>'movement_id' = $2`,
+			treasury.PostMovementArgs{}.Kind(), id.String()).Scan(&n))
+		return n
+	}
+
+	t.Run("requester may not decide", func(t *testing.T) {
+		movement := request(t)
+
+		_, err := service.Decide(ctx, movement.ID, models.TreasuryMovementApproved, "requester", nil)
+		assert.ErrorIs(t, err, treasury.ErrSelfApproval)
+		assert.Zero(t, postJobs(t, movement.ID))
+	})
+
+	t.Run("approval enqueues posting", func(t *testing.T) {
+		movement := request(t)
+
+		got, err := service.Decide(ctx, movement.ID, models.TreasuryMovementApproved, "approver", nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.TreasuryMovementApproved, got.Status)
+		assert.Equal(t, 1, postJobs(t, movement.ID))
+
+		_, err = service.Decide(ctx, movement.ID, models.TreasuryMovementRejected, "approver", nil)
+		assert.ErrorIs(t, err, treasury.ErrMovementNotPending)
+	})
+
+	t.Run("rejection enqueues nothing", func(t *testing.T) {
+		movement := request(t)
+		reason := "not needed"
+
+		got, err := service.Decide(ctx, movement.ID, models.TreasuryMovementRejected, "approver", &reason)
+		require.NoError(t, err)
+		assert.Equal(t, models.TreasuryMovementRejected, got.Status)
+		assert.Zero(t, postJobs(t, movement.ID))
+
+		assert.ErrorIs(t, service.Post(ctx, movement.ID), treasury.ErrMovementNotApproved)
+		assert.Zero(t, ledgerClient.postings(movement.ID))
+	})
+
+	t.Run("post is idempotent", func(t *testing.T) {
+		movement := request(t)
+		_, err := service.Decide(ctx, movement.ID, models.TreasuryMovementApproved, "approver", nil)
+		require.NoError(t, err)
+
+		require.NoError(t, service.Post(ctx, movement.ID))
+		got, err := service.GetMovement(ctx, movement.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.TreasuryMovementPosted, got.Status)
+
+		require.NoError(t, service.Post(ctx, movement.ID), "posting a posted movement again is a no-op")
+		assert.Equal(t, 1, ledgerClient.postings(movement.ID))
+	})
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+
+	"kovra/internal/models"
+)
+
+// GenesisHash is the prev_hash of the first entry of every chain.
+var GenesisHash = make([]byte, sha256.Size)
+
+// Hash computes the row hash of an entry: SHA-256 over the previous hash
+// followed by every field, each length-prefixed so no two entries encode
+// the same. States must already be in canonical form (see Canonicalize).
+func Hash(prev []byte, e *models.AuditEntry) []byte {
+	h := sha256.New()
+	h.Write(prev)
+
+	field := func(b []byte) {
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(len(b)))
+		h.Write(n[:])
+		h.Write(b)
+	}
+	optional := func(s *string) {
+		if s == nil {
+			field(nil)
+			return
+		}
+		field([]byte(*s))
+	}
+
+	field([]byte(e.ID.String()))
+	field([]byte(e.ComplianceRegion))
+	field([]byte(strconv.FormatInt(e.Seq, 10)))
+	if e.TenantID != nil {
+		field([]byte(e.TenantID.String()))
+	} else {
+		field(nil)
+	}
+	if e.LegalEntityID != nil {
+		field([]byte(e.LegalEntityID.String()))
+	} else {
+		field(nil)
+	}
+	field([]byte(e.ResourceType))
+	field([]byte(e.ResourceID))
+	field([]byte(e.Action))
+	field([]byte(e.ActorType))
+	field([]byte(e.ActorID))
+	field(e.BeforeState)
+	field(e.AfterState)
+	if e.IPAddress != nil {
+		field([]byte(e.IPAddress.String()))
+	} else {
+		field(nil)
+	}
+	optional(e.RequestID)
+	field([]byte(strconv.FormatInt(e.CreatedAt.UnixMicro(), 10)))
+
+	return h.Sum(nil)
+}
+
+// Canonicalize rewrites a JSON document with sorted object keys and no
+// insignificant whitespace. PostgreSQL stores states as JSONB, which does
+// not keep the original formatting, so states are hashed in this form on
+// write and canonicalized again when verified. A nil or JSON null document
+// returns nil.
+func Canonicalize(raw json.RawMessage) (json.RawMessage, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,197 @@
+// Package audit records every mutating API call in a tamper-evident audit
+// trail and verifies it.
+//
+// Entries are written in the same transaction as the change they describe.
+// Each compliance region has its own hash chain: an entry's hash covers the
+// previous entry's hash, so modifying, removing or reordering entries breaks
+// the chain from that point on.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/auth"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// Resource types.
+const (
+	ResourceTenant               = "tenant"
+	ResourceWallet               = "wallet"
+	ResourceTransfer             = "transfer"
+	ResourceRecipient            = "recipient"
+	ResourceKYCSubmission        = "kyc_submission"
+	ResourceComplianceCase       = "compliance_case"
+	ResourceWebhookDelivery      = "webhook_delivery"
+	ResourceSanctionsLists       = "sanctions_lists"
+	ResourceBankBalance          = "bank_balance"
+	ResourceBankStatement        = "bank_statement"
+	ResourceReconciliationReport = "reconciliation_report"
+	ResourceStatementEntry       = "statement_entry"
+	ResourceExpectedDeposit      = "expected_deposit"
+	ResourceRegionalSettlement   = "regional_settlement"
+	ResourceLiquidityTopUp       = "liquidity_top_up"
+	ResourceTreasuryMovement     = "treasury_movement"
+	ResourceRefund               = "refund"
+)
+
+// Actions.
+const (
+	ActionCreate              = "create"
+	ActionUpdate              = "update"
+	ActionChangeStatus        = "change_status"
+	ActionRotateWebhookSecret = "rotate_webhook_secret"
+	ActionDecide              = "decide"
+	ActionAssign              = "assign"
+	ActionAddNote             = "add_note"
+	ActionReplay              = "replay"
+	ActionReload              = "reload"
+	ActionSignOff             = "sign_off"
+	ActionMatch               = "match"
+	ActionPark                = "park"
+)
+
+// Entry describes a change to record.
+type Entry struct {
+	// TenantID is the tenant the change belongs to, if any. The entry is
+	// placed in the region of the tenant's legal entity unless Region is set.
+	TenantID *uuid.UUID
+	Region   models.ComplianceRegion
+
+	ResourceType string
+	ResourceID   string
+	Action       string
+
+	// Before and After are marshalled to JSON; nil values are omitted.
+	Before any
+	After  any
+}
+
+// Trail appends entries to the audit trail.
+type Trail struct {
+	repo *repository.AuditRepository
+	now  func() time.Time
+}
+
+// NewTrail creates a new audit trail.
+func NewTrail(repo *repository.AuditRepository) *Trail {
+	return &Trail{repo: repo, now: time.Now}
+}
+
+// RecordTx appends an entry in tx. The actor, request ID and client IP are
+// taken from ctx. Appending locks the head of the region's chain until tx
+// ends, so record entries last in a transaction.
+func (t *Trail) RecordTx(ctx context.Context, tx pgx.Tx, e Entry) error {
+	repo := t.repo.WithTx(tx)
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("generate audit entry ID: %w", err)
+	}
+
+	actor := auth.ActorFromContext(ctx)
+	entry := &models.AuditEntry{
+		ID:               id,
+		ComplianceRegion: e.Region,
+		TenantID:         e.TenantID,
+		ResourceType:     e.ResourceType,
+		ResourceID:       e.ResourceID,
+		Action:           e.Action,
+		ActorType:        actor.Type,
+		ActorID:          actor.ID,
+		CreatedAt:        t.now().UTC().Truncate(time.Microsecond),
+	}
+
+	if e.TenantID != nil {
+		scope, err := repo.GetTenantScope(ctx, *e.TenantID)
+		if err != nil {
+			return fmt.Errorf("get tenant audit scope: %w", err)
+		}
+		if scope != nil {
+			entry.LegalEntityID = &scope.LegalEntityID
+			if entry.ComplianceRegion == "" {
+				entry.ComplianceRegion = scope.ComplianceRegion
+			}
+		}
+	}
+	if entry.ComplianceRegion == "" {
+		entry.ComplianceRegion = models.ComplianceRegionUnknown
+	}
+
+	if entry.BeforeState, err = marshalState(e.Before); err != nil {
+		return fmt.Errorf("marshal before state: %w", err)
+	}
+	if entry.AfterState, err = marshalState(e.After); err != nil {
+		return fmt.Errorf("marshal after state: %w", err)
+	}
+
+	if reqID := middleware.GetReqID(ctx); reqID != "" {
+		entry.RequestID = &reqID
+	}
+	if ip, ok := ClientIPFromContext(ctx); ok {
+		entry.IPAddress = &ip
+	}
+
+	head, err := repo.GetChainHeadForUpdate(ctx, entry.ComplianceRegion)
+	if err != nil {
+		return fmt.Errorf("lock audit chain: %w", err)
+	}
+	if head == nil {
+		return fmt.Errorf("no audit chain for region %s", entry.ComplianceRegion)
+	}
+
+	entry.Seq = head.Seq + 1
+	entry.PrevHash = head.RowHash
+	entry.RowHash = Hash(entry.PrevHash, entry)
+
+	// Inserting the entry advances the chain head, in a trigger
+	if err := repo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("create audit entry: %w", err)
+	}
+	return nil
+}
+
+// TenantState returns a copy of a tenant to record as a state, without the
+// API key hash and the webhook signing key.
+func TenantState(t *models.Tenant) *models.Tenant {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	c.APIKeyHash = nil
+	c.WebhookSigningKey = nil
+	return &c
+}
+
+func marshalState(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(raw)
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns a context carrying the client IP of the request.
+func WithClientIP(ctx context.Context, ip netip.Addr) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext returns the client IP of the request, if known.
+func ClientIPFromContext(ctx context.Context) (netip.Addr, bool) {
+	ip, ok := ctx.Value(clientIPKey{}).(netip.Addr)
+	return ip, ok
+}
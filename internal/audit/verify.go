@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"kovra/internal/models"
+)
+
+// Problem kinds reported by Verify.
+const (
+	// ProblemModified: the entry's fields no longer match its hash.
+	ProblemModified = "modified"
+	// ProblemBrokenLink: the entry's prev_hash is not the previous entry's hash.
+	ProblemBrokenLink = "broken_link"
+	// ProblemMissing: entries are missing before this one.
+	ProblemMissing = "missing"
+	// ProblemHeadMismatch: the chain head does not point at the last entry.
+	ProblemHeadMismatch = "head_mismatch"
+)
+
+const verifyBatchSize = 1000
+
+// ChainReader reads audit chains. It is implemented by
+// repository.AuditRepository.
+type ChainReader interface {
+	GetChainHead(ctx context.Context, region models.ComplianceRegion) (*models.AuditChainHead, error)
+	ListChain(ctx context.Context, region models.ComplianceRegion, afterSeq int64, limit int) ([]*models.AuditEntry, error)
+}
+
+// Problem is a break in a chain.
+type Problem struct {
+	Seq    int64
+	Kind   string
+	Detail string
+}
+
+// Report is the result of verifying one region's chain.
+type Report struct {
+	Region   models.ComplianceRegion
+	Entries  int64
+	HeadSeq  int64
+	HeadHash []byte
+	Problems []Problem
+}
+
+// OK returns true if the chain is intact.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Verify walks a region's chain from the first entry, recomputing every
+// hash. It detects entries that were modified, deleted from the start or
+// middle of the chain, or deleted from its end (against the chain head).
+func Verify(ctx context.Context, reader ChainReader, region models.ComplianceRegion) (*Report, error) {
+	head, err := reader.GetChainHead(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("get chain head: %w", err)
+	}
+	if head == nil {
+		return nil, fmt.Errorf("no audit chain for region %s", region)
+	}
+
+	report := &Report{Region: region, HeadSeq: head.Seq, HeadHash: head.RowHash}
+	prevHash := GenesisHash
+	var lastSeq int64
+
+	for {
+		entries, err := reader.ListChain(ctx, region, lastSeq, verifyBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("list chain after %d: %w", lastSeq, err)
+		}
+
+		for _, e := range entries {
+			switch {
+			case e.Seq != lastSeq+1:
+				report.problem(e.Seq, ProblemMissing, "entries %d to %d are missing", lastSeq+1, e.Seq-1)
+			case !bytes.Equal(e.PrevHash, prevHash):
+				report.problem(e.Seq, ProblemBrokenLink, "prev_hash does not match entry %d", lastSeq)
+			}
+
+			if ok, err := hashMatches(e); err != nil {
+				report.problem(e.Seq, ProblemModified, "state is not valid JSON: %v", err)
+			} else if !ok {
+				report.problem(e.Seq, ProblemModified, "row_hash does not match the entry's fields")
+			}
+
+			prevHash = e.RowHash
+			lastSeq = e.Seq
+			report.Entries++
+		}
+
+		if len(entries) < verifyBatchSize {
+			break
+		}
+	}
+
+	switch {
+	case head.Seq > lastSeq:
+		report.problem(head.Seq, ProblemMissing, "entries %d to %d are missing", lastSeq+1, head.Seq)
+	case head.Seq < lastSeq:
+		report.problem(lastSeq, ProblemHeadMismatch, "chain head is at %d", head.Seq)
+	case !bytes.Equal(head.RowHash, prevHash):
+		report.problem(lastSeq, ProblemHeadMismatch, "chain head hash does not match the last entry")
+	}
+
+	return report, nil
+}
+
+// hashMatches recomputes an entry's hash from its stored fields.
+func hashMatches(e *models.AuditEntry) (bool, error) {
+	c := *e
+	var err error
+	if c.BeforeState, err = Canonicalize(e.BeforeState); err != nil {
+		return false, err
+	}
+	if c.AfterState, err = Canonicalize(e.AfterState); err != nil {
+		return false, err
+	}
+	return bytes.Equal(Hash(c.PrevHash, &c), e.RowHash), nil
+}
+
+func (r *Report) problem(seq int64, kind, format string, args ...any) {
+	r.Problems = append(r.Problems, Problem{Seq: seq, Kind: kind, Detail: fmt.Sprintf(format, args...)})
+}
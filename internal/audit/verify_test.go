@@ -0,0 +1,151 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+// memoryChain is an in-memory ChainReader.
+type memoryChain struct {
+	head    models.AuditChainHead
+	entries []*models.AuditEntry
+}
+
+func (c *memoryChain) GetChainHead(ctx context.Context, region models.ComplianceRegion) (*models.AuditChainHead, error) {
+	head := c.head
+	return &head, nil
+}
+
+func (c *memoryChain) ListChain(ctx context.Context, region models.ComplianceRegion, afterSeq int64, limit int) ([]*models.AuditEntry, error) {
+	var out []*models.AuditEntry
+	for _, e := range c.entries {
+		if e.Seq > afterSeq && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// append adds an entry the way Trail.RecordTx does.
+func (c *memoryChain) append(t *testing.T, before, after any) {
+	t.Helper()
+	tenantID := uuid.New()
+	reqID := "req-" + uuid.NewString()
+	ip := netip.MustParseAddr("203.0.113.50")
+
+	e := &models.AuditEntry{
+		ID:               uuid.New(),
+		ComplianceRegion: models.ComplianceRegionEU,
+		Seq:              c.head.Seq + 1,
+		TenantID:         &tenantID,
+		ResourceType:     "tenant",
+		ResourceID:       tenantID.String(),
+		Action:           "update",
+		ActorType:        "tenant",
+		ActorID:          tenantID.String(),
+		IPAddress:        &ip,
+		RequestID:        &reqID,
+		CreatedAt:        time.Date(2026, 3, 2, 12, 0, int(c.head.Seq), 0, time.UTC),
+		PrevHash:         c.head.RowHash,
+	}
+
+	var err error
+	e.BeforeState, err = marshalState(before)
+	require.NoError(t, err)
+	e.AfterState, err = marshalState(after)
+	require.NoError(t, err)
+	e.RowHash = Hash(e.PrevHash, e)
+
+	c.entries = append(c.entries, e)
+	c.head.Seq = e.Seq
+	c.head.RowHash = e.RowHash
+}
+
+func newChain(t *testing.T, n int) *memoryChain {
+	c := &memoryChain{head: models.AuditChainHead{ComplianceRegion: models.ComplianceRegionEU, RowHash: GenesisHash}}
+	for i := 0; i < n; i++ {
+		c.append(t, map[string]any{"status": "active", "n": i}, map[string]any{"status": "suspended", "n": i})
+	}
+	return c
+}
+
+func verify(t *testing.T, c *memoryChain) *Report {
+	t.Helper()
+	report, err := Verify(context.Background(), c, models.ComplianceRegionEU)
+	require.NoError(t, err)
+	return report
+}
+
+func kinds(r *Report) []string {
+	out := make([]string, len(r.Problems))
+	for i, p := range r.Problems {
+		out[i] = p.Kind
+	}
+	return out
+}
+
+func TestVerifyIntactChain(t *testing.T) {
+	c := newChain(t, 5)
+	report := verify(t, c)
+	assert.True(t, report.OK(), report.Problems)
+	assert.Equal(t, int64(5), report.Entries)
+
+	empty := verify(t, newChain(t, 0))
+	assert.True(t, empty.OK(), empty.Problems)
+}
+
+func TestVerifyStatesSurviveJSONBFormatting(t *testing.T) {
+	c := newChain(t, 1)
+	// PostgreSQL returns JSONB with its own spacing and key order
+	c.entries[0].AfterState = json.RawMessage(`{"n": 0, "status": "suspended"}`)
+
+	report := verify(t, c)
+	assert.True(t, report.OK(), report.Problems)
+}
+
+func TestVerifyDetectsModifiedEntry(t *testing.T) {
+	c := newChain(t, 5)
+	c.entries[2].AfterState = json.RawMessage(`{"n":2,"status":"active"}`)
+
+	report := verify(t, c)
+	assert.Equal(t, []string{ProblemModified}, kinds(report))
+	assert.Equal(t, int64(3), report.Problems[0].Seq)
+}
+
+func TestVerifyDetectsRehashedEntry(t *testing.T) {
+	c := newChain(t, 5)
+	// Recomputing the tampered entry's hash breaks the next link instead
+	c.entries[2].ActorID = "someone-else"
+	c.entries[2].RowHash = Hash(c.entries[2].PrevHash, c.entries[2])
+
+	report := verify(t, c)
+	assert.Equal(t, []string{ProblemBrokenLink}, kinds(report))
+	assert.Equal(t, int64(4), report.Problems[0].Seq)
+}
+
+func TestVerifyDetectsDeletedEntry(t *testing.T) {
+	c := newChain(t, 5)
+	c.entries = append(c.entries[:1], c.entries[2:]...)
+
+	report := verify(t, c)
+	assert.Equal(t, []string{ProblemMissing}, kinds(report))
+	assert.Equal(t, int64(3), report.Problems[0].Seq)
+}
+
+func TestVerifyDetectsDeletedTail(t *testing.T) {
+	c := newChain(t, 5)
+	c.entries = c.entries[:3]
+
+	report := verify(t, c)
+	assert.Equal(t, []string{ProblemMissing}, kinds(report))
+	assert.Equal(t, "entries 4 to 5 are missing", report.Problems[0].Detail)
+}
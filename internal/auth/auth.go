@@ -0,0 +1,52 @@
+// Package auth carries the caller of an API request in the request
+// context.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"kovra/internal/models"
+)
+
+// Actor types.
+const (
+	ActorTypeTenant    = "tenant"
+	ActorTypeOperator  = "operator"
+	ActorTypeAnonymous = "anonymous"
+)
+
+// Actor is the caller of a request.
+type Actor struct {
+	Type string
+	ID   string
+	// Region is the compliance region a regional operator is confined to,
+	// empty for every other actor.
+	Region models.ComplianceRegion
+}
+
+// Anonymous is the actor of work done without an authenticated caller, such
+// as rail callbacks and background jobs. API requests always carry one.
+var Anonymous = Actor{Type: ActorTypeAnonymous, ID: "anonymous"}
+
+type actorKey struct{}
+
+// WithActor returns a context carrying the actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor of the request, or Anonymous.
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok {
+		return actor
+	}
+	return Anonymous
+}
+
+// HashAPIKey returns the hex SHA-256 of an API key, as stored in tenants.api_key_hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
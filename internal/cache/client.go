@@ -7,6 +7,12 @@ import (
 
 	"github.com/redis/rueidis"
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"kovra/internal/metrics"
+	"kovra/internal/tracing"
 )
 
 // Client wraps Redis operations using rueidis.
@@ -43,7 +49,29 @@ func (c *Client) Close() {
 
 // Ping checks if Redis is reachable.
 func (c *Client) Ping(ctx context.Context) error {
-	return c.redis.Do(ctx, c.redis.B().Ping().Build()).Error()
+	return c.do(ctx, c.redis.B().Ping().Build()).Error()
+}
+
+// do runs a command, in a span if ctx is traced, counting it in the metrics
+// if it fails. A nil reply is not a failure.
+func (c *Client) do(ctx context.Context, cmd rueidis.Completed) rueidis.RedisResult {
+	command := cmd.Commands()[0]
+	span := trace.SpanFromContext(ctx)
+	if span.IsRecording() {
+		ctx, span = tracing.Tracer().Start(ctx, "redis "+command,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationName(command)),
+		)
+		defer span.End()
+	}
+
+	result := c.redis.Do(ctx, cmd)
+	if err := result.Error(); err != nil && !rueidis.IsRedisNil(err) {
+		metrics.RedisError(command)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result
 }
 
 // --- FX Rate Lock ---
@@ -64,7 +92,7 @@ func (c *Client) LockFXRate(ctx context.Context, lock FXRateLock, ttl time.Durat
 
 	// Use SETNX with separate EXPIRE for atomic set-if-not-exists
 	cmd := c.redis.B().Setnx().Key(key).Value(value).Build()
-	set, err := c.redis.Do(ctx, cmd).AsBool()
+	set, err := c.do(ctx, cmd).AsBool()
 	if err != nil {
 		return fmt.Errorf("lock FX rate: %w", err)
 	}
@@ -74,7 +102,7 @@ func (c *Client) LockFXRate(ctx context.Context, lock FXRateLock, ttl time.Durat
 
 	// Set expiration
 	expireCmd := c.redis.B().Expire().Key(key).Seconds(int64(ttl.Seconds())).Build()
-	c.redis.Do(ctx, expireCmd)
+	c.do(ctx, expireCmd)
 
 	return nil
 }
@@ -83,7 +111,7 @@ func (c *Client) LockFXRate(ctx context.Context, lock FXRateLock, ttl time.Durat
 func (c *Client) GetFXRate(ctx context.Context, quoteID string) (*FXRateLock, error) {
 	key := fmt.Sprintf("fx_rate:%s", quoteID)
 
-	value, err := c.redis.Do(ctx, c.redis.B().Get().Key(key).Build()).ToString()
+	value, err := c.do(ctx, c.redis.B().Get().Key(key).Build()).ToString()
 	if err != nil {
 		if rueidis.IsRedisNil(err) {
 			return nil, nil // Not found
@@ -103,7 +131,7 @@ func (c *Client) GetFXRate(ctx context.Context, quoteID string) (*FXRateLock, er
 		return nil, fmt.Errorf("parse rate: %w", err)
 	}
 
-	ttl, err := c.redis.Do(ctx, c.redis.B().Ttl().Key(key).Build()).ToInt64()
+	ttl, err := c.do(ctx, c.redis.B().Ttl().Key(key).Build()).ToInt64()
 	if err != nil {
 		ttl = 0
 	}
@@ -120,7 +148,7 @@ func (c *Client) GetFXRate(ctx context.Context, quoteID string) (*FXRateLock, er
 // DeleteFXRate removes a locked FX rate.
 func (c *Client) DeleteFXRate(ctx context.Context, quoteID string) error {
 	key := fmt.Sprintf("fx_rate:%s", quoteID)
-	return c.redis.Do(ctx, c.redis.B().Del().Key(key).Build()).Error()
+	return c.do(ctx, c.redis.B().Del().Key(key).Build()).Error()
 }
 
 // --- Rate Limiting ---
@@ -155,7 +183,7 @@ func (c *Client) CheckRateLimit(ctx context.Context, tenantID string, limitPerMi
 		end
 	`
 
-	result, err := c.redis.Do(ctx,
+	result, err := c.do(ctx,
 		c.redis.B().Eval().Script(script).Numkeys(1).Key(key).Arg(
 			fmt.Sprintf("%d", now),
 			fmt.Sprintf("%d", windowStart),
@@ -178,7 +206,7 @@ func (c *Client) SetIdempotencyKey(ctx context.Context, tenantID, key string, re
 
 	// Use SETNX for atomic set-if-not-exists
 	cmd := c.redis.B().Setnx().Key(redisKey).Value(string(result)).Build()
-	set, err := c.redis.Do(ctx, cmd).AsBool()
+	set, err := c.do(ctx, cmd).AsBool()
 	if err != nil {
 		return err
 	}
@@ -188,13 +216,13 @@ func (c *Client) SetIdempotencyKey(ctx context.Context, tenantID, key string, re
 
 	// Set expiration
 	expireCmd := c.redis.B().Expire().Key(redisKey).Seconds(int64(ttl.Seconds())).Build()
-	return c.redis.Do(ctx, expireCmd).Error()
+	return c.do(ctx, expireCmd).Error()
 }
 
 // GetIdempotencyKey retrieves an idempotency result.
 func (c *Client) GetIdempotencyKey(ctx context.Context, tenantID, key string) ([]byte, error) {
 	redisKey := fmt.Sprintf("idempotency:%s:%s", tenantID, key)
-	result, err := c.redis.Do(ctx, c.redis.B().Get().Key(redisKey).Build()).ToString()
+	result, err := c.do(ctx, c.redis.B().Get().Key(redisKey).Build()).ToString()
 	if err != nil {
 		if rueidis.IsRedisNil(err) {
 			return nil, nil
@@ -209,7 +237,7 @@ func (c *Client) GetIdempotencyKey(ctx context.Context, tenantID, key string) ([
 // CacheTenantByAPIKey caches tenant ID lookup by API key.
 func (c *Client) CacheTenantByAPIKey(ctx context.Context, apiKeyHash, tenantID string, ttl time.Duration) error {
 	key := fmt.Sprintf("api_key:%s", apiKeyHash)
-	return c.redis.Do(ctx,
+	return c.do(ctx,
 		c.redis.B().Set().Key(key).Value(tenantID).Ex(ttl).Build(),
 	).Error()
 }
@@ -217,7 +245,7 @@ func (c *Client) CacheTenantByAPIKey(ctx context.Context, apiKeyHash, tenantID s
 // GetTenantByAPIKey retrieves cached tenant ID.
 func (c *Client) GetTenantByAPIKey(ctx context.Context, apiKeyHash string) (string, error) {
 	key := fmt.Sprintf("api_key:%s", apiKeyHash)
-	result, err := c.redis.Do(ctx, c.redis.B().Get().Key(key).Build()).ToString()
+	result, err := c.do(ctx, c.redis.B().Get().Key(key).Build()).ToString()
 	if err != nil {
 		if rueidis.IsRedisNil(err) {
 			return "", nil
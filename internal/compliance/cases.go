@@ -0,0 +1,422 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/liquidity"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrCaseNotFound           = errors.New("compliance case not found")
+	ErrCaseClosed             = errors.New("compliance case is closed")
+	ErrSecondReviewerRequired = errors.New("approval must be confirmed by a second reviewer")
+	ErrTransferNotHeld        = errors.New("transfer is not held for review")
+	ErrReasonRequired         = errors.New("a reason is required to reject")
+)
+
+// CaseConfig holds review queue settings.
+type CaseConfig struct {
+	// FourEyesThreshold is the risk score at or above which a case is high
+	// priority and its approval needs a second reviewer.
+	FourEyesThreshold int
+	SLA               time.Duration
+	HighPrioritySLA   time.Duration
+}
+
+// Decision is a reviewer's decision on a case.
+type Decision struct {
+	Decision models.CaseDecision
+	Reason   *string
+	// Reviewer is the ID of the authenticated operator deciding, which the
+	// four-eyes check and chk_case_second_approver compare.
+	Reviewer string
+}
+
+// CaseService manages manual review of held transfers. Every action is
+// recorded in the compliance log of the transfer's region.
+type CaseService struct {
+	db           *db.DB
+	caseRepo     *repository.ComplianceCaseRepository
+	logRepo      *repository.ComplianceLogRepository
+	transferRepo *repository.TransferRepository
+	outboxRepo   *repository.OutboxRepository
+	liquidity    *liquidity.Service
+	trail        *audit.Trail
+	cfg          CaseConfig
+}
+
+// NewCaseService creates a new case service.
+func NewCaseService(
+	database *db.DB,
+	caseRepo *repository.ComplianceCaseRepository,
+	logRepo *repository.ComplianceLogRepository,
+	transferRepo *repository.TransferRepository,
+	outboxRepo *repository.OutboxRepository,
+	liquidityService *liquidity.Service,
+	trail *audit.Trail,
+	cfg CaseConfig,
+) *CaseService {
+	if cfg.FourEyesThreshold <= 0 {
+		cfg.FourEyesThreshold = 80
+	}
+	if cfg.SLA <= 0 {
+		cfg.SLA = 24 * time.Hour
+	}
+	if cfg.HighPrioritySLA <= 0 {
+		cfg.HighPrioritySLA = 4 * time.Hour
+	}
+	return &CaseService{
+		db:           database,
+		caseRepo:     caseRepo,
+		logRepo:      logRepo,
+		transferRepo: transferRepo,
+		outboxRepo:   outboxRepo,
+		liquidity:    liquidityService,
+		trail:        trail,
+		cfg:          cfg,
+	}
+}
+
+// OpenTx opens a review case for a held transfer in tx.
+func (s *CaseService) OpenTx(ctx context.Context, tx pgx.Tx, transfer *models.Transfer, reason models.CaseReason, riskScore int) (*models.ComplianceCase, error) {
+	highRisk := riskScore >= s.cfg.FourEyesThreshold
+
+	priority, sla := models.CasePriorityNormal, s.cfg.SLA
+	if highRisk {
+		priority, sla = models.CasePriorityHigh, s.cfg.HighPrioritySLA
+	}
+
+	c, err := s.caseRepo.WithTx(tx).Create(ctx, models.CreateComplianceCaseParams{
+		TransferID:             transfer.ID,
+		TenantID:               transfer.TenantID,
+		ComplianceRegion:       transfer.ComplianceRegion,
+		Reason:                 reason,
+		Priority:               priority,
+		RiskScore:              &riskScore,
+		RequiresSecondApproval: highRisk,
+		DueAt:                  time.Now().Add(sla),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create case: %w", err)
+	}
+
+	if err := s.log(ctx, tx, c, models.ScreeningResultCaseOpened, ScreenedBySanctions, map[string]any{
+		"reason":   reason,
+		"priority": priority,
+		"due_at":   c.DueAt,
+	}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Assign sets the reviewer of an open case. An empty assignee unassigns it.
+func (s *CaseService) Assign(ctx context.Context, id uuid.UUID, assignee, reviewer string) (*models.ComplianceCase, error) {
+	return s.update(ctx, id, audit.ActionAssign, func(tx pgx.Tx, c *models.ComplianceCase) error {
+		var to *string
+		if assignee != "" {
+			to = &assignee
+		}
+		if err := s.caseRepo.WithTx(tx).Assign(ctx, c.ID, to); err != nil {
+			return err
+		}
+		return s.log(ctx, tx, c, models.ScreeningResultAssigned, reviewer, map[string]any{
+			"assigned_to": to,
+			"previous":    c.AssignedTo,
+		})
+	})
+}
+
+// AddNote adds a reviewer note to a case. Notes are allowed after closing.
+func (s *CaseService) AddNote(ctx context.Context, id uuid.UUID, author, body string) (*models.ComplianceCaseNote, error) {
+	ctx, err := s.locate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ComplianceCaseNote, error) {
+		c, err := s.caseRepo.WithTx(tx).GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, ErrCaseNotFound
+		}
+
+		note, err := s.caseRepo.WithTx(tx).AddNote(ctx, c.ID, author, body)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.log(ctx, tx, c, models.ScreeningResultNoted, author, map[string]any{
+			"note_id": note.ID,
+		}); err != nil {
+			return nil, err
+		}
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &c.TenantID,
+			Region:       c.ComplianceRegion,
+			ResourceType: audit.ResourceComplianceCase,
+			ResourceID:   c.ID.String(),
+			Action:       audit.ActionAddNote,
+			After:        note,
+		}); err != nil {
+			return nil, err
+		}
+		return note, nil
+	})
+}
+
+// Decide applies a reviewer's decision.
+//
+// Approving releases the transfer to processing; on four-eyes cases the first
+// approval only moves the case to pending_approval, and a different reviewer
+// must confirm it. Rejecting rejects the transfer with the given reason.
+// Escalating raises the case to high priority and requires four-eyes approval.
+func (s *CaseService) Decide(ctx context.Context, id uuid.UUID, d Decision) (*models.ComplianceCase, error) {
+	if d.Decision == models.CaseDecisionReject && (d.Reason == nil || *d.Reason == "") {
+		return nil, ErrReasonRequired
+	}
+
+	return s.update(ctx, id, audit.ActionDecide, func(tx pgx.Tx, c *models.ComplianceCase) error {
+		switch d.Decision {
+		case models.CaseDecisionEscalate:
+			dueAt := time.Now().Add(s.cfg.HighPrioritySLA)
+			if err := s.caseRepo.WithTx(tx).Escalate(ctx, c.ID, dueAt); err != nil {
+				return err
+			}
+			return s.log(ctx, tx, c, models.ScreeningResultEscalated, d.Reviewer, map[string]any{
+				"reason": d.Reason,
+				"due_at": dueAt,
+			})
+
+		case models.CaseDecisionApprove:
+			if c.RequiresSecondApproval && c.Status != models.CaseStatusPendingApproval {
+				if err := s.caseRepo.WithTx(tx).RequestApproval(ctx, c.ID, d.Decision, d.Reason, d.Reviewer); err != nil {
+					return err
+				}
+				return s.log(ctx, tx, c, models.ScreeningResultApprovalRequested, d.Reviewer, map[string]any{
+					"reason": d.Reason,
+				})
+			}
+			if c.Status == models.CaseStatusPendingApproval && c.DecidedBy != nil && *c.DecidedBy == d.Reviewer {
+				return ErrSecondReviewerRequired
+			}
+			return s.close(ctx, tx, c, d, models.CaseStatusApproved)
+
+		case models.CaseDecisionReject:
+			return s.close(ctx, tx, c, d, models.CaseStatusRejected)
+		}
+		return fmt.Errorf("unknown decision %q", d.Decision)
+	})
+}
+
+// close records the final decision and resumes or rejects the transfer.
+func (s *CaseService) close(ctx context.Context, tx pgx.Tx, c *models.ComplianceCase, d Decision, status models.CaseStatus) error {
+	// Locked so that the transfer cannot be cancelled meanwhile
+	transfer, err := s.transferRepo.WithTx(tx).GetForUpdate(ctx, c.TransferID)
+	if err != nil {
+		return err
+	}
+	if transfer == nil || transfer.Status != models.TransferStatusValidating {
+		return ErrTransferNotHeld
+	}
+
+	// Under four-eyes the first reviewer keeps decided_by; the confirming one is approved_by
+	decidedBy, approvedBy := d.Reviewer, (*string)(nil)
+	if status == models.CaseStatusApproved && c.Status == models.CaseStatusPendingApproval && c.DecidedBy != nil {
+		decidedBy, approvedBy = *c.DecidedBy, &d.Reviewer
+	}
+
+	if err := s.caseRepo.WithTx(tx).Close(ctx, c.ID, status, d.Decision, d.Reason, decidedBy, approvedBy); err != nil {
+		return err
+	}
+
+	result := models.ScreeningResultApproved
+	if status == models.CaseStatusRejected {
+		result = models.ScreeningResultRejected
+	}
+	if err := s.log(ctx, tx, c, result, d.Reviewer, map[string]any{
+		"reason":      d.Reason,
+		"decided_by":  decidedBy,
+		"approved_by": approvedBy,
+	}); err != nil {
+		return err
+	}
+
+	transfers := s.transferRepo.WithTx(tx)
+	if status == models.CaseStatusApproved {
+		if err := transfers.UpdateComplianceStatus(ctx, transfer.ID, models.ComplianceStatusCleared, c.RiskScore); err != nil {
+			return err
+		}
+		transfer.ComplianceStatus = models.ComplianceStatusCleared
+
+		admitted, err := s.liquidity.AdmitTx(ctx, tx, transfer)
+		if err != nil {
+			return err
+		}
+		if !admitted {
+			// The transfer stays in validating until the settlement account is topped up
+			return nil
+		}
+		if err := transfers.UpdateStatus(ctx, transfer.ID, models.TransferStatusProcessing, nil); err != nil {
+			return err
+		}
+		return appendStatusEvent(ctx, s.outboxRepo.WithTx(tx), transfer, models.TransferStatusProcessing, nil)
+	}
+
+	if err := transfers.UpdateComplianceStatus(ctx, transfer.ID, models.ComplianceStatusRejected, c.RiskScore); err != nil {
+		return err
+	}
+	if err := transfers.UpdateStatus(ctx, transfer.ID, models.TransferStatusRejected, d.Reason); err != nil {
+		return err
+	}
+	transfer.ComplianceStatus = models.ComplianceStatusRejected
+	return appendStatusEvent(ctx, s.outboxRepo.WithTx(tx), transfer, models.TransferStatusRejected, d.Reason)
+}
+
+// FlagOverdue marks open cases past their SLA and logs each breach.
+func (s *CaseService) FlagOverdue(ctx context.Context) ([]*models.ComplianceCase, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) ([]*models.ComplianceCase, error) {
+		cases, err := s.caseRepo.WithTx(tx).MarkOverdue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cases {
+			if err := s.log(ctx, tx, c, models.ScreeningResultSLABreached, "system", map[string]any{
+				"due_at":      c.DueAt,
+				"assigned_to": c.AssignedTo,
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return cases, nil
+	})
+}
+
+// update locks an open case, applies fn, records action in the audit trail
+// and returns the updated case.
+func (s *CaseService) update(ctx context.Context, id uuid.UUID, action string, fn func(tx pgx.Tx, c *models.ComplianceCase) error) (*models.ComplianceCase, error) {
+	ctx, err := s.locate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ComplianceCase, error) {
+		cases := s.caseRepo.WithTx(tx)
+
+		c, err := cases.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			return nil, ErrCaseNotFound
+		}
+		if c.Status.IsClosed() {
+			return nil, ErrCaseClosed
+		}
+
+		if err := fn(tx, c); err != nil {
+			return nil, err
+		}
+
+		updated, err := cases.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &c.TenantID,
+			Region:       c.ComplianceRegion,
+			ResourceType: audit.ResourceComplianceCase,
+			ResourceID:   c.ID.String(),
+			Action:       action,
+			Before:       c,
+			After:        updated,
+		}); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
+}
+
+// locate routes ctx to the database holding the case: the case of a transfer
+// kept in a regional database is opened there with it.
+func (s *CaseService) locate(ctx context.Context, id uuid.UUID) (context.Context, error) {
+	ctx, err := s.db.Locate(ctx, func(ctx context.Context) (bool, error) {
+		c, err := s.caseRepo.GetByID(ctx, id)
+		return c != nil, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("locate case: %w", err)
+	}
+	return ctx, nil
+}
+
+func (s *CaseService) log(ctx context.Context, tx pgx.Tx, c *models.ComplianceCase, result models.ScreeningResult, actor string, details map[string]any) error {
+	raw, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("marshal case action: %w", err)
+	}
+
+	_, err = s.logRepo.WithTx(tx).Create(ctx, models.CreateComplianceLogParams{
+		ComplianceRegion: c.ComplianceRegion,
+		TransferID:       c.TransferID,
+		TenantID:         c.TenantID,
+		CaseID:           &c.ID,
+		ScreeningType:    models.ScreeningTypeManualReview,
+		Result:           result,
+		RiskScore:        c.RiskScore,
+		RawResponse:      raw,
+		ScreenedBy:       actor,
+	})
+	return err
+}
+
+// CaseSLAArgs are the arguments of the review SLA job.
+type CaseSLAArgs struct{}
+
+// Kind returns the job kind.
+func (CaseSLAArgs) Kind() string { return "compliance.case_sla" }
+
+// InsertOpts returns the default insert options.
+func (CaseSLAArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3}
+}
+
+// CaseSLAWorker flags review cases that breached their SLA.
+type CaseSLAWorker struct {
+	cases  *CaseService
+	logger *zap.Logger
+}
+
+// NewCaseSLAWorker creates a new review SLA worker.
+func NewCaseSLAWorker(cases *CaseService, logger *zap.Logger) *CaseSLAWorker {
+	return &CaseSLAWorker{cases: cases, logger: logger}
+}
+
+// Work runs the SLA job.
+func (w *CaseSLAWorker) Work(ctx context.Context, job *jobs.Job[CaseSLAArgs]) error {
+	breached, err := w.cases.FlagOverdue(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range breached {
+		w.logger.Warn("compliance case breached SLA",
+			zap.String("case_id", c.ID.String()),
+			zap.String("transfer_id", c.TransferID.String()),
+			zap.String("priority", string(c.Priority)),
+			zap.Time("due_at", c.DueAt),
+		)
+	}
+	return nil
+}
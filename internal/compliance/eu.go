@@ -0,0 +1,97 @@
+package compliance
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type euEntity struct {
+	LogicalID   string `xml:"logicalId,attr"`
+	EUReference string `xml:"euReferenceNumber,attr"`
+	SubjectType struct {
+		Code string `xml:"code,attr"`
+	} `xml:"subjectType"`
+	Regulations []struct {
+		Programme string `xml:"programme,attr"`
+	} `xml:"regulation"`
+	Names []struct {
+		WholeName string `xml:"wholeName,attr"`
+		Strong    string `xml:"strong,attr"`
+	} `xml:"nameAlias"`
+}
+
+// ParseEU parses the EU consolidated financial sanctions list (XML export).
+// The first strong nameAlias of each sanctionEntity is its primary name.
+func ParseEU(r io.Reader) ([]Entry, error) {
+	dec := xml.NewDecoder(r)
+
+	var entries []Entry
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse EU list: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "sanctionEntity" {
+			continue
+		}
+
+		var e euEntity
+		if err := dec.DecodeElement(&e, &start); err != nil {
+			return nil, fmt.Errorf("parse EU sanctionEntity: %w", err)
+		}
+		if entry, ok := e.toEntry(); ok {
+			entries = append(entries, entry)
+		}
+	}
+}
+
+func (e euEntity) toEntry() (Entry, bool) {
+	entry := Entry{
+		Source:     SourceEU,
+		ExternalID: e.LogicalID,
+		EntityType: EntityTypeEntity,
+	}
+	if e.EUReference != "" {
+		entry.ExternalID = e.EUReference
+	}
+	if strings.EqualFold(e.SubjectType.Code, "person") {
+		entry.EntityType = EntityTypeIndividual
+	}
+
+	var weak []string
+	for _, n := range e.Names {
+		name := strings.TrimSpace(n.WholeName)
+		switch {
+		case name == "":
+		case entry.Name == "" && n.Strong != "false":
+			entry.Name = name
+		case n.Strong == "false":
+			weak = append(weak, name)
+		default:
+			entry.Aliases = append(entry.Aliases, name)
+		}
+	}
+	// Only weak aliases: promote the first one
+	if entry.Name == "" && len(weak) > 0 {
+		entry.Name, weak = weak[0], weak[1:]
+	}
+	entry.Aliases = append(entry.Aliases, weak...)
+
+	seen := make(map[string]bool)
+	for _, reg := range e.Regulations {
+		if p := strings.TrimSpace(reg.Programme); p != "" && !seen[p] {
+			seen[p] = true
+			entry.Programs = append(entry.Programs, p)
+		}
+	}
+
+	return entry, entry.Name != ""
+}
@@ -0,0 +1,126 @@
+package compliance
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// noiseTokens are dropped before comparing names: legal forms and
+// connectives that do not identify a party.
+var noiseTokens = map[string]bool{
+	"the": true, "of": true, "and": true,
+	"ltd": true, "limited": true, "llc": true, "inc": true, "corp": true, "co": true,
+	"plc": true, "gmbh": true, "ag": true, "sa": true, "bv": true, "nv": true,
+	"pt": true, "tbk": true, "cv": true,
+}
+
+// Normalize lowercases a name, strips diacritics and punctuation, and drops
+// legal forms, so "PT. Bank Négara, Tbk" becomes "bank negara".
+func Normalize(name string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, name)
+	if err != nil {
+		folded = name
+	}
+
+	fields := strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := fields[:0]
+	for _, f := range fields {
+		if !noiseTokens[f] {
+			tokens = append(tokens, f)
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// Similarity scores two normalized names from 0 (unrelated) to 1 (identical).
+//
+// It takes the best Jaro-Winkler score over three views of the names: as
+// written, with tokens sorted (word order: "HUSSEIN, Saddam"), and with spaces
+// removed (segmentation: "Al Qaeda" vs "Al-Qaida").
+func Similarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	best := jaroWinkler(a, b)
+	if s := jaroWinkler(sortTokens(a), sortTokens(b)); s > best {
+		best = s
+	}
+	if s := jaroWinkler(strings.ReplaceAll(a, " ", ""), strings.ReplaceAll(b, " ", "")); s > best {
+		best = s
+	}
+	return best
+}
+
+func sortTokens(s string) string {
+	tokens := strings.Fields(s)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// jaroWinkler returns the Jaro-Winkler similarity of a and b.
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	la, lb := len(ra), len(rb)
+	if la == 0 || lb == 0 {
+		return 0
+	}
+
+	window := max(la, lb)/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, la)
+	matchedB := make([]bool, lb)
+	matches := 0
+	for i := range ra {
+		lo, hi := max(0, i-window), min(lb, i+window+1)
+		for j := lo; j < hi; j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(la) + m/float64(lb) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, la, lb) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
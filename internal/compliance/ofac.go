@@ -0,0 +1,119 @@
+package compliance
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OFAC files use "-0-" for empty fields.
+const ofacNull = "-0-"
+
+// ParseOFAC parses the OFAC SDN list (sdn.csv) and, if alt is non-nil, its
+// alias file (alt.csv). Neither file has a header row.
+//
+// sdn.csv: ent_num, SDN_Name, SDN_Type, Program, Title, Call_Sign, ...
+// alt.csv: ent_num, alt_num, alt_type, alt_name, alt_remarks
+func ParseOFAC(sdn io.Reader, alt io.Reader) ([]Entry, error) {
+	var entries []Entry
+	index := make(map[string]int)
+
+	err := readOFACRecords(sdn, func(rec []string) {
+		if len(rec) < 4 {
+			return
+		}
+		id := ofacField(rec[0])
+		name := ofacField(rec[1])
+		if id == "" || name == "" {
+			return
+		}
+
+		index[id] = len(entries)
+		entries = append(entries, Entry{
+			Source:     SourceOFAC,
+			ExternalID: id,
+			EntityType: ofacEntityType(ofacField(rec[2])),
+			Name:       name,
+			Programs:   ofacPrograms(ofacField(rec[3])),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parse OFAC SDN: %w", err)
+	}
+
+	if alt != nil {
+		err := readOFACRecords(alt, func(rec []string) {
+			if len(rec) < 4 {
+				return
+			}
+			i, ok := index[ofacField(rec[0])]
+			if !ok {
+				return
+			}
+			if name := ofacField(rec[3]); name != "" {
+				entries[i].Aliases = append(entries[i].Aliases, name)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("parse OFAC aliases: %w", err)
+		}
+	}
+
+	return entries, nil
+}
+
+func readOFACRecords(r io.Reader, fn func(rec []string)) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(rec)
+	}
+}
+
+func ofacField(s string) string {
+	s = strings.TrimSpace(s)
+	if s == ofacNull {
+		return ""
+	}
+	return s
+}
+
+func ofacEntityType(sdnType string) string {
+	switch strings.ToLower(sdnType) {
+	case "individual":
+		return EntityTypeIndividual
+	case "vessel":
+		return EntityTypeVessel
+	case "aircraft":
+		return EntityTypeAircraft
+	default:
+		// Entities have no SDN_Type
+		return EntityTypeEntity
+	}
+}
+
+// ofacPrograms splits "SDGT] [IRGC" into its programs.
+func ofacPrograms(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var programs []string
+	for _, p := range strings.Split(s, "] [") {
+		p = strings.Trim(p, "[] ")
+		if p != "" {
+			programs = append(programs, p)
+		}
+	}
+	return programs
+}
@@ -0,0 +1,40 @@
+// Package compliance screens transfers against sanctions lists.
+package compliance
+
+import "time"
+
+// Source identifies a sanctions list.
+type Source string
+
+const (
+	SourceOFAC Source = "ofac" // US Treasury OFAC SDN list
+	SourceEU   Source = "eu"   // EU consolidated financial sanctions list
+	SourceUK   Source = "uk"   // UK HMT consolidated list
+)
+
+// Entity types shared by all lists.
+const (
+	EntityTypeIndividual = "individual"
+	EntityTypeEntity     = "entity"
+	EntityTypeVessel     = "vessel"
+	EntityTypeAircraft   = "aircraft"
+)
+
+// Entry is a single listed party.
+type Entry struct {
+	Source     Source
+	ExternalID string
+	EntityType string
+	Name       string
+	Aliases    []string
+	Programs   []string
+}
+
+// ListInfo describes a loaded sanctions list.
+type ListInfo struct {
+	Source   Source    `json:"source"`
+	Path     string    `json:"path"`
+	Entries  int       `json:"entries"`
+	LoadedAt time.Time `json:"loaded_at"`
+	ModTime  time.Time `json:"mod_time"`
+}
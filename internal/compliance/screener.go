@@ -0,0 +1,279 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrNoLists is returned when screening is attempted with no lists loaded.
+// Screening fails closed: an empty index never clears a name.
+var ErrNoLists = errors.New("no sanctions lists loaded")
+
+// ScreenerConfig holds list locations and matching thresholds.
+type ScreenerConfig struct {
+	OFACPath    string // sdn.csv
+	OFACAltPath string // alt.csv (optional)
+	EUPath      string // EU consolidated XML
+	UKPath      string // UK HMT ConList.csv
+
+	// Threshold is the minimum similarity for a primary-name match.
+	Threshold float64
+	// AliasThreshold is the minimum similarity for an alias match. Aliases
+	// are noisier than primary names, so this is usually higher.
+	AliasThreshold float64
+	// MatchAliases enables matching against listed aliases.
+	MatchAliases bool
+	// AllowEmpty lets Screen clear names when no list is configured.
+	// Intended for local development only.
+	AllowEmpty bool
+}
+
+// Match is a listed name that matched a screened name.
+type Match struct {
+	Source     Source   `json:"source"`
+	ExternalID string   `json:"external_id"`
+	EntityType string   `json:"entity_type"`
+	ListedName string   `json:"listed_name"`
+	Alias      bool     `json:"alias"`
+	Programs   []string `json:"programs,omitempty"`
+	Score      float64  `json:"score"`
+}
+
+// candidate is a single normalized name in the index.
+type candidate struct {
+	entry      *Entry
+	name       string
+	normalized string
+	alias      bool
+}
+
+// Screener matches names against the loaded sanctions lists.
+// It is safe for concurrent use; Load swaps the index atomically.
+type Screener struct {
+	cfg    ScreenerConfig
+	logger *zap.Logger
+
+	mu         sync.RWMutex
+	candidates []candidate
+	lists      []ListInfo
+}
+
+// NewScreener creates a screener. Call Load before screening.
+func NewScreener(cfg ScreenerConfig, logger *zap.Logger) *Screener {
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = 0.90
+	}
+	if cfg.AliasThreshold <= 0 {
+		cfg.AliasThreshold = cfg.Threshold
+	}
+	return &Screener{cfg: cfg, logger: logger}
+}
+
+// Load parses all configured lists and replaces the index.
+// On error the previous index is kept.
+func (s *Screener) Load() error {
+	type source struct {
+		src   Source
+		path  string
+		parse func(f *os.File) ([]Entry, error)
+	}
+	sources := []source{
+		{SourceOFAC, s.cfg.OFACPath, func(f *os.File) ([]Entry, error) {
+			if s.cfg.OFACAltPath == "" {
+				return ParseOFAC(f, nil)
+			}
+			alt, err := os.Open(s.cfg.OFACAltPath)
+			if err != nil {
+				return nil, err
+			}
+			defer alt.Close()
+			return ParseOFAC(f, alt)
+		}},
+		{SourceEU, s.cfg.EUPath, func(f *os.File) ([]Entry, error) { return ParseEU(f) }},
+		{SourceUK, s.cfg.UKPath, func(f *os.File) ([]Entry, error) { return ParseUK(f) }},
+	}
+
+	var entries []Entry
+	var lists []ListInfo
+	for _, src := range sources {
+		if src.path == "" {
+			continue
+		}
+
+		f, err := os.Open(src.path)
+		if err != nil {
+			return fmt.Errorf("open %s list: %w", src.src, err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("stat %s list: %w", src.src, err)
+		}
+		parsed, err := src.parse(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, parsed...)
+		lists = append(lists, ListInfo{
+			Source:   src.src,
+			Path:     src.path,
+			Entries:  len(parsed),
+			LoadedAt: time.Now().UTC(),
+			ModTime:  info.ModTime().UTC(),
+		})
+	}
+
+	s.Replace(entries, lists)
+
+	for _, l := range lists {
+		s.logger.Info("loaded sanctions list",
+			zap.String("source", string(l.Source)),
+			zap.Int("entries", l.Entries),
+		)
+	}
+	return nil
+}
+
+// Replace swaps the index for the given entries.
+func (s *Screener) Replace(entries []Entry, lists []ListInfo) {
+	candidates := make([]candidate, 0, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if n := Normalize(e.Name); n != "" {
+			candidates = append(candidates, candidate{entry: e, name: e.Name, normalized: n})
+		}
+		for _, alias := range e.Aliases {
+			if n := Normalize(alias); n != "" {
+				candidates = append(candidates, candidate{entry: e, name: alias, normalized: n, alias: true})
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.candidates = candidates
+	s.lists = lists
+	s.mu.Unlock()
+}
+
+// Lists returns the currently loaded lists.
+func (s *Screener) Lists() []ListInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]ListInfo(nil), s.lists...)
+}
+
+// Screen returns the listed parties matching name, best match first.
+// Each listed party appears at most once, with its best-scoring name.
+func (s *Screener) Screen(name string) ([]Match, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.candidates) == 0 {
+		if s.cfg.AllowEmpty {
+			return nil, nil
+		}
+		return nil, ErrNoLists
+	}
+
+	query := Normalize(name)
+	if query == "" {
+		return nil, nil
+	}
+
+	best := make(map[*Entry]Match)
+	for _, c := range s.candidates {
+		if c.alias && !s.cfg.MatchAliases {
+			continue
+		}
+		if !comparableLength(query, c.normalized) {
+			continue
+		}
+
+		threshold := s.cfg.Threshold
+		if c.alias {
+			threshold = s.cfg.AliasThreshold
+		}
+
+		score := Similarity(query, c.normalized)
+		if score < threshold {
+			continue
+		}
+		if prev, ok := best[c.entry]; ok && prev.Score >= score {
+			continue
+		}
+		best[c.entry] = Match{
+			Source:     c.entry.Source,
+			ExternalID: c.entry.ExternalID,
+			EntityType: c.entry.EntityType,
+			ListedName: c.name,
+			Alias:      c.alias,
+			Programs:   c.entry.Programs,
+			Score:      score,
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for _, m := range best {
+		matches = append(matches, m)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ExternalID < matches[j].ExternalID
+	})
+	return matches, nil
+}
+
+// Watch reloads the lists every interval if any file changed, until ctx is cancelled.
+func (s *Screener) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.changed() {
+				continue
+			}
+			if err := s.Load(); err != nil {
+				s.logger.Error("failed to reload sanctions lists", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Screener) changed() bool {
+	for _, l := range s.Lists() {
+		info, err := os.Stat(l.Path)
+		if err == nil && !info.ModTime().UTC().Equal(l.ModTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// comparableLength skips pairs whose lengths differ too much to reach any
+// useful threshold, which keeps full-list scans cheap.
+func comparableLength(a, b string) bool {
+	la, lb := len(a), len(b)
+	if la > lb {
+		la, lb = lb, la
+	}
+	return la*2 >= lb
+}
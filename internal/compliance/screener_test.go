@@ -0,0 +1,137 @@
+package compliance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testSDN = `36,"AEROCARIBBEAN AIRLINES",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- 
+173,"ANGLO-CARIBBEAN CO., LTD.",-0- ,"CUBA",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- 
+2674,"HUSSEIN, Saddam","individual","IRAQ2] [SDGT",-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- ,-0- 
+`
+
+const testAlt = `36,12,"aka","AERO-CARIBBEAN",-0- 
+2674,220,"aka","AL-TIKRITI, Saddam Hussein",-0- 
+`
+
+const testEU = `<?xml version="1.0" encoding="UTF-8"?>
+<export xmlns="http://eu.europa.ec/fpi/fsd/export">
+  <sanctionEntity logicalId="13" euReferenceNumber="EU.27.28">
+    <regulation programme="IRQ"/>
+    <subjectType code="person"/>
+    <nameAlias wholeName="Saddam Hussein Al-Tikriti" strong="true"/>
+    <nameAlias wholeName="Abu Ali" strong="false"/>
+  </sanctionEntity>
+  <sanctionEntity logicalId="14">
+    <regulation programme="SYR"/>
+    <subjectType code="enterprise"/>
+    <nameAlias wholeName="Syrian Petroleum Company" strong="true"/>
+  </sanctionEntity>
+</export>`
+
+const testUK = `Last Updated,01/01/2026
+Name 6,Name 1,Name 2,Name 3,Name 4,Name 5,Title,Alias Type,Group Type,Regime,Group ID
+HUSSEIN,Saddam,,,,,,Primary name,Individual,Iraq,7001
+AL-TIKRITI,Saddam,Hussein,,,,,AKA,Individual,Iraq,7001
+"KORYO BANK",,,,,,,Primary name,Entity,Democratic People's Republic of Korea,7002
+`
+
+func TestParseOFAC(t *testing.T) {
+	entries, err := ParseOFAC(strings.NewReader(testSDN), strings.NewReader(testAlt))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "36", entries[0].ExternalID)
+	assert.Equal(t, EntityTypeEntity, entries[0].EntityType)
+	assert.Equal(t, []string{"AERO-CARIBBEAN"}, entries[0].Aliases)
+
+	assert.Equal(t, EntityTypeIndividual, entries[2].EntityType)
+	assert.Equal(t, []string{"IRAQ2", "SDGT"}, entries[2].Programs)
+	assert.Equal(t, []string{"AL-TIKRITI, Saddam Hussein"}, entries[2].Aliases)
+}
+
+func TestParseEU(t *testing.T) {
+	entries, err := ParseEU(strings.NewReader(testEU))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "EU.27.28", entries[0].ExternalID)
+	assert.Equal(t, EntityTypeIndividual, entries[0].EntityType)
+	assert.Equal(t, "Saddam Hussein Al-Tikriti", entries[0].Name)
+	assert.Equal(t, []string{"Abu Ali"}, entries[0].Aliases)
+
+	assert.Equal(t, "14", entries[1].ExternalID)
+	assert.Equal(t, EntityTypeEntity, entries[1].EntityType)
+	assert.Equal(t, []string{"SYR"}, entries[1].Programs)
+}
+
+func TestParseUK(t *testing.T) {
+	entries, err := ParseUK(strings.NewReader(testUK))
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "7001", entries[0].ExternalID)
+	assert.Equal(t, "Saddam HUSSEIN", entries[0].Name)
+	assert.Equal(t, []string{"Saddam Hussein AL-TIKRITI"}, entries[0].Aliases)
+	assert.Equal(t, "KORYO BANK", entries[1].Name)
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "bank negara", Normalize("PT. Bank Négara, Tbk"))
+	assert.Equal(t, "anglo caribbean", Normalize("ANGLO-CARIBBEAN CO., LTD."))
+	assert.Equal(t, "", Normalize("Ltd."))
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, Similarity("saddam hussein", "saddam hussein"))
+	assert.Greater(t, Similarity("hussein saddam", "saddam hussein"), 0.99)
+	assert.Greater(t, Similarity("al qaida", "alqaeda"), 0.90)
+	assert.Less(t, Similarity("bank negara", "koryo bank"), 0.90)
+}
+
+func TestScreenerScreen(t *testing.T) {
+	entries, err := ParseOFAC(strings.NewReader(testSDN), strings.NewReader(testAlt))
+	require.NoError(t, err)
+
+	s := NewScreener(ScreenerConfig{Threshold: 0.90, AliasThreshold: 0.93, MatchAliases: true}, zap.NewNop())
+	s.Replace(entries, nil)
+
+	matches, err := s.Screen("Sadam Husein")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "2674", matches[0].ExternalID)
+	assert.False(t, matches[0].Alias)
+
+	matches, err = s.Screen("Aero Caribbean")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "36", matches[0].ExternalID)
+
+	matches, err = s.Screen("PT Sinar Export Indonesia")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestScreenerAliasesDisabled(t *testing.T) {
+	s := NewScreener(ScreenerConfig{Threshold: 0.95}, zap.NewNop())
+	s.Replace([]Entry{{Source: SourceEU, ExternalID: "1", Name: "Syrian Petroleum Company", Aliases: []string{"Sytrol"}}}, nil)
+
+	matches, err := s.Screen("Sytrol")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestScreenerFailsClosed(t *testing.T) {
+	s := NewScreener(ScreenerConfig{}, zap.NewNop())
+	_, err := s.Screen("anyone")
+	assert.ErrorIs(t, err, ErrNoLists)
+
+	s = NewScreener(ScreenerConfig{AllowEmpty: true}, zap.NewNop())
+	matches, err := s.Screen("anyone")
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
@@ -0,0 +1,124 @@
+package compliance
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseUK parses the UK HMT consolidated list (ConList.csv).
+//
+// The file starts with a "Last Updated" line followed by a header row, and
+// has one row per name: rows sharing a Group ID belong to the same party.
+func ParseUK(r io.Reader) ([]Entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var col map[string]int
+	var entries []Entry
+	index := make(map[string]int)
+
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse UK list: %w", err)
+		}
+
+		if col == nil {
+			if h := ukHeader(rec); h != nil {
+				col = h
+			}
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		groupID := get("group id")
+		name := ukName(get)
+		if groupID == "" || name == "" {
+			continue
+		}
+
+		i, ok := index[groupID]
+		if !ok {
+			i = len(entries)
+			index[groupID] = i
+			entries = append(entries, Entry{
+				Source:     SourceUK,
+				ExternalID: groupID,
+				EntityType: ukEntityType(get("group type")),
+			})
+			if regime := get("regime"); regime != "" {
+				entries[i].Programs = []string{regime}
+			}
+		}
+
+		e := &entries[i]
+		primary := strings.Contains(strings.ToLower(get("alias type")), "primary")
+		if e.Name == "" && primary {
+			e.Name = name
+		} else {
+			e.Aliases = append(e.Aliases, name)
+		}
+	}
+
+	if col == nil {
+		return nil, errors.New("parse UK list: header row not found")
+	}
+
+	// Parties listed only under aliases: promote the first one
+	result := entries[:0]
+	for _, e := range entries {
+		if e.Name == "" && len(e.Aliases) > 0 {
+			e.Name, e.Aliases = e.Aliases[0], e.Aliases[1:]
+		}
+		if e.Name != "" {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func ukHeader(rec []string) map[string]int {
+	col := make(map[string]int, len(rec))
+	for i, h := range rec {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["group id"]; !ok {
+		return nil
+	}
+	return col
+}
+
+// ukName joins the given names (Name 1-5) and the surname (Name 6).
+func ukName(get func(string) string) string {
+	var parts []string
+	for _, c := range []string{"name 1", "name 2", "name 3", "name 4", "name 5", "name 6"} {
+		if v := get(c); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func ukEntityType(groupType string) string {
+	switch strings.ToLower(groupType) {
+	case "individual":
+		return EntityTypeIndividual
+	case "ship":
+		return EntityTypeVessel
+	default:
+		return EntityTypeEntity
+	}
+}
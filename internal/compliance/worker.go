@@ -0,0 +1,355 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/liquidity"
+	"kovra/internal/models"
+	"kovra/internal/monitoring"
+	"kovra/internal/outbox"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+// ScreenedBySanctions identifies automated sanctions screening in compliance logs.
+const ScreenedBySanctions = "system:sanctions"
+
+// ScreenedByMonitoring identifies transaction monitoring in compliance logs.
+const ScreenedByMonitoring = "system:monitoring"
+
+// ScreenTransferArgs are the arguments of the transfer screening job.
+type ScreenTransferArgs struct {
+	TransferID uuid.UUID `json:"transfer_id"`
+}
+
+// Kind returns the job kind.
+func (ScreenTransferArgs) Kind() string { return "compliance.screen_transfer" }
+
+// InsertOpts returns the default insert options. One screening job per transfer.
+func (a ScreenTransferArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "compliance", MaxAttempts: 10, UniqueKey: a.TransferID.String()}
+}
+
+// screeningReport is stored as the raw response of a compliance log.
+type screeningReport struct {
+	Subjects []screenedSubject `json:"subjects"`
+	Lists    []ListInfo        `json:"lists"`
+}
+
+type screenedSubject struct {
+	Role    string  `json:"role"`
+	Name    string  `json:"name"`
+	Matches []Match `json:"matches"`
+}
+
+// ScreenTransferWorker runs the validating step of a transfer: it screens the
+// tenant and recipient names and runs the monitoring rules, then either
+// releases the transfer to processing or holds it in validating and opens a
+// review case.
+type ScreenTransferWorker struct {
+	db            *db.DB
+	screener      *Screener
+	monitor       *monitoring.Monitor
+	cases         *CaseService
+	liquidity     *liquidity.Service
+	transferRepo  *repository.TransferRepository
+	tenantRepo    *repository.TenantRepository
+	recipientRepo *repository.RecipientRepository
+	logRepo       *repository.ComplianceLogRepository
+	outboxRepo    *repository.OutboxRepository
+	logger        *zap.Logger
+}
+
+// NewScreenTransferWorker creates a new transfer screening worker.
+func NewScreenTransferWorker(
+	database *db.DB,
+	screener *Screener,
+	monitor *monitoring.Monitor,
+	cases *CaseService,
+	liquidityService *liquidity.Service,
+	transferRepo *repository.TransferRepository,
+	tenantRepo *repository.TenantRepository,
+	recipientRepo *repository.RecipientRepository,
+	logRepo *repository.ComplianceLogRepository,
+	outboxRepo *repository.OutboxRepository,
+	logger *zap.Logger,
+) *ScreenTransferWorker {
+	return &ScreenTransferWorker{
+		db:            database,
+		screener:      screener,
+		monitor:       monitor,
+		cases:         cases,
+		liquidity:     liquidityService,
+		transferRepo:  transferRepo,
+		tenantRepo:    tenantRepo,
+		recipientRepo: recipientRepo,
+		logRepo:       logRepo,
+		outboxRepo:    outboxRepo,
+		logger:        logger,
+	}
+}
+
+// Work runs the screening job. It is idempotent: transfers that are past
+// validating or already screened are left untouched.
+func (w *ScreenTransferWorker) Work(ctx context.Context, job *jobs.Job[ScreenTransferArgs]) error {
+	transfer, err := w.transferRepo.GetByID(ctx, job.Args.TransferID)
+	if err != nil {
+		return fmt.Errorf("get transfer: %w", err)
+	}
+	if transfer == nil {
+		return jobs.Cancel(fmt.Errorf("transfer %s not found", job.Args.TransferID))
+	}
+
+	if transfer.Status == models.TransferStatusCreated {
+		moved, err := w.transition(ctx, transfer, models.TransferStatusValidating)
+		if err != nil {
+			return err
+		}
+		if !moved {
+			return nil
+		}
+		transfer.Status = models.TransferStatusValidating
+	}
+
+	if transfer.Status != models.TransferStatusValidating ||
+		transfer.ComplianceStatus != models.ComplianceStatusPending {
+		return nil
+	}
+
+	report, err := w.screen(ctx, transfer)
+	if err != nil {
+		return err
+	}
+
+	monitored, err := w.monitor.Evaluate(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("evaluate monitoring rules: %w", err)
+	}
+
+	return w.record(ctx, transfer, report, monitored)
+}
+
+// screen matches the parties of a transfer against the sanctions lists.
+func (w *ScreenTransferWorker) screen(ctx context.Context, transfer *models.Transfer) (*screeningReport, error) {
+	tenant, err := w.tenantRepo.GetByID(ctx, transfer.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, jobs.Cancel(fmt.Errorf("tenant %s not found", transfer.TenantID))
+	}
+
+	subjects := []screenedSubject{{Role: "tenant", Name: tenant.LegalName}}
+	if tenant.DisplayName != "" && tenant.DisplayName != tenant.LegalName {
+		subjects = append(subjects, screenedSubject{Role: "tenant", Name: tenant.DisplayName})
+	}
+
+	if transfer.RecipientID != nil {
+		recipient, err := w.recipientRepo.GetByID(ctx, *transfer.RecipientID)
+		if err != nil {
+			return nil, fmt.Errorf("get recipient: %w", err)
+		}
+		if recipient != nil {
+			subjects = append(subjects, screenedSubject{Role: "recipient", Name: recipient.Name})
+		}
+	}
+
+	for i := range subjects {
+		matches, err := w.screener.Screen(subjects[i].Name)
+		if err != nil {
+			return nil, err
+		}
+		subjects[i].Matches = matches
+	}
+
+	return &screeningReport{Subjects: subjects, Lists: w.screener.Lists()}, nil
+}
+
+// record stores the screening and monitoring outcome and moves the transfer
+// on. A sanctions hit or a monitoring score at the region's review threshold
+// keeps the transfer in validating with compliance status review. The risk
+// score of the transfer is the higher of the two.
+func (w *ScreenTransferWorker) record(ctx context.Context, transfer *models.Transfer, report *screeningReport, monitored monitoring.Result) error {
+	var best float64
+	for _, s := range report.Subjects {
+		for _, m := range s.Matches {
+			best = math.Max(best, m.Score)
+		}
+	}
+	sanctionsScore := int(math.Round(best * 100))
+	score := max(sanctionsScore, monitored.Score)
+
+	result := models.ScreeningResultClear
+	if best > 0 {
+		result = models.ScreeningResultHit
+	}
+	monitoringResult := models.ScreeningResultClear
+	if len(monitored.Alerts) > 0 {
+		monitoringResult = models.ScreeningResultHit
+	}
+
+	held := result == models.ScreeningResultHit || monitored.Review
+	complianceStatus := models.ComplianceStatusCleared
+	if held {
+		complianceStatus = models.ComplianceStatusReview
+	}
+
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal screening report: %w", err)
+	}
+	monitoringRaw, err := json.Marshal(monitored)
+	if err != nil {
+		return fmt.Errorf("marshal monitoring result: %w", err)
+	}
+
+	var cancelled bool
+	err = w.db.WithTx(ctx, func(tx pgx.Tx) error {
+		// Locked so that the transfer cannot be cancelled meanwhile
+		current, err := w.transferRepo.WithTx(tx).GetForUpdate(ctx, transfer.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Status != models.TransferStatusValidating {
+			cancelled = true
+			return nil
+		}
+
+		if _, err := w.logRepo.WithTx(tx).Create(ctx, models.CreateComplianceLogParams{
+			ComplianceRegion: transfer.ComplianceRegion,
+			TransferID:       transfer.ID,
+			TenantID:         transfer.TenantID,
+			ScreeningType:    models.ScreeningTypeSanctions,
+			Result:           result,
+			RiskScore:        &sanctionsScore,
+			RawResponse:      raw,
+			ScreenedBy:       ScreenedBySanctions,
+		}); err != nil {
+			return err
+		}
+
+		if _, err := w.logRepo.WithTx(tx).Create(ctx, models.CreateComplianceLogParams{
+			ComplianceRegion: transfer.ComplianceRegion,
+			TransferID:       transfer.ID,
+			TenantID:         transfer.TenantID,
+			ScreeningType:    models.ScreeningTypeMonitoring,
+			Result:           monitoringResult,
+			RiskScore:        &monitored.Score,
+			RawResponse:      monitoringRaw,
+			ScreenedBy:       ScreenedByMonitoring,
+		}); err != nil {
+			return err
+		}
+		if err := w.monitor.RecordTx(ctx, tx, transfer, monitored); err != nil {
+			return err
+		}
+
+		if err := w.transferRepo.WithTx(tx).UpdateComplianceStatus(ctx, transfer.ID, complianceStatus, &score); err != nil {
+			return err
+		}
+		transfer.ComplianceStatus = complianceStatus
+
+		if held {
+			reason := models.CaseReasonSanctionsHit
+			if result != models.ScreeningResultHit {
+				reason = models.CaseReasonMonitoringAlert
+			}
+			// The transfer stays in validating until the case is decided
+			if _, err := w.cases.OpenTx(ctx, tx, transfer, reason, score); err != nil {
+				return err
+			}
+			return appendStatusEvent(ctx, w.outboxRepo.WithTx(tx), transfer, models.TransferStatusValidating, nil)
+		}
+
+		admitted, err := w.liquidity.AdmitTx(ctx, tx, transfer)
+		if err != nil {
+			return err
+		}
+		if !admitted {
+			// The transfer stays in validating until the settlement account is topped up
+			return appendStatusEvent(ctx, w.outboxRepo.WithTx(tx), transfer, models.TransferStatusValidating, nil)
+		}
+
+		if err := w.transferRepo.WithTx(tx).UpdateStatus(ctx, transfer.ID, models.TransferStatusProcessing, nil); err != nil {
+			return err
+		}
+		return appendStatusEvent(ctx, w.outboxRepo.WithTx(tx), transfer, models.TransferStatusProcessing, nil)
+	})
+	if err != nil {
+		return fmt.Errorf("record screening: %w", err)
+	}
+	if cancelled {
+		return nil
+	}
+
+	if held {
+		w.logger.Warn("transfer held for compliance review",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.Bool("sanctions_hit", result == models.ScreeningResultHit),
+			zap.Int("monitoring_alerts", len(monitored.Alerts)),
+			zap.Int("risk_score", score),
+		)
+	}
+	return nil
+}
+
+// transition moves a transfer to status and records the event. It reports
+// false, changing nothing, if the transfer has left its status meanwhile.
+func (w *ScreenTransferWorker) transition(ctx context.Context, transfer *models.Transfer, status models.TransferStatus) (bool, error) {
+	moved := false
+	err := w.db.WithTx(ctx, func(tx pgx.Tx) error {
+		current, err := w.transferRepo.WithTx(tx).GetForUpdate(ctx, transfer.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil || current.Status != transfer.Status {
+			return nil
+		}
+
+		if err := w.transferRepo.WithTx(tx).UpdateStatus(ctx, transfer.ID, status, nil); err != nil {
+			return err
+		}
+		moved = true
+		return appendStatusEvent(ctx, w.outboxRepo.WithTx(tx), transfer, status, nil)
+	})
+	return moved, err
+}
+
+// appendStatusEvent records a transfer.status_changed event for a transfer
+// moving from its current status to status.
+func appendStatusEvent(ctx context.Context, outboxRepo *repository.OutboxRepository, transfer *models.Transfer, status models.TransferStatus, failureReason *string) error {
+	previous := transfer.Status
+	event, err := outbox.NewEvent(models.AggregateTransfer, transfer.ID, &transfer.TenantID,
+		string(models.WebhookEventTransferStatusChanged),
+		webhook.NewTransferStatusChanged(transfer, status, &previous, failureReason))
+	if err != nil {
+		return err
+	}
+	return outboxRepo.Append(ctx, event)
+}
+
+// EnqueueOnCreate returns an outbox handler that schedules screening for
+// newly created transfers, in the relay transaction.
+func EnqueueOnCreate(client *jobs.Client) outbox.HandlerFunc {
+	return func(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error {
+		var data webhook.TransferStatusChanged
+		if err := json.Unmarshal(event.Payload, &data); err != nil {
+			return fmt.Errorf("decode %s: %w", event.EventType, err)
+		}
+		if data.Status != models.TransferStatusCreated {
+			return nil
+		}
+
+		_, err := client.InsertTx(ctx, tx, ScreenTransferArgs{TransferID: data.TransferID}, nil)
+		return err
+	}
+}
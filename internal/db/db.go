@@ -3,25 +3,54 @@ package db
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"kovra/internal/config"
+	"kovra/internal/models"
 )
 
-// DB wraps a PostgreSQL connection pool.
+// DB wraps a PostgreSQL connection pool, and the pools of the regional
+// databases holding the transfers of their compliance region, if any.
 type DB struct {
-	pool *pgxpool.Pool
+	pool    *pgxpool.Pool
+	regions map[models.ComplianceRegion]*pgxpool.Pool
 }
 
 // New creates a new database connection pool.
 func New(ctx context.Context, cfg config.DatabaseConfig) (*DB, error) {
-	poolConfig, err := pgxpool.ParseConfig(cfg.URL)
+	pool, err := connect(ctx, cfg.URL, cfg.MaxConns)
+	if err != nil {
+		return nil, err
+	}
+	db := &DB{pool: pool, regions: make(map[models.ComplianceRegion]*pgxpool.Pool)}
+
+	for name, url := range cfg.RegionURLs {
+		region := models.ComplianceRegion(name)
+		if RegionRole(region) == "" {
+			db.Close()
+			return nil, fmt.Errorf("database for unknown region %q", name)
+		}
+		regional, err := connect(ctx, url, cfg.MaxConns)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("%s: %w", region, err)
+		}
+		db.regions[region] = regional
+	}
+
+	return db, nil
+}
+
+func connect(ctx context.Context, url string, maxConns int32) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
 	if err != nil {
 		return nil, fmt.Errorf("parse database URL: %w", err)
 	}
 
-	poolConfig.MaxConns = cfg.MaxConns
+	poolConfig.MaxConns = maxConns
+	poolConfig.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
@@ -34,20 +63,53 @@ func New(ctx context.Context, cfg config.DatabaseConfig) (*DB, error) {
 		return nil, fmt.Errorf("ping database: %w", err)
 	}
 
-	return &DB{pool: pool}, nil
+	return pool, nil
+}
+
+// FromPool wraps an existing connection pool.
+func FromPool(pool *pgxpool.Pool) *DB {
+	return &DB{pool: pool}
 }
 
-// Pool returns the underlying connection pool.
+// WithRegions adds the pools of regional databases, as New does from the
+// configuration.
+func (db *DB) WithRegions(regions map[models.ComplianceRegion]*pgxpool.Pool) *DB {
+	return &DB{pool: db.pool, regions: regions}
+}
+
+// Pool returns the underlying connection pool of the primary database.
 func (db *DB) Pool() *pgxpool.Pool {
 	return db.pool
 }
 
-// Close closes all connections in the pool.
+// Regions returns the compliance regions with a database of their own, in
+// order.
+func (db *DB) Regions() []models.ComplianceRegion {
+	regions := make([]models.ComplianceRegion, 0, len(db.regions))
+	for region := range db.regions {
+		regions = append(regions, region)
+	}
+	slices.Sort(regions)
+	return regions
+}
+
+// Close closes all connections in the pools.
 func (db *DB) Close() {
 	db.pool.Close()
+	for _, pool := range db.regions {
+		pool.Close()
+	}
 }
 
-// Ping verifies the database connection is alive.
+// Ping verifies the database connections are alive.
 func (db *DB) Ping(ctx context.Context) error {
-	return db.pool.Ping(ctx)
+	if err := db.pool.Ping(ctx); err != nil {
+		return err
+	}
+	for _, region := range db.Regions() {
+		if err := db.regions[region].Ping(ctx); err != nil {
+			return fmt.Errorf("%s: %w", region, err)
+		}
+	}
+	return nil
 }
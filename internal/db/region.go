@@ -0,0 +1,223 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+)
+
+// GlobalRole is the database role that reads every region, for auditors.
+const GlobalRole = "kovra_global"
+
+// RegionRole returns the database role confined to a compliance region by
+// the data residency policies on transfers, or "" for a region without one.
+func RegionRole(region models.ComplianceRegion) string {
+	switch region {
+	case models.ComplianceRegionID:
+		return "kovra_id_region"
+	case models.ComplianceRegionEU:
+		return "kovra_eu_region"
+	case models.ComplianceRegionUK:
+		return "kovra_uk_region"
+	}
+	return ""
+}
+
+type regionKey struct{}
+
+// Confine returns a context whose queries run as the role of region, so
+// that they see only the transfers of that region. Queries of a context
+// without a region run as the app user and see every region.
+func Confine(ctx context.Context, region models.ComplianceRegion) context.Context {
+	return context.WithValue(ctx, regionKey{}, region)
+}
+
+// ConfinedRegion returns the region the queries of ctx are confined to.
+func ConfinedRegion(ctx context.Context) (models.ComplianceRegion, bool) {
+	region, ok := ctx.Value(regionKey{}).(models.ComplianceRegion)
+	return region, ok
+}
+
+// setRegionRole switches a transaction to the role of the context's region,
+// if it has one. The role reverts when the transaction ends.
+func setRegionRole(ctx context.Context, tx pgx.Tx) error {
+	region, ok := ConfinedRegion(ctx)
+	if !ok {
+		return nil
+	}
+	return SetRole(ctx, tx, RegionRole(region))
+}
+
+// SetRole switches a transaction to a database role until it ends.
+func SetRole(ctx context.Context, tx pgx.Tx, role string) error {
+	if role == "" {
+		return errors.New("no database role for region")
+	}
+	if _, err := tx.Exec(ctx, "SET LOCAL ROLE "+pgx.Identifier{role}.Sanitize()); err != nil {
+		return fmt.Errorf("set role: %w", err)
+	}
+	return nil
+}
+
+// RegionPool runs queries on the database their context is routed to, or
+// its own pool. Queries of a context confined to a region run in a
+// transaction of their own as the region's role; other queries go straight
+// to the pool. Repositories query through it so that a regional caller
+// never reads another region's transfers, whichever repository method it
+// reaches.
+type RegionPool struct {
+	pool *pgxpool.Pool
+}
+
+// NewRegionPool wraps a connection pool.
+func NewRegionPool(pool *pgxpool.Pool) *RegionPool {
+	return &RegionPool{pool: pool}
+}
+
+// begin starts the transaction of a confined query, or returns nil if the
+// context is not confined to a region.
+func (p *RegionPool) begin(ctx context.Context) (pgx.Tx, error) {
+	if _, ok := ConfinedRegion(ctx); !ok {
+		return nil, nil
+	}
+	tx, err := routedPool(ctx, p.pool).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := setRegionRole(ctx, tx); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Exec executes a statement.
+func (p *RegionPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	if tx == nil {
+		return routedPool(ctx, p.pool).Exec(ctx, sql, args...)
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return tag, err
+	}
+	return tag, tx.Commit(ctx)
+}
+
+// Query runs a query. A confined query commits once its rows are read.
+func (p *RegionPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return routedPool(ctx, p.pool).Query(ctx, sql, args...)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	return &txRows{Rows: rows, ctx: ctx, tx: tx}, nil
+}
+
+// QueryRow runs a query returning at most one row. A confined query
+// commits once the row is scanned.
+func (p *RegionPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return errRow{err: err}
+	}
+	if tx == nil {
+		return routedPool(ctx, p.pool).QueryRow(ctx, sql, args...)
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return errRow{err: err}
+	}
+	return &txRow{rows: &txRows{Rows: rows, ctx: ctx, tx: tx}}
+}
+
+// txRows ends the transaction of a confined query when its rows are
+// exhausted or closed, and reports a failed commit through Err.
+type txRows struct {
+	pgx.Rows
+	ctx  context.Context
+	tx   pgx.Tx
+	done bool
+	err  error
+}
+
+func (r *txRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.finish()
+	return false
+}
+
+func (r *txRows) Close() {
+	r.finish()
+}
+
+func (r *txRows) Err() error {
+	if err := r.Rows.Err(); err != nil {
+		return err
+	}
+	return r.err
+}
+
+func (r *txRows) finish() {
+	if r.done {
+		return
+	}
+	r.done = true
+	r.Rows.Close()
+	if r.Rows.Err() != nil {
+		r.tx.Rollback(r.ctx)
+		return
+	}
+	r.err = r.tx.Commit(r.ctx)
+}
+
+// txRow scans the first row of a confined query, as pgx.Row does.
+type txRow struct {
+	rows *txRows
+}
+
+func (r *txRow) Scan(dest ...any) error {
+	defer r.rows.Close()
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	r.rows.Close()
+	return r.rows.Err()
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
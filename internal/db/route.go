@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+)
+
+type routeKey struct{}
+
+// InRegion returns a context whose queries and transactions run on the
+// database holding the transfers of region: the region's own database if it
+// has one, the primary otherwise. Everything a transfer touches in a
+// transaction, such as its outbox events, refunds and compliance logs, is
+// written next to it; the reference data it reads, such as tenants and
+// wallets, is replicated from the primary.
+func (db *DB) InRegion(ctx context.Context, region models.ComplianceRegion) context.Context {
+	pool, ok := db.regions[region]
+	if !ok {
+		pool = db.pool
+	}
+	return context.WithValue(ctx, routeKey{}, pool)
+}
+
+// Scope returns a context for the transfer-scoped queries of a caller
+// confined to region: they run on the region's database as its role, so
+// they only see the transfers of that region. Without a region it returns
+// ctx. Reference data, such as tenants and wallets, is written on the
+// primary whatever the caller's region, so only the queries about
+// transfers take this context.
+func (db *DB) Scope(ctx context.Context, region models.ComplianceRegion) context.Context {
+	if region == "" {
+		return ctx
+	}
+	return Confine(db.InRegion(ctx, region), region)
+}
+
+// Databases returns a context for the primary and one for each regional
+// database, for reads spanning every region. A context already routed to a
+// database is returned alone.
+func (db *DB) Databases(ctx context.Context) []context.Context {
+	if _, ok := ctx.Value(routeKey{}).(*pgxpool.Pool); ok {
+		return []context.Context{ctx}
+	}
+	contexts := []context.Context{context.WithValue(ctx, routeKey{}, db.pool)}
+	for _, region := range db.Regions() {
+		contexts = append(contexts, db.InRegion(ctx, region))
+	}
+	return contexts
+}
+
+// Locate returns the context of the first database for which found reports
+// true, or ctx if there is none. With a single database, or a context
+// already routed, it returns ctx without asking.
+func (db *DB) Locate(ctx context.Context, found func(ctx context.Context) (bool, error)) (context.Context, error) {
+	if _, ok := ctx.Value(routeKey{}).(*pgxpool.Pool); ok || len(db.regions) == 0 {
+		return ctx, nil
+	}
+	for _, c := range db.Databases(ctx) {
+		ok, err := found(c)
+		if err != nil {
+			return ctx, err
+		}
+		if ok {
+			return c, nil
+		}
+	}
+	return ctx, nil
+}
+
+// PoolFor returns the pool the queries of ctx run on.
+func (db *DB) PoolFor(ctx context.Context) *pgxpool.Pool {
+	return routedPool(ctx, db.pool)
+}
+
+// PrimaryName is the name Name gives the primary database.
+const PrimaryName = "primary"
+
+// Name returns the name of the database the queries of ctx run on: the
+// region of a regional database, or PrimaryName. Reports spanning every
+// database tell them apart by it.
+func (db *DB) Name(ctx context.Context) string {
+	pool := db.PoolFor(ctx)
+	for region, regional := range db.regions {
+		if regional == pool {
+			return string(region)
+		}
+	}
+	return PrimaryName
+}
+
+// routedPool returns the pool ctx is routed to, or pool if it is not.
+func routedPool(ctx context.Context, pool *pgxpool.Pool) *pgxpool.Pool {
+	if routed, ok := ctx.Value(routeKey{}).(*pgxpool.Pool); ok {
+		return routed
+	}
+	return pool
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"kovra/internal/tracing"
+)
+
+// queryTracer records a span for every query run within a traced operation.
+// Queries outside of one, such as those of the polling loops, are not
+// traced, so that idle polls do not each start a trace.
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx
+	}
+	name := queryName(data.SQL)
+	ctx, _ = tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBQuerySummary(name)),
+	)
+	return ctx
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	if data.Err != nil && data.Err != pgx.ErrNoRows {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
+
+// queryName names a query by its sqlc name, from the "-- name: GetJobByID
+// :one" line sqlc prefixes it with, or else by its first keyword, such as
+// BEGIN or COMMIT.
+func queryName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) >= 3 && fields[0] == "--" && fields[1] == "name:" {
+		return fields[2]
+	}
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(fields[0])
+}
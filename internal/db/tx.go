@@ -9,13 +9,20 @@ import (
 
 // WithTx executes a function within a database transaction.
 // If the function returns an error, the transaction is rolled back.
-// Otherwise, the transaction is committed.
+// Otherwise, the transaction is committed. The transaction runs on the
+// database the context is routed to, as the role of the region it is
+// confined to, if any.
 func (db *DB) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
-	tx, err := db.pool.Begin(ctx)
+	tx, err := db.PoolFor(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
 
+	if err := setRegionRole(ctx, tx); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
 	if err := fn(tx); err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
 			return fmt.Errorf("rollback failed: %v (original error: %w)", rbErr, err)
@@ -34,11 +41,16 @@ func (db *DB) WithTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
 func WithTxResult[T any](ctx context.Context, db *DB, fn func(tx pgx.Tx) (T, error)) (T, error) {
 	var result T
 
-	tx, err := db.pool.Begin(ctx)
+	tx, err := db.PoolFor(ctx).Begin(ctx)
 	if err != nil {
 		return result, fmt.Errorf("begin transaction: %w", err)
 	}
 
+	if err := setRegionRole(ctx, tx); err != nil {
+		tx.Rollback(ctx)
+		return result, err
+	}
+
 	result, err = fn(tx)
 	if err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
@@ -0,0 +1,157 @@
+// Package export streams transfers out of the database for finance, as CSV
+// or newline-delimited JSON, without holding the export in memory.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/db"
+	"kovra/internal/models"
+)
+
+var (
+	ErrInvalidRequest   = errors.New("invalid export request")
+	ErrUnknownColumn    = errors.New("unknown export column")
+	ErrRegionNotAllowed = errors.New("export outside the caller's region")
+)
+
+// Format is the encoding of an export.
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// IsValid checks if the format is supported.
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatNDJSON
+}
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv"
+}
+
+// column is an exportable column and the SQL expression that reads it as
+// text, so that every value is written exactly as Postgres stores it.
+type column struct {
+	name string
+	expr string
+}
+
+// columns are the exportable columns of a transfer, in their default order.
+var columns = []column{
+	{"id", "id::text"},
+	{"created_at", "uuid_extract_timestamp(id)::text"},
+	{"tenant_id", "tenant_id::text"},
+	{"compliance_region", "compliance_region"},
+	{"status", "status::text"},
+	{"from_currency", "from_currency::text"},
+	{"to_currency", "to_currency::text"},
+	{"from_amount", "from_amount::text"},
+	{"to_amount", "to_amount::text"},
+	{"fx_rate", "fx_rate::text"},
+	{"total_fee", "total_fee::text"},
+	{"rail", "rail::text"},
+	{"rail_reference", "rail_reference::text"},
+	{"recipient_id", "recipient_id::text"},
+	{"batch_id", "batch_id::text"},
+	{"quote_id", "quote_id::text"},
+	{"source_legal_entity_id", "source_legal_entity_id::text"},
+	{"dest_legal_entity_id", "dest_legal_entity_id::text"},
+	{"idempotency_key", "idempotency_key::text"},
+	{"compliance_status", "compliance_status::text"},
+	{"risk_score", "risk_score::text"},
+	{"failure_reason", "failure_reason"},
+	{"updated_at", "updated_at::text"},
+	{"completed_at", "completed_at::text"},
+}
+
+// Columns returns the names of the exportable columns, in their default
+// order.
+func Columns() []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// ParseColumns parses a comma-separated list of column names. An empty list
+// selects every column.
+func ParseColumns(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return Columns(), nil
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := lookupColumn(name); !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func lookupColumn(name string) (column, bool) {
+	for _, c := range columns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return column{}, false
+}
+
+// Request selects the transfers of an export: those created in [From, To)
+// of a tenant, a compliance region, or both.
+type Request struct {
+	TenantID *uuid.UUID
+	Region   *models.ComplianceRegion
+	From     time.Time
+	To       time.Time
+	Format   Format
+	Columns  []string
+}
+
+// Validate checks the request is complete.
+func (r *Request) Validate() error {
+	if r.TenantID == nil && r.Region == nil {
+		return fmt.Errorf("%w: a tenant or a region is required", ErrInvalidRequest)
+	}
+	if r.Region != nil && db.RegionRole(*r.Region) == "" {
+		return fmt.Errorf("%w: unknown region %q", ErrInvalidRequest, *r.Region)
+	}
+	if r.From.IsZero() || r.To.IsZero() {
+		return fmt.Errorf("%w: from and to are required", ErrInvalidRequest)
+	}
+	if !r.From.Before(r.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidRequest)
+	}
+	if !r.Format.IsValid() {
+		return fmt.Errorf("%w: unsupported format %q", ErrInvalidRequest, r.Format)
+	}
+	if len(r.Columns) == 0 {
+		return fmt.Errorf("%w: no columns selected", ErrInvalidRequest)
+	}
+	for _, name := range r.Columns {
+		if _, ok := lookupColumn(name); !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownColumn, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/db"
+	"kovra/internal/models"
+)
+
+func TestParseColumns(t *testing.T) {
+	all, err := ParseColumns("")
+	require.NoError(t, err)
+	assert.Equal(t, Columns(), all)
+
+	cols, err := ParseColumns(" ID, from_amount,STATUS,id ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "from_amount", "status"}, cols)
+
+	_, err = ParseColumns("id,api_key_hash")
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+}
+
+func TestRequestValidate(t *testing.T) {
+	tenantID := uuid.New()
+	eu := models.ComplianceRegionEU
+	unknown := models.ComplianceRegionUnknown
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+
+	valid := Request{Region: &eu, From: from, To: to, Format: FormatCSV, Columns: Columns()}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(r *Request)
+	}{
+		{"no tenant or region", func(r *Request) { r.Region = nil }},
+		{"region without role", func(r *Request) { r.Region = &unknown }},
+		{"missing range", func(r *Request) { r.From = time.Time{} }},
+		{"empty range", func(r *Request) { r.To = r.From }},
+		{"unsupported format", func(r *Request) { r.Format = "parquet" }},
+		{"no columns", func(r *Request) { r.Columns = nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			assert.ErrorIs(t, r.Validate(), ErrInvalidRequest)
+		})
+	}
+
+	tenantOnly := valid
+	tenantOnly.Region = nil
+	tenantOnly.TenantID = &tenantID
+	assert.NoError(t, tenantOnly.Validate())
+}
+
+func TestExportConfinedToRegion(t *testing.T) {
+	eu, id := models.ComplianceRegionEU, models.ComplianceRegionID
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := Request{From: from, To: from.AddDate(0, 1, 0), Format: FormatCSV, Columns: Columns()}
+	ctx := db.Confine(context.Background(), eu)
+
+	// Refused before the database is touched
+	e := &Exporter{batchSize: defaultBatchSize}
+
+	other := req
+	other.Region = &id
+	_, err := e.Export(ctx, io.Discard, other)
+	assert.ErrorIs(t, err, ErrRegionNotAllowed)
+
+	global := req
+	tenantID := uuid.New()
+	global.TenantID = &tenantID
+	_, err = e.Export(ctx, io.Discard, global)
+	assert.ErrorIs(t, err, ErrRegionNotAllowed)
+}
+
+func TestDeclareQuery(t *testing.T) {
+	eu := models.ComplianceRegionEU
+	query, args := declareQuery(Request{Region: &eu, Columns: []string{"id", "created_at"}})
+
+	assert.Contains(t, query, "SELECT id::text, uuid_extract_timestamp(id)::text\nFROM transfers")
+	require.Len(t, args, 4)
+	assert.Equal(t, "EU", *args[3].(*string))
+}
+
+func TestWriters(t *testing.T) {
+	names := []string{"id", "from_amount", "failure_reason"}
+	rows := [][]pgtype.Text{
+		{{String: "a", Valid: true}, {String: "100.00", Valid: true}, {}},
+		{{String: "b", Valid: true}, {String: "5.50", Valid: true}, {String: `insufficient "funds", retry`, Valid: true}},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newRowWriter(&buf, FormatCSV, names)
+		require.NoError(t, err)
+		for _, row := range rows {
+			require.NoError(t, w.Write(row))
+		}
+		require.NoError(t, w.Flush())
+
+		assert.Equal(t, "id,from_amount,failure_reason\na,100.00,\nb,5.50,\"insufficient \"\"funds\"\", retry\"\n", buf.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, err := newRowWriter(&buf, FormatNDJSON, names)
+		require.NoError(t, err)
+		for _, row := range rows {
+			require.NoError(t, w.Write(row))
+		}
+		require.NoError(t, w.Flush())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, `{"id":"a","from_amount":"100.00","failure_reason":null}`, lines[0])
+
+		var second map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+		assert.Equal(t, `insufficient "funds", retry`, second["failure_reason"])
+	})
+}
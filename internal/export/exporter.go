@@ -0,0 +1,176 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"kovra/internal/db"
+)
+
+// defaultBatchSize is the number of rows fetched from the cursor at a time,
+// which bounds the memory an export holds.
+const defaultBatchSize = 1000
+
+// Exporter streams transfers through a server-side cursor.
+type Exporter struct {
+	db        *db.DB
+	batchSize int
+}
+
+// NewExporter creates a new transfer exporter.
+func NewExporter(database *db.DB) *Exporter {
+	return &Exporter{db: database, batchSize: defaultBatchSize}
+}
+
+// Export writes the transfers selected by req to w, oldest first, and
+// returns the number of rows written. The export reads a snapshot of the
+// database as the role of the requested region, or as the auditors' global
+// role for an export across regions, so row level security keeps the
+// transfers of other regions out of it whatever the filters say. A context
+// confined to a region may only export that region. With regional
+// databases, an export across regions reads each database in turn, so its
+// rows are oldest first within each of them. Nothing is written to w before
+// the first batch has been fetched.
+func (e *Exporter) Export(ctx context.Context, w io.Writer, req Request) (int64, error) {
+	if err := req.Validate(); err != nil {
+		return 0, err
+	}
+
+	role := db.GlobalRole
+	if req.Region != nil {
+		role = db.RegionRole(*req.Region)
+	}
+	if confined, ok := db.ConfinedRegion(ctx); ok && (req.Region == nil || *req.Region != confined) {
+		return 0, fmt.Errorf("%w: confined to %s", ErrRegionNotAllowed, confined)
+	}
+
+	databases := e.db.Databases(ctx)
+	if req.Region != nil {
+		databases = []context.Context{e.db.InRegion(ctx, *req.Region)}
+	}
+
+	var (
+		out   rowWriter
+		total int64
+	)
+	for _, dbCtx := range databases {
+		n, err := e.exportFrom(dbCtx, w, &out, req, role)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// exportFrom writes the transfers selected by req from the database of ctx,
+// creating out on the first batch fetched if it does not exist yet.
+func (e *Exporter) exportFrom(ctx context.Context, w io.Writer, out *rowWriter, req Request, role string) (int64, error) {
+	tx, err := e.db.PoolFor(ctx).BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	// Read-only, so there is nothing to commit
+	defer tx.Rollback(ctx)
+
+	if err := db.SetRole(ctx, tx, role); err != nil {
+		return 0, err
+	}
+
+	query, args := declareQuery(req)
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("declare cursor: %w", err)
+	}
+
+	var (
+		total int64
+		fetch = fmt.Sprintf("FETCH FORWARD %d FROM transfer_export", e.batchSize)
+	)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return total, fmt.Errorf("fetch transfers: %w", err)
+		}
+
+		if *out == nil {
+			if *out, err = newRowWriter(w, req.Format, req.Columns); err != nil {
+				rows.Close()
+				return total, err
+			}
+		}
+
+		n, err := writeRows(rows, *out, len(req.Columns))
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if err := (*out).Flush(); err != nil {
+			return total, fmt.Errorf("write export: %w", err)
+		}
+		if n < int64(e.batchSize) {
+			return total, nil
+		}
+	}
+}
+
+// writeRows writes a fetched batch and returns the number of rows in it.
+func writeRows(rows pgx.Rows, out rowWriter, width int) (int64, error) {
+	defer rows.Close()
+
+	row := make([]pgtype.Text, width)
+	dest := make([]any, width)
+	for i := range row {
+		dest[i] = &row[i]
+	}
+
+	var n int64
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return n, fmt.Errorf("scan transfer: %w", err)
+		}
+		if err := out.Write(row); err != nil {
+			return n, fmt.Errorf("write export: %w", err)
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, fmt.Errorf("fetch transfers: %w", err)
+	}
+	return n, nil
+}
+
+// declareQuery returns the statement declaring the export cursor. The
+// creation time bounds are mapped onto the UUIDv7 id range, as in the
+// transfer list.
+func declareQuery(req Request) (string, []any) {
+	exprs := make([]string, len(req.Columns))
+	for i, name := range req.Columns {
+		c, _ := lookupColumn(name)
+		exprs[i] = c.expr
+	}
+
+	var region *string
+	if req.Region != nil {
+		s := string(*req.Region)
+		region = &s
+	}
+
+	query := `DECLARE transfer_export NO SCROLL CURSOR FOR
+SELECT ` + strings.Join(exprs, ", ") + `
+FROM transfers
+WHERE id >= ts_to_uuid_min($1)
+  AND id < ts_to_uuid_min($2)
+  AND ($3::uuid IS NULL OR tenant_id = $3)
+  AND ($4::text IS NULL OR compliance_region = $4)
+ORDER BY id`
+
+	return query, []any{req.From, req.To, req.TenantID, region}
+}
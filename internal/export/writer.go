@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// rowWriter encodes the rows of an export. Rows are buffered until Flush.
+type rowWriter interface {
+	Write(row []pgtype.Text) error
+	Flush() error
+}
+
+func newRowWriter(w io.Writer, format Format, names []string) (rowWriter, error) {
+	if format == FormatNDJSON {
+		return newNDJSONWriter(w, names), nil
+	}
+	return newCSVWriter(w, names)
+}
+
+// csvWriter writes a header line and then a line per row, with NULL as an
+// empty field.
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVWriter(w io.Writer, names []string) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w), record: make([]string, len(names))}
+	if err := cw.w.Write(names); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(row []pgtype.Text) error {
+	for i, v := range row {
+		cw.record[i] = v.String
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// ndjsonWriter writes a JSON object per row, with the columns in their
+// selected order and NULL as null.
+type ndjsonWriter struct {
+	w     *bufio.Writer
+	names [][]byte
+	buf   []byte
+}
+
+func newNDJSONWriter(w io.Writer, names []string) *ndjsonWriter {
+	nw := &ndjsonWriter{w: bufio.NewWriter(w), names: make([][]byte, len(names))}
+	for i, name := range names {
+		nw.names[i], _ = json.Marshal(name)
+	}
+	return nw
+}
+
+func (nw *ndjsonWriter) Write(row []pgtype.Text) error {
+	buf := append(nw.buf[:0], '{')
+	for i, v := range row {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, nw.names[i]...)
+		buf = append(buf, ':')
+		if !v.Valid {
+			buf = append(buf, "null"...)
+			continue
+		}
+		s, err := json.Marshal(v.String)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, s...)
+	}
+	buf = append(buf, '}', '\n')
+	nw.buf = buf
+
+	_, err := nw.w.Write(buf)
+	return err
+}
+
+func (nw *ndjsonWriter) Flush() error {
+	return nw.w.Flush()
+}
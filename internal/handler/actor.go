@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+)
+
+// authenticated returns the caller of the request. Anonymous callers get a
+// 401, and ok is false.
+func authenticated(w http.ResponseWriter, r *http.Request) (actor auth.Actor, ok bool) {
+	actor = auth.ActorFromContext(r.Context())
+	if actor.Type == auth.ActorTypeAnonymous {
+		Unauthorized(w, "authentication required")
+		return auth.Actor{}, false
+	}
+	return actor, true
+}
+
+// operator returns the operator calling the request, for the actions whose
+// actor is recorded as who requested, reviewed or approved something. Such
+// identities are never taken from the request body, so the checks that a
+// second person approves compare authenticated callers. Anonymous callers
+// get a 401 and tenants a 403, and ok is false.
+func operator(w http.ResponseWriter, r *http.Request) (actor auth.Actor, ok bool) {
+	actor = auth.ActorFromContext(r.Context())
+	switch actor.Type {
+	case auth.ActorTypeOperator:
+		return actor, true
+	case auth.ActorTypeAnonymous:
+		Unauthorized(w, "authentication required")
+	default:
+		Forbidden(w, "only operators may perform this action")
+	}
+	return auth.Actor{}, false
+}
+
+// authorizeTenant reports whether the caller may act on the resources of a
+// tenant: operators on those of any tenant, and a tenant on its own only.
+// Anonymous callers get a 401 and other tenants a 403, and ok is false.
+func authorizeTenant(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID) (ok bool) {
+	actor := auth.ActorFromContext(r.Context())
+	switch {
+	case actor.Type == auth.ActorTypeOperator:
+		return true
+	case actor.Type == auth.ActorTypeAnonymous:
+		Unauthorized(w, "authentication required")
+	case actor.Type == auth.ActorTypeTenant && actor.ID == tenantID.String():
+		return true
+	default:
+		Forbidden(w, "not permitted for this tenant")
+	}
+	return false
+}
+
+// actingTenant returns the tenant a request creates resources for. A tenant
+// acts for itself, and may only name itself; an operator acts for the tenant
+// named in the request, and must name one. Otherwise the caller gets a 400,
+// 401 or 403, and ok is false.
+func actingTenant(w http.ResponseWriter, r *http.Request, named uuid.UUID) (tenantID uuid.UUID, ok bool) {
+	actor, ok := authenticated(w, r)
+	if !ok {
+		return uuid.Nil, false
+	}
+	if actor.Type == auth.ActorTypeOperator {
+		if named == uuid.Nil {
+			BadRequest(w, "tenant_id is required")
+			return uuid.Nil, false
+		}
+		return named, true
+	}
+	own, err := uuid.Parse(actor.ID)
+	if err != nil {
+		Forbidden(w, "not permitted for this tenant")
+		return uuid.Nil, false
+	}
+	if named != uuid.Nil && named != own {
+		Forbidden(w, "tenants may only act for themselves")
+		return uuid.Nil, false
+	}
+	return own, true
+}
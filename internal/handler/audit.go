@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// AuditHandler handles audit trail endpoints for auditors. The entries
+// written with a transfer are kept in its regional database, where each
+// region's chain continues on its own, so every endpoint reads all
+// databases.
+type AuditHandler struct {
+	db   *db.DB
+	repo *repository.AuditRepository
+}
+
+// NewAuditHandler creates a new audit handler.
+func NewAuditHandler(database *db.DB, repo *repository.AuditRepository) *AuditHandler {
+	return &AuditHandler{db: database, repo: repo}
+}
+
+// AuditChains are the chain heads of one database.
+type AuditChains struct {
+	Database string
+	Heads    []*models.AuditChainHead
+}
+
+// AuditReport is the verification of a region's chain in one database.
+type AuditReport struct {
+	Database string
+	*audit.Report
+}
+
+// List returns audit entries, newest first.
+// GET /api/v1/audit/entries
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.AuditFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if region := q.Get("region"); region != "" {
+		rg := models.ComplianceRegion(strings.ToUpper(region))
+		filter.ComplianceRegion = &rg
+	}
+
+	if tenantStr := q.Get("tenant_id"); tenantStr != "" {
+		tenantID, err := uuid.Parse(tenantStr)
+		if err != nil {
+			BadRequest(w, "invalid tenant_id")
+			return
+		}
+		filter.TenantID = &tenantID
+	}
+
+	if resourceType := q.Get("resource_type"); resourceType != "" {
+		filter.ResourceType = &resourceType
+	}
+
+	if resourceID := q.Get("resource_id"); resourceID != "" {
+		filter.ResourceID = &resourceID
+	}
+
+	if actorID := q.Get("actor_id"); actorID != "" {
+		filter.ActorID = &actorID
+	}
+
+	if requestID := q.Get("request_id"); requestID != "" {
+		filter.RequestID = &requestID
+	}
+
+	if fromStr := q.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			BadRequest(w, "from must be an RFC 3339 timestamp")
+			return
+		}
+		filter.From = &from
+	}
+
+	if toStr := q.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			BadRequest(w, "to must be an RFC 3339 timestamp")
+			return
+		}
+		filter.To = &to
+	}
+
+	// Each database is read from the start of the list, and the pages merged
+	offset, limit := filter.Offset, filter.Limit
+	filter.Offset, filter.Limit = 0, offset+limit
+	var lists [][]*models.AuditEntry
+	for _, ctx := range h.db.Databases(r.Context()) {
+		entries, err := h.repo.List(ctx, filter)
+		if err != nil {
+			InternalError(w, "failed to list audit entries")
+			return
+		}
+		lists = append(lists, entries)
+	}
+
+	JSON(w, http.StatusOK, repository.MergeOffset(lists, offset, limit, func(a, b *models.AuditEntry) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(b.ID[:], a.ID[:])
+	}))
+}
+
+// ListChains returns the head of each region's chain in each database.
+// GET /api/v1/audit/chains
+func (h *AuditHandler) ListChains(w http.ResponseWriter, r *http.Request) {
+	var chains []AuditChains
+	for _, ctx := range h.db.Databases(r.Context()) {
+		heads, err := h.repo.ListChainHeads(ctx)
+		if err != nil {
+			InternalError(w, "failed to list audit chains")
+			return
+		}
+		chains = append(chains, AuditChains{Database: h.db.Name(ctx), Heads: heads})
+	}
+
+	JSON(w, http.StatusOK, chains)
+}
+
+// Verify walks a region's chain in each database and reports any break in
+// them.
+// GET /api/v1/audit/chains/{region}/verify
+func (h *AuditHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	region := models.ComplianceRegion(strings.ToUpper(chi.URLParam(r, "region")))
+
+	var reports []AuditReport
+	for _, ctx := range h.db.Databases(r.Context()) {
+		head, err := h.repo.GetChainHead(ctx, region)
+		if err != nil {
+			InternalError(w, "failed to get audit chain")
+			return
+		}
+		if head == nil {
+			continue
+		}
+
+		report, err := audit.Verify(ctx, h.repo, region)
+		if err != nil {
+			InternalError(w, "failed to verify audit chain")
+			return
+		}
+		reports = append(reports, AuditReport{Database: h.db.Name(ctx), Report: report})
+	}
+
+	if len(reports) == 0 {
+		NotFound(w, "audit chain not found")
+		return
+	}
+
+	JSON(w, http.StatusOK, reports)
+}
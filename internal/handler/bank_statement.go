@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/statement"
+)
+
+// maxStatementSize caps the size of an uploaded statement file.
+const maxStatementSize = 20 << 20
+
+// BankStatementHandler handles bank statement uploads.
+type BankStatementHandler struct {
+	importer *statement.Importer
+	repo     *repository.BankStatementRepository
+}
+
+// NewBankStatementHandler creates a new bank statement handler.
+func NewBankStatementHandler(importer *statement.Importer, repo *repository.BankStatementRepository) *BankStatementHandler {
+	return &BankStatementHandler{importer: importer, repo: repo}
+}
+
+// BankStatementResponse is a statement with its entries.
+type BankStatementResponse struct {
+	*models.BankStatement
+	Entries []*models.BankStatementEntry
+}
+
+// Upload imports a statement file sent as the request body, recording the
+// calling operator as its importer. The format, CSV layout and account (for
+// CSV files without an account column) are given as query parameters.
+// POST /api/v1/reconciliation/statements?format=camt053|mt940|csv
+func (h *BankStatementHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+
+	format := statement.Format(q.Get("format"))
+	if !format.IsValid() {
+		BadRequest(w, "format must be camt053, mt940 or csv")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxStatementSize))
+	if err != nil {
+		BadRequest(w, "statement file is too large or unreadable")
+		return
+	}
+	if len(data) == 0 {
+		BadRequest(w, "statement file is required")
+		return
+	}
+
+	statements, err := h.importer.Import(r.Context(), statement.ImportParams{
+		Format:     format,
+		Layout:     q.Get("layout"),
+		Account:    q.Get("account"),
+		ImportedBy: actor.ID,
+		Data:       data,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, statement.ErrInvalidStatement),
+			errors.Is(err, statement.ErrUnknownLayout),
+			errors.Is(err, statement.ErrUnknownAccount),
+			errors.Is(err, statement.ErrCurrencyNotSupported):
+			BadRequest(w, err.Error())
+		case errors.Is(err, statement.ErrDuplicateStatement):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to import statement")
+		}
+		return
+	}
+
+	JSON(w, http.StatusCreated, statements)
+}
+
+// List returns imported statements, newest first.
+// GET /api/v1/reconciliation/statements
+func (h *BankStatementHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.BankStatementFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if leStr := q.Get("legal_entity_id"); leStr != "" {
+		legalEntityID, err := uuid.Parse(leStr)
+		if err != nil {
+			BadRequest(w, "invalid legal_entity_id")
+			return
+		}
+		filter.LegalEntityID = &legalEntityID
+	}
+
+	if kindStr := q.Get("account_kind"); kindStr != "" {
+		kind := models.BankAccountKind(kindStr)
+		if !kind.IsValid() {
+			BadRequest(w, "account_kind must be fbo or nostro")
+			return
+		}
+		filter.AccountKind = &kind
+	}
+
+	statements, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list statements")
+		return
+	}
+
+	JSON(w, http.StatusOK, statements)
+}
+
+// Get returns a statement with its entries.
+// GET /api/v1/reconciliation/statements/{id}
+func (h *BankStatementHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid statement ID")
+		return
+	}
+
+	stmt, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get statement")
+		return
+	}
+
+	if stmt == nil {
+		NotFound(w, "statement not found")
+		return
+	}
+
+	entries, err := h.repo.ListEntries(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get statement entries")
+		return
+	}
+
+	JSON(w, http.StatusOK, BankStatementResponse{BankStatement: stmt, Entries: entries})
+}
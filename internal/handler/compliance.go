@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/audit"
+	"kovra/internal/compliance"
+	"kovra/internal/db"
+	"kovra/internal/repository"
+)
+
+// ComplianceHandler handles screening endpoints.
+type ComplianceHandler struct {
+	db       *db.DB
+	logRepo  *repository.ComplianceLogRepository
+	screener *compliance.Screener
+	trail    *audit.Trail
+}
+
+// NewComplianceHandler creates a new compliance handler.
+func NewComplianceHandler(database *db.DB, logRepo *repository.ComplianceLogRepository, screener *compliance.Screener, trail *audit.Trail) *ComplianceHandler {
+	return &ComplianceHandler{
+		db:       database,
+		logRepo:  logRepo,
+		screener: screener,
+		trail:    trail,
+	}
+}
+
+// ListTransferLogs returns the screening history of a transfer.
+// GET /api/v1/transfers/{id}/compliance-logs
+func (h *ComplianceHandler) ListTransferLogs(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	logs, err := h.logRepo.ListByTransfer(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to list compliance logs")
+		return
+	}
+
+	JSON(w, http.StatusOK, logs)
+}
+
+// ListSanctionsLists returns the loaded sanctions lists.
+// GET /api/v1/admin/sanctions/lists
+func (h *ComplianceHandler) ListSanctionsLists(w http.ResponseWriter, r *http.Request) {
+	JSON(w, http.StatusOK, h.screener.Lists())
+}
+
+// ReloadSanctionsLists re-reads the sanctions lists from disk.
+// POST /api/v1/admin/sanctions/reload
+func (h *ComplianceHandler) ReloadSanctionsLists(w http.ResponseWriter, r *http.Request) {
+	before := h.screener.Lists()
+	if err := h.screener.Load(); err != nil {
+		InternalError(w, "failed to reload sanctions lists")
+		return
+	}
+	after := h.screener.Lists()
+
+	// The lists live in memory, so the entry gets a transaction of its own
+	err := h.db.WithTx(r.Context(), func(tx pgx.Tx) error {
+		return h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			ResourceType: audit.ResourceSanctionsLists,
+			ResourceID:   "sanctions",
+			Action:       audit.ActionReload,
+			Before:       before,
+			After:        after,
+		})
+	})
+	if err != nil {
+		InternalError(w, "failed to record sanctions reload")
+		return
+	}
+
+	JSON(w, http.StatusOK, after)
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+	"kovra/internal/compliance"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// ComplianceCaseHandler handles the manual review queue. The case of a
+// transfer kept in a regional database is kept there with it, so the queue is
+// read from every database.
+type ComplianceCaseHandler struct {
+	db       *db.DB
+	cases    *compliance.CaseService
+	caseRepo *repository.ComplianceCaseRepository
+	logRepo  *repository.ComplianceLogRepository
+}
+
+// NewComplianceCaseHandler creates a new compliance case handler.
+func NewComplianceCaseHandler(database *db.DB, cases *compliance.CaseService, caseRepo *repository.ComplianceCaseRepository, logRepo *repository.ComplianceLogRepository) *ComplianceCaseHandler {
+	return &ComplianceCaseHandler{
+		db:       database,
+		cases:    cases,
+		caseRepo: caseRepo,
+		logRepo:  logRepo,
+	}
+}
+
+// ComplianceCaseResponse is a case with its notes and action log.
+type ComplianceCaseResponse struct {
+	*models.ComplianceCase
+	Notes []*models.ComplianceCaseNote
+	Log   []*models.ComplianceLog
+}
+
+// AssignCaseRequest represents a case assignment request.
+type AssignCaseRequest struct {
+	Assignee string `json:"assignee"`
+}
+
+// AddCaseNoteRequest represents a case note request.
+type AddCaseNoteRequest struct {
+	Note string `json:"note"`
+}
+
+// DecideCaseRequest represents a case decision request.
+type DecideCaseRequest struct {
+	Decision string  `json:"decision"`
+	Reason   *string `json:"reason,omitempty"`
+}
+
+// List returns review cases. Without a status filter only open cases are returned.
+// GET /api/v1/compliance/cases
+func (h *ComplianceCaseHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.ComplianceCaseFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if status := q.Get("status"); status != "" {
+		s := models.CaseStatus(status)
+		filter.Status = &s
+	} else {
+		open := true
+		filter.Open = &open
+	}
+
+	if tenantStr := q.Get("tenant_id"); tenantStr != "" {
+		tenantID, err := uuid.Parse(tenantStr)
+		if err != nil {
+			BadRequest(w, "invalid tenant_id")
+			return
+		}
+		filter.TenantID = &tenantID
+	}
+
+	if assignee := q.Get("assigned_to"); assignee != "" {
+		filter.AssignedTo = &assignee
+	}
+
+	if overdueStr := q.Get("overdue"); overdueStr != "" {
+		overdue, err := strconv.ParseBool(overdueStr)
+		if err != nil {
+			BadRequest(w, "invalid overdue")
+			return
+		}
+		filter.Overdue = &overdue
+	}
+
+	// Each database is read from the start of the queue, and the pages merged
+	actor := auth.ActorFromContext(r.Context())
+	offset, limit := filter.Offset, filter.Limit
+	filter.Offset, filter.Limit = 0, offset+limit
+	var lists [][]*models.ComplianceCase
+	for _, ctx := range h.db.Databases(h.db.Scope(r.Context(), actor.Region)) {
+		cases, err := h.caseRepo.List(ctx, filter)
+		if err != nil {
+			InternalError(w, "failed to list compliance cases")
+			return
+		}
+		lists = append(lists, cases)
+	}
+
+	JSON(w, http.StatusOK, repository.MergeOffset(lists, offset, limit, compareCases))
+}
+
+// Get returns a case with its notes and action log.
+// GET /api/v1/compliance/cases/{id}
+func (h *ComplianceCaseHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := parseCaseID(w, r)
+	if !ok {
+		return
+	}
+
+	var c *models.ComplianceCase
+	ctx, err := h.db.Locate(r.Context(), func(ctx context.Context) (bool, error) {
+		var err error
+		c, err = h.caseRepo.GetByID(ctx, id)
+		return c != nil, err
+	})
+	if err != nil {
+		InternalError(w, "failed to get compliance case")
+		return
+	}
+
+	if c == nil {
+		NotFound(w, "compliance case not found")
+		return
+	}
+
+	notes, err := h.caseRepo.ListNotes(ctx, id)
+	if err != nil {
+		InternalError(w, "failed to get compliance case notes")
+		return
+	}
+
+	log, err := h.logRepo.ListByCase(ctx, id)
+	if err != nil {
+		InternalError(w, "failed to get compliance case log")
+		return
+	}
+
+	JSON(w, http.StatusOK, ComplianceCaseResponse{ComplianceCase: c, Notes: notes, Log: log})
+}
+
+// Assign assigns a case to a reviewer. The calling operator is logged as
+// the one who assigned it.
+// POST /api/v1/compliance/cases/{id}/assign
+func (h *ComplianceCaseHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCaseID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AssignCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	c, err := h.cases.Assign(r.Context(), id, strings.TrimSpace(req.Assignee), actor.ID)
+	if err != nil {
+		caseError(w, err, "failed to assign compliance case")
+		return
+	}
+
+	JSON(w, http.StatusOK, c)
+}
+
+// AddNote adds a note by the calling operator to a case.
+// POST /api/v1/compliance/cases/{id}/notes
+func (h *ComplianceCaseHandler) AddNote(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCaseID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AddCaseNoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Note) == "" {
+		BadRequest(w, "note is required")
+		return
+	}
+
+	note, err := h.cases.AddNote(r.Context(), id, actor.ID, req.Note)
+	if err != nil {
+		caseError(w, err, "failed to add compliance case note")
+		return
+	}
+
+	JSON(w, http.StatusCreated, note)
+}
+
+// Decide records a decision of the calling operator: approve, reject or
+// escalate. The second approval of a four-eyes case must come from another
+// operator than the first.
+// POST /api/v1/compliance/cases/{id}/decision
+func (h *ComplianceCaseHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, ok := parseCaseID(w, r)
+	if !ok {
+		return
+	}
+
+	var req DecideCaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	decision := models.CaseDecision(req.Decision)
+	if !decision.IsValid() {
+		BadRequest(w, "decision must be approve, reject or escalate")
+		return
+	}
+
+	c, err := h.cases.Decide(r.Context(), id, compliance.Decision{
+		Decision: decision,
+		Reason:   req.Reason,
+		Reviewer: actor.ID,
+	})
+	if err != nil {
+		caseError(w, err, "failed to decide compliance case")
+		return
+	}
+
+	JSON(w, http.StatusOK, c)
+}
+
+func parseCaseID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid compliance case ID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func caseError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, compliance.ErrCaseNotFound):
+		NotFound(w, err.Error())
+	case errors.Is(err, compliance.ErrCaseClosed), errors.Is(err, compliance.ErrTransferNotHeld):
+		Conflict(w, err.Error())
+	case errors.Is(err, compliance.ErrSecondReviewerRequired):
+		Forbidden(w, err.Error())
+	case errors.Is(err, compliance.ErrReasonRequired):
+		BadRequest(w, err.Error())
+	default:
+		InternalError(w, message)
+	}
+}
+
+// compareCases orders cases as the queue lists them: high priority first,
+// then by due time.
+func compareCases(a, b *models.ComplianceCase) int {
+	if ah, bh := a.Priority == models.CasePriorityHigh, b.Priority == models.CasePriorityHigh; ah != bh {
+		if ah {
+			return -1
+		}
+		return 1
+	}
+	return a.DueAt.Compare(b.DueAt)
+}
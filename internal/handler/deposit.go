@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/auth"
+	"kovra/internal/matching"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// DepositHandler handles deposits announced by tenants.
+type DepositHandler struct {
+	service *matching.Service
+	repo    *repository.ExpectedDepositRepository
+}
+
+// NewDepositHandler creates a new deposit handler.
+func NewDepositHandler(service *matching.Service, repo *repository.ExpectedDepositRepository) *DepositHandler {
+	return &DepositHandler{service: service, repo: repo}
+}
+
+// CreateDepositRequest represents a deposit a tenant is about to make.
+type CreateDepositRequest struct {
+	TenantID     uuid.UUID `json:"tenant_id"`
+	Currency     string    `json:"currency"`
+	Amount       string    `json:"amount"`
+	Reference    string    `json:"reference"`
+	ExpectedDate string    `json:"expected_date"`
+}
+
+// Create announces a deposit into the FBO account of the tenant's legal
+// entity. The payment must carry the reference to be matched automatically.
+// POST /api/v1/deposits
+func (h *DepositHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateDepositRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	// A tenant acts for itself; an operator names the tenant
+	tenantID, ok := actingTenant(w, r, req.TenantID)
+	if !ok {
+		return
+	}
+	req.TenantID = tenantID
+
+	if len(req.Currency) != 3 {
+		BadRequest(w, "currency must be a 3-letter code")
+		return
+	}
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		BadRequest(w, "invalid amount")
+		return
+	}
+
+	expectedDate, err := time.Parse(time.DateOnly, req.ExpectedDate)
+	if err != nil {
+		BadRequest(w, "expected_date must be YYYY-MM-DD")
+		return
+	}
+
+	deposit, err := h.service.CreateExpectedDeposit(r.Context(), models.CreateExpectedDepositParams{
+		TenantID:     req.TenantID,
+		Currency:     strings.ToUpper(req.Currency),
+		Amount:       amount,
+		Reference:    req.Reference,
+		ExpectedDate: expectedDate,
+		CreatedBy:    auth.ActorFromContext(r.Context()).ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, matching.ErrTenantNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, matching.ErrWalletNotFound),
+			errors.Is(err, matching.ErrInvalidDeposit):
+			BadRequest(w, err.Error())
+		case errors.Is(err, matching.ErrDuplicateDeposit):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to create deposit")
+		}
+		return
+	}
+
+	JSON(w, http.StatusCreated, deposit)
+}
+
+// Get returns an expected deposit.
+// GET /api/v1/deposits/{id}
+func (h *DepositHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid deposit ID")
+		return
+	}
+
+	deposit, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get deposit")
+		return
+	}
+
+	if deposit == nil {
+		NotFound(w, "deposit not found")
+		return
+	}
+
+	if !authorizeTenant(w, r, deposit.TenantID) {
+		return
+	}
+
+	JSON(w, http.StatusOK, deposit)
+}
+
+// ListByTenant returns a tenant's expected deposits, newest first.
+// GET /api/v1/tenants/{id}/deposits
+func (h *DepositHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.ExpectedDepositFilter{
+		TenantID: &tenantID,
+		Limit:    100,
+		Offset:   0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if statusStr := q.Get("status"); statusStr != "" {
+		status := models.ExpectedDepositStatus(statusStr)
+		if !status.IsValid() {
+			BadRequest(w, "status must be pending, matched or cancelled")
+			return
+		}
+		filter.Status = &status
+	}
+
+	deposits, err := h.repo.List(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list deposits")
+		return
+	}
+
+	JSON(w, http.StatusOK, deposits)
+}
+
+// Cancel cancels a deposit that has not arrived.
+// POST /api/v1/deposits/{id}/cancel
+func (h *DepositHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid deposit ID")
+		return
+	}
+
+	existing, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get deposit")
+		return
+	}
+	if existing != nil && !authorizeTenant(w, r, existing.TenantID) {
+		return
+	}
+
+	deposit, err := h.service.CancelExpectedDeposit(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, matching.ErrDepositNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, matching.ErrDepositNotPending):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to cancel deposit")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, deposit)
+}
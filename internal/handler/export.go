@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+	"kovra/internal/db"
+	"kovra/internal/export"
+	"kovra/internal/models"
+)
+
+// exportWriteTimeout is how long a client may take to read each part of an
+// export before it is dropped. An export itself runs for as long as the
+// client keeps reading.
+const exportWriteTimeout = 30 * time.Second
+
+// ExportHandler handles bulk exports for finance.
+type ExportHandler struct {
+	exporter *export.Exporter
+}
+
+// NewExportHandler creates a new export handler.
+func NewExportHandler(exporter *export.Exporter) *ExportHandler {
+	return &ExportHandler{exporter: exporter}
+}
+
+// Transfers streams the transfers of a tenant, a region or both created in
+// [from, to) as CSV or NDJSON, optionally gzipped. A region export runs as
+// the region's database role, so it never contains another region's rows;
+// a regional operator may only export their own region.
+// An export failing after it started streaming is cut off rather than
+// ending cleanly, so a client never mistakes it for a complete one.
+// GET /api/v1/transfers/export
+func (h *ExportHandler) Transfers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	p := &queryParser{r: r}
+
+	req := export.Request{
+		TenantID: p.uuid("tenant_id"),
+		Format:   export.FormatCSV,
+	}
+	if from := p.time("from"); from != nil {
+		req.From = *from
+	}
+	if to := p.time("to"); to != nil {
+		req.To = *to
+	}
+	if p.err != nil {
+		BadRequest(w, p.err.Error())
+		return
+	}
+
+	// A tenant exports its own transfers only
+	if actor := auth.ActorFromContext(r.Context()); actor.Type == auth.ActorTypeTenant {
+		var named uuid.UUID
+		if req.TenantID != nil {
+			named = *req.TenantID
+		}
+		tenantID, ok := actingTenant(w, r, named)
+		if !ok {
+			return
+		}
+		req.TenantID = &tenantID
+	}
+
+	if region := q.Get("region"); region != "" {
+		rg := models.ComplianceRegion(strings.ToUpper(region))
+		req.Region = &rg
+	} else if actor := auth.ActorFromContext(r.Context()); actor.Region != "" {
+		// A regional operator exports their own region by default
+		rg := actor.Region
+		req.Region = &rg
+	}
+	if format := q.Get("format"); format != "" {
+		req.Format = export.Format(strings.ToLower(format))
+	}
+
+	columns, err := export.ParseColumns(q.Get("columns"))
+	if err != nil {
+		BadRequest(w, err.Error())
+		return
+	}
+	req.Columns = columns
+
+	compress := false
+	if gz := q.Get("gzip"); gz != "" {
+		if compress, err = strconv.ParseBool(gz); err != nil {
+			BadRequest(w, "invalid gzip")
+			return
+		}
+	}
+
+	if err := req.Validate(); err != nil {
+		BadRequest(w, err.Error())
+		return
+	}
+
+	out := &exportWriter{w: w, rc: http.NewResponseController(w)}
+	filename := "transfers-" + req.From.UTC().Format("20060102") + "-" + req.To.UTC().Format("20060102") + "." + string(req.Format)
+	contentType := req.Format.ContentType()
+
+	var body io.Writer = out
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(out)
+		body = zw
+		filename += ".gz"
+		contentType = "application/gzip"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// A regional operator's export is confined to their region
+	ctx := r.Context()
+	if actor := auth.ActorFromContext(ctx); actor.Region != "" {
+		ctx = db.Confine(ctx, actor.Region)
+	}
+
+	_, err = h.exporter.Export(ctx, body, req)
+	if err == nil && zw != nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !out.started {
+		w.Header().Del("Content-Disposition")
+		switch {
+		case errors.Is(err, export.ErrInvalidRequest), errors.Is(err, export.ErrUnknownColumn):
+			BadRequest(w, err.Error())
+		case errors.Is(err, export.ErrRegionNotAllowed):
+			Forbidden(w, err.Error())
+		default:
+			InternalError(w, "failed to export transfers")
+		}
+		return
+	}
+	panic(http.ErrAbortHandler)
+}
+
+// exportWriter extends the write deadline of the response before every
+// write, so that a long export outlives the server's write timeout.
+type exportWriter struct {
+	w       http.ResponseWriter
+	rc      *http.ResponseController
+	started bool
+}
+
+func (ew *exportWriter) Write(p []byte) (int, error) {
+	ew.started = true
+	// Not every ResponseWriter supports deadlines; httptest's does not
+	_ = ew.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	n, err := ew.w.Write(p)
+	if err == nil {
+		err = ew.rc.Flush()
+	}
+	return n, err
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/treasury"
+)
+
+// FXExposureHandler handles FX exposure reporting.
+type FXExposureHandler struct {
+	service *treasury.Service
+	repo    *repository.FXExposureRepository
+}
+
+// NewFXExposureHandler creates a new FX exposure handler.
+func NewFXExposureHandler(service *treasury.Service, repo *repository.FXExposureRepository) *FXExposureHandler {
+	return &FXExposureHandler{
+		service: service,
+		repo:    repo,
+	}
+}
+
+// Get returns the FX_SETTLEMENT positions valued at the current rates.
+// GET /api/v1/fx/exposure
+func (h *FXExposureHandler) Get(w http.ResponseWriter, r *http.Request) {
+	exposure, err := h.service.Exposure(r.Context())
+	if err != nil {
+		if errors.Is(err, treasury.ErrNoEURRate) {
+			Conflict(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to get fx exposure")
+		return
+	}
+
+	JSON(w, http.StatusOK, exposure)
+}
+
+// TakeSnapshot stores the current FX exposure.
+// POST /api/v1/fx/exposure/snapshots
+func (h *FXExposureHandler) TakeSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.Snapshot(r.Context())
+	if err != nil {
+		if errors.Is(err, treasury.ErrNoEURRate) {
+			Conflict(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to take fx exposure snapshot")
+		return
+	}
+
+	JSON(w, http.StatusCreated, snapshot)
+}
+
+// ListSnapshots returns FX exposure snapshots, newest first.
+// GET /api/v1/fx/exposure/snapshots
+func (h *FXExposureHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.FXExposureFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if fromStr := q.Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			BadRequest(w, "from must be an RFC 3339 timestamp")
+			return
+		}
+		filter.From = &from
+	}
+
+	if toStr := q.Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			BadRequest(w, "to must be an RFC 3339 timestamp")
+			return
+		}
+		filter.To = &to
+	}
+
+	snapshots, err := h.service.ListSnapshots(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list fx exposure snapshots")
+		return
+	}
+
+	JSON(w, http.StatusOK, snapshots)
+}
+
+// GetSnapshot returns an FX exposure snapshot.
+// GET /api/v1/fx/exposure/snapshots/{id}
+func (h *FXExposureHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid snapshot ID")
+		return
+	}
+
+	snapshot, err := h.service.GetSnapshot(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, treasury.ErrSnapshotNotFound) {
+			NotFound(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to get fx exposure snapshot")
+		return
+	}
+
+	JSON(w, http.StatusOK, snapshot)
+}
+
+// GetQuoteMargin returns the margin captured on an FX transfer's quote.
+// GET /api/v1/fx/quote-margins/{transferID}
+func (h *FXExposureHandler) GetQuoteMargin(w http.ResponseWriter, r *http.Request) {
+	transferID, err := uuid.Parse(chi.URLParam(r, "transferID"))
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	margin, err := h.repo.GetQuoteMargin(r.Context(), transferID)
+	if err != nil {
+		InternalError(w, "failed to get quote margin")
+		return
+	}
+	if margin == nil {
+		NotFound(w, "quote margin not found")
+		return
+	}
+
+	JSON(w, http.StatusOK, margin)
+}
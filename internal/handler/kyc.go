@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+	"kovra/internal/kyc"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// KYCHandler handles KYC review and tenant lifecycle endpoints.
+type KYCHandler struct {
+	kyc     *kyc.Service
+	kycRepo *repository.KYCRepository
+}
+
+// NewKYCHandler creates a new KYC handler.
+func NewKYCHandler(kycService *kyc.Service, kycRepo *repository.KYCRepository) *KYCHandler {
+	return &KYCHandler{
+		kyc:     kycService,
+		kycRepo: kycRepo,
+	}
+}
+
+// SubmitKYCRequest represents a KYC submission request.
+type SubmitKYCRequest struct {
+	RequestedLevel string               `json:"requested_level"`
+	Documents      []models.KYCDocument `json:"documents"`
+}
+
+// DecideKYCRequest represents a KYC review decision.
+type DecideKYCRequest struct {
+	Decision string  `json:"decision"`
+	Reason   *string `json:"reason,omitempty"`
+}
+
+// SetTenantStatusRequest represents a tenant lifecycle change.
+type SetTenantStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// Submit submits KYC documents for review, as the tenant itself or an
+// operator on its behalf.
+// POST /api/v1/tenants/{id}/kyc/submissions
+func (h *KYCHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	actor, ok := authenticated(w, r)
+	if !ok {
+		return
+	}
+
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	if actor.Type == auth.ActorTypeTenant && actor.ID != tenantID.String() {
+		Forbidden(w, "tenants may only submit their own kyc documents")
+		return
+	}
+
+	var req SubmitKYCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	level := models.KYCLevel(req.RequestedLevel)
+	if level != models.KYCLevelStandard && level != models.KYCLevelEnhanced {
+		BadRequest(w, "requested_level must be standard or enhanced")
+		return
+	}
+
+	if len(req.Documents) == 0 {
+		BadRequest(w, "at least one document is required")
+		return
+	}
+	for _, doc := range req.Documents {
+		if doc.Type == "" || doc.Reference == "" {
+			BadRequest(w, "each document needs a type and reference")
+			return
+		}
+	}
+
+	sub, err := h.kyc.Submit(r.Context(), models.CreateKYCSubmissionParams{
+		TenantID:       tenantID,
+		RequestedLevel: level,
+		Documents:      req.Documents,
+		SubmittedBy:    actor.ID,
+	})
+	if err != nil {
+		kycError(w, err, "failed to submit kyc documents")
+		return
+	}
+
+	JSON(w, http.StatusCreated, sub)
+}
+
+// ListByTenant returns a tenant's KYC submissions, newest first.
+// GET /api/v1/tenants/{id}/kyc/submissions
+func (h *KYCHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	subs, err := h.kycRepo.ListSubmissionsByTenant(r.Context(), tenantID)
+	if err != nil {
+		InternalError(w, "failed to list kyc submissions")
+		return
+	}
+
+	JSON(w, http.StatusOK, subs)
+}
+
+// Get returns a KYC submission by ID.
+// GET /api/v1/kyc/submissions/{id}
+func (h *KYCHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid kyc submission ID")
+		return
+	}
+
+	sub, err := h.kycRepo.GetSubmission(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get kyc submission")
+		return
+	}
+
+	if sub == nil {
+		NotFound(w, "kyc submission not found")
+		return
+	}
+
+	if !authorizeTenant(w, r, sub.TenantID) {
+		return
+	}
+
+	JSON(w, http.StatusOK, sub)
+}
+
+// Decide approves or rejects a KYC submission as the calling operator, who
+// must not be its submitter.
+// POST /api/v1/kyc/submissions/{id}/decision
+func (h *KYCHandler) Decide(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid kyc submission ID")
+		return
+	}
+
+	var req DecideKYCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	decision := models.KYCDecision(req.Decision)
+	if !decision.IsValid() {
+		BadRequest(w, "decision must be approve or reject")
+		return
+	}
+
+	sub, err := h.kyc.Decide(r.Context(), id, kyc.Review{
+		Decision: decision,
+		Reason:   req.Reason,
+		Reviewer: actor.ID,
+	})
+	if err != nil {
+		kycError(w, err, "failed to decide kyc submission")
+		return
+	}
+
+	JSON(w, http.StatusOK, sub)
+}
+
+// SetStatus moves a tenant through its lifecycle, recording the calling
+// operator as the one who changed it.
+// POST /api/v1/tenants/{id}/status
+func (h *KYCHandler) SetStatus(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	var req SetTenantStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	tenant, err := h.kyc.SetStatus(r.Context(), tenantID, kyc.StatusChange{
+		Status: models.TenantStatus(req.Status),
+		Reason: req.Reason,
+		Actor:  actor.ID,
+	})
+	if err != nil {
+		kycError(w, err, "failed to change tenant status")
+		return
+	}
+
+	JSON(w, http.StatusOK, tenant)
+}
+
+// ListStatusChanges returns a tenant's lifecycle history, newest first.
+// GET /api/v1/tenants/{id}/status-history
+func (h *KYCHandler) ListStatusChanges(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	changes, err := h.kycRepo.ListStatusChanges(r.Context(), tenantID)
+	if err != nil {
+		InternalError(w, "failed to list tenant status changes")
+		return
+	}
+
+	JSON(w, http.StatusOK, changes)
+}
+
+// ListTierLimits returns the transaction caps of each KYC tier.
+// GET /api/v1/kyc/tier-limits
+func (h *KYCHandler) ListTierLimits(w http.ResponseWriter, r *http.Request) {
+	limits, err := h.kycRepo.ListTierLimits(r.Context())
+	if err != nil {
+		InternalError(w, "failed to list kyc tier limits")
+		return
+	}
+
+	JSON(w, http.StatusOK, limits)
+}
+
+func kycError(w http.ResponseWriter, err error, message string) {
+	var limitErr *kyc.LimitError
+	switch {
+	case errors.As(err, &limitErr):
+		Error(w, http.StatusUnprocessableEntity, "LIMIT_EXCEEDED", limitErr.Error())
+	case errors.Is(err, kyc.ErrTenantNotFound), errors.Is(err, kyc.ErrSubmissionNotFound):
+		NotFound(w, err.Error())
+	case errors.Is(err, kyc.ErrTenantCannotTransact), errors.Is(err, kyc.ErrSubmitterCannotReview):
+		Forbidden(w, err.Error())
+	case errors.Is(err, kyc.ErrTenantClosed), errors.Is(err, kyc.ErrInvalidTransition),
+		errors.Is(err, kyc.ErrKYCRequired), errors.Is(err, kyc.ErrSubmissionPending),
+		errors.Is(err, kyc.ErrSubmissionDecided):
+		Conflict(w, err.Error())
+	case errors.Is(err, kyc.ErrReasonRequired), errors.Is(err, kyc.ErrNoReferenceRate):
+		BadRequest(w, err.Error())
+	default:
+		InternalError(w, message)
+	}
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/jobs"
+	"kovra/internal/liquidity"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// LiquidityHandler handles settlement account liquidity.
+type LiquidityHandler struct {
+	service   *liquidity.Service
+	repo      *repository.LiquidityRepository
+	jobClient *jobs.Client
+}
+
+// NewLiquidityHandler creates a new liquidity handler.
+func NewLiquidityHandler(service *liquidity.Service, repo *repository.LiquidityRepository, jobClient *jobs.Client) *LiquidityHandler {
+	return &LiquidityHandler{
+		service:   service,
+		repo:      repo,
+		jobClient: jobClient,
+	}
+}
+
+// ConfigureSettlementRequest represents the liquidity limits of a legal
+// entity's settlement account in a currency.
+type ConfigureSettlementRequest struct {
+	LegalEntityID  string `json:"legal_entity_id"`
+	Currency       string `json:"currency"`
+	MinBalance     string `json:"min_balance"`
+	TargetBalance  string `json:"target_balance"`
+	AlertThreshold string `json:"alert_threshold"`
+}
+
+// DecideTopUpRequest represents treasury completing or cancelling a top-up
+// instruction.
+type DecideTopUpRequest struct {
+	Status string `json:"status"`
+}
+
+// ListPositions returns every settlement account's balance against its
+// queued payouts.
+// GET /api/v1/liquidity/positions
+func (h *LiquidityHandler) ListPositions(w http.ResponseWriter, r *http.Request) {
+	positions, err := h.service.Positions(r.Context())
+	if err != nil {
+		InternalError(w, "failed to get liquidity positions")
+		return
+	}
+
+	JSON(w, http.StatusOK, positions)
+}
+
+// ConfigureSettlement sets the limits of a settlement account.
+// POST /api/v1/liquidity/settlements
+func (h *LiquidityHandler) ConfigureSettlement(w http.ResponseWriter, r *http.Request) {
+	var req ConfigureSettlementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	legalEntityID, err := uuid.Parse(req.LegalEntityID)
+	if err != nil {
+		BadRequest(w, "invalid legal_entity_id")
+		return
+	}
+
+	if len(req.Currency) != 3 {
+		BadRequest(w, "currency must be a 3-letter code")
+		return
+	}
+
+	minBalance, err := decimal.NewFromString(req.MinBalance)
+	if err != nil {
+		BadRequest(w, "invalid min_balance")
+		return
+	}
+	targetBalance, err := decimal.NewFromString(req.TargetBalance)
+	if err != nil {
+		BadRequest(w, "invalid target_balance")
+		return
+	}
+	alertThreshold, err := decimal.NewFromString(req.AlertThreshold)
+	if err != nil {
+		BadRequest(w, "invalid alert_threshold")
+		return
+	}
+
+	settlement, err := h.service.Configure(r.Context(), models.UpsertRegionalSettlementParams{
+		LegalEntityID:  legalEntityID,
+		Currency:       strings.ToUpper(req.Currency),
+		MinBalance:     minBalance,
+		TargetBalance:  targetBalance,
+		AlertThreshold: alertThreshold,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, liquidity.ErrLegalEntityNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, liquidity.ErrCurrencyNotSupported),
+			errors.Is(err, liquidity.ErrInvalidLimits):
+			BadRequest(w, err.Error())
+		default:
+			InternalError(w, "failed to configure settlement account")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, settlement)
+}
+
+// ListAlerts returns liquidity alerts, newest first.
+// GET /api/v1/liquidity/alerts
+func (h *LiquidityHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseLiquidityFilter(w, r, func(s string) bool {
+		return models.LiquidityAlertStatus(s).IsValid()
+	}, "status must be open or resolved")
+	if !ok {
+		return
+	}
+
+	alerts, err := h.repo.ListAlerts(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list liquidity alerts")
+		return
+	}
+
+	JSON(w, http.StatusOK, alerts)
+}
+
+// ListTopUps returns top-up instructions, newest first.
+// GET /api/v1/liquidity/top-ups
+func (h *LiquidityHandler) ListTopUps(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseLiquidityFilter(w, r, func(s string) bool {
+		return models.LiquidityTopUpStatus(s).IsValid()
+	}, "status must be pending, completed or cancelled")
+	if !ok {
+		return
+	}
+
+	topUps, err := h.repo.ListTopUps(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list top-up instructions")
+		return
+	}
+
+	JSON(w, http.StatusOK, topUps)
+}
+
+// DecideTopUp completes or cancels a pending top-up instruction as the
+// calling operator.
+// POST /api/v1/liquidity/top-ups/{id}/decision
+func (h *LiquidityHandler) DecideTopUp(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid top-up ID")
+		return
+	}
+
+	var req DecideTopUpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	status := models.LiquidityTopUpStatus(req.Status)
+	if status != models.LiquidityTopUpCompleted && status != models.LiquidityTopUpCancelled {
+		BadRequest(w, "status must be completed or cancelled")
+		return
+	}
+
+	topUp, err := h.service.DecideTopUp(r.Context(), id, status, actor.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, liquidity.ErrTopUpNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, liquidity.ErrTopUpNotPending):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to decide top-up instruction")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, topUp)
+}
+
+// ListHolds returns payouts held for liquidity, newest first.
+// GET /api/v1/liquidity/holds
+func (h *LiquidityHandler) ListHolds(w http.ResponseWriter, r *http.Request) {
+	filter, ok := parseLiquidityFilter(w, r, func(s string) bool {
+		return models.LiquidityHoldStatus(s).IsValid()
+	}, "status must be held, released or cancelled")
+	if !ok {
+		return
+	}
+
+	holds, err := h.repo.ListHolds(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list held payouts")
+		return
+	}
+
+	JSON(w, http.StatusOK, holds)
+}
+
+// RunCheck enqueues a liquidity check.
+// POST /api/v1/liquidity/check
+func (h *LiquidityHandler) RunCheck(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobClient.Insert(r.Context(), liquidity.CheckArgs{}, nil)
+	if err != nil {
+		InternalError(w, "failed to enqueue liquidity check")
+		return
+	}
+
+	JSON(w, http.StatusAccepted, job)
+}
+
+// parseLiquidityFilter parses the limit, offset and status of a liquidity
+// list. It writes a bad request and returns false if the status is invalid.
+func parseLiquidityFilter(w http.ResponseWriter, r *http.Request, valid func(string) bool, invalid string) (models.LiquidityFilter, bool) {
+	q := r.URL.Query()
+	filter := models.LiquidityFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if status := q.Get("status"); status != "" {
+		if !valid(status) {
+			BadRequest(w, invalid)
+			return filter, false
+		}
+		filter.Status = &status
+	}
+
+	return filter, true
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// MonitoringHandler handles transaction monitoring endpoints.
+type MonitoringHandler struct {
+	alertRepo *repository.MonitoringAlertRepository
+}
+
+// NewMonitoringHandler creates a new monitoring handler.
+func NewMonitoringHandler(alertRepo *repository.MonitoringAlertRepository) *MonitoringHandler {
+	return &MonitoringHandler{alertRepo: alertRepo}
+}
+
+// ListAlerts returns monitoring alerts, newest first.
+// GET /api/v1/compliance/alerts
+func (h *MonitoringHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.MonitoringAlertFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if tenantStr := q.Get("tenant_id"); tenantStr != "" {
+		tenantID, err := uuid.Parse(tenantStr)
+		if err != nil {
+			BadRequest(w, "invalid tenant_id")
+			return
+		}
+		filter.TenantID = &tenantID
+	}
+
+	if transferStr := q.Get("transfer_id"); transferStr != "" {
+		transferID, err := uuid.Parse(transferStr)
+		if err != nil {
+			BadRequest(w, "invalid transfer_id")
+			return
+		}
+		filter.TransferID = &transferID
+	}
+
+	if ruleType := q.Get("rule_type"); ruleType != "" {
+		filter.RuleType = &ruleType
+	}
+
+	if region := q.Get("compliance_region"); region != "" {
+		cr := models.ComplianceRegion(region)
+		filter.ComplianceRegion = &cr
+	}
+
+	alerts, err := h.alertRepo.List(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list monitoring alerts")
+		return
+	}
+
+	JSON(w, http.StatusOK, alerts)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"kovra/internal/db"
+	"kovra/internal/repository"
+)
+
+// OutboxHandler handles outbox admin endpoints.
+type OutboxHandler struct {
+	db   *db.DB
+	repo *repository.OutboxRepository
+}
+
+// NewOutboxHandler creates a new outbox handler.
+func NewOutboxHandler(database *db.DB, repo *repository.OutboxRepository) *OutboxHandler {
+	return &OutboxHandler{db: database, repo: repo}
+}
+
+// OutboxStatsResponse reports the relay backlog.
+type OutboxStatsResponse struct {
+	Pending         int64      `json:"pending"`
+	Failing         int64      `json:"failing"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+	LagSeconds      float64    `json:"lag_seconds"`
+}
+
+// Stats returns outbox lag metrics over the outboxes of the primary and
+// every regional database, each of which has its own relay.
+// GET /api/v1/admin/outbox/stats
+func (h *OutboxHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	var resp OutboxStatsResponse
+	for _, ctx := range h.db.Databases(r.Context()) {
+		stats, err := h.repo.Stats(ctx)
+		if err != nil {
+			InternalError(w, "failed to get outbox stats")
+			return
+		}
+
+		resp.Pending += stats.Pending
+		resp.Failing += stats.Failing
+		if stats.OldestPendingAt != nil && (resp.OldestPendingAt == nil || stats.OldestPendingAt.Before(*resp.OldestPendingAt)) {
+			resp.OldestPendingAt = stats.OldestPendingAt
+		}
+	}
+	if resp.OldestPendingAt != nil {
+		resp.LagSeconds = time.Since(*resp.OldestPendingAt).Seconds()
+	}
+
+	JSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Page is the envelope of a paginated list, newest first. NextCursor is
+// passed back as the cursor query parameter to fetch the following page;
+// it is null on the last page.
+type Page struct {
+	Items      any     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+}
+
+// newPage wraps a page of a list in its envelope.
+func newPage[T any](p models.Page[T]) Page {
+	page := Page{Items: p.Items}
+	if p.Next != nil {
+		cursor := encodeCursor(*p.Next)
+		page.NextCursor = &cursor
+	}
+	return page
+}
+
+// encodeCursor returns the opaque cursor of a page starting after id.
+func encodeCursor(id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(id[:])
+}
+
+// decodeCursor returns the ID a cursor starts after.
+func decodeCursor(cursor string) (uuid.UUID, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(b) != 16 {
+		return uuid.Nil, errInvalidCursor
+	}
+	return uuid.UUID(b), nil
+}
+
+// parsePageFilter parses the limit and cursor of a paginated list. It
+// writes a bad request and returns false if the cursor is invalid.
+func parsePageFilter(w http.ResponseWriter, r *http.Request) (models.PageFilter, bool) {
+	q := r.URL.Query()
+	filter := models.PageFilter{Limit: 100}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			BadRequest(w, err.Error())
+			return filter, false
+		}
+		filter.After = &after
+	}
+
+	return filter, true
+}
+
+// queryParser parses optional query parameters, keeping the first error so
+// a handler can check once after parsing all of them.
+type queryParser struct {
+	r   *http.Request
+	err error
+}
+
+// string returns the parameter, or nil if it is absent.
+func (p *queryParser) string(name string) *string {
+	if v := p.r.URL.Query().Get(name); v != "" {
+		return &v
+	}
+	return nil
+}
+
+// uuid returns the parameter parsed as a UUID.
+func (p *queryParser) uuid(name string) *uuid.UUID {
+	v := p.string(name)
+	if v == nil {
+		return nil
+	}
+	id, err := uuid.Parse(*v)
+	if err != nil {
+		p.fail("invalid " + name)
+		return nil
+	}
+	return &id
+}
+
+// decimal returns the parameter parsed as a decimal.
+func (p *queryParser) decimal(name string) *decimal.Decimal {
+	v := p.string(name)
+	if v == nil {
+		return nil
+	}
+	d, err := decimal.NewFromString(*v)
+	if err != nil {
+		p.fail("invalid " + name)
+		return nil
+	}
+	return &d
+}
+
+// time returns the parameter parsed as an RFC 3339 time.
+func (p *queryParser) time(name string) *time.Time {
+	v := p.string(name)
+	if v == nil {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *v)
+	if err != nil {
+		p.fail("invalid " + name + ", expected RFC 3339")
+		return nil
+	}
+	return &t
+}
+
+func (p *queryParser) fail(message string) {
+	if p.err == nil {
+		p.err = errors.New(message)
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/rail"
+	"kovra/internal/refund"
+)
+
+// maxCallbackBody bounds the size of a rail callback body.
+const maxCallbackBody = 64 << 10
+
+// RailHandler handles status callbacks from the payment rails.
+type RailHandler struct {
+	service *rail.Service
+}
+
+// NewRailHandler creates a new rail callback handler.
+func NewRailHandler(service *rail.Service) *RailHandler {
+	return &RailHandler{service: service}
+}
+
+// Callback receives a signed status callback from a rail and applies it to
+// its transfer. A repeated or outdated callback is acknowledged without
+// being applied, so the rail stops retrying it.
+// POST /api/v1/rails/{rail}/callbacks
+func (h *RailHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	railName := models.Rail(strings.ToUpper(chi.URLParam(r, "rail")))
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBody))
+	if err != nil {
+		BadRequest(w, "failed to read request body")
+		return
+	}
+
+	if err := h.service.Verify(railName, r.Header.Get(rail.HeaderSignature), body); err != nil {
+		if errors.Is(err, rail.ErrUnknownRail) {
+			NotFound(w, err.Error())
+			return
+		}
+		Unauthorized(w, "invalid signature")
+		return
+	}
+
+	var cb rail.Callback
+	if err := json.Unmarshal(body, &cb); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	recorded, err := h.service.Handle(r.Context(), railName, cb, body)
+	if err != nil {
+		switch {
+		case errors.Is(err, rail.ErrInvalidCallback):
+			BadRequest(w, err.Error())
+		case errors.Is(err, rail.ErrTransferNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, rail.ErrReferenceMismatch),
+			errors.Is(err, refund.ErrExceedsRefundable),
+			errors.Is(err, refund.ErrNoWallet):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to apply callback")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, recorded)
+}
+
+// ListByTransfer returns the rail callbacks received for a transfer, oldest
+// first.
+// GET /api/v1/transfers/{id}/rail-callbacks
+func (h *RailHandler) ListByTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	callbacks, err := h.service.ListByTransfer(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to list rail callbacks")
+		return
+	}
+
+	JSON(w, http.StatusOK, callbacks)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// RecipientHandler handles recipient endpoints.
+type RecipientHandler struct {
+	db    *db.DB
+	repo  *repository.RecipientRepository
+	trail *audit.Trail
+}
+
+// NewRecipientHandler creates a new recipient handler.
+func NewRecipientHandler(database *db.DB, repo *repository.RecipientRepository, trail *audit.Trail) *RecipientHandler {
+	return &RecipientHandler{
+		db:    database,
+		repo:  repo,
+		trail: trail,
+	}
+}
+
+// CreateRecipientRequest represents a recipient creation request.
+type CreateRecipientRequest struct {
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	Name          string          `json:"name"`
+	Country       string          `json:"country"`
+	AccountNumber *string         `json:"account_number,omitempty"`
+	BankCode      *string         `json:"bank_code,omitempty"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+}
+
+// Create creates a new recipient.
+// POST /api/v1/recipients
+func (h *RecipientHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRecipientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	// A tenant acts for itself; an operator names the tenant
+	tenantID, ok := actingTenant(w, r, req.TenantID)
+	if !ok {
+		return
+	}
+	req.TenantID = tenantID
+
+	if strings.TrimSpace(req.Name) == "" {
+		BadRequest(w, "name is required")
+		return
+	}
+
+	if len(req.Country) != 2 {
+		BadRequest(w, "country must be an ISO 3166-1 alpha-2 code")
+		return
+	}
+
+	params := models.CreateRecipientParams{
+		TenantID:      req.TenantID,
+		Name:          strings.TrimSpace(req.Name),
+		Country:       strings.ToUpper(req.Country),
+		AccountNumber: req.AccountNumber,
+		BankCode:      req.BankCode,
+		Metadata:      req.Metadata,
+	}
+
+	recipient, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.Recipient, error) {
+		recipient, err := h.repo.WithTx(tx).Create(r.Context(), params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &recipient.TenantID,
+			ResourceType: audit.ResourceRecipient,
+			ResourceID:   recipient.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        recipient,
+		}); err != nil {
+			return nil, err
+		}
+
+		return recipient, nil
+	})
+	if err != nil {
+		InternalError(w, "failed to create recipient")
+		return
+	}
+
+	JSON(w, http.StatusCreated, recipient)
+}
+
+// Get returns a recipient by ID.
+// GET /api/v1/recipients/{id}
+func (h *RecipientHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid recipient ID")
+		return
+	}
+
+	recipient, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get recipient")
+		return
+	}
+
+	if recipient == nil {
+		NotFound(w, "recipient not found")
+		return
+	}
+
+	if !authorizeTenant(w, r, recipient.TenantID) {
+		return
+	}
+
+	JSON(w, http.StatusOK, recipient)
+}
+
+// ListByTenant returns recipients for a tenant.
+// GET /api/v1/tenants/{id}/recipients
+func (h *RecipientHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	limit, offset := 100, 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 1000 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	recipients, err := h.repo.ListByTenant(r.Context(), id, limit, offset)
+	if err != nil {
+		InternalError(w, "failed to list recipients")
+		return
+	}
+
+	JSON(w, http.StatusOK, recipients)
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/jobs"
+	"kovra/internal/models"
+	"kovra/internal/reconciliation"
+	"kovra/internal/repository"
+)
+
+// ReconciliationHandler handles reconciliation reports and bank balances.
+type ReconciliationHandler struct {
+	service   *reconciliation.Service
+	repo      *repository.ReconciliationRepository
+	jobClient *jobs.Client
+}
+
+// NewReconciliationHandler creates a new reconciliation handler.
+func NewReconciliationHandler(service *reconciliation.Service, repo *repository.ReconciliationRepository, jobClient *jobs.Client) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		service:   service,
+		repo:      repo,
+		jobClient: jobClient,
+	}
+}
+
+// ReconciliationReportResponse is a report with its discrepancies.
+type ReconciliationReportResponse struct {
+	*models.ReconciliationReport
+	Discrepancies []*models.ReconciliationDiscrepancy
+}
+
+// RunReconciliationRequest represents a request to run a reconciliation.
+type RunReconciliationRequest struct {
+	BusinessDate string `json:"business_date,omitempty"`
+}
+
+// SignOffReportRequest represents a report sign-off request.
+type SignOffReportRequest struct {
+	Note *string `json:"note,omitempty"`
+}
+
+// RecordBankBalanceRequest represents a bank statement closing balance.
+type RecordBankBalanceRequest struct {
+	LegalEntityID string `json:"legal_entity_id"`
+	AccountKind   string `json:"account_kind"`
+	Currency      string `json:"currency"`
+	Balance       string `json:"balance"`
+	AsOf          string `json:"as_of"`
+}
+
+// ListReports returns reconciliation reports, newest first.
+// GET /api/v1/reconciliation/reports
+func (h *ReconciliationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, offset := 100, 0
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 1000 {
+			limit = l
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	var status *models.ReconciliationStatus
+	if s := q.Get("status"); s != "" {
+		st := models.ReconciliationStatus(s)
+		status = &st
+	}
+
+	reports, err := h.repo.ListReports(r.Context(), status, limit, offset)
+	if err != nil {
+		InternalError(w, "failed to list reconciliation reports")
+		return
+	}
+
+	JSON(w, http.StatusOK, reports)
+}
+
+// GetReport returns a report with its discrepancies.
+// GET /api/v1/reconciliation/reports/{id}
+func (h *ReconciliationHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid reconciliation report ID")
+		return
+	}
+
+	report, err := h.repo.GetReport(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get reconciliation report")
+		return
+	}
+
+	if report == nil {
+		NotFound(w, "reconciliation report not found")
+		return
+	}
+
+	discrepancies, err := h.repo.ListDiscrepancies(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get reconciliation discrepancies")
+		return
+	}
+
+	JSON(w, http.StatusOK, ReconciliationReportResponse{ReconciliationReport: report, Discrepancies: discrepancies})
+}
+
+// RunReport enqueues a reconciliation run outside the daily schedule, of a
+// past business date or, by default, of yesterday.
+// POST /api/v1/reconciliation/reports
+func (h *ReconciliationHandler) RunReport(w http.ResponseWriter, r *http.Request) {
+	var req RunReconciliationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	if req.BusinessDate != "" {
+		date, err := time.Parse(time.DateOnly, req.BusinessDate)
+		if err != nil {
+			BadRequest(w, "business_date must be YYYY-MM-DD")
+			return
+		}
+		if !date.AddDate(0, 0, 1).Before(time.Now()) {
+			BadRequest(w, "business_date must have ended")
+			return
+		}
+	}
+
+	job, err := h.jobClient.Insert(r.Context(), reconciliation.RunArgs{BusinessDate: req.BusinessDate}, nil)
+	if err != nil {
+		InternalError(w, "failed to enqueue reconciliation")
+		return
+	}
+
+	JSON(w, http.StatusAccepted, job)
+}
+
+// SignOffReport marks a report as reviewed by the calling operator.
+// POST /api/v1/reconciliation/reports/{id}/sign-off
+func (h *ReconciliationHandler) SignOffReport(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid reconciliation report ID")
+		return
+	}
+
+	var req SignOffReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	report, err := h.service.SignOff(r.Context(), id, reconciliation.SignOff{
+		Reviewer: actor.ID,
+		Note:     req.Note,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, reconciliation.ErrReportNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, reconciliation.ErrReportSignedOff):
+			Conflict(w, err.Error())
+		case errors.Is(err, reconciliation.ErrNoteRequired):
+			BadRequest(w, err.Error())
+		default:
+			InternalError(w, "failed to sign off reconciliation report")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, report)
+}
+
+// RecordBankBalance records the closing balance of an FBO or Nostro account.
+// POST /api/v1/reconciliation/bank-balances
+func (h *ReconciliationHandler) RecordBankBalance(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	var req RecordBankBalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	legalEntityID, err := uuid.Parse(req.LegalEntityID)
+	if err != nil {
+		BadRequest(w, "invalid legal_entity_id")
+		return
+	}
+
+	kind := models.BankAccountKind(req.AccountKind)
+	if !kind.IsValid() {
+		BadRequest(w, "account_kind must be fbo or nostro")
+		return
+	}
+
+	if len(req.Currency) != 3 {
+		BadRequest(w, "currency must be a 3-letter code")
+		return
+	}
+
+	balance, err := decimal.NewFromString(req.Balance)
+	if err != nil {
+		BadRequest(w, "invalid balance")
+		return
+	}
+
+	asOf, err := time.Parse(time.DateOnly, req.AsOf)
+	if err != nil {
+		BadRequest(w, "as_of must be YYYY-MM-DD")
+		return
+	}
+
+	recorded, err := h.service.RecordBankBalance(r.Context(), models.CreateBankBalanceParams{
+		LegalEntityID: legalEntityID,
+		AccountKind:   kind,
+		Currency:      strings.ToUpper(req.Currency),
+		Balance:       balance,
+		AsOf:          asOf,
+		RecordedBy:    actor.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, reconciliation.ErrLegalEntityNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, reconciliation.ErrCurrencyNotSupported):
+			BadRequest(w, err.Error())
+		default:
+			InternalError(w, "failed to record bank balance")
+		}
+		return
+	}
+
+	JSON(w, http.StatusCreated, recorded)
+}
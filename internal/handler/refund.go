@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/refund"
+	"kovra/internal/repository"
+)
+
+// RefundHandler handles refunds of transfers.
+type RefundHandler struct {
+	service      *refund.Service
+	transferRepo *repository.TransferRepository
+}
+
+// NewRefundHandler creates a new refund handler.
+func NewRefundHandler(service *refund.Service, transferRepo *repository.TransferRepository) *RefundHandler {
+	return &RefundHandler{service: service, transferRepo: transferRepo}
+}
+
+// authorizeTransfer reports whether the caller may act on the refunds of a
+// transfer, as authorizeTenant does for the transfer's tenant. A missing
+// transfer is left to the service to report.
+func (h *RefundHandler) authorizeTransfer(w http.ResponseWriter, r *http.Request, id uuid.UUID) bool {
+	transfer, err := h.transferRepo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get transfer")
+		return false
+	}
+	return transfer == nil || authorizeTenant(w, r, transfer.TenantID)
+}
+
+// CreateRefundRequest represents a request to refund a transfer. Without an
+// amount all that remains of the payout is refunded.
+type CreateRefundRequest struct {
+	Amount         *string `json:"amount,omitempty"`
+	ReverseFee     bool    `json:"reverse_fee"`
+	Reason         string  `json:"reason"`
+	ReturnCode     *string `json:"return_code,omitempty"`
+	IdempotencyKey *string `json:"idempotency_key,omitempty"`
+}
+
+// Create refunds a completed transfer in full or in part.
+// POST /api/v1/transfers/{id}/refunds
+func (h *RefundHandler) Create(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	if !h.authorizeTransfer(w, r, id) {
+		return
+	}
+
+	var req CreateRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Reason) == "" {
+		BadRequest(w, "reason is required")
+		return
+	}
+
+	params := refund.Request{
+		ReverseFee:     req.ReverseFee,
+		Reason:         req.Reason,
+		ReturnCode:     req.ReturnCode,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+	if req.Amount != nil {
+		amount, err := decimal.NewFromString(*req.Amount)
+		if err != nil {
+			BadRequest(w, "invalid amount")
+			return
+		}
+		params.Amount = &amount
+	}
+
+	rf, err := h.service.Refund(r.Context(), id, params)
+	if err != nil {
+		switch {
+		case errors.Is(err, refund.ErrTransferNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, refund.ErrInvalidRefund):
+			BadRequest(w, err.Error())
+		case errors.Is(err, refund.ErrTransferNotCompleted),
+			errors.Is(err, refund.ErrExceedsRefundable),
+			errors.Is(err, refund.ErrNoWallet):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to refund transfer")
+		}
+		return
+	}
+
+	JSON(w, http.StatusCreated, rf)
+}
+
+// ListByTransfer returns the refunds of a transfer in order.
+// GET /api/v1/transfers/{id}/refunds
+func (h *RefundHandler) ListByTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	if !h.authorizeTransfer(w, r, id) {
+		return
+	}
+
+	refunds, err := h.service.ListByTransfer(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, refund.ErrTransferNotFound) {
+			NotFound(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to list refunds")
+		return
+	}
+
+	JSON(w, http.StatusOK, refunds)
+}
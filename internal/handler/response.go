@@ -71,3 +71,7 @@ func Unauthorized(w http.ResponseWriter, message string) {
 func TooManyRequests(w http.ResponseWriter, message string) {
 	Error(w, http.StatusTooManyRequests, "RATE_LIMITED", message)
 }
+
+func Forbidden(w http.ResponseWriter, message string) {
+	Error(w, http.StatusForbidden, "FORBIDDEN", message)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/jobs"
+	"kovra/internal/matching"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// StatementMatchHandler handles the queue of statement entries waiting for
+// a match.
+type StatementMatchHandler struct {
+	service   *matching.Service
+	repo      *repository.BankStatementRepository
+	jobClient *jobs.Client
+}
+
+// NewStatementMatchHandler creates a new statement match handler.
+func NewStatementMatchHandler(service *matching.Service, repo *repository.BankStatementRepository, jobClient *jobs.Client) *StatementMatchHandler {
+	return &StatementMatchHandler{
+		service:   service,
+		repo:      repo,
+		jobClient: jobClient,
+	}
+}
+
+// MatchEntryRequest represents a manual match of a statement entry to
+// either transfers or an expected deposit.
+type MatchEntryRequest struct {
+	TransferIDs       []uuid.UUID `json:"transfer_ids,omitempty"`
+	ExpectedDepositID *uuid.UUID  `json:"expected_deposit_id,omitempty"`
+}
+
+// ListQueue returns the entries waiting for a match, oldest first. Parked
+// inbound credits have match_status suspense.
+// GET /api/v1/reconciliation/statement-entries
+func (h *StatementMatchHandler) ListQueue(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.StatementEntryFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if statusStr := q.Get("match_status"); statusStr != "" {
+		status := models.EntryMatchStatus(statusStr)
+		if status != models.EntryUnmatched && status != models.EntrySuspense {
+			BadRequest(w, "match_status must be unmatched or suspense")
+			return
+		}
+		filter.MatchStatus = &status
+	}
+
+	if leStr := q.Get("legal_entity_id"); leStr != "" {
+		legalEntityID, err := uuid.Parse(leStr)
+		if err != nil {
+			BadRequest(w, "invalid legal_entity_id")
+			return
+		}
+		filter.LegalEntityID = &legalEntityID
+	}
+
+	entries, err := h.repo.ListOpenEntries(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list statement entries")
+		return
+	}
+
+	JSON(w, http.StatusOK, entries)
+}
+
+// GetEntry returns a statement entry with what it was matched to.
+// GET /api/v1/reconciliation/statement-entries/{id}
+func (h *StatementMatchHandler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid statement entry ID")
+		return
+	}
+
+	entry, err := h.service.GetEntry(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get statement entry")
+		return
+	}
+
+	if entry == nil {
+		NotFound(w, "statement entry not found")
+		return
+	}
+
+	JSON(w, http.StatusOK, entry)
+}
+
+// MatchEntry matches a statement entry by hand, as the calling operator.
+// POST /api/v1/reconciliation/statement-entries/{id}/match
+func (h *StatementMatchHandler) MatchEntry(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid statement entry ID")
+		return
+	}
+
+	var req MatchEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	entry, err := h.service.Match(r.Context(), id, matching.ManualMatch{
+		TransferIDs:       req.TransferIDs,
+		ExpectedDepositID: req.ExpectedDepositID,
+		MatchedBy:         actor.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, matching.ErrEntryNotFound),
+			errors.Is(err, matching.ErrTransferNotFound),
+			errors.Is(err, matching.ErrDepositNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, matching.ErrEntryMatched),
+			errors.Is(err, matching.ErrAlreadyMatched),
+			errors.Is(err, matching.ErrDepositNotPending):
+			Conflict(w, err.Error())
+		case errors.Is(err, matching.ErrAmountMismatch),
+			errors.Is(err, matching.ErrInvalidMatch):
+			BadRequest(w, err.Error())
+		default:
+			InternalError(w, "failed to match statement entry")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, entry)
+}
+
+// RunMatching enqueues an automatic matching run.
+// POST /api/v1/reconciliation/statement-entries/match
+func (h *StatementMatchHandler) RunMatching(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobClient.Insert(r.Context(), matching.RunArgs{}, nil)
+	if err != nil {
+		InternalError(w, "failed to enqueue matching")
+		return
+	}
+
+	JSON(w, http.StatusAccepted, job)
+}
@@ -6,19 +6,28 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"kovra/internal/audit"
+	"kovra/internal/db"
 	"kovra/internal/models"
 	"kovra/internal/repository"
 )
 
 // TenantHandler handles tenant endpoints.
 type TenantHandler struct {
-	repo *repository.TenantRepository
+	db    *db.DB
+	repo  *repository.TenantRepository
+	trail *audit.Trail
 }
 
 // NewTenantHandler creates a new tenant handler.
-func NewTenantHandler(repo *repository.TenantRepository) *TenantHandler {
-	return &TenantHandler{repo: repo}
+func NewTenantHandler(database *db.DB, repo *repository.TenantRepository, trail *audit.Trail) *TenantHandler {
+	return &TenantHandler{
+		db:    database,
+		repo:  repo,
+		trail: trail,
+	}
 }
 
 // CreateTenantRequest represents a tenant creation request.
@@ -62,7 +71,24 @@ func (h *TenantHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Metadata:       req.Metadata,
 	}
 
-	tenant, err := h.repo.Create(r.Context(), params)
+	tenant, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.Tenant, error) {
+		tenant, err := h.repo.WithTx(tx).Create(r.Context(), params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &tenant.ID,
+			ResourceType: audit.ResourceTenant,
+			ResourceID:   tenant.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        audit.TenantState(tenant),
+		}); err != nil {
+			return nil, err
+		}
+
+		return tenant, nil
+	})
 	if err != nil {
 		InternalError(w, "failed to create tenant")
 		return
@@ -123,6 +149,18 @@ func (h *TenantHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Status and KYC level only change through the lifecycle and KYC
+	// review endpoints, which record and enforce each change.
+	if req.TenantStatus != nil {
+		BadRequest(w, "tenant_status is changed via POST /api/v1/tenants/{id}/status")
+		return
+	}
+
+	if req.KYCLevel != nil {
+		BadRequest(w, "kyc_level is changed by approving a KYC submission")
+		return
+	}
+
 	params := models.UpdateTenantParams{
 		DisplayName:          req.DisplayName,
 		LegalName:            req.LegalName,
@@ -131,30 +169,50 @@ func (h *TenantHandler) Update(w http.ResponseWriter, r *http.Request) {
 		WebhookURL:           req.WebhookURL,
 	}
 
-	if req.TenantStatus != nil {
-		status := models.TenantStatus(*req.TenantStatus)
-		params.TenantStatus = &status
-	}
-
-	if req.KYCLevel != nil {
-		level := models.KYCLevel(*req.KYCLevel)
-		params.KYCLevel = &level
-	}
-
 	if req.Metadata != nil {
 		params.Metadata = *req.Metadata
 	}
 
-	tenant, err := h.repo.Update(r.Context(), id, params)
+	tenant, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.Tenant, error) {
+		repo := h.repo.WithTx(tx)
+		before, err := repo.GetByIDForUpdate(r.Context(), id)
+		if err != nil || before == nil {
+			return nil, err
+		}
+
+		tenant, err := repo.Update(r.Context(), id, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &tenant.ID,
+			ResourceType: audit.ResourceTenant,
+			ResourceID:   tenant.ID.String(),
+			Action:       audit.ActionUpdate,
+			Before:       audit.TenantState(before),
+			After:        audit.TenantState(tenant),
+		}); err != nil {
+			return nil, err
+		}
+
+		return tenant, nil
+	})
 	if err != nil {
 		InternalError(w, "failed to update tenant")
 		return
 	}
 
+	if tenant == nil {
+		NotFound(w, "tenant not found")
+		return
+	}
+
 	JSON(w, http.StatusOK, tenant)
 }
 
-// ListByLegalEntity returns tenants by legal entity.
+// ListByLegalEntity returns a page of the tenants of a legal entity, newest
+// first, optionally filtered by status and country.
 // GET /api/v1/legal-entities/{id}/tenants
 func (h *TenantHandler) ListByLegalEntity(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -164,11 +222,30 @@ func (h *TenantHandler) ListByLegalEntity(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	tenants, err := h.repo.ListByLegalEntity(r.Context(), id)
+	page, ok := parsePageFilter(w, r)
+	if !ok {
+		return
+	}
+
+	q := &queryParser{r: r}
+	filter := models.TenantFilter{
+		PageFilter: page,
+		Country:    q.string("country"),
+	}
+	if status := q.string("status"); status != nil {
+		s := models.TenantStatus(*status)
+		if !s.IsValid() {
+			BadRequest(w, "invalid status")
+			return
+		}
+		filter.Status = &s
+	}
+
+	tenants, err := h.repo.ListByLegalEntity(r.Context(), id, filter)
 	if err != nil {
 		InternalError(w, "failed to list tenants")
 		return
 	}
 
-	JSON(w, http.StatusOK, tenants)
+	JSON(w, http.StatusOK, newPage(tenants))
 }
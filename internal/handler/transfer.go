@@ -2,28 +2,55 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/shopspring/decimal"
 
+	"kovra/internal/audit"
+	"kovra/internal/auth"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/kyc"
 	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/rail"
 	"kovra/internal/repository"
+	"kovra/internal/webhook"
 )
 
+// errTransferNotCancellable is returned when a transfer is past the point
+// where it can be cancelled.
+var errTransferNotCancellable = errors.New("transfer can no longer be cancelled")
+
 // TransferHandler handles transfer endpoints.
 type TransferHandler struct {
-	repo       *repository.TransferRepository
-	walletRepo *repository.WalletRepository
+	db            *db.DB
+	repo          *repository.TransferRepository
+	walletRepo    *repository.WalletRepository
+	outboxRepo    *repository.OutboxRepository
+	liquidityRepo *repository.LiquidityRepository
+	jobClient     *jobs.Client
+	kyc           *kyc.Service
+	trail         *audit.Trail
 }
 
 // NewTransferHandler creates a new transfer handler.
-func NewTransferHandler(repo *repository.TransferRepository, walletRepo *repository.WalletRepository) *TransferHandler {
+func NewTransferHandler(database *db.DB, repo *repository.TransferRepository, walletRepo *repository.WalletRepository, outboxRepo *repository.OutboxRepository, liquidityRepo *repository.LiquidityRepository, jobClient *jobs.Client, kycService *kyc.Service, trail *audit.Trail) *TransferHandler {
 	return &TransferHandler{
-		repo:       repo,
-		walletRepo: walletRepo,
+		db:            database,
+		repo:          repo,
+		walletRepo:    walletRepo,
+		outboxRepo:    outboxRepo,
+		liquidityRepo: liquidityRepo,
+		jobClient:     jobClient,
+		kyc:           kycService,
+		trail:         trail,
 	}
 }
 
@@ -53,11 +80,14 @@ func (h *TransferHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate required fields
-	if req.TenantID == uuid.Nil {
-		BadRequest(w, "tenant_id is required")
+	// A tenant creates its own transfers; only an operator names the tenant
+	tenantID, ok := actingTenant(w, r, req.TenantID)
+	if !ok {
 		return
 	}
+	req.TenantID = tenantID
+
+	// Validate required fields
 
 	if req.FromCurrency == "" || req.ToCurrency == "" {
 		BadRequest(w, "from_currency and to_currency are required")
@@ -92,9 +122,25 @@ func (h *TransferHandler) Create(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The transfer, and everything written with it, lives in the database
+	// of its compliance region. With regional databases the tenant's limits
+	// count the transfers of that region.
+	region := models.DeriveComplianceRegion(req.FromCurrency, req.ToCurrency)
+	ctx := h.db.InRegion(r.Context(), region)
+
+	// A regional operator only creates transfers of their region, as its
+	// database role
+	if actor := auth.ActorFromContext(r.Context()); actor.Region != "" {
+		if actor.Region != region {
+			Forbidden(w, "transfer outside the operator's region")
+			return
+		}
+		ctx = h.db.Scope(r.Context(), region)
+	}
+
 	// Check idempotency
 	if req.IdempotencyKey != nil {
-		existing, err := h.repo.GetByIdempotencyKey(r.Context(), req.TenantID, *req.IdempotencyKey)
+		existing, err := h.repo.GetByIdempotencyKey(ctx, req.TenantID, *req.IdempotencyKey)
 		if err != nil {
 			InternalError(w, "failed to check idempotency")
 			return
@@ -127,15 +173,168 @@ func (h *TransferHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Rail:                rail,
 	}
 
-	transfer, err := h.repo.Create(r.Context(), params)
+	// Check the tenant's status and limits, then create the transfer and
+	// record its quote margin and event in one transaction
+	transfer, err := db.WithTxResult(ctx, h.db, func(tx pgx.Tx) (*models.Transfer, error) {
+		if err := h.kyc.CheckTransferTx(ctx, tx, params.TenantID, params.FromCurrency, params.FromAmount); err != nil {
+			return nil, err
+		}
+
+		transfer, err := h.repo.WithTx(tx).Create(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.repo.WithTx(tx).RecordQuoteMargin(ctx, transfer.ID); err != nil {
+			return nil, err
+		}
+
+		event, err := outbox.NewEvent(models.AggregateTransfer, transfer.ID, &transfer.TenantID,
+			string(models.WebhookEventTransferStatusChanged),
+			webhook.NewTransferStatusChanged(transfer, transfer.Status, nil, nil))
+		if err != nil {
+			return nil, err
+		}
+		if err := h.outboxRepo.WithTx(tx).Append(ctx, event); err != nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &transfer.TenantID,
+			Region:       transfer.ComplianceRegion,
+			ResourceType: audit.ResourceTransfer,
+			ResourceID:   transfer.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        transfer,
+		}); err != nil {
+			return nil, err
+		}
+
+		return transfer, nil
+	})
 	if err != nil {
-		InternalError(w, "failed to create transfer")
+		kycError(w, err, "failed to create transfer")
 		return
 	}
 
 	JSON(w, http.StatusCreated, transfer)
 }
 
+// CancelTransferRequest represents a transfer cancellation request.
+type CancelTransferRequest struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// Cancel cancels a transfer that has not been screened through, or whose
+// payout the rail has not acknowledged yet. The transfer is locked while it
+// is cancelled, so it cannot move on concurrently. Its payout hold is voided
+// and its liquidity hold and quote released; a cancelled transfer no longer
+// counts towards the tenant's limits.
+//
+// The payout hold is voided by a job enqueued with the cancellation, once it
+// commits: voiding it before, and failing to commit, would leave a transfer
+// that can still be paid out with its hold voided.
+// POST /api/v1/transfers/{id}/cancel
+func (h *TransferHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid transfer ID")
+		return
+	}
+
+	// The tenant of a transfer never changes, so it is checked before the
+	// transfer is locked
+	existing, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get transfer")
+		return
+	}
+	if existing == nil {
+		NotFound(w, "transfer not found")
+		return
+	}
+	if !authorizeTenant(w, r, existing.TenantID) {
+		return
+	}
+
+	var req CancelTransferRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			BadRequest(w, "invalid request body")
+			return
+		}
+	}
+
+	transfer, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.Transfer, error) {
+		repo := h.repo.WithTx(tx)
+		before, err := repo.GetForUpdate(r.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		if before == nil {
+			return nil, nil
+		}
+		if !before.IsCancellable() {
+			return nil, errTransferNotCancellable
+		}
+
+		if err := repo.UpdateStatus(r.Context(), id, models.TransferStatusCancelled, req.Reason); err != nil {
+			return nil, err
+		}
+		if err := h.liquidityRepo.WithTx(tx).CancelHoldByTransfer(r.Context(), id); err != nil {
+			return nil, err
+		}
+		if err := repo.ReleaseQuoteMargin(r.Context(), id); err != nil {
+			return nil, err
+		}
+
+		previous := before.Status
+		event, err := outbox.NewEvent(models.AggregateTransfer, id, &before.TenantID,
+			string(models.WebhookEventTransferStatusChanged),
+			webhook.NewTransferStatusChanged(before, models.TransferStatusCancelled, &previous, req.Reason))
+		if err != nil {
+			return nil, err
+		}
+		if err := h.outboxRepo.WithTx(tx).Append(r.Context(), event); err != nil {
+			return nil, err
+		}
+
+		after, err := repo.GetByID(r.Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &before.TenantID,
+			Region:       before.ComplianceRegion,
+			ResourceType: audit.ResourceTransfer,
+			ResourceID:   id.String(),
+			Action:       audit.ActionChangeStatus,
+			Before:       before,
+			After:        after,
+		}); err != nil {
+			return nil, err
+		}
+
+		if _, err := h.jobClient.InsertTx(r.Context(), tx, rail.VoidHoldArgs{TransferID: id}, nil); err != nil {
+			return nil, fmt.Errorf("enqueue payout hold void: %w", err)
+		}
+		return after, nil
+	})
+	if err != nil {
+		if errors.Is(err, errTransferNotCancellable) {
+			Conflict(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to cancel transfer")
+		return
+	}
+	if transfer == nil {
+		NotFound(w, "transfer not found")
+		return
+	}
+
+	JSON(w, http.StatusOK, transfer)
+}
+
 // Get returns a transfer by ID.
 // GET /api/v1/transfers/{id}
 func (h *TransferHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -157,10 +356,16 @@ func (h *TransferHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizeTenant(w, r, transfer.TenantID) {
+		return
+	}
+
 	JSON(w, http.StatusOK, transfer)
 }
 
-// ListByTenant returns transfers for a tenant.
+// ListByTenant returns a page of a tenant's transfers, newest first,
+// optionally filtered by status, currencies, amount range, rail, recipient,
+// batch, compliance region and status, and creation or update time.
 // GET /api/v1/tenants/{id}/transfers
 func (h *TransferHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -170,42 +375,67 @@ func (h *TransferHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse query params
-	filter := models.TransferFilter{
-		Limit:  100,
-		Offset: 0,
+	page, ok := parsePageFilter(w, r)
+	if !ok {
+		return
 	}
 
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
-			filter.Limit = limit
-		}
+	q := &queryParser{r: r}
+	filter := models.TransferFilter{
+		PageFilter:       page,
+		FromCurrency:     q.string("from_currency"),
+		ToCurrency:       q.string("to_currency"),
+		ComplianceStatus: q.string("compliance_status"),
+		RecipientID:      q.uuid("recipient_id"),
+		BatchID:          q.uuid("batch_id"),
+		MinAmount:        q.decimal("min_amount"),
+		MaxAmount:        q.decimal("max_amount"),
+		CreatedFrom:      q.time("created_from"),
+		CreatedTo:        q.time("created_to"),
+		UpdatedAfter:     q.time("updated_after"),
+		UpdatedBefore:    q.time("updated_before"),
 	}
-
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
-			filter.Offset = offset
-		}
+	if q.err != nil {
+		BadRequest(w, q.err.Error())
+		return
 	}
 
-	if status := r.URL.Query().Get("status"); status != "" {
-		s := models.TransferStatus(status)
+	if status := q.string("status"); status != nil {
+		s := models.TransferStatus(*status)
+		if !s.IsValid() {
+			BadRequest(w, "invalid status")
+			return
+		}
 		filter.Status = &s
 	}
 
-	if fromCurrency := r.URL.Query().Get("from_currency"); fromCurrency != "" {
-		filter.FromCurrency = &fromCurrency
+	if rail := q.string("rail"); rail != nil {
+		rl := models.Rail(strings.ToUpper(*rail))
+		if !rl.IsValid() {
+			BadRequest(w, "invalid rail")
+			return
+		}
+		filter.Rail = &rl
 	}
 
-	if toCurrency := r.URL.Query().Get("to_currency"); toCurrency != "" {
-		filter.ToCurrency = &toCurrency
+	if region := q.string("compliance_region"); region != nil {
+		cr := models.ComplianceRegion(strings.ToUpper(*region))
+		filter.ComplianceRegion = &cr
 	}
 
-	transfers, err := h.repo.ListByTenant(r.Context(), id, filter)
-	if err != nil {
-		InternalError(w, "failed to list transfers")
-		return
+	// A tenant's transfers are spread over the regional databases; a
+	// regional operator lists those of their region
+	var pages []models.Page[*models.Transfer]
+	scope := h.db.Scope(r.Context(), auth.ActorFromContext(r.Context()).Region)
+	for _, ctx := range h.db.Databases(scope) {
+		page, err := h.repo.ListByTenant(ctx, id, filter)
+		if err != nil {
+			InternalError(w, "failed to list transfers")
+			return
+		}
+		pages = append(pages, page)
 	}
 
-	JSON(w, http.StatusOK, transfers)
+	transfers := repository.MergePages(pages, filter.Limit, func(t *models.Transfer) uuid.UUID { return t.ID })
+	JSON(w, http.StatusOK, newPage(transfers))
 }
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+	"kovra/internal/treasury"
+)
+
+// TreasuryHandler handles treasury movements between system accounts.
+type TreasuryHandler struct {
+	service *treasury.Service
+}
+
+// NewTreasuryHandler creates a new treasury handler.
+func NewTreasuryHandler(service *treasury.Service) *TreasuryHandler {
+	return &TreasuryHandler{service: service}
+}
+
+// MovementLegRequest represents one transfer of a rebalance.
+type MovementLegRequest struct {
+	Currency      string `json:"currency"`
+	DebitAccount  string `json:"debit_account"`
+	CreditAccount string `json:"credit_account"`
+	Amount        string `json:"amount"`
+}
+
+// RequestRebalanceRequest represents a request to move funds between
+// system accounts.
+type RequestRebalanceRequest struct {
+	Reason string               `json:"reason"`
+	Legs   []MovementLegRequest `json:"legs"`
+}
+
+// RequestFXDealRequest represents a request to book an external FX deal.
+type RequestFXDealRequest struct {
+	Counterparty  string `json:"counterparty"`
+	DealReference string `json:"deal_reference"`
+	SellCurrency  string `json:"sell_currency"`
+	SellAmount    string `json:"sell_amount"`
+	BuyCurrency   string `json:"buy_currency"`
+	BuyAmount     string `json:"buy_amount"`
+	Reason        string `json:"reason"`
+}
+
+// DecideMovementRequest represents the approval or rejection of a movement.
+type DecideMovementRequest struct {
+	Status string  `json:"status"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// RequestRebalance requests a movement of funds between system accounts, on
+// behalf of the calling operator.
+// POST /api/v1/treasury/rebalances
+func (h *TreasuryHandler) RequestRebalance(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	var req RequestRebalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	legs := make([]models.TreasuryMovementLeg, len(req.Legs))
+	for i, l := range req.Legs {
+		amount, err := decimal.NewFromString(l.Amount)
+		if err != nil {
+			BadRequest(w, "invalid leg amount")
+			return
+		}
+		legs[i] = models.TreasuryMovementLeg{
+			Currency:      strings.ToUpper(l.Currency),
+			DebitAccount:  strings.ToUpper(l.DebitAccount),
+			CreditAccount: strings.ToUpper(l.CreditAccount),
+			Amount:        amount,
+		}
+	}
+
+	movement, err := h.service.RequestRebalance(r.Context(), req.Reason, actor.ID, legs)
+	if err != nil {
+		if errors.Is(err, treasury.ErrInvalidMovement) {
+			BadRequest(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to request rebalance")
+		return
+	}
+
+	JSON(w, http.StatusCreated, movement)
+}
+
+// RequestFXDeal requests the booking of an FX deal covering FX_SETTLEMENT
+// positions, on behalf of the calling operator.
+// POST /api/v1/treasury/fx-deals
+func (h *TreasuryHandler) RequestFXDeal(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	var req RequestFXDealRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	sellAmount, err := decimal.NewFromString(req.SellAmount)
+	if err != nil {
+		BadRequest(w, "invalid sell_amount")
+		return
+	}
+	buyAmount, err := decimal.NewFromString(req.BuyAmount)
+	if err != nil {
+		BadRequest(w, "invalid buy_amount")
+		return
+	}
+
+	movement, err := h.service.RequestFXDeal(r.Context(), treasury.FXDeal{
+		Counterparty:  strings.TrimSpace(req.Counterparty),
+		DealReference: strings.TrimSpace(req.DealReference),
+		SellCurrency:  strings.ToUpper(req.SellCurrency),
+		SellAmount:    sellAmount,
+		BuyCurrency:   strings.ToUpper(req.BuyCurrency),
+		BuyAmount:     buyAmount,
+		Reason:        req.Reason,
+		RequestedBy:   actor.ID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, treasury.ErrInvalidMovement):
+			BadRequest(w, err.Error())
+		case errors.Is(err, treasury.ErrDuplicateDeal):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to request fx deal")
+		}
+		return
+	}
+
+	JSON(w, http.StatusCreated, movement)
+}
+
+// ListMovements returns treasury movements, newest first.
+// GET /api/v1/treasury/movements
+func (h *TreasuryHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.TreasuryMovementFilter{
+		Limit:  100,
+		Offset: 0,
+	}
+
+	if limitStr := q.Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 1000 {
+			filter.Limit = limit
+		}
+	}
+
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+			filter.Offset = offset
+		}
+	}
+
+	if statusStr := q.Get("status"); statusStr != "" {
+		status := models.TreasuryMovementStatus(statusStr)
+		if !status.IsValid() {
+			BadRequest(w, "status must be pending, approved, rejected or posted")
+			return
+		}
+		filter.Status = &status
+	}
+
+	if kindStr := q.Get("kind"); kindStr != "" {
+		kind := models.TreasuryMovementKind(kindStr)
+		if !kind.IsValid() {
+			BadRequest(w, "kind must be rebalance or fx_deal")
+			return
+		}
+		filter.Kind = &kind
+	}
+
+	movements, err := h.service.ListMovements(r.Context(), filter)
+	if err != nil {
+		InternalError(w, "failed to list treasury movements")
+		return
+	}
+
+	JSON(w, http.StatusOK, movements)
+}
+
+// GetMovement returns a treasury movement with its legs.
+// GET /api/v1/treasury/movements/{id}
+func (h *TreasuryHandler) GetMovement(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid movement ID")
+		return
+	}
+
+	movement, err := h.service.GetMovement(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, treasury.ErrMovementNotFound) {
+			NotFound(w, err.Error())
+			return
+		}
+		InternalError(w, "failed to get treasury movement")
+		return
+	}
+
+	JSON(w, http.StatusOK, movement)
+}
+
+// DecideMovement approves or rejects a pending treasury movement as the
+// calling operator, who must not be its requester.
+// POST /api/v1/treasury/movements/{id}/decision
+func (h *TreasuryHandler) DecideMovement(w http.ResponseWriter, r *http.Request) {
+	actor, ok := operator(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		BadRequest(w, "invalid movement ID")
+		return
+	}
+
+	var req DecideMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		BadRequest(w, "invalid request body")
+		return
+	}
+
+	status := models.TreasuryMovementStatus(req.Status)
+	if status != models.TreasuryMovementApproved && status != models.TreasuryMovementRejected {
+		BadRequest(w, "status must be approved or rejected")
+		return
+	}
+
+	movement, err := h.service.Decide(r.Context(), id, status, actor.ID, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, treasury.ErrMovementNotFound):
+			NotFound(w, err.Error())
+		case errors.Is(err, treasury.ErrMovementNotPending),
+			errors.Is(err, treasury.ErrSelfApproval):
+			Conflict(w, err.Error())
+		default:
+			InternalError(w, "failed to decide treasury movement")
+		}
+		return
+	}
+
+	JSON(w, http.StatusOK, movement)
+}
@@ -7,7 +7,11 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/kyc"
 	"kovra/internal/ledger"
 	"kovra/internal/models"
 	"kovra/internal/repository"
@@ -15,15 +19,21 @@ import (
 
 // WalletHandler handles wallet endpoints.
 type WalletHandler struct {
+	db           *db.DB
 	repo         *repository.WalletRepository
 	ledgerClient *ledger.Client
+	kyc          *kyc.Service
+	trail        *audit.Trail
 }
 
 // NewWalletHandler creates a new wallet handler.
-func NewWalletHandler(repo *repository.WalletRepository, ledgerClient *ledger.Client) *WalletHandler {
+func NewWalletHandler(database *db.DB, repo *repository.WalletRepository, ledgerClient *ledger.Client, kycService *kyc.Service, trail *audit.Trail) *WalletHandler {
 	return &WalletHandler{
+		db:           database,
 		repo:         repo,
 		ledgerClient: ledgerClient,
+		kyc:          kycService,
+		trail:        trail,
 	}
 }
 
@@ -42,16 +52,24 @@ func (h *WalletHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.TenantID == uuid.Nil {
-		BadRequest(w, "tenant_id is required")
+	// A tenant acts for itself; an operator names the tenant
+	acting, ok := actingTenant(w, r, req.TenantID)
+	if !ok {
 		return
 	}
+	req.TenantID = acting
 
 	if req.Currency == "" || len(req.Currency) != 3 {
 		BadRequest(w, "currency must be a 3-letter ISO code")
 		return
 	}
 
+	// Only tenants that may transact get new wallets
+	if _, err := h.kyc.RequireCanTransact(r.Context(), req.TenantID); err != nil {
+		kycError(w, err, "failed to check tenant")
+		return
+	}
+
 	// Check if wallet already exists
 	existing, err := h.repo.GetByTenantAndCurrency(r.Context(), req.TenantID, req.Currency)
 	if err != nil {
@@ -79,7 +97,7 @@ func (h *WalletHandler) Create(w http.ResponseWriter, r *http.Request) {
 	accountID := ledger.NewAccountID(tenantID, ledger.AccountTypeTenantWallet, currency)
 
 	// Create account in TigerBeetle
-	err = h.ledgerClient.CreateAccount(accountID, uint32(currency), uint16(ledger.AccountTypeTenantWallet))
+	err = h.ledgerClient.CreateAccount(r.Context(), accountID, uint32(currency), uint16(ledger.AccountTypeTenantWallet))
 	if err != nil {
 		InternalError(w, "failed to create ledger account")
 		return
@@ -92,7 +110,24 @@ func (h *WalletHandler) Create(w http.ResponseWriter, r *http.Request) {
 		TBAccountID: accountID.ToBigInt(),
 	}
 
-	wallet, err := h.repo.Create(r.Context(), params)
+	wallet, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.Wallet, error) {
+		wallet, err := h.repo.WithTx(tx).Create(r.Context(), params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &wallet.TenantID,
+			ResourceType: audit.ResourceWallet,
+			ResourceID:   wallet.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        wallet,
+		}); err != nil {
+			return nil, err
+		}
+
+		return wallet, nil
+	})
 	if err != nil {
 		InternalError(w, "failed to create wallet")
 		return
@@ -122,10 +157,15 @@ func (h *WalletHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizeTenant(w, r, wallet.TenantID) {
+		return
+	}
+
 	JSON(w, http.StatusOK, wallet)
 }
 
-// ListByTenant returns all wallets for a tenant.
+// ListByTenant returns a page of a tenant's wallets, newest first,
+// optionally filtered by currency and status.
 // GET /api/v1/tenants/{id}/wallets
 func (h *WalletHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
@@ -135,13 +175,25 @@ func (h *WalletHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wallets, err := h.repo.ListByTenant(r.Context(), id)
+	page, ok := parsePageFilter(w, r)
+	if !ok {
+		return
+	}
+
+	q := &queryParser{r: r}
+	filter := models.WalletFilter{
+		PageFilter: page,
+		Currency:   q.string("currency"),
+		Status:     q.string("status"),
+	}
+
+	wallets, err := h.repo.ListPageByTenant(r.Context(), id, filter)
 	if err != nil {
 		InternalError(w, "failed to list wallets")
 		return
 	}
 
-	JSON(w, http.StatusOK, wallets)
+	JSON(w, http.StatusOK, newPage(wallets))
 }
 
 // WalletBalanceResponse represents wallet balance.
@@ -174,9 +226,13 @@ func (h *WalletHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizeTenant(w, r, wallet.TenantID) {
+		return
+	}
+
 	// Get balance from TigerBeetle
 	accountID := ledger.FromBigInt(wallet.TBAccountID)
-	balance, err := h.ledgerClient.GetBalance(accountID)
+	balance, err := h.ledgerClient.GetBalance(r.Context(), accountID)
 	if err != nil {
 		InternalError(w, "failed to get balance from ledger")
 		return
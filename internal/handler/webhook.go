@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+// WebhookHandler handles webhook delivery endpoints.
+type WebhookHandler struct {
+	db         *db.DB
+	repo       *repository.WebhookDeliveryRepository
+	tenantRepo *repository.TenantRepository
+	keys       *webhook.KeyCipher
+	trail      *audit.Trail
+}
+
+// NewWebhookHandler creates a new webhook handler.
+func NewWebhookHandler(database *db.DB, repo *repository.WebhookDeliveryRepository, tenantRepo *repository.TenantRepository, keys *webhook.KeyCipher, trail *audit.Trail) *WebhookHandler {
+	return &WebhookHandler{
+		db:         database,
+		repo:       repo,
+		tenantRepo: tenantRepo,
+		keys:       keys,
+		trail:      trail,
+	}
+}
+
+// WebhookDeliveryResponse is a delivery together with its attempt log.
+type WebhookDeliveryResponse struct {
+	*models.WebhookDelivery
+	AttemptLog []*models.WebhookDeliveryAttempt
+}
+
+// WebhookSecretResponse returns a newly issued signing secret.
+type WebhookSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+// ListByTenant returns webhook deliveries for a tenant.
+// GET /api/v1/tenants/{id}/webhook-deliveries
+func (h *WebhookHandler) ListByTenant(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	if !authorizeTenant(w, r, id) {
+		return
+	}
+
+	limit := 100
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 1000 {
+			limit = l
+		}
+	}
+
+	var status *models.WebhookDeliveryStatus
+	if s := r.URL.Query().Get("status"); s != "" {
+		st := models.WebhookDeliveryStatus(s)
+		status = &st
+	}
+
+	deliveries, err := h.repo.ListByTenant(r.Context(), id, status, limit)
+	if err != nil {
+		InternalError(w, "failed to list webhook deliveries")
+		return
+	}
+
+	JSON(w, http.StatusOK, deliveries)
+}
+
+// Get returns a webhook delivery and its attempt log.
+// GET /api/v1/webhook-deliveries/{id}
+func (h *WebhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid webhook delivery ID")
+		return
+	}
+
+	delivery, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get webhook delivery")
+		return
+	}
+
+	if delivery == nil {
+		NotFound(w, "webhook delivery not found")
+		return
+	}
+
+	if !authorizeTenant(w, r, delivery.TenantID) {
+		return
+	}
+
+	attempts, err := h.repo.ListAttempts(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to list webhook delivery attempts")
+		return
+	}
+
+	JSON(w, http.StatusOK, WebhookDeliveryResponse{
+		WebhookDelivery: delivery,
+		AttemptLog:      attempts,
+	})
+}
+
+// Replay re-queues a delivery (including dead-lettered ones) for immediate redelivery.
+// POST /api/v1/webhook-deliveries/{id}/replay
+func (h *WebhookHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid webhook delivery ID")
+		return
+	}
+
+	existing, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get webhook delivery")
+		return
+	}
+	if existing != nil && !authorizeTenant(w, r, existing.TenantID) {
+		return
+	}
+
+	delivery, err := db.WithTxResult(r.Context(), h.db, func(tx pgx.Tx) (*models.WebhookDelivery, error) {
+		delivery, err := h.repo.WithTx(tx).Replay(r.Context(), id)
+		if err != nil || delivery == nil {
+			return nil, err
+		}
+
+		if err := h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &delivery.TenantID,
+			ResourceType: audit.ResourceWebhookDelivery,
+			ResourceID:   delivery.ID.String(),
+			Action:       audit.ActionReplay,
+			After:        delivery,
+		}); err != nil {
+			return nil, err
+		}
+
+		return delivery, nil
+	})
+	if err != nil {
+		InternalError(w, "failed to replay webhook delivery")
+		return
+	}
+
+	if delivery == nil {
+		NotFound(w, "webhook delivery not found")
+		return
+	}
+
+	JSON(w, http.StatusAccepted, delivery)
+}
+
+// RotateSecret issues a new webhook signing secret for a tenant, at the
+// request of the tenant itself or an operator.
+// The secret is returned once; only its signing key is stored, sealed.
+// POST /api/v1/tenants/{id}/webhook-secret
+func (h *WebhookHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		BadRequest(w, "invalid tenant ID")
+		return
+	}
+
+	if !authorizeTenant(w, r, id) {
+		return
+	}
+
+	tenant, err := h.tenantRepo.GetByID(r.Context(), id)
+	if err != nil {
+		InternalError(w, "failed to get tenant")
+		return
+	}
+
+	if tenant == nil {
+		NotFound(w, "tenant not found")
+		return
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		InternalError(w, "failed to generate webhook secret")
+		return
+	}
+
+	sealed, err := h.keys.Seal(id, webhook.SigningKey(secret))
+	if err != nil {
+		InternalError(w, "failed to seal webhook signing key")
+		return
+	}
+
+	// The audit entry records that the secret changed, never the secret
+	// or its key.
+	err = h.db.WithTx(r.Context(), func(tx pgx.Tx) error {
+		if err := h.tenantRepo.WithTx(tx).SetWebhookSigningKey(r.Context(), id, sealed); err != nil {
+			return err
+		}
+
+		return h.trail.RecordTx(r.Context(), tx, audit.Entry{
+			TenantID:     &id,
+			ResourceType: audit.ResourceTenant,
+			ResourceID:   id.String(),
+			Action:       audit.ActionRotateWebhookSecret,
+		})
+	})
+	if err != nil {
+		InternalError(w, "failed to store webhook secret")
+		return
+	}
+
+	JSON(w, http.StatusCreated, WebhookSecretResponse{Secret: secret})
+}
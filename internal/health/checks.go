@@ -0,0 +1,131 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/cache"
+	"kovra/internal/db"
+	"kovra/internal/ledger"
+)
+
+// saturationThreshold is the share of a pool's connections in use above
+// which the database is reported degraded: requests start waiting for
+// connections soon after.
+const saturationThreshold = 0.9
+
+// Postgres returns a check of each database, the primary and the regional
+// ones, pinging it and reporting the use of its connection pool.
+func Postgres(database *db.DB) []Check {
+	checks := []Check{{
+		Name:     "postgres",
+		Critical: true,
+		Run:      pingPool(database.Pool()),
+	}}
+	for _, region := range database.Regions() {
+		checks = append(checks, Check{
+			Name:     "postgres_" + strings.ToLower(string(region)),
+			Critical: true,
+			Run:      pingPool(database.PoolFor(database.InRegion(context.Background(), region))),
+		})
+	}
+	return checks
+}
+
+func pingPool(pool *pgxpool.Pool) func(ctx context.Context) (Details, error) {
+	return func(ctx context.Context) (Details, error) {
+		s := pool.Stat()
+		saturation := float64(s.AcquiredConns()) / float64(s.MaxConns())
+		details := Details{
+			"acquired_conns":      s.AcquiredConns(),
+			"idle_conns":          s.IdleConns(),
+			"max_conns":           s.MaxConns(),
+			"saturation":          saturation,
+			"empty_acquire_count": s.EmptyAcquireCount(),
+		}
+		if err := pool.Ping(ctx); err != nil {
+			return details, err
+		}
+		if saturation >= saturationThreshold {
+			return details, Degraded("connection pool %.0f%% in use", saturation*100)
+		}
+		return details, nil
+	}
+}
+
+// Migrations returns a check comparing the schema version of every database
+// with the newest migration the service was built with. A database behind it
+// lacks tables or columns the service uses. A database ahead of it is fine,
+// as migrations are applied before the services are rolled out.
+func Migrations(database *db.DB, expected int64, ttl time.Duration) Check {
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		TTL:      ttl,
+		Run: func(ctx context.Context) (Details, error) {
+			details := Details{"expected": expected}
+			var behind []string
+
+			pools := map[string]*pgxpool.Pool{"primary": database.Pool()}
+			for _, region := range database.Regions() {
+				pools[string(region)] = database.PoolFor(database.InRegion(ctx, region))
+			}
+			for name, pool := range pools {
+				// Goose deletes the row of a migration it rolls back
+				var version int64
+				err := pool.QueryRow(ctx, "SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version").Scan(&version)
+				if err != nil {
+					return details, fmt.Errorf("%s: read schema version: %w", name, err)
+				}
+				details[name] = version
+				if version < expected {
+					behind = append(behind, name)
+				}
+			}
+
+			if len(behind) > 0 {
+				return details, fmt.Errorf("schema behind version %d: %s", expected, strings.Join(behind, ", "))
+			}
+			return details, nil
+		},
+	}
+}
+
+// TigerBeetle returns a check looking up the sentinel account, which fails
+// if the cluster does not answer. The service creates the sentinel when it
+// starts, so a missing one degrades the check: the cluster is not the one
+// the service was started against, or it was wiped since.
+func TigerBeetle(client *ledger.Client, ttl time.Duration) Check {
+	return Check{
+		Name:     "tigerbeetle",
+		Critical: true,
+		TTL:      ttl,
+		Run: func(ctx context.Context) (Details, error) {
+			account, err := client.GetAccount(ctx, ledger.SentinelAccountID)
+			if err != nil {
+				return nil, err
+			}
+			details := Details{"sentinel_found": account != nil}
+			if account == nil {
+				return details, Degraded("sentinel account %s not found", ledger.SentinelAccountID)
+			}
+			return details, nil
+		},
+	}
+}
+
+// Redis returns a check pinging Redis, which holds the FX rate locks of
+// quotes, rate limits and idempotency keys.
+func Redis(client *cache.Client) Check {
+	return Check{
+		Name:     "redis",
+		Critical: true,
+		Run: func(ctx context.Context) (Details, error) {
+			return nil, client.Ping(ctx)
+		},
+	}
+}
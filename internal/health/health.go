@@ -0,0 +1,207 @@
+// Package health checks the dependencies of the service for the readiness
+// endpoint, reporting the status and latency of each. Results of expensive
+// checks are reused for a while, and concurrent requests share a run, so load
+// balancers can poll often without loading the dependencies.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status is the status of a component or of the service as a whole.
+type Status string
+
+const (
+	StatusUp       Status = "up"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// Details holds what a check found out about its component, such as pool
+// usage or a schema version.
+type Details map[string]any
+
+// Check is a probe of one component.
+type Check struct {
+	Name string
+	// Critical checks that fail make the service not ready; the failure of
+	// other checks only degrades it.
+	Critical bool
+	// TTL is how long a result is reused. Zero runs the check for every
+	// request, though concurrent requests still share a run.
+	TTL time.Duration
+	Run func(ctx context.Context) (Details, error)
+}
+
+// Component is the result of a check.
+type Component struct {
+	Name      string    `json:"name"`
+	Status    Status    `json:"status"`
+	Critical  bool      `json:"critical"`
+	LatencyMS float64   `json:"latency_ms"`
+	Error     string    `json:"error,omitempty"`
+	Details   Details   `json:"details,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the status of the service and of each of its components, in the
+// order of the checks.
+type Report struct {
+	Status     Status      `json:"status"`
+	Components []Component `json:"components"`
+}
+
+// degradedError marks a check that found its component working, but not
+// well.
+type degradedError struct {
+	err error
+}
+
+func (e *degradedError) Error() string { return e.err.Error() }
+func (e *degradedError) Unwrap() error { return e.err }
+
+// Degraded returns an error reporting a component as degraded rather than
+// down.
+func Degraded(format string, args ...any) error {
+	return &degradedError{err: fmt.Errorf(format, args...)}
+}
+
+// checkState is the last result of a check, and its run in progress, if any.
+type checkState struct {
+	mu      sync.Mutex
+	last    *Component
+	running chan struct{}
+}
+
+// Checker runs the checks of the service.
+type Checker struct {
+	checks  []Check
+	states  []*checkState
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// NewChecker returns a checker running the given checks. A check that has
+// not finished after timeout is reported down, though it keeps running in
+// the background, and its result is kept for later requests.
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	states := make([]*checkState, len(checks))
+	for i := range states {
+		states[i] = &checkState{}
+	}
+	return &Checker{checks: checks, states: states, timeout: timeout, now: time.Now}
+}
+
+// Check runs the checks concurrently, reusing fresh results, and reports the
+// service down if a critical check failed and degraded if any other did.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Components: make([]Component, len(c.checks))}
+
+	var wg sync.WaitGroup
+	for i := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Components[i] = c.component(ctx, i)
+		}()
+	}
+	wg.Wait()
+
+	for _, comp := range report.Components {
+		switch {
+		case comp.Status == StatusUp:
+		case comp.Status == StatusDown && comp.Critical:
+			report.Status = StatusDown
+		case report.Status == StatusUp:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+// component returns the result of check i, running it unless its last result
+// is fresh or a run is already in progress.
+func (c *Checker) component(ctx context.Context, i int) Component {
+	check, state := c.checks[i], c.states[i]
+
+	state.mu.Lock()
+	if state.last != nil && c.now().Sub(state.last.CheckedAt) < check.TTL {
+		comp := *state.last
+		state.mu.Unlock()
+		return comp
+	}
+	if state.running == nil {
+		state.running = make(chan struct{})
+		go c.run(check, state)
+	}
+	running := state.running
+	state.mu.Unlock()
+
+	start := c.now()
+	timer := time.NewTimer(c.timeout)
+	defer timer.Stop()
+	select {
+	case <-running:
+		state.mu.Lock()
+		defer state.mu.Unlock()
+		return *state.last
+	case <-timer.C:
+		return c.failed(check, start, fmt.Errorf("check timed out after %s", c.timeout))
+	case <-ctx.Done():
+		return c.failed(check, start, ctx.Err())
+	}
+}
+
+// run runs a check for every request waiting on it. It is detached from the
+// requests, as they share it and some clients, such as TigerBeetle's, do not
+// return on cancellation.
+func (c *Checker) run(check Check, state *checkState) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	start := c.now()
+	details, err := check.Run(ctx)
+	comp := Component{
+		Name:      check.Name,
+		Status:    StatusUp,
+		Critical:  check.Critical,
+		LatencyMS: milliseconds(c.now().Sub(start)),
+		Details:   details,
+		CheckedAt: c.now(),
+	}
+	if err != nil {
+		comp.Status = StatusDown
+		var degraded *degradedError
+		if errors.As(err, &degraded) {
+			comp.Status = StatusDegraded
+		}
+		comp.Error = err.Error()
+	}
+
+	state.mu.Lock()
+	state.last = &comp
+	close(state.running)
+	state.running = nil
+	state.mu.Unlock()
+}
+
+// failed returns the result of a check waited on since start that did not
+// finish in time.
+func (c *Checker) failed(check Check, start time.Time, err error) Component {
+	return Component{
+		Name:      check.Name,
+		Status:    StatusDown,
+		Critical:  check.Critical,
+		LatencyMS: milliseconds(c.now().Sub(start)),
+		Error:     err.Error(),
+		CheckedAt: c.now(),
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckerStatus(t *testing.T) {
+	up := func(ctx context.Context) (Details, error) { return Details{"version": 1}, nil }
+	down := func(ctx context.Context) (Details, error) { return nil, errors.New("connection refused") }
+	degraded := func(ctx context.Context) (Details, error) { return nil, Degraded("pool %d%% in use", 95) }
+
+	report := NewChecker(time.Second,
+		Check{Name: "postgres", Critical: true, Run: up},
+		Check{Name: "optional", Run: down},
+	).Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status, "a non-critical failure only degrades the service")
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "postgres", report.Components[0].Name)
+	assert.Equal(t, StatusUp, report.Components[0].Status)
+	assert.Equal(t, Details{"version": 1}, report.Components[0].Details)
+	assert.Equal(t, StatusDown, report.Components[1].Status)
+	assert.Equal(t, "connection refused", report.Components[1].Error)
+
+	report = NewChecker(time.Second,
+		Check{Name: "postgres", Critical: true, Run: degraded},
+	).Check(context.Background())
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.Equal(t, "pool 95% in use", report.Components[0].Error)
+
+	report = NewChecker(time.Second,
+		Check{Name: "postgres", Critical: true, Run: degraded},
+		Check{Name: "tigerbeetle", Critical: true, Run: down},
+	).Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+}
+
+func TestCheckerCachesResults(t *testing.T) {
+	var runs atomic.Int32
+	count := func(ctx context.Context) (Details, error) {
+		runs.Add(1)
+		return nil, nil
+	}
+
+	now := time.Now()
+	c := NewChecker(time.Second,
+		Check{Name: "tigerbeetle", TTL: 10 * time.Second, Run: count},
+	)
+	c.now = func() time.Time { return now }
+
+	c.Check(context.Background())
+	c.Check(context.Background())
+	assert.Equal(t, int32(1), runs.Load(), "a fresh result is reused")
+
+	now = now.Add(10 * time.Second)
+	c.Check(context.Background())
+	assert.Equal(t, int32(2), runs.Load(), "an expired result is refreshed")
+}
+
+func TestCheckerSharesRuns(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	slow := func(ctx context.Context) (Details, error) {
+		runs.Add(1)
+		<-release
+		return nil, nil
+	}
+	c := NewChecker(time.Second, Check{Name: "postgres", Critical: true, Run: slow})
+
+	var wg sync.WaitGroup
+	reports := make([]Report, 5)
+	for i := range reports {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports[i] = c.Check(context.Background())
+		}()
+	}
+	// Let the requests queue up on the run
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), runs.Load(), "concurrent requests share a run")
+	for _, report := range reports {
+		assert.Equal(t, StatusUp, report.Status)
+	}
+}
+
+func TestCheckerTimeout(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	defer close(release)
+	hung := func(ctx context.Context) (Details, error) {
+		runs.Add(1)
+		// Like the TigerBeetle client, ignores the context
+		<-release
+		return nil, nil
+	}
+	c := NewChecker(20*time.Millisecond, Check{Name: "tigerbeetle", Critical: true, Run: hung})
+
+	report := c.Check(context.Background())
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Contains(t, report.Components[0].Error, "timed out")
+
+	c.Check(context.Background())
+	assert.Equal(t, int32(1), runs.Load(), "a hung check is not started again")
+}
@@ -0,0 +1,406 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"kovra/internal/metrics"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/tracing"
+)
+
+const maintenanceInterval = 30 * time.Second
+
+var errPanicked = errors.New("job panicked")
+
+// Store is the persistence used to execute jobs.
+type Store interface {
+	Insert(ctx context.Context, params models.InsertJobParams) (*models.Job, error)
+	Claim(ctx context.Context, queue string, batchSize int, lease time.Duration) ([]*models.Job, error)
+	// Complete, Retry and Discard record the outcome of the given attempt of
+	// a job, and do nothing if the job has moved on to another.
+	Complete(ctx context.Context, id uuid.UUID, attempt int) error
+	Retry(ctx context.Context, id uuid.UUID, attempt int, scheduledAt time.Time, lastError string) error
+	Discard(ctx context.Context, id uuid.UUID, attempt int, lastError string) error
+	RescueStuck(ctx context.Context) (int64, error)
+	DeleteFinalized(ctx context.Context, before time.Time) (int64, error)
+}
+
+// QueueConfig holds per-queue worker configuration.
+type QueueConfig struct {
+	MaxWorkers int
+}
+
+// PeriodicJob is enqueued every Interval while the client runs.
+type PeriodicJob struct {
+	Interval time.Duration
+	Args     Args
+}
+
+// Config holds job client configuration.
+type Config struct {
+	Queues       map[string]QueueConfig
+	PollInterval time.Duration
+	// JobTimeout bounds a single job execution. The claim lease is twice as
+	// long, after which a job is assumed lost and requeued.
+	JobTimeout time.Duration
+	// Retention is how long completed and discarded jobs are kept.
+	Retention time.Duration
+	Periodic  []PeriodicJob
+}
+
+// Client enqueues jobs and, once started, runs workers for the configured queues.
+type Client struct {
+	repo    *repository.JobRepository
+	store   Store
+	workers *Workers
+	cfg     Config
+	logger  *zap.Logger
+	now     func() time.Time
+
+	mu         sync.Mutex
+	started    bool
+	stopFetch  context.CancelFunc
+	cancelWork context.CancelFunc
+	fetchWG    sync.WaitGroup
+	jobWG      sync.WaitGroup
+}
+
+// NewClient creates a new job client. workers may be nil for insert-only clients.
+func NewClient(repo *repository.JobRepository, workers *Workers, cfg Config, logger *zap.Logger) *Client {
+	return newClient(repo, repo, workers, cfg, logger)
+}
+
+func newClient(repo *repository.JobRepository, store Store, workers *Workers, cfg Config, logger *zap.Logger) *Client {
+	if len(cfg.Queues) == 0 {
+		cfg.Queues = map[string]QueueConfig{DefaultQueue: {MaxWorkers: 10}}
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = time.Minute
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if workers == nil {
+		workers = NewWorkers()
+	}
+
+	return &Client{
+		repo:    repo,
+		store:   store,
+		workers: workers,
+		cfg:     cfg,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Insert enqueues a job. It returns nil if an equivalent unique job is already pending.
+func (c *Client) Insert(ctx context.Context, args Args, opts *InsertOpts) (*models.Job, error) {
+	return c.insert(ctx, c.store, args, opts)
+}
+
+// InsertTx enqueues a job within tx; workers see it only after tx commits.
+func (c *Client) InsertTx(ctx context.Context, tx pgx.Tx, args Args, opts *InsertOpts) (*models.Job, error) {
+	return c.insert(ctx, c.repo.WithTx(tx), args, opts)
+}
+
+func (c *Client) insert(ctx context.Context, store Store, args Args, opts *InsertOpts) (*models.Job, error) {
+	params, err := c.insertParams(args, opts)
+	if err != nil {
+		return nil, err
+	}
+	return store.Insert(ctx, params)
+}
+
+func (c *Client) insertParams(args Args, opts *InsertOpts) (models.InsertJobParams, error) {
+	var o InsertOpts
+	if withOpts, ok := args.(ArgsWithOpts); ok {
+		o = withOpts.InsertOpts()
+	}
+	if opts != nil {
+		if opts.Queue != "" {
+			o.Queue = opts.Queue
+		}
+		if opts.Priority != 0 {
+			o.Priority = opts.Priority
+		}
+		if opts.MaxAttempts != 0 {
+			o.MaxAttempts = opts.MaxAttempts
+		}
+		if !opts.ScheduledAt.IsZero() {
+			o.ScheduledAt = opts.ScheduledAt
+		}
+		if opts.UniqueKey != "" {
+			o.UniqueKey = opts.UniqueKey
+		}
+	}
+
+	if o.Queue == "" {
+		o.Queue = DefaultQueue
+	}
+	if o.Priority == 0 {
+		o.Priority = DefaultPriority
+	}
+	if o.MaxAttempts == 0 {
+		o.MaxAttempts = DefaultMaxAttempts
+	}
+	if o.ScheduledAt.IsZero() {
+		o.ScheduledAt = c.now()
+	}
+
+	encoded, err := json.Marshal(args)
+	if err != nil {
+		return models.InsertJobParams{}, fmt.Errorf("marshal %s args: %w", args.Kind(), err)
+	}
+
+	params := models.InsertJobParams{
+		Kind:        args.Kind(),
+		Queue:       o.Queue,
+		Args:        encoded,
+		Priority:    o.Priority,
+		MaxAttempts: o.MaxAttempts,
+		ScheduledAt: o.ScheduledAt,
+	}
+	if o.UniqueKey != "" {
+		params.UniqueKey = &o.UniqueKey
+	}
+	return params, nil
+}
+
+// Start launches the fetch loops and maintenance. Fetching stops when ctx is
+// cancelled; running jobs are allowed to finish until Stop returns.
+func (c *Client) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.started {
+		return errors.New("jobs: client already started")
+	}
+	c.started = true
+
+	fetchCtx, stopFetch := context.WithCancel(ctx)
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	c.stopFetch = stopFetch
+	c.cancelWork = cancelWork
+
+	for queue, qc := range c.cfg.Queues {
+		maxWorkers := qc.MaxWorkers
+		if maxWorkers <= 0 {
+			maxWorkers = 1
+		}
+		c.fetchWG.Add(1)
+		go c.fetchLoop(fetchCtx, workCtx, queue, maxWorkers)
+	}
+
+	c.fetchWG.Add(1)
+	go c.maintenanceLoop(fetchCtx)
+
+	c.logger.Info("job workers started", zap.Int("queues", len(c.cfg.Queues)))
+	return nil
+}
+
+// Stop stops fetching new jobs and waits for running jobs to finish.
+// If ctx expires first, running jobs are cancelled and ctx.Err() is returned.
+func (c *Client) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.started {
+		c.mu.Unlock()
+		return nil
+	}
+	c.started = false
+	c.mu.Unlock()
+
+	c.stopFetch()
+	c.fetchWG.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		c.jobWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		c.cancelWork()
+		c.logger.Info("job workers stopped")
+		return nil
+	case <-ctx.Done():
+		c.cancelWork()
+		<-done
+		return fmt.Errorf("stop job workers: %w", ctx.Err())
+	}
+}
+
+func (c *Client) fetchLoop(fetchCtx, workCtx context.Context, queue string, maxWorkers int) {
+	defer c.fetchWG.Done()
+
+	slots := make(chan struct{}, maxWorkers)
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if free := maxWorkers - len(slots); free > 0 {
+			jobs, err := c.store.Claim(fetchCtx, queue, free, 2*c.cfg.JobTimeout)
+			if err != nil && fetchCtx.Err() == nil {
+				c.logger.Error("failed to claim jobs", zap.String("queue", queue), zap.Error(err))
+			}
+
+			for _, job := range jobs {
+				slots <- struct{}{}
+				c.jobWG.Add(1)
+				go func(job *models.Job) {
+					defer func() {
+						<-slots
+						c.jobWG.Done()
+					}()
+					c.execute(workCtx, job)
+				}(job)
+			}
+		}
+
+		select {
+		case <-fetchCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// execute runs one claimed job, in a span of the trace that enqueued it, and
+// records its outcome.
+func (c *Client) execute(ctx context.Context, job *models.Job) {
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, job.TraceContext), "job "+job.Kind,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("job.id", job.ID.String()),
+			attribute.String("job.queue", job.Queue),
+			attribute.Int("job.attempt", job.Attempt),
+		),
+	)
+	defer span.End()
+
+	logger := c.logger.With(
+		zap.String("job_id", job.ID.String()),
+		zap.String("kind", job.Kind),
+		zap.Int("attempt", job.Attempt),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+	)
+
+	start := c.now()
+	err := c.work(ctx, job)
+	metrics.ObserveJob(job.Queue, c.now().Sub(start), errorType(err))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	var recordErr error
+	switch {
+	case err == nil:
+		recordErr = c.store.Complete(ctx, job.ID, job.Attempt)
+	case isCancel(err) || job.Attempt >= job.MaxAttempts:
+		logger.Error("job discarded", zap.Error(err))
+		recordErr = c.store.Discard(ctx, job.ID, job.Attempt, err.Error())
+	default:
+		retryAt := c.now().Add(Backoff(job.Attempt))
+		logger.Warn("job failed, will retry", zap.Time("retry_at", retryAt), zap.Error(err))
+		recordErr = c.store.Retry(ctx, job.ID, job.Attempt, retryAt, err.Error())
+	}
+
+	if recordErr != nil {
+		logger.Error("failed to record job result", zap.Error(recordErr))
+	}
+}
+
+func (c *Client) work(ctx context.Context, job *models.Job) (err error) {
+	fn, ok := c.workers.lookup(job.Kind)
+	if !ok {
+		return Cancel(fmt.Errorf("no worker registered for kind %q", job.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", errPanicked, r)
+		}
+	}()
+
+	jobCtx, cancel := context.WithTimeout(ctx, c.cfg.JobTimeout)
+	defer cancel()
+
+	return fn(jobCtx, job)
+}
+
+// errorType classifies the error of a failed job run for the metrics, or
+// returns "" if the run succeeded.
+func errorType(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case isCancel(err):
+		return "cancelled"
+	case errors.Is(err, errPanicked):
+		return "panic"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
+
+func (c *Client) maintenanceLoop(ctx context.Context) {
+	defer c.fetchWG.Done()
+
+	ticker := time.NewTicker(maintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		c.maintain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// maintain requeues jobs with expired leases, prunes old finalized jobs and
+// schedules the next run of each periodic job.
+func (c *Client) maintain(ctx context.Context) {
+	if n, err := c.store.RescueStuck(ctx); err != nil && ctx.Err() == nil {
+		c.logger.Error("failed to rescue stuck jobs", zap.Error(err))
+	} else if n > 0 {
+		c.logger.Warn("rescued stuck jobs", zap.Int64("count", n))
+	}
+
+	if _, err := c.store.DeleteFinalized(ctx, c.now().Add(-c.cfg.Retention)); err != nil && ctx.Err() == nil {
+		c.logger.Error("failed to prune finalized jobs", zap.Error(err))
+	}
+
+	// The unique key keeps a single pending run per periodic job
+	for _, p := range c.cfg.Periodic {
+		_, err := c.Insert(ctx, p.Args, &InsertOpts{
+			ScheduledAt: c.now().Add(p.Interval),
+			UniqueKey:   "periodic",
+		})
+		if err != nil && ctx.Err() == nil {
+			c.logger.Error("failed to schedule periodic job", zap.String("kind", p.Args.Kind()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/models"
+)
+
+type echoArgs struct {
+	Message string `json:"message"`
+}
+
+func (echoArgs) Kind() string { return "test.echo" }
+
+func (echoArgs) InsertOpts() InsertOpts {
+	return InsertOpts{Queue: "echo", MaxAttempts: 5}
+}
+
+// recordingStore records job outcomes for execute tests.
+type recordingStore struct {
+	Store
+	completed []uuid.UUID
+	retried   map[uuid.UUID]time.Time
+	discarded map[uuid.UUID]string
+	// attempts holds the attempt each outcome was recorded for
+	attempts map[uuid.UUID]int
+}
+
+func newRecordingStore() *recordingStore {
+	return &recordingStore{
+		retried:   make(map[uuid.UUID]time.Time),
+		discarded: make(map[uuid.UUID]string),
+		attempts:  make(map[uuid.UUID]int),
+	}
+}
+
+func (s *recordingStore) Complete(ctx context.Context, id uuid.UUID, attempt int) error {
+	s.completed = append(s.completed, id)
+	s.attempts[id] = attempt
+	return nil
+}
+
+func (s *recordingStore) Retry(ctx context.Context, id uuid.UUID, attempt int, scheduledAt time.Time, lastError string) error {
+	s.retried[id] = scheduledAt
+	s.attempts[id] = attempt
+	return nil
+}
+
+func (s *recordingStore) Discard(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	s.discarded[id] = lastError
+	s.attempts[id] = attempt
+	return nil
+}
+
+func newTestJob(t *testing.T, kind string, args any, attempt, maxAttempts int) *models.Job {
+	t.Helper()
+
+	encoded, err := json.Marshal(args)
+	require.NoError(t, err)
+
+	return &models.Job{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Args:        encoded,
+		State:       models.JobStateRunning,
+		Attempt:     attempt,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+func TestExecuteRecordsOutcome(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := newRecordingStore()
+
+	var seen []string
+	workers := NewWorkers()
+	AddWorker(workers, WorkFunc[echoArgs](func(ctx context.Context, job *Job[echoArgs]) error {
+		seen = append(seen, job.Args.Message)
+		switch job.Args.Message {
+		case "fail":
+			return errors.New("temporary")
+		case "cancel":
+			return Cancel(errors.New("permanent"))
+		case "panic":
+			panic("boom")
+		}
+		return nil
+	}))
+
+	c := newClient(nil, store, workers, Config{}, zap.NewNop())
+	c.now = func() time.Time { return now }
+
+	ok := newTestJob(t, "test.echo", echoArgs{Message: "ok"}, 1, 5)
+	failing := newTestJob(t, "test.echo", echoArgs{Message: "fail"}, 2, 5)
+	exhausted := newTestJob(t, "test.echo", echoArgs{Message: "fail"}, 5, 5)
+	cancelled := newTestJob(t, "test.echo", echoArgs{Message: "cancel"}, 1, 5)
+	panicking := newTestJob(t, "test.echo", echoArgs{Message: "panic"}, 1, 5)
+	unknown := newTestJob(t, "test.unknown", echoArgs{}, 1, 5)
+
+	for _, job := range []*models.Job{ok, failing, exhausted, cancelled, panicking, unknown} {
+		c.execute(context.Background(), job)
+	}
+
+	assert.Equal(t, []string{"ok", "fail", "fail", "cancel", "panic"}, seen)
+	assert.Equal(t, []uuid.UUID{ok.ID}, store.completed)
+	assert.Equal(t, now.Add(Backoff(2)), store.retried[failing.ID])
+	assert.Contains(t, store.retried, panicking.ID)
+	assert.Contains(t, store.discarded, exhausted.ID)
+	assert.Contains(t, store.discarded, cancelled.ID)
+	assert.Contains(t, store.discarded[unknown.ID], "no worker registered")
+
+	// Each outcome is recorded for the attempt that ran
+	for _, job := range []*models.Job{ok, failing, exhausted, cancelled, panicking, unknown} {
+		assert.Equal(t, job.Attempt, store.attempts[job.ID])
+	}
+}
+
+func TestInsertParamsMergesOptions(t *testing.T) {
+	c := newClient(nil, nil, nil, Config{}, zap.NewNop())
+
+	params, err := c.insertParams(echoArgs{Message: "hi"}, &InsertOpts{Priority: 2, UniqueKey: "k"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "test.echo", params.Kind)
+	assert.Equal(t, "echo", params.Queue)
+	assert.Equal(t, 2, params.Priority)
+	assert.Equal(t, 5, params.MaxAttempts)
+	require.NotNil(t, params.UniqueKey)
+	assert.Equal(t, "k", *params.UniqueKey)
+	assert.JSONEq(t, `{"message":"hi"}`, string(params.Args))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, Backoff(1))
+	assert.Equal(t, 17*time.Second, Backoff(2))
+	assert.Equal(t, 82*time.Second, Backoff(3))
+	assert.Equal(t, 24*time.Hour, Backoff(25))
+}
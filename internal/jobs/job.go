@@ -0,0 +1,133 @@
+// Package jobs is a durable background job queue on PostgreSQL.
+//
+// Jobs are rows in the jobs table. Workers claim them with
+// SELECT ... FOR UPDATE SKIP LOCKED, so any number of processes can consume
+// the same queue. Failed jobs are retried with backoff until max_attempts,
+// then discarded. A job inserted inside a pgx transaction (InsertTx) only
+// becomes visible to workers once that transaction commits.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"kovra/internal/models"
+)
+
+const (
+	DefaultQueue       = "default"
+	DefaultPriority    = 1
+	DefaultMaxAttempts = 25
+)
+
+// Args is implemented by the argument struct of every job kind.
+// Args are stored as JSON, so fields must be exported and JSON-encodable.
+type Args interface {
+	Kind() string
+}
+
+// ArgsWithOpts lets a job kind declare its default insert options.
+type ArgsWithOpts interface {
+	Args
+	InsertOpts() InsertOpts
+}
+
+// InsertOpts controls how a job is enqueued. Zero values use the defaults.
+type InsertOpts struct {
+	Queue       string
+	Priority    int // 1 (highest) to 4
+	MaxAttempts int
+	ScheduledAt time.Time
+	// UniqueKey deduplicates jobs of the same kind: while a job with this key
+	// is available or running, inserting another one is a no-op.
+	UniqueKey string
+}
+
+// Job is a claimed job with its decoded arguments.
+type Job[T Args] struct {
+	*models.Job
+	Args T
+}
+
+// Worker executes jobs of one kind.
+type Worker[T Args] interface {
+	Work(ctx context.Context, job *Job[T]) error
+}
+
+// WorkFunc adapts a function to the Worker interface.
+type WorkFunc[T Args] func(ctx context.Context, job *Job[T]) error
+
+// Work calls f(ctx, job).
+func (f WorkFunc[T]) Work(ctx context.Context, job *Job[T]) error {
+	return f(ctx, job)
+}
+
+// Workers maps job kinds to their workers.
+type Workers struct {
+	units map[string]func(ctx context.Context, job *models.Job) error
+}
+
+// NewWorkers creates an empty worker registry.
+func NewWorkers() *Workers {
+	return &Workers{units: make(map[string]func(ctx context.Context, job *models.Job) error)}
+}
+
+// AddWorker registers a worker for the kind of T. It panics if the kind is already registered.
+func AddWorker[T Args](workers *Workers, worker Worker[T]) {
+	var zero T
+	kind := zero.Kind()
+	if _, exists := workers.units[kind]; exists {
+		panic(fmt.Sprintf("jobs: worker for kind %q already registered", kind))
+	}
+
+	workers.units[kind] = func(ctx context.Context, job *models.Job) error {
+		var args T
+		if err := json.Unmarshal(job.Args, &args); err != nil {
+			return Cancel(fmt.Errorf("decode %s args: %w", kind, err))
+		}
+		return worker.Work(ctx, &Job[T]{Job: job, Args: args})
+	}
+}
+
+func (w *Workers) lookup(kind string) (func(ctx context.Context, job *models.Job) error, bool) {
+	fn, ok := w.units[kind]
+	return fn, ok
+}
+
+// cancelError marks a job failure as permanent.
+type cancelError struct {
+	err error
+}
+
+func (e *cancelError) Error() string { return "job cancelled: " + e.err.Error() }
+func (e *cancelError) Unwrap() error { return e.err }
+
+// Cancel wraps err so the job is discarded instead of retried.
+func Cancel(err error) error {
+	return &cancelError{err: err}
+}
+
+func isCancel(err error) bool {
+	var ce *cancelError
+	return errors.As(err, &ce)
+}
+
+// Backoff returns the delay before retrying a job that failed on the given
+// attempt: attempt^4 seconds plus one, capped at 24 hours.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 75 {
+		return 24 * time.Hour
+	}
+	a := time.Duration(attempt)
+	d := a*a*a*a*time.Second + time.Second
+	if d > 24*time.Hour {
+		return 24 * time.Hour
+	}
+	return d
+}
@@ -0,0 +1,91 @@
+package kyc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+)
+
+// ErrLimitExceeded is wrapped by every LimitError.
+var ErrLimitExceeded = errors.New("transaction limit exceeded")
+
+// Limit names used in LimitError.
+const (
+	LimitPerTransfer = "per_transfer"
+	LimitDaily       = "daily"
+	LimitMonthly     = "monthly"
+)
+
+// LimitError reports which cap a transfer would exceed.
+type LimitError struct {
+	Limit     string
+	LimitUSD  decimal.Decimal
+	UsedUSD   decimal.Decimal
+	AmountUSD decimal.Decimal
+}
+
+func (e *LimitError) Error() string {
+	if e.Limit == LimitPerTransfer {
+		return fmt.Sprintf("%s limit of %s USD exceeded by a transfer of %s USD",
+			e.Limit, e.LimitUSD.StringFixed(2), e.AmountUSD.StringFixed(2))
+	}
+	return fmt.Sprintf("%s limit of %s USD exceeded: %s USD used, %s USD requested",
+		e.Limit, e.LimitUSD.StringFixed(2), e.UsedUSD.StringFixed(2), e.AmountUSD.StringFixed(2))
+}
+
+func (e *LimitError) Unwrap() error { return ErrLimitExceeded }
+
+// Usage is a tenant's transfer volume in USD for the current day and month.
+type Usage struct {
+	Day   decimal.Decimal
+	Month decimal.Decimal
+}
+
+// Limits are the caps that apply to a tenant, in USD. Nil means uncapped.
+type Limits struct {
+	PerTransfer *decimal.Decimal
+	Daily       *decimal.Decimal
+	Monthly     *decimal.Decimal
+}
+
+// EffectiveLimits layers the KYC tier caps on top of the tenant's limit
+// policy: each cap is the lower of the two. Either may be nil.
+func EffectiveLimits(tier *models.KYCTierLimit, policy *models.LimitPolicy) Limits {
+	var l Limits
+	if tier != nil {
+		l.PerTransfer = tier.PerTransferLimitUSD
+		l.Daily = tier.DailyLimitUSD
+		l.Monthly = tier.MonthlyLimitUSD
+	}
+	if policy != nil {
+		l.PerTransfer = lower(l.PerTransfer, policy.PerTransferLimitUSD)
+		l.Daily = lower(l.Daily, policy.DailyLimitUSD)
+		l.Monthly = lower(l.Monthly, policy.MonthlyLimitUSD)
+	}
+	return l
+}
+
+// Check returns a *LimitError if a transfer of amountUSD on top of usage
+// exceeds any cap.
+func (l Limits) Check(amountUSD decimal.Decimal, usage Usage) error {
+	if l.PerTransfer != nil && amountUSD.GreaterThan(*l.PerTransfer) {
+		return &LimitError{Limit: LimitPerTransfer, LimitUSD: *l.PerTransfer, AmountUSD: amountUSD}
+	}
+	if l.Daily != nil && usage.Day.Add(amountUSD).GreaterThan(*l.Daily) {
+		return &LimitError{Limit: LimitDaily, LimitUSD: *l.Daily, UsedUSD: usage.Day, AmountUSD: amountUSD}
+	}
+	if l.Monthly != nil && usage.Month.Add(amountUSD).GreaterThan(*l.Monthly) {
+		return &LimitError{Limit: LimitMonthly, LimitUSD: *l.Monthly, UsedUSD: usage.Month, AmountUSD: amountUSD}
+	}
+	return nil
+}
+
+func lower(a *decimal.Decimal, b decimal.Decimal) *decimal.Decimal {
+	if a == nil || b.LessThan(*a) {
+		return &b
+	}
+	return a
+}
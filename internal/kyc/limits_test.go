@@ -0,0 +1,109 @@
+package kyc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+func usd(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func TestEffectiveLimitsTakesLowerCap(t *testing.T) {
+	tier := &models.KYCTierLimit{
+		KYCLevel:            models.KYCLevelStandard,
+		PerTransferLimitUSD: usd("25000"),
+		DailyLimitUSD:       usd("100000"),
+		MonthlyLimitUSD:     usd("1000000"),
+	}
+	policy := &models.LimitPolicy{
+		PerTransferLimitUSD: decimal.RequireFromString("500000"),
+		DailyLimitUSD:       decimal.RequireFromString("50000"),
+		MonthlyLimitUSD:     decimal.RequireFromString("1000000"),
+	}
+
+	l := EffectiveLimits(tier, policy)
+	assert.Equal(t, "25000", l.PerTransfer.String())
+	assert.Equal(t, "50000", l.Daily.String())
+	assert.Equal(t, "1000000", l.Monthly.String())
+}
+
+func TestEffectiveLimitsUncappedTier(t *testing.T) {
+	tier := &models.KYCTierLimit{KYCLevel: models.KYCLevelEnhanced}
+
+	assert.Equal(t, Limits{}, EffectiveLimits(tier, nil))
+
+	policy := &models.LimitPolicy{
+		PerTransferLimitUSD: decimal.RequireFromString("100000"),
+		DailyLimitUSD:       decimal.RequireFromString("500000"),
+		MonthlyLimitUSD:     decimal.RequireFromString("5000000"),
+	}
+	l := EffectiveLimits(tier, policy)
+	assert.Equal(t, "100000", l.PerTransfer.String())
+	assert.Equal(t, "500000", l.Daily.String())
+	assert.Equal(t, "5000000", l.Monthly.String())
+}
+
+func TestLimitsCheck(t *testing.T) {
+	l := Limits{PerTransfer: usd("25000"), Daily: usd("100000"), Monthly: usd("1000000")}
+
+	tests := []struct {
+		name   string
+		amount string
+		usage  Usage
+		limit  string
+	}{
+		{name: "within limits", amount: "25000", usage: Usage{Day: decimal.RequireFromString("75000")}},
+		{name: "per transfer", amount: "25000.01", limit: LimitPerTransfer},
+		{name: "daily", amount: "1000", usage: Usage{Day: decimal.RequireFromString("99500")}, limit: LimitDaily},
+		{
+			name:   "monthly",
+			amount: "1000",
+			usage:  Usage{Day: decimal.RequireFromString("500"), Month: decimal.RequireFromString("999500")},
+			limit:  LimitMonthly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := l.Check(decimal.RequireFromString(tt.amount), tt.usage)
+			if tt.limit == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var limitErr *LimitError
+			require.True(t, errors.As(err, &limitErr), "expected a limit error, got %v", err)
+			assert.Equal(t, tt.limit, limitErr.Limit)
+			assert.ErrorIs(t, err, ErrLimitExceeded)
+		})
+	}
+}
+
+func TestBasicTierCannotSend(t *testing.T) {
+	l := EffectiveLimits(&models.KYCTierLimit{
+		KYCLevel:            models.KYCLevelBasic,
+		PerTransferLimitUSD: usd("0"),
+		DailyLimitUSD:       usd("0"),
+		MonthlyLimitUSD:     usd("0"),
+	}, nil)
+
+	assert.ErrorIs(t, l.Check(decimal.RequireFromString("0.01"), Usage{}), ErrLimitExceeded)
+}
+
+func TestTenantStatusTransitions(t *testing.T) {
+	assert.True(t, models.TenantStatusPendingKYC.CanTransitionTo(models.TenantStatusActive))
+	assert.True(t, models.TenantStatusActive.CanTransitionTo(models.TenantStatusSuspended))
+	assert.True(t, models.TenantStatusSuspended.CanTransitionTo(models.TenantStatusActive))
+	assert.True(t, models.TenantStatusSuspended.CanTransitionTo(models.TenantStatusClosed))
+	assert.False(t, models.TenantStatusPendingKYC.CanTransitionTo(models.TenantStatusSuspended))
+	assert.False(t, models.TenantStatusClosed.CanTransitionTo(models.TenantStatusActive))
+	assert.False(t, models.TenantStatusActive.CanTransitionTo(models.TenantStatusPendingKYC))
+}
@@ -0,0 +1,353 @@
+// Package kyc gates tenants on their verification: KYC document review,
+// the tenant lifecycle and the transaction caps of each KYC tier.
+package kyc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrTenantNotFound        = errors.New("tenant not found")
+	ErrTenantCannotTransact  = errors.New("tenant is not allowed to transact")
+	ErrTenantClosed          = errors.New("tenant is closed")
+	ErrInvalidTransition     = errors.New("tenant status transition not allowed")
+	ErrKYCRequired           = errors.New("tenant must pass KYC above basic before activation")
+	ErrSubmissionNotFound    = errors.New("kyc submission not found")
+	ErrSubmissionPending     = errors.New("tenant already has a kyc submission under review")
+	ErrSubmissionDecided     = errors.New("kyc submission is already decided")
+	ErrSubmitterCannotReview = errors.New("kyc submission must be reviewed by someone other than the submitter")
+	ErrReasonRequired        = errors.New("a reason is required")
+	ErrNoReferenceRate       = errors.New("no USD reference rate for currency")
+)
+
+// Review is a reviewer's decision on a KYC submission.
+type Review struct {
+	Decision models.KYCDecision
+	Reason   *string
+	// Reviewer is the ID of the authenticated operator deciding, compared
+	// with the submitter's.
+	Reviewer string
+}
+
+// StatusChange moves a tenant through its lifecycle.
+type StatusChange struct {
+	Status models.TenantStatus
+	Reason string
+	Actor  string
+}
+
+// Service manages KYC review and the tenant lifecycle, and checks whether
+// a tenant may transact. Wallet freezes that follow status changes run as
+// SyncWalletsArgs jobs.
+type Service struct {
+	db         *db.DB
+	tenantRepo *repository.TenantRepository
+	kycRepo    *repository.KYCRepository
+	limitRepo  *repository.LimitRepository
+	jobClient  *jobs.Client
+	trail      *audit.Trail
+	now        func() time.Time
+}
+
+// NewService creates a new KYC service.
+func NewService(
+	database *db.DB,
+	tenantRepo *repository.TenantRepository,
+	kycRepo *repository.KYCRepository,
+	limitRepo *repository.LimitRepository,
+	jobClient *jobs.Client,
+	trail *audit.Trail,
+) *Service {
+	return &Service{
+		db:         database,
+		tenantRepo: tenantRepo,
+		kycRepo:    kycRepo,
+		limitRepo:  limitRepo,
+		jobClient:  jobClient,
+		trail:      trail,
+		now:        time.Now,
+	}
+}
+
+// Submit records KYC documents for review. A tenant has at most one
+// submission under review.
+func (s *Service) Submit(ctx context.Context, params models.CreateKYCSubmissionParams) (*models.KYCSubmission, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.KYCSubmission, error) {
+		tenant, err := s.tenantRepo.WithTx(tx).GetByIDForUpdate(ctx, params.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("get tenant: %w", err)
+		}
+		if tenant == nil {
+			return nil, ErrTenantNotFound
+		}
+		if tenant.TenantStatus == models.TenantStatusClosed {
+			return nil, ErrTenantClosed
+		}
+
+		kycRepo := s.kycRepo.WithTx(tx)
+		existing, err := kycRepo.ListSubmissionsByTenant(ctx, tenant.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list kyc submissions: %w", err)
+		}
+		for _, sub := range existing {
+			if sub.Status == models.KYCSubmissionStatusPending {
+				return nil, ErrSubmissionPending
+			}
+		}
+
+		sub, err := kycRepo.CreateSubmission(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &sub.TenantID,
+			ResourceType: audit.ResourceKYCSubmission,
+			ResourceID:   sub.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        sub,
+		}); err != nil {
+			return nil, err
+		}
+		return sub, nil
+	})
+}
+
+// Decide approves or rejects a submission. Approval raises the tenant to the
+// requested KYC level and activates a tenant that is pending KYC.
+func (s *Service) Decide(ctx context.Context, id uuid.UUID, review Review) (*models.KYCSubmission, error) {
+	if review.Decision == models.KYCDecisionReject && (review.Reason == nil || strings.TrimSpace(*review.Reason) == "") {
+		return nil, ErrReasonRequired
+	}
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.KYCSubmission, error) {
+		kycRepo := s.kycRepo.WithTx(tx)
+		sub, err := kycRepo.GetSubmissionForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get kyc submission: %w", err)
+		}
+		if sub == nil {
+			return nil, ErrSubmissionNotFound
+		}
+		if sub.Status != models.KYCSubmissionStatusPending {
+			return nil, ErrSubmissionDecided
+		}
+		if sub.SubmittedBy == review.Reviewer {
+			return nil, ErrSubmitterCannotReview
+		}
+
+		tenantRepo := s.tenantRepo.WithTx(tx)
+		tenant, err := tenantRepo.GetByIDForUpdate(ctx, sub.TenantID)
+		if err != nil {
+			return nil, fmt.Errorf("get tenant: %w", err)
+		}
+		if tenant == nil {
+			return nil, ErrTenantNotFound
+		}
+
+		status := models.KYCSubmissionStatusRejected
+		if review.Decision == models.KYCDecisionApprove {
+			if tenant.TenantStatus == models.TenantStatusClosed {
+				return nil, ErrTenantClosed
+			}
+			status = models.KYCSubmissionStatusApproved
+
+			level := sub.RequestedLevel
+			if _, err := tenantRepo.Update(ctx, tenant.ID, models.UpdateTenantParams{KYCLevel: &level}); err != nil {
+				return nil, fmt.Errorf("update kyc level: %w", err)
+			}
+			tenant.KYCLevel = level
+
+			if tenant.TenantStatus == models.TenantStatusPendingKYC {
+				if err := s.transition(ctx, tx, tenant, StatusChange{
+					Status: models.TenantStatusActive,
+					Reason: "kyc submission " + sub.ID.String() + " approved",
+					Actor:  review.Reviewer,
+				}); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := kycRepo.DecideSubmission(ctx, sub.ID, status, review.Reviewer, review.Reason); err != nil {
+			return nil, fmt.Errorf("decide kyc submission: %w", err)
+		}
+
+		decided, err := kycRepo.GetSubmission(ctx, sub.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &sub.TenantID,
+			ResourceType: audit.ResourceKYCSubmission,
+			ResourceID:   sub.ID.String(),
+			Action:       audit.ActionDecide,
+			Before:       sub,
+			After:        decided,
+		}); err != nil {
+			return nil, err
+		}
+		return decided, nil
+	})
+}
+
+// SetStatus moves a tenant to a new lifecycle status and schedules its
+// wallets to be frozen, unfrozen or closed to match. Repeating the current
+// status only reschedules the wallet sync.
+func (s *Service) SetStatus(ctx context.Context, tenantID uuid.UUID, change StatusChange) (*models.Tenant, error) {
+	if strings.TrimSpace(change.Reason) == "" {
+		return nil, ErrReasonRequired
+	}
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.Tenant, error) {
+		tenantRepo := s.tenantRepo.WithTx(tx)
+		tenant, err := tenantRepo.GetByIDForUpdate(ctx, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("get tenant: %w", err)
+		}
+		if tenant == nil {
+			return nil, ErrTenantNotFound
+		}
+
+		before := *tenant
+		if tenant.TenantStatus != change.Status {
+			if err := s.transition(ctx, tx, tenant, change); err != nil {
+				return nil, err
+			}
+		} else if _, err := s.jobClient.InsertTx(ctx, tx, SyncWalletsArgs{TenantID: tenant.ID}, nil); err != nil {
+			return nil, fmt.Errorf("schedule wallet sync: %w", err)
+		}
+
+		updated, err := tenantRepo.GetByID(ctx, tenant.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &tenant.ID,
+			ResourceType: audit.ResourceTenant,
+			ResourceID:   tenant.ID.String(),
+			Action:       audit.ActionChangeStatus,
+			Before:       audit.TenantState(&before),
+			After:        audit.TenantState(updated),
+		}); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
+}
+
+// transition validates and applies a status change in tx, records it and
+// schedules the wallet sync.
+func (s *Service) transition(ctx context.Context, tx pgx.Tx, tenant *models.Tenant, change StatusChange) error {
+	if !tenant.TenantStatus.CanTransitionTo(change.Status) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidTransition, tenant.TenantStatus, change.Status)
+	}
+	if change.Status == models.TenantStatusActive && tenant.KYCLevel == models.KYCLevelBasic {
+		return ErrKYCRequired
+	}
+
+	status := change.Status
+	if _, err := s.tenantRepo.WithTx(tx).Update(ctx, tenant.ID, models.UpdateTenantParams{TenantStatus: &status}); err != nil {
+		return fmt.Errorf("update tenant status: %w", err)
+	}
+
+	if _, err := s.kycRepo.WithTx(tx).CreateStatusChange(ctx, models.CreateTenantStatusChangeParams{
+		TenantID:   tenant.ID,
+		FromStatus: tenant.TenantStatus,
+		ToStatus:   change.Status,
+		Reason:     change.Reason,
+		ChangedBy:  change.Actor,
+	}); err != nil {
+		return fmt.Errorf("record status change: %w", err)
+	}
+	tenant.TenantStatus = change.Status
+
+	if _, err := s.jobClient.InsertTx(ctx, tx, SyncWalletsArgs{TenantID: tenant.ID}, nil); err != nil {
+		return fmt.Errorf("schedule wallet sync: %w", err)
+	}
+	return nil
+}
+
+// RequireCanTransact returns the tenant if it may transact, or
+// ErrTenantNotFound / ErrTenantCannotTransact.
+func (s *Service) RequireCanTransact(ctx context.Context, tenantID uuid.UUID) (*models.Tenant, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+	if !tenant.CanTransact() {
+		return nil, ErrTenantCannotTransact
+	}
+	return tenant, nil
+}
+
+// CheckTransferTx checks in tx that a tenant may send amount in currency:
+// the tenant must be able to transact and stay within the caps of its KYC
+// tier and limit policy. It locks the tenant row, so concurrent transfers of
+// the same tenant are checked one at a time and status changes wait for them.
+func (s *Service) CheckTransferTx(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, currency string, amount decimal.Decimal) error {
+	tenant, err := s.tenantRepo.WithTx(tx).GetByIDForUpdate(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return ErrTenantNotFound
+	}
+	if !tenant.CanTransact() {
+		return ErrTenantCannotTransact
+	}
+
+	limitRepo := s.limitRepo.WithTx(tx)
+	rate, err := limitRepo.GetUSDRate(ctx, currency)
+	if err != nil {
+		return fmt.Errorf("get usd rate: %w", err)
+	}
+	if rate == nil {
+		return fmt.Errorf("%w %s", ErrNoReferenceRate, currency)
+	}
+
+	tier, err := s.kycRepo.WithTx(tx).GetTierLimit(ctx, tenant.KYCLevel)
+	if err != nil {
+		return fmt.Errorf("get tier limit: %w", err)
+	}
+	policy, err := limitRepo.GetPolicyByTenant(ctx, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("get limit policy: %w", err)
+	}
+	limits := EffectiveLimits(tier, policy)
+
+	now := s.now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var usage Usage
+	if limits.Daily != nil {
+		if usage.Day, err = limitRepo.SumVolumeUSD(ctx, tenant.ID, day); err != nil {
+			return fmt.Errorf("sum daily volume: %w", err)
+		}
+	}
+	if limits.Monthly != nil {
+		if usage.Month, err = limitRepo.SumVolumeUSD(ctx, tenant.ID, month); err != nil {
+			return fmt.Errorf("sum monthly volume: %w", err)
+		}
+	}
+
+	return limits.Check(amount.Mul(*rate), usage)
+}
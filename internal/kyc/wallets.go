@@ -0,0 +1,159 @@
+package kyc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// SyncWalletsArgs are the arguments of the job that brings a tenant's
+// wallets in line with its status.
+type SyncWalletsArgs struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+// Kind returns the job kind.
+func (SyncWalletsArgs) Kind() string { return "kyc.sync_wallets" }
+
+// InsertOpts returns the default insert options.
+func (SyncWalletsArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: jobs.DefaultQueue, MaxAttempts: 10}
+}
+
+// SyncWalletsWorker freezes the wallets of a suspended tenant, unfreezes
+// those of an active tenant and closes those of a closed tenant, both in the
+// ledger and in the wallet status. It reads the tenant's current status, so
+// a job that runs after a later status change converges to the latest one.
+type SyncWalletsWorker struct {
+	tenantRepo   *repository.TenantRepository
+	walletRepo   *repository.WalletRepository
+	ledgerClient *ledger.Client
+	logger       *zap.Logger
+}
+
+// NewSyncWalletsWorker creates a new wallet sync worker.
+func NewSyncWalletsWorker(
+	tenantRepo *repository.TenantRepository,
+	walletRepo *repository.WalletRepository,
+	ledgerClient *ledger.Client,
+	logger *zap.Logger,
+) *SyncWalletsWorker {
+	return &SyncWalletsWorker{
+		tenantRepo:   tenantRepo,
+		walletRepo:   walletRepo,
+		ledgerClient: ledgerClient,
+		logger:       logger,
+	}
+}
+
+// Work syncs every wallet of the tenant. Each step is idempotent, so a
+// failed job is safe to retry.
+func (w *SyncWalletsWorker) Work(ctx context.Context, job *jobs.Job[SyncWalletsArgs]) error {
+	tenant, err := w.tenantRepo.GetByID(ctx, job.Args.TenantID)
+	if err != nil {
+		return fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return jobs.Cancel(fmt.Errorf("tenant %s not found", job.Args.TenantID))
+	}
+
+	wallets, err := w.walletRepo.ListByTenant(ctx, tenant.ID)
+	if err != nil {
+		return fmt.Errorf("list wallets: %w", err)
+	}
+
+	for _, wallet := range wallets {
+		switch tenant.TenantStatus {
+		case models.TenantStatusSuspended:
+			err = w.freeze(ctx, wallet)
+		case models.TenantStatusClosed:
+			err = w.close(ctx, wallet)
+		default:
+			err = w.unfreeze(ctx, wallet)
+		}
+		if err != nil {
+			return fmt.Errorf("sync wallet %s: %w", wallet.ID, err)
+		}
+	}
+
+	w.logger.Info("synced tenant wallets",
+		zap.String("tenant_id", tenant.ID.String()),
+		zap.String("tenant_status", string(tenant.TenantStatus)),
+		zap.Int("wallets", len(wallets)),
+	)
+	return nil
+}
+
+// freeze closes the wallet's ledger account with a pending transfer. The
+// freeze ID is stored before it is sent to the ledger so a retry reuses it.
+func (w *SyncWalletsWorker) freeze(ctx context.Context, wallet *models.Wallet) error {
+	if wallet.Status != models.WalletStatusActive {
+		return nil
+	}
+
+	freezeID := wallet.LedgerFreezeID
+	if freezeID == nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("generate freeze ID: %w", err)
+		}
+		if err := w.walletRepo.UpdateFreeze(ctx, wallet.ID, models.WalletStatusActive, &id); err != nil {
+			return fmt.Errorf("store freeze ID: %w", err)
+		}
+		freezeID = &id
+	}
+
+	if err := w.ledgerClient.FreezeAccount(ctx, wallet.ID, ledger.FromBigInt(wallet.TBAccountID), *freezeID); err != nil {
+		return err
+	}
+	if err := w.walletRepo.UpdateFreeze(ctx, wallet.ID, models.WalletStatusFrozen, freezeID); err != nil {
+		return err
+	}
+	wallet.Status = models.WalletStatusFrozen
+	wallet.LedgerFreezeID = freezeID
+	return nil
+}
+
+// unfreeze voids the wallet's freeze, if any, and reactivates it.
+func (w *SyncWalletsWorker) unfreeze(ctx context.Context, wallet *models.Wallet) error {
+	if wallet.Status == models.WalletStatusClosed {
+		return nil
+	}
+	if wallet.LedgerFreezeID == nil {
+		if wallet.Status == models.WalletStatusActive {
+			return nil
+		}
+		return w.walletRepo.UpdateFreeze(ctx, wallet.ID, models.WalletStatusActive, nil)
+	}
+
+	if err := w.ledgerClient.UnfreezeAccount(ctx, wallet.ID, ledger.FromBigInt(wallet.TBAccountID), *wallet.LedgerFreezeID); err != nil {
+		return err
+	}
+	return w.walletRepo.UpdateFreeze(ctx, wallet.ID, models.WalletStatusActive, nil)
+}
+
+// close freezes the wallet if needed and posts the freeze, which closes the
+// ledger account permanently.
+func (w *SyncWalletsWorker) close(ctx context.Context, wallet *models.Wallet) error {
+	if wallet.Status == models.WalletStatusClosed {
+		return nil
+	}
+	if err := w.freeze(ctx, wallet); err != nil {
+		return err
+	}
+	if wallet.LedgerFreezeID == nil {
+		return fmt.Errorf("frozen wallet has no ledger freeze")
+	}
+
+	if err := w.ledgerClient.CloseAccount(ctx, wallet.ID, ledger.FromBigInt(wallet.TBAccountID), *wallet.LedgerFreezeID); err != nil {
+		return err
+	}
+	return w.walletRepo.UpdateFreeze(ctx, wallet.ID, models.WalletStatusClosed, wallet.LedgerFreezeID)
+}
@@ -1,6 +1,7 @@
 package ledger
 
 import (
+	"context"
 	"fmt"
 
 	tb "github.com/tigerbeetle/tigerbeetle-go"
@@ -11,7 +12,7 @@ import (
 
 // Client wraps the TigerBeetle client with domain-specific operations.
 type Client struct {
-	tb        tb.Client
+	tb        instrumentedClient
 	clusterID tbtypes.Uint128 // ubah dari uint64
 }
 
@@ -29,7 +30,7 @@ func NewClient(cfg config.TigerBeetleConfig) (*Client, error) {
 	}
 
 	return &Client{
-		tb:        client,
+		tb:        instrumentedClient{client},
 		clusterID: clusterID,
 	}, nil
 }
@@ -40,7 +41,7 @@ func (c *Client) Close() {
 }
 
 // CreateAccount creates a new account in TigerBeetle.
-func (c *Client) CreateAccount(id AccountID, ledger uint32, code uint16) error {
+func (c *Client) CreateAccount(ctx context.Context, id AccountID, ledger uint32, code uint16) error {
 	accounts := []tbtypes.Account{{
 		ID:     tbtypes.BytesToUint128(id),
 		Ledger: ledger,
@@ -48,7 +49,7 @@ func (c *Client) CreateAccount(id AccountID, ledger uint32, code uint16) error {
 		Flags:  0,
 	}}
 
-	results, err := c.tb.CreateAccounts(accounts)
+	results, err := c.tb.CreateAccounts(ctx, accounts)
 	if err != nil {
 		return fmt.Errorf("create account: %w", err)
 	}
@@ -63,7 +64,7 @@ func (c *Client) CreateAccount(id AccountID, ledger uint32, code uint16) error {
 }
 
 // CreateAccountWithFlags creates a new account with custom flags.
-func (c *Client) CreateAccountWithFlags(id AccountID, ledger uint32, code uint16, flags tbtypes.AccountFlags) error {
+func (c *Client) CreateAccountWithFlags(ctx context.Context, id AccountID, ledger uint32, code uint16, flags tbtypes.AccountFlags) error {
 	accounts := []tbtypes.Account{{
 		ID:     tbtypes.BytesToUint128(id),
 		Ledger: ledger,
@@ -71,7 +72,7 @@ func (c *Client) CreateAccountWithFlags(id AccountID, ledger uint32, code uint16
 		Flags:  flags.ToUint16(),
 	}}
 
-	results, err := c.tb.CreateAccounts(accounts)
+	results, err := c.tb.CreateAccounts(ctx, accounts)
 	if err != nil {
 		return fmt.Errorf("create account: %w", err)
 	}
@@ -86,8 +87,8 @@ func (c *Client) CreateAccountWithFlags(id AccountID, ledger uint32, code uint16
 }
 
 // GetAccount retrieves an account from TigerBeetle.
-func (c *Client) GetAccount(id AccountID) (*tbtypes.Account, error) {
-	accounts, err := c.tb.LookupAccounts([]tbtypes.Uint128{tbtypes.BytesToUint128(id)})
+func (c *Client) GetAccount(ctx context.Context, id AccountID) (*tbtypes.Account, error) {
+	accounts, err := c.tb.LookupAccounts(ctx, []tbtypes.Uint128{tbtypes.BytesToUint128(id)})
 	if err != nil {
 		return nil, fmt.Errorf("lookup account: %w", err)
 	}
@@ -100,8 +101,8 @@ func (c *Client) GetAccount(id AccountID) (*tbtypes.Account, error) {
 }
 
 // GetBalance retrieves the balance for an account.
-func (c *Client) GetBalance(id AccountID) (Balance, error) {
-	account, err := c.GetAccount(id)
+func (c *Client) GetBalance(ctx context.Context, id AccountID) (Balance, error) {
+	account, err := c.GetAccount(ctx, id)
 	if err != nil {
 		return Balance{}, err
 	}
@@ -119,10 +120,10 @@ func (c *Client) GetBalance(id AccountID) (Balance, error) {
 }
 
 // CreateTransfer creates a single transfer between accounts.
-func (c *Client) CreateTransfer(transfer Transfer) error {
+func (c *Client) CreateTransfer(ctx context.Context, transfer Transfer) error {
 	transfers := []tbtypes.Transfer{transfer.toTigerBeetle()}
 
-	results, err := c.tb.CreateTransfers(transfers)
+	results, err := c.tb.CreateTransfers(ctx, transfers)
 	if err != nil {
 		return fmt.Errorf("create transfer: %w", err)
 	}
@@ -137,13 +138,13 @@ func (c *Client) CreateTransfer(transfer Transfer) error {
 }
 
 // CreateTransfers creates multiple transfers atomically.
-func (c *Client) CreateTransfers(transfers []Transfer) error {
+func (c *Client) CreateTransfers(ctx context.Context, transfers []Transfer) error {
 	tbTransfers := make([]tbtypes.Transfer, len(transfers))
 	for i, t := range transfers {
 		tbTransfers[i] = t.toTigerBeetle()
 	}
 
-	results, err := c.tb.CreateTransfers(tbTransfers)
+	results, err := c.tb.CreateTransfers(ctx, tbTransfers)
 	if err != nil {
 		return fmt.Errorf("create transfers: %w", err)
 	}
@@ -158,7 +159,7 @@ func (c *Client) CreateTransfers(transfers []Transfer) error {
 }
 
 // CreateLinkedTransfers creates a chain of linked transfers (all-or-nothing).
-func (c *Client) CreateLinkedTransfers(transfers []Transfer) error {
+func (c *Client) CreateLinkedTransfers(ctx context.Context, transfers []Transfer) error {
 	if len(transfers) == 0 {
 		return nil
 	}
@@ -168,7 +169,7 @@ func (c *Client) CreateLinkedTransfers(transfers []Transfer) error {
 		transfers[i].Flags |= TransferFlagLinked
 	}
 
-	return c.CreateTransfers(transfers)
+	return c.CreateTransfers(ctx, transfers)
 }
 
 // uint128ToUint64 converts TigerBeetle Uint128 to uint64.
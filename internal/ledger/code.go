@@ -0,0 +1,180 @@
+package ledger
+
+import (
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// TransferCode classifies a ledger transfer by the business operation it
+// books. Codes are stored in TigerBeetle, so a value is never reused.
+type TransferCode uint16
+
+const (
+	// CodePayout moves a payout from a tenant wallet towards its
+	// destination settlement account.
+	CodePayout TransferCode = 100
+	// CodeFee takes a fee into FEE_REVENUE.
+	CodeFee TransferCode = 101
+	// CodeFXLeg moves the source or destination amount of an FX transfer
+	// through FX_SETTLEMENT.
+	CodeFXLeg TransferCode = 102
+
+	// CodeTopUp pre-funds a settlement account.
+	CodeTopUp TransferCode = 110
+
+	// CodeRefund returns the funds of a payout to the tenant.
+	CodeRefund TransferCode = 120
+	// CodeCompensation reverses the posted half of an FX transfer whose
+	// other half failed.
+	CodeCompensation TransferCode = 121
+
+	// CodeNetting settles offsetting payouts against each other.
+	CodeNetting TransferCode = 130
+
+	// CodeAdjustment corrects a balance by hand.
+	CodeAdjustment TransferCode = 140
+
+	// CodeAccountFreeze is the transfer code of the closing transfers that freeze accounts.
+	CodeAccountFreeze TransferCode = 900
+
+	// CodeDeposit credits a matched deposit to a tenant wallet.
+	CodeDeposit TransferCode = 910
+	// CodeSuspense parks an unmatched inbound credit in a suspense account.
+	CodeSuspense TransferCode = 911
+
+	// CodeRebalance moves funds between system accounts.
+	CodeRebalance TransferCode = 920
+	// CodeFXDeal books an external FX deal covering FX_SETTLEMENT positions.
+	CodeFXDeal TransferCode = 921
+)
+
+// String returns the name of the code, as used in reports.
+func (c TransferCode) String() string {
+	switch c {
+	case CodePayout:
+		return "PAYOUT"
+	case CodeFee:
+		return "FEE"
+	case CodeFXLeg:
+		return "FX_LEG"
+	case CodeTopUp:
+		return "TOP_UP"
+	case CodeRefund:
+		return "REFUND"
+	case CodeCompensation:
+		return "COMPENSATION"
+	case CodeNetting:
+		return "NETTING"
+	case CodeAdjustment:
+		return "ADJUSTMENT"
+	case CodeAccountFreeze:
+		return "ACCOUNT_FREEZE"
+	case CodeDeposit:
+		return "DEPOSIT"
+	case CodeSuspense:
+		return "SUSPENSE"
+	case CodeRebalance:
+		return "REBALANCE"
+	case CodeFXDeal:
+		return "FX_DEAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IsValid returns true if the code is registered.
+func (c TransferCode) IsValid() bool {
+	return c.String() != "UNKNOWN"
+}
+
+// RecordKind identifies the PostgreSQL table of the record a ledger
+// transfer belongs to.
+type RecordKind uint32
+
+const (
+	RecordUnknown          RecordKind = 0
+	RecordTransfer         RecordKind = 1
+	RecordStatementEntry   RecordKind = 2
+	RecordTreasuryMovement RecordKind = 3
+	RecordWallet           RecordKind = 4
+)
+
+// String returns the name of the record kind.
+func (k RecordKind) String() string {
+	switch k {
+	case RecordTransfer:
+		return "transfer"
+	case RecordStatementEntry:
+		return "statement_entry"
+	case RecordTreasuryMovement:
+		return "treasury_movement"
+	case RecordWallet:
+		return "wallet"
+	default:
+		return "unknown"
+	}
+}
+
+// Reference traces a ledger transfer back to its business record. It is
+// kept in the user data of every transfer:
+//
+//   - UserData128 is the ID of the record, e.g. the PostgreSQL transfer ID.
+//     The chains of an FX transfer share it, which correlates them.
+//   - UserData64 is the step of the transfer among the record's postings,
+//     from 1, or 0 if the record has a single posting. Refund n of a
+//     transfer posts its steps from 100n+1.
+//   - UserData32 is the kind of the record.
+//
+// The transfer code classifies what the posting does.
+//
+// Treasury legs posted before this convention kept the leg sequence in
+// UserData32 and nothing in UserData64. TigerBeetle transfers are immutable,
+// so ReferenceOf reads a treasury transfer without a step in that layout.
+type Reference struct {
+	Kind RecordKind
+	ID   uuid.UUID
+	Step uint64
+}
+
+// WithReference returns a copy of the transfer carrying the reference in
+// its user data.
+func (t Transfer) WithReference(ref Reference) Transfer {
+	return t.WithUserData([16]byte(ref.ID), ref.Step, uint32(ref.Kind))
+}
+
+// Reference returns the reference kept in the user data of the transfer.
+func (t Transfer) Reference() Reference {
+	return Reference{
+		Kind: RecordKind(t.UserData32),
+		ID:   uuid.UUID(t.UserData128),
+		Step: t.UserData64,
+	}
+}
+
+// ReferenceOf decodes the reference of a transfer read from TigerBeetle.
+func ReferenceOf(t tbtypes.Transfer) Reference {
+	code := TransferCode(t.Code)
+	if (code == CodeRebalance || code == CodeFXDeal) && t.UserData64 == 0 {
+		// A leg of the earlier layout; legs are numbered from 1
+		return Reference{
+			Kind: RecordTreasuryMovement,
+			ID:   uuid.UUID(t.UserData128.Bytes()),
+			Step: uint64(t.UserData32),
+		}
+	}
+	return Reference{
+		Kind: RecordKind(t.UserData32),
+		ID:   uuid.UUID(t.UserData128.Bytes()),
+		Step: t.UserData64,
+	}
+}
+
+// TransferIDOf returns the PostgreSQL transfer ID of a transfer read from
+// TigerBeetle, if it belongs to a transfer.
+func TransferIDOf(t tbtypes.Transfer) (uuid.UUID, bool) {
+	ref := ReferenceOf(t)
+	if ref.Kind != RecordTransfer {
+		return uuid.Nil, false
+	}
+	return ref.ID, true
+}
@@ -0,0 +1,85 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+func TestReferenceRoundTrip(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name string
+		code TransferCode
+		ref  Reference
+	}{
+		{"transfer step", CodePayout, Reference{Kind: RecordTransfer, ID: id, Step: 3}},
+		{"single posting", CodeDeposit, Reference{Kind: RecordStatementEntry, ID: id}},
+		{"refund step", CodeRefund, Reference{Kind: RecordTransfer, ID: id, Step: 201}},
+		{"treasury leg", CodeRebalance, Reference{Kind: RecordTreasuryMovement, ID: id, Step: 2}},
+		{"wallet", CodeAccountFreeze, Reference{Kind: RecordWallet, ID: id, Step: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer := Transfer{ID: uuid.New(), Code: tt.code}.WithReference(tt.ref)
+			assert.Equal(t, tt.ref, transfer.Reference())
+			assert.Equal(t, tt.ref, ReferenceOf(transfer.toTigerBeetle()))
+		})
+	}
+}
+
+func TestReferenceOfEarlierTreasuryLeg(t *testing.T) {
+	id := uuid.New()
+	for _, code := range []TransferCode{CodeRebalance, CodeFXDeal} {
+		leg := tbtypes.Transfer{
+			Code:        uint16(code),
+			UserData128: tbtypes.BytesToUint128([16]byte(id)),
+			UserData32:  2,
+		}
+		assert.Equal(t, Reference{Kind: RecordTreasuryMovement, ID: id, Step: 2}, ReferenceOf(leg), code.String())
+
+		_, ok := TransferIDOf(leg)
+		assert.False(t, ok, "a leg numbered 1 or 2 is not read as a transfer or statement entry")
+	}
+}
+
+func TestTransferIDOf(t *testing.T) {
+	id := uuid.New()
+
+	got, ok := TransferIDOf(Transfer{Code: CodePayout}.WithReference(Reference{Kind: RecordTransfer, ID: id, Step: 1}).toTigerBeetle())
+	assert.True(t, ok)
+	assert.Equal(t, id, got)
+
+	for _, kind := range []RecordKind{RecordUnknown, RecordStatementEntry, RecordTreasuryMovement, RecordWallet} {
+		got, ok := TransferIDOf(Transfer{Code: CodeDeposit}.WithReference(Reference{Kind: kind, ID: id, Step: 1}).toTigerBeetle())
+		assert.False(t, ok, kind.String())
+		assert.Equal(t, uuid.Nil, got)
+	}
+}
+
+func TestTransferCodeString(t *testing.T) {
+	names := map[TransferCode]string{
+		CodePayout:        "PAYOUT",
+		CodeFee:           "FEE",
+		CodeFXLeg:         "FX_LEG",
+		CodeTopUp:         "TOP_UP",
+		CodeRefund:        "REFUND",
+		CodeCompensation:  "COMPENSATION",
+		CodeNetting:       "NETTING",
+		CodeAdjustment:    "ADJUSTMENT",
+		CodeAccountFreeze: "ACCOUNT_FREEZE",
+		CodeDeposit:       "DEPOSIT",
+		CodeSuspense:      "SUSPENSE",
+		CodeRebalance:     "REBALANCE",
+		CodeFXDeal:        "FX_DEAL",
+	}
+	for code, name := range names {
+		assert.Equal(t, name, code.String())
+		assert.True(t, code.IsValid(), name)
+	}
+
+	assert.Equal(t, "UNKNOWN", TransferCode(0).String())
+	assert.False(t, TransferCode(999).IsValid())
+}
@@ -0,0 +1,84 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Accounts are frozen with a zero-amount pending transfer that closes the
+// debit account. While it is pending no transfer can touch the account;
+// voiding it reopens the account and posting it closes the account for good.
+// The counterparty is the system PENDING_OUTBOUND account of the currency.
+//
+// The void and post transfers get IDs derived from the freeze ID, so each
+// operation can be retried safely. All three reference the wallet: the
+// freeze is step 1 and the void or post step 2.
+
+// FreezeAccount closes the account of a wallet with the pending closing
+// transfer freezeID.
+func (c *Client) FreezeAccount(ctx context.Context, walletID uuid.UUID, account AccountID, freezeID uuid.UUID) error {
+	t := Transfer{
+		ID:            freezeID,
+		DebitAccount:  account,
+		CreditAccount: NewAccountID(SystemTenantID, AccountTypePendingOutbound, account.Currency()),
+		Ledger:        uint32(account.Currency()),
+		Code:          CodeAccountFreeze,
+		Flags:         TransferFlagPending | TransferFlagClosingDebit,
+	}
+	t = t.WithReference(Reference{Kind: RecordWallet, ID: walletID, Step: 1})
+	return c.createIdempotent(ctx, t, "freeze account", tbtypes.TransferExists)
+}
+
+// UnfreezeAccount voids the freeze freezeID, reopening the account.
+func (c *Client) UnfreezeAccount(ctx context.Context, walletID uuid.UUID, account AccountID, freezeID uuid.UUID) error {
+	t := Transfer{
+		ID:            uuid.NewSHA1(freezeID, []byte("void")),
+		DebitAccount:  account,
+		CreditAccount: NewAccountID(SystemTenantID, AccountTypePendingOutbound, account.Currency()),
+		Ledger:        uint32(account.Currency()),
+		Code:          CodeAccountFreeze,
+		Flags:         TransferFlagVoidPending,
+		PendingID:     freezeID,
+	}
+	t = t.WithReference(Reference{Kind: RecordWallet, ID: walletID, Step: 2})
+	// A freeze that never reached the ledger has nothing to void.
+	return c.createIdempotent(ctx, t, "unfreeze account",
+		tbtypes.TransferPendingTransferAlreadyVoided, tbtypes.TransferPendingTransferNotFound)
+}
+
+// CloseAccount posts the freeze freezeID, closing the account permanently.
+func (c *Client) CloseAccount(ctx context.Context, walletID uuid.UUID, account AccountID, freezeID uuid.UUID) error {
+	t := Transfer{
+		ID:            uuid.NewSHA1(freezeID, []byte("post")),
+		DebitAccount:  account,
+		CreditAccount: NewAccountID(SystemTenantID, AccountTypePendingOutbound, account.Currency()),
+		Ledger:        uint32(account.Currency()),
+		Code:          CodeAccountFreeze,
+		Flags:         TransferFlagPostPending,
+		PendingID:     freezeID,
+	}
+	t = t.WithReference(Reference{Kind: RecordWallet, ID: walletID, Step: 2})
+	return c.createIdempotent(ctx, t, "close account", tbtypes.TransferPendingTransferAlreadyPosted)
+}
+
+// createIdempotent creates a single transfer, treating a replay of the same
+// transfer or any of the done results as success.
+func (c *Client) createIdempotent(ctx context.Context, transfer Transfer, op string, done ...tbtypes.CreateTransferResult) error {
+	results, err := c.tb.CreateTransfers(ctx, []tbtypes.Transfer{transfer.toTigerBeetle()})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, result := range results {
+		if result.Result == tbtypes.TransferOK || result.Result == tbtypes.TransferExists || slices.Contains(done, result.Result) {
+			continue
+		}
+		return fmt.Errorf("%s failed: %s", op, createTransferResultString(result.Result))
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	tb "github.com/tigerbeetle/tigerbeetle-go"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"kovra/internal/metrics"
+	"kovra/internal/tracing"
+)
+
+// instrumentedClient records every TigerBeetle request the ledger makes in
+// a span of the caller's trace, and records its latency and the result code
+// of each account and transfer in it. TigerBeetle only returns the results
+// of the events that failed; the others are counted as OK.
+type instrumentedClient struct {
+	tb.Client
+}
+
+func (c instrumentedClient) CreateAccounts(ctx context.Context, accounts []tbtypes.Account) ([]tbtypes.AccountEventResult, error) {
+	span := startSpan(ctx, "create_accounts", len(accounts))
+	defer span.End()
+
+	start := time.Now()
+	results, err := c.Client.CreateAccounts(accounts)
+	metrics.ObserveLedgerRequest("create_accounts", time.Since(start))
+	if err != nil {
+		metrics.AddLedgerResults("create_accounts", "error", len(accounts))
+		failSpan(span, err)
+		return results, err
+	}
+
+	metrics.AddLedgerResults("create_accounts", createAccountResultString(tbtypes.AccountOK), len(accounts)-len(results))
+	for _, r := range results {
+		metrics.AddLedgerResults("create_accounts", createAccountResultString(r.Result), 1)
+	}
+	span.SetAttributes(attribute.Int("tigerbeetle.failed_events", len(results)))
+	return results, nil
+}
+
+func (c instrumentedClient) CreateTransfers(ctx context.Context, transfers []tbtypes.Transfer) ([]tbtypes.TransferEventResult, error) {
+	span := startSpan(ctx, "create_transfers", len(transfers))
+	defer span.End()
+
+	start := time.Now()
+	results, err := c.Client.CreateTransfers(transfers)
+	metrics.ObserveLedgerRequest("create_transfers", time.Since(start))
+	if err != nil {
+		metrics.AddLedgerResults("create_transfers", "error", len(transfers))
+		failSpan(span, err)
+		return results, err
+	}
+
+	metrics.AddLedgerResults("create_transfers", createTransferResultString(tbtypes.TransferOK), len(transfers)-len(results))
+	for _, r := range results {
+		metrics.AddLedgerResults("create_transfers", createTransferResultString(r.Result), 1)
+	}
+	span.SetAttributes(attribute.Int("tigerbeetle.failed_events", len(results)))
+	return results, nil
+}
+
+func (c instrumentedClient) LookupAccounts(ctx context.Context, ids []tbtypes.Uint128) ([]tbtypes.Account, error) {
+	span := startSpan(ctx, "lookup_accounts", len(ids))
+	defer span.End()
+
+	start := time.Now()
+	accounts, err := c.Client.LookupAccounts(ids)
+	observeLookup(span, "lookup_accounts", start, len(ids), len(accounts), err)
+	return accounts, err
+}
+
+func (c instrumentedClient) LookupTransfers(ctx context.Context, ids []tbtypes.Uint128) ([]tbtypes.Transfer, error) {
+	span := startSpan(ctx, "lookup_transfers", len(ids))
+	defer span.End()
+
+	start := time.Now()
+	transfers, err := c.Client.LookupTransfers(ids)
+	observeLookup(span, "lookup_transfers", start, len(ids), len(transfers), err)
+	return transfers, err
+}
+
+func (c instrumentedClient) GetAccountTransfers(ctx context.Context, filter tbtypes.AccountFilter) ([]tbtypes.Transfer, error) {
+	span := startSpan(ctx, "get_account_transfers", 1)
+	defer span.End()
+
+	start := time.Now()
+	transfers, err := c.Client.GetAccountTransfers(filter)
+	metrics.ObserveLedgerRequest("get_account_transfers", time.Since(start))
+	if err != nil {
+		metrics.AddLedgerResults("get_account_transfers", "error", 1)
+		failSpan(span, err)
+		return transfers, err
+	}
+	metrics.AddLedgerResults("get_account_transfers", "OK", 1)
+	span.SetAttributes(semconv.DBResponseReturnedRows(len(transfers)))
+	return transfers, nil
+}
+
+// startSpan starts the span of a request of n events if ctx is traced, and
+// otherwise returns a span that records nothing.
+func startSpan(ctx context.Context, operation string, n int) trace.Span {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return trace.SpanFromContext(context.Background())
+	}
+	_, span := tracing.Tracer().Start(ctx, "tigerbeetle "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNameKey.String("tigerbeetle"),
+			semconv.DBOperationName(operation),
+			semconv.DBOperationBatchSize(n),
+		),
+	)
+	return span
+}
+
+// failSpan marks a span as failed by err.
+func failSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// observeLookup records a lookup of n IDs of which found exist.
+func observeLookup(span trace.Span, operation string, start time.Time, n, found int, err error) {
+	metrics.ObserveLedgerRequest(operation, time.Since(start))
+	if err != nil {
+		metrics.AddLedgerResults(operation, "error", n)
+		failSpan(span, err)
+		return
+	}
+	metrics.AddLedgerResults(operation, "OK", found)
+	metrics.AddLedgerResults(operation, "NotFound", n-found)
+	span.SetAttributes(semconv.DBResponseReturnedRows(found))
+}
@@ -0,0 +1,173 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// lookupBatchSize stays under TigerBeetle's limit of 8189 IDs per lookup.
+const lookupBatchSize = 8000
+
+// accountTransfersPageSize stays under TigerBeetle's limit of 8189 transfers
+// per query.
+const accountTransfersPageSize = 8000
+
+// asOfAttempts is how many times BalancesAsOf reads an account that keeps
+// being posted to.
+const asOfAttempts = 5
+
+// GetBalances looks up the balances of many accounts. Accounts that do not
+// exist are left out of the result.
+func (c *Client) GetBalances(ctx context.Context, ids []AccountID) (map[AccountID]Balance, error) {
+	balances := make(map[AccountID]Balance, len(ids))
+
+	for start := 0; start < len(ids); start += lookupBatchSize {
+		batch := ids[start:min(start+lookupBatchSize, len(ids))]
+		tbIDs := make([]tbtypes.Uint128, len(batch))
+		for i, id := range batch {
+			tbIDs[i] = tbtypes.BytesToUint128(id)
+		}
+
+		accounts, err := c.tb.LookupAccounts(ctx, tbIDs)
+		if err != nil {
+			return nil, fmt.Errorf("lookup accounts: %w", err)
+		}
+		for _, a := range accounts {
+			balances[AccountID(a.ID.Bytes())] = Balance{
+				Debits:  uint128ToUint64(a.DebitsPosted),
+				Credits: uint128ToUint64(a.CreditsPosted),
+				Pending: uint128ToUint64(a.DebitsPending),
+			}
+		}
+	}
+
+	return balances, nil
+}
+
+// BalancesAsOf looks up the posted balances of many accounts as they were at
+// cutoff, by taking back the transfers TigerBeetle timestamped after it from
+// their current balances. Pending holds are left as they are now. Accounts
+// that do not exist are left out of the result.
+//
+// An account posted to while its transfers are read is read again, so the
+// transfers taken back always match the balance they are taken from.
+func (c *Client) BalancesAsOf(ctx context.Context, ids []AccountID, cutoff time.Time) (map[AccountID]Balance, error) {
+	before, err := c.GetBalances(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[AccountID]Balance, len(before))
+	pending := slices.Collect(maps.Keys(before))
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt == asOfAttempts {
+			return nil, fmt.Errorf("balances of %d accounts kept changing while read as of %s", len(pending), cutoff.Format(time.RFC3339))
+		}
+
+		later := make(map[AccountID]Balance, len(pending))
+		for _, id := range pending {
+			b, err := c.postedSince(ctx, id, cutoff)
+			if err != nil {
+				return nil, err
+			}
+			later[id] = b
+		}
+
+		after, err := c.GetBalances(ctx, pending)
+		if err != nil {
+			return nil, err
+		}
+		var changed []AccountID
+		for _, id := range pending {
+			b, a := before[id], after[id]
+			if b.Debits != a.Debits || b.Credits != a.Credits {
+				changed = append(changed, id)
+				continue
+			}
+			b.Debits -= later[id].Debits
+			b.Credits -= later[id].Credits
+			result[id] = b
+		}
+		before, pending = after, changed
+	}
+
+	return result, nil
+}
+
+// postedSince sums the amounts posted to an account by the transfers
+// TigerBeetle timestamped after cutoff. Pending transfers and voids post
+// nothing.
+func (c *Client) postedSince(ctx context.Context, id AccountID, cutoff time.Time) (Balance, error) {
+	var posted Balance
+	filter := tbtypes.AccountFilter{
+		AccountID:    tbtypes.BytesToUint128(id),
+		TimestampMin: uint64(cutoff.UnixNano()) + 1,
+		Limit:        accountTransfersPageSize,
+		Flags:        tbtypes.AccountFilterFlags{Debits: true, Credits: true}.ToUint32(),
+	}
+
+	for {
+		transfers, err := c.tb.GetAccountTransfers(ctx, filter)
+		if err != nil {
+			return Balance{}, fmt.Errorf("get account transfers: %w", err)
+		}
+		for _, t := range transfers {
+			flags := t.TransferFlags()
+			if flags.Pending || flags.VoidPendingTransfer {
+				continue
+			}
+			amount := uint128ToUint64(t.Amount)
+			if AccountID(t.DebitAccountID.Bytes()) == id {
+				posted.Debits += amount
+			}
+			if AccountID(t.CreditAccountID.Bytes()) == id {
+				posted.Credits += amount
+			}
+		}
+		if len(transfers) < accountTransfersPageSize {
+			return posted, nil
+		}
+		filter.TimestampMin = transfers[len(transfers)-1].Timestamp + 1
+	}
+}
+
+// ExistingTransfers returns which of the given transfer IDs exist in the ledger.
+func (c *Client) ExistingTransfers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	found := make(map[uuid.UUID]bool, len(ids))
+
+	for start := 0; start < len(ids); start += lookupBatchSize {
+		batch := ids[start:min(start+lookupBatchSize, len(ids))]
+		tbIDs := make([]tbtypes.Uint128, len(batch))
+		for i, id := range batch {
+			tbIDs[i] = tbtypes.BytesToUint128(id)
+		}
+
+		transfers, err := c.tb.LookupTransfers(ctx, tbIDs)
+		if err != nil {
+			return nil, fmt.Errorf("lookup transfers: %w", err)
+		}
+		for _, t := range transfers {
+			found[uuid.UUID(t.ID.Bytes())] = true
+		}
+	}
+
+	return found, nil
+}
+
+// TransferIDFromBigInt converts a transfer ID stored as NUMERIC(39,0), as in
+// transfers.tb_transfer_ids, back to the ledger transfer ID.
+func TransferIDFromBigInt(n *big.Int) uuid.UUID {
+	return uuid.UUID(FromBigInt(n))
+}
+
+// TransferIDToBigInt converts a ledger transfer ID for storage as NUMERIC(39,0).
+func TransferIDToBigInt(id uuid.UUID) *big.Int {
+	return new(big.Int).SetBytes(id[:])
+}
@@ -0,0 +1,143 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// The payout of a transfer is held with a pending transfer from the tenant's
+// wallet to the system PENDING_OUTBOUND account of the currency, reserving
+// the funds until the payout settles or is abandoned. The hold is the first
+// step of the transfer; voiding or posting it keeps its step.
+//
+// When the rail settles the payout, the hold is posted and PENDING_OUTBOUND
+// pays the fee into FEE_REVENUE (step 2) and the payout into the
+// REGIONAL_SETTLEMENT account it leaves from (step 3). An FX payout goes
+// through FX_SETTLEMENT instead, which pays the destination amount into
+// REGIONAL_SETTLEMENT in the destination currency (step 4). This is the
+// booking a refund reverses.
+//
+// The hold and the transfers resolving it get IDs derived from the transfer
+// ID, so each operation can be retried safely.
+
+// PayoutHoldID returns the ID of the pending transfer holding the payout of
+// a transfer.
+func PayoutHoldID(transferID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(transferID, []byte("hold"))
+}
+
+// Payout is the payout of a transfer to book in the ledger. Amount is the
+// payout and FeeAmount the fee, both in the source currency; DestAmount is
+// the payout in the destination currency.
+type Payout struct {
+	TransferID   uuid.UUID
+	Wallet       AccountID
+	Currency     Currency
+	Amount       uint64
+	FeeAmount    uint64
+	DestCurrency Currency
+	DestAmount   uint64
+}
+
+// HoldPayout places the payout hold of a transfer on its wallet. Placing it
+// again is harmless.
+func (c *Client) HoldPayout(ctx context.Context, p Payout) error {
+	pendingOut := NewAccountID(SystemTenantID, AccountTypePendingOutbound, p.Currency)
+	if err := c.ensureAccounts(ctx, pendingOut); err != nil {
+		return err
+	}
+
+	t := Transfer{
+		ID:            PayoutHoldID(p.TransferID),
+		DebitAccount:  p.Wallet,
+		CreditAccount: pendingOut,
+		Amount:        p.Amount + p.FeeAmount,
+		Ledger:        uint32(p.Currency),
+		Code:          CodePayout,
+		Flags:         TransferFlagPending,
+	}
+	t = t.WithReference(Reference{Kind: RecordTransfer, ID: p.TransferID, Step: 1})
+	return c.createIdempotent(ctx, t, "hold payout")
+}
+
+// SettlePayout posts the payout hold of a transfer and books the payout
+// into the settlement account. It returns the IDs of the ledger transfers
+// booking the payout, and can be retried safely.
+func (c *Client) SettlePayout(ctx context.Context, p Payout) ([]uuid.UUID, error) {
+	pendingOut := NewAccountID(SystemTenantID, AccountTypePendingOutbound, p.Currency)
+	feeRevenue := NewAccountID(SystemTenantID, AccountTypeFeeRevenue, p.Currency)
+	settlement := NewAccountID(SystemTenantID, AccountTypeRegionalSettlement, p.DestCurrency)
+	if err := c.ensureAccounts(ctx, pendingOut, feeRevenue, settlement); err != nil {
+		return nil, err
+	}
+
+	post := Transfer{
+		ID:            uuid.NewSHA1(p.TransferID, []byte("post")),
+		DebitAccount:  p.Wallet,
+		CreditAccount: pendingOut,
+		Amount:        p.Amount + p.FeeAmount,
+		Ledger:        uint32(p.Currency),
+		Code:          CodePayout,
+		Flags:         TransferFlagPostPending,
+		PendingID:     PayoutHoldID(p.TransferID),
+	}
+	chains := [][]Transfer{{post.WithReference(Reference{Kind: RecordTransfer, ID: p.TransferID, Step: 1})}}
+	add := func(chain int, name string, debit, credit AccountID, amount uint64, currency Currency, code TransferCode, step uint64) {
+		if amount == 0 {
+			return
+		}
+		t := Transfer{
+			ID:            uuid.NewSHA1(p.TransferID, []byte(name)),
+			DebitAccount:  debit,
+			CreditAccount: credit,
+			Amount:        amount,
+			Ledger:        uint32(currency),
+			Code:          code,
+		}
+		chains[chain] = append(chains[chain], t.WithReference(Reference{Kind: RecordTransfer, ID: p.TransferID, Step: step}))
+	}
+
+	add(0, "fee", pendingOut, feeRevenue, p.FeeAmount, p.Currency, CodeFee, 2)
+	if p.DestCurrency == p.Currency {
+		add(0, "payout", pendingOut, settlement, p.Amount, p.Currency, CodePayout, 3)
+	} else {
+		srcFX := NewAccountID(SystemTenantID, AccountTypeFXSettlement, p.Currency)
+		dstFX := NewAccountID(SystemTenantID, AccountTypeFXSettlement, p.DestCurrency)
+		if err := c.ensureAccounts(ctx, srcFX, dstFX); err != nil {
+			return nil, err
+		}
+		add(0, "payout", pendingOut, srcFX, p.Amount, p.Currency, CodeFXLeg, 3)
+		chains = append(chains, nil)
+		add(1, "deliver", dstFX, settlement, p.DestAmount, p.DestCurrency, CodeFXLeg, 4)
+	}
+
+	var ids []uuid.UUID
+	for _, chain := range chains {
+		if len(chain) == 0 {
+			continue
+		}
+		if err := c.createLinkedIdempotent(ctx, chain, "settle payout"); err != nil {
+			return nil, err
+		}
+		for _, t := range chain {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids, nil
+}
+
+// VoidPayoutHold voids the payout hold of a transfer, returning the funds to
+// the wallet. A transfer without a hold has nothing to void.
+func (c *Client) VoidPayoutHold(ctx context.Context, transferID uuid.UUID) error {
+	t := Transfer{
+		ID:        uuid.NewSHA1(transferID, []byte("void")),
+		Code:      CodePayout,
+		Flags:     TransferFlagVoidPending,
+		PendingID: PayoutHoldID(transferID),
+	}
+	t = t.WithReference(Reference{Kind: RecordTransfer, ID: transferID, Step: 1})
+	return c.createIdempotent(ctx, t, "void payout hold",
+		tbtypes.TransferPendingTransferAlreadyVoided, tbtypes.TransferPendingTransferNotFound)
+}
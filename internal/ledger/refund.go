@@ -0,0 +1,91 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// A refund reverses the payout of a transfer back into the tenant's wallet.
+// The refunded payout returns from the REGIONAL_SETTLEMENT account it was
+// paid out of; for an FX transfer it returns in the destination currency to
+// FX_SETTLEMENT, which gives the source currency back at the rate of the
+// transfer. It passes through PENDING_OUTBOUND, picking up the reversed fee
+// from FEE_REVENUE, and is credited to the wallet. The transfers in one
+// currency form a linked chain.
+//
+// Every transfer of a refund references the original transfer, with steps
+// from 100 times the refund's sequence, and transfer IDs derived from the
+// refund ID, so posting can be retried safely.
+
+// Refund is a refund of a transfer to post to the ledger. Amount and
+// FeeAmount are in the source currency, DestAmount in the destination
+// currency.
+type Refund struct {
+	ID           uuid.UUID
+	TransferID   uuid.UUID
+	Seq          uint32
+	Wallet       AccountID
+	Currency     Currency
+	Amount       uint64
+	FeeAmount    uint64
+	DestCurrency Currency
+	DestAmount   uint64
+}
+
+// PostRefund posts the reversing transfers of a refund.
+func (c *Client) PostRefund(ctx context.Context, r Refund) error {
+	pendingOut := NewAccountID(SystemTenantID, AccountTypePendingOutbound, r.Currency)
+	feeRevenue := NewAccountID(SystemTenantID, AccountTypeFeeRevenue, r.Currency)
+	settlement := NewAccountID(SystemTenantID, AccountTypeRegionalSettlement, r.DestCurrency)
+	if err := c.ensureAccounts(ctx, pendingOut, feeRevenue, settlement); err != nil {
+		return err
+	}
+
+	var chains [][]Transfer
+	var chain []Transfer
+	step := uint64(r.Seq) * 100
+	add := func(name string, debit, credit AccountID, amount uint64, currency Currency) {
+		if amount == 0 {
+			return
+		}
+		step++
+		t := Transfer{
+			ID:            uuid.NewSHA1(r.ID, []byte(name)),
+			DebitAccount:  debit,
+			CreditAccount: credit,
+			Amount:        amount,
+			Ledger:        uint32(currency),
+			Code:          CodeRefund,
+		}
+		chain = append(chain, t.WithReference(Reference{Kind: RecordTransfer, ID: r.TransferID, Step: step}))
+	}
+
+	source := settlement
+	if r.DestCurrency != r.Currency {
+		dstFX := NewAccountID(SystemTenantID, AccountTypeFXSettlement, r.DestCurrency)
+		source = NewAccountID(SystemTenantID, AccountTypeFXSettlement, r.Currency)
+		if err := c.ensureAccounts(ctx, dstFX, source); err != nil {
+			return err
+		}
+
+		add("return", settlement, dstFX, r.DestAmount, r.DestCurrency)
+		chains = append(chains, chain)
+		chain = nil
+	}
+
+	add("payout", source, pendingOut, r.Amount, r.Currency)
+	add("fee", feeRevenue, pendingOut, r.FeeAmount, r.Currency)
+	add("credit", pendingOut, r.Wallet, r.Amount+r.FeeAmount, r.Currency)
+	chains = append(chains, chain)
+
+	for _, chain := range chains {
+		if len(chain) == 0 {
+			continue
+		}
+		if err := c.createLinkedIdempotent(ctx, chain, "post refund"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
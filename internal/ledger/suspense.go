@@ -0,0 +1,106 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Money arriving on an FBO account is booked from the system
+// PENDING_INBOUND account of the currency. A credit matched to an expected
+// deposit goes straight to the tenant's wallet; one that matches nothing is
+// parked in the SUSPENSE account of the legal entity holding the FBO account
+// and moved to the wallet once it is matched. Either way the wallets and
+// suspense accounts together follow the FBO bank balance.
+//
+// Transfer IDs are derived from the statement entry ID, so each booking can
+// be retried safely. Every booking references the statement entry: a direct
+// deposit is its single posting, a parked credit is step 1 and its release
+// step 2.
+
+// SuspenseAccountID returns the suspense account of a legal entity.
+func SuspenseAccountID(legalEntityID uuid.UUID, currency Currency) AccountID {
+	return NewAccountIDFromUUID(legalEntityID, AccountTypeSuspense, currency)
+}
+
+// CreditDeposit credits an inbound statement entry to a wallet.
+func (c *Client) CreditDeposit(ctx context.Context, entryID uuid.UUID, wallet AccountID, amount uint64) error {
+	pendingInbound := NewAccountID(SystemTenantID, AccountTypePendingInbound, wallet.Currency())
+	if err := c.ensureAccounts(ctx, pendingInbound); err != nil {
+		return err
+	}
+
+	t := Transfer{
+		ID:            uuid.NewSHA1(entryID, []byte("deposit")),
+		DebitAccount:  pendingInbound,
+		CreditAccount: wallet,
+		Amount:        amount,
+		Ledger:        uint32(wallet.Currency()),
+		Code:          CodeDeposit,
+	}
+	t = t.WithReference(Reference{Kind: RecordStatementEntry, ID: entryID})
+	return c.createIdempotent(ctx, t, "credit deposit")
+}
+
+// ParkInSuspense books an inbound statement entry to the suspense account
+// of the legal entity.
+func (c *Client) ParkInSuspense(ctx context.Context, entryID, legalEntityID uuid.UUID, currency Currency, amount uint64) error {
+	pendingInbound := NewAccountID(SystemTenantID, AccountTypePendingInbound, currency)
+	suspense := SuspenseAccountID(legalEntityID, currency)
+	if err := c.ensureAccounts(ctx, pendingInbound, suspense); err != nil {
+		return err
+	}
+
+	t := Transfer{
+		ID:            uuid.NewSHA1(entryID, []byte("suspense")),
+		DebitAccount:  pendingInbound,
+		CreditAccount: suspense,
+		Amount:        amount,
+		Ledger:        uint32(currency),
+		Code:          CodeSuspense,
+	}
+	t = t.WithReference(Reference{Kind: RecordStatementEntry, ID: entryID, Step: 1})
+	return c.createIdempotent(ctx, t, "park in suspense")
+}
+
+// ReleaseSuspense moves a parked statement entry from the suspense account
+// of the legal entity to a wallet.
+func (c *Client) ReleaseSuspense(ctx context.Context, entryID, legalEntityID uuid.UUID, wallet AccountID, amount uint64) error {
+	t := Transfer{
+		ID:            uuid.NewSHA1(entryID, []byte("release")),
+		DebitAccount:  SuspenseAccountID(legalEntityID, wallet.Currency()),
+		CreditAccount: wallet,
+		Amount:        amount,
+		Ledger:        uint32(wallet.Currency()),
+		Code:          CodeDeposit,
+	}
+	t = t.WithReference(Reference{Kind: RecordStatementEntry, ID: entryID, Step: 2})
+	return c.createIdempotent(ctx, t, "release suspense")
+}
+
+// ensureAccounts creates system accounts that do not exist yet. The account
+// code is its type, as for wallets.
+func (c *Client) ensureAccounts(ctx context.Context, ids ...AccountID) error {
+	accounts := make([]tbtypes.Account, len(ids))
+	for i, id := range ids {
+		accounts[i] = tbtypes.Account{
+			ID:     tbtypes.BytesToUint128(id),
+			Ledger: uint32(id.Currency()),
+			Code:   uint16(id.AccountType()),
+		}
+	}
+
+	results, err := c.tb.CreateAccounts(ctx, accounts)
+	if err != nil {
+		return fmt.Errorf("create accounts: %w", err)
+	}
+
+	for _, result := range results {
+		if result.Result != tbtypes.AccountOK && result.Result != tbtypes.AccountExists {
+			return fmt.Errorf("create account failed: %s", createAccountResultString(result.Result))
+		}
+	}
+	return nil
+}
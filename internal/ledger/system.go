@@ -0,0 +1,16 @@
+package ledger
+
+import "context"
+
+// SentinelAccountID is the system account the readiness check looks up. It
+// takes part in no transfer, and exists from the first start of the service
+// on a cluster, so a cluster lacking it is not the one the service uses.
+var SentinelAccountID = NewAccountID(SystemTenantID, AccountTypeSentinel, CurrencyEUR)
+
+// EnsureSystemAccounts creates the system accounts that must exist before
+// any booking, on every start of the service. The accounts that bookings
+// use are created as they are first needed. Creating them again is
+// harmless.
+func (c *Client) EnsureSystemAccounts(ctx context.Context) error {
+	return c.ensureAccounts(ctx, SentinelAccountID)
+}
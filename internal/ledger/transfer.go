@@ -14,7 +14,8 @@ import (
 // Transfers must always balance and are executed within a specific ledger
 // (currency/book) and business context (Code).
 //
-// UserData fields are reserved for correlation, idempotency, and tracing.
+// UserData fields are reserved for correlation, idempotency, and tracing:
+// they hold the Reference of the business record the transfer books.
 // They must not affect accounting semantics.
 type Transfer struct {
 	ID            uuid.UUID
@@ -22,15 +23,16 @@ type Transfer struct {
 	CreditAccount AccountID
 	Amount        uint64
 	Ledger        uint32
-	Code          uint16
+	Code          TransferCode
 	Flags         TransferFlags
+	PendingID     uuid.UUID // pending transfer posted or voided by this one
 	UserData128   [16]byte
 	UserData64    uint64
 	UserData32    uint32
 }
 
 // NewTransfer creates a new transfer.
-func NewTransfer(debit, credit AccountID, amount uint64, ledger uint32, code uint16) (Transfer, error) {
+func NewTransfer(debit, credit AccountID, amount uint64, ledger uint32, code TransferCode) (Transfer, error) {
 	id, err := uuid.NewV7()
 
 	if err != nil {
@@ -93,6 +95,8 @@ func (t Transfer) toTigerBeetle() tbtypes.Transfer {
 		Pending:             t.Flags&TransferFlagPending != 0,
 		PostPendingTransfer: t.Flags&TransferFlagPostPending != 0,
 		VoidPendingTransfer: t.Flags&TransferFlagVoidPending != 0,
+		ClosingDebit:        t.Flags&TransferFlagClosingDebit != 0,
+		ClosingCredit:       t.Flags&TransferFlagClosingCredit != 0,
 	}
 
 	return tbtypes.Transfer{
@@ -100,8 +104,9 @@ func (t Transfer) toTigerBeetle() tbtypes.Transfer {
 		DebitAccountID:  tbtypes.BytesToUint128(t.DebitAccount),
 		CreditAccountID: tbtypes.BytesToUint128(t.CreditAccount),
 		Amount:          tbtypes.ToUint128(t.Amount),
+		PendingID:       tbtypes.BytesToUint128([16]byte(t.PendingID)),
 		Ledger:          t.Ledger,
-		Code:            t.Code,
+		Code:            uint16(t.Code),
 		Flags:           flags.ToUint16(),
 		UserData128:     tbtypes.BytesToUint128(t.UserData128),
 		UserData64:      t.UserData64,
@@ -122,7 +127,7 @@ func NewTransferBuilder() *TransferBuilder {
 }
 
 // Add adds a transfer to the chain.
-func (b *TransferBuilder) Add(debit, credit AccountID, amount uint64, ledger uint32, code uint16) (*TransferBuilder, error) {
+func (b *TransferBuilder) Add(debit, credit AccountID, amount uint64, ledger uint32, code TransferCode) (*TransferBuilder, error) {
 	transfer, err := NewTransfer(debit, credit, amount, ledger, code)
 	if err != nil {
 		return nil, err
@@ -172,7 +177,8 @@ type FXTransferPair struct {
 	// Executes in destination currency ledger
 	DestinationChain []Transfer
 
-	// CorrelationID links both chains for reconciliation (stored in UserData128)
+	// CorrelationID links both chains for reconciliation: the PostgreSQL
+	// transfer ID, stored in UserData128 of every step
 	CorrelationID [16]byte
 }
 
@@ -182,37 +188,32 @@ type FXTransferPair struct {
 // Therefore, cross-currency FX requires two coordinated chains:
 //
 // Source Chain (srcCurrency ledger):
-//  1. TENANT_WALLET → PENDING_OUTBOUND (hold source funds)
-//  2. PENDING_OUTBOUND → FX_POSITION (platform acquires source currency)
+//  1. TENANT_WALLET → PENDING_OUTBOUND (hold source funds, CodePayout)
+//  2. PENDING_OUTBOUND → FX_POSITION (platform acquires source currency, CodeFXLeg)
 //
 // Destination Chain (dstCurrency ledger):
-//  1. FX_POSITION → FEE_REVENUE (fee deduction, if any)
-//  2. FX_POSITION → REGIONAL_SETTLEMENT (payout to destination)
+//  1. FX_POSITION → FEE_REVENUE (fee deduction if any, CodeFee)
+//  2. FX_POSITION → REGIONAL_SETTLEMENT (payout to destination, CodeFXLeg)
 //
 // The FX_POSITION accounts track the platform's currency exposure:
 //   - Credit to FX_POSITION_SRC = platform receives source currency
 //   - Debit from FX_POSITION_DST = platform pays out destination currency
 //
+// Every step references the PostgreSQL transfer, numbered across both
+// chains.
+//
 // Application-level coordination required:
 //   - Both chains must succeed, or both must be compensated
-//   - Use CorrelationID to link chains in PostgreSQL for reconciliation
 //   - FX rate and conversion details are stored in PostgreSQL, not TigerBeetle
 func FXTransferChains(
+	transferID uuid.UUID,
 	tenantID uint64,
 	srcCurrency Currency,
 	dstCurrency Currency,
 	srcAmount uint64,
 	dstAmount uint64,
 	feeAmount uint64,
-	transferCode uint16,
 ) (FXTransferPair, error) {
-	// Generate correlation ID to link both chains
-	correlationUUID, err := uuid.NewV7()
-	if err != nil {
-		return FXTransferPair{}, fmt.Errorf("generate correlation id: %w", err)
-	}
-	correlationID := [16]byte(correlationUUID)
-
 	// === SOURCE CHAIN (srcCurrency ledger) ===
 	srcBuilder := NewTransferBuilder()
 
@@ -222,20 +223,16 @@ func FXTransferChains(
 	srcLedger := uint32(srcCurrency)
 
 	// Step 1: Debit tenant wallet → pending outbound (hold)
-	if _, err := srcBuilder.Add(srcWallet, srcPendingOut, srcAmount, srcLedger, transferCode); err != nil {
+	if _, err := srcBuilder.Add(srcWallet, srcPendingOut, srcAmount, srcLedger, CodePayout); err != nil {
 		return FXTransferPair{}, fmt.Errorf("add source step 1: %w", err)
 	}
 
 	// Step 2: Pending outbound → FX position (platform acquires source currency)
-	if _, err := srcBuilder.Add(srcPendingOut, srcFXPosition, srcAmount, srcLedger, transferCode); err != nil {
+	if _, err := srcBuilder.Add(srcPendingOut, srcFXPosition, srcAmount, srcLedger, CodeFXLeg); err != nil {
 		return FXTransferPair{}, fmt.Errorf("add source step 2: %w", err)
 	}
 
-	// Set correlation ID on source chain
-	srcChain := srcBuilder.BuildLinked()
-	for i := range srcChain {
-		srcChain[i].UserData128 = correlationID
-	}
+	srcChain := referenceSteps(srcBuilder.BuildLinked(), transferID, 1)
 
 	// === DESTINATION CHAIN (dstCurrency ledger) ===
 	dstBuilder := NewTransferBuilder()
@@ -247,27 +244,23 @@ func FXTransferChains(
 
 	// Step 1: FX position → fee revenue (fee deduction)
 	if feeAmount > 0 {
-		if _, err := dstBuilder.Add(dstFXPosition, dstFeeRevenue, feeAmount, dstLedger, transferCode); err != nil {
+		if _, err := dstBuilder.Add(dstFXPosition, dstFeeRevenue, feeAmount, dstLedger, CodeFee); err != nil {
 			return FXTransferPair{}, fmt.Errorf("add dest fee step: %w", err)
 		}
 	}
 
 	// Step 2: FX position → regional settlement (payout)
 	finalAmount := dstAmount - feeAmount
-	if _, err := dstBuilder.Add(dstFXPosition, dstRegionalSettlement, finalAmount, dstLedger, transferCode); err != nil {
+	if _, err := dstBuilder.Add(dstFXPosition, dstRegionalSettlement, finalAmount, dstLedger, CodeFXLeg); err != nil {
 		return FXTransferPair{}, fmt.Errorf("add dest payout step: %w", err)
 	}
 
-	// Set correlation ID on destination chain
-	dstChain := dstBuilder.BuildLinked()
-	for i := range dstChain {
-		dstChain[i].UserData128 = correlationID
-	}
+	dstChain := referenceSteps(dstBuilder.BuildLinked(), transferID, uint64(len(srcChain))+1)
 
 	return FXTransferPair{
 		SourceChain:      srcChain,
 		DestinationChain: dstChain,
-		CorrelationID:    correlationID,
+		CorrelationID:    [16]byte(transferID),
 	}, nil
 }
 
@@ -275,15 +268,17 @@ func FXTransferChains(
 // All transfers execute atomically in the same ledger via TigerBeetle linked transfers.
 //
 // Steps:
-//  1. TENANT_WALLET → PENDING_OUTBOUND (debit source wallet)
-//  2. PENDING_OUTBOUND → FEE_REVENUE (fee deduction, if feeAmount > 0)
-//  3. PENDING_OUTBOUND → REGIONAL_SETTLEMENT (final payout)
+//  1. TENANT_WALLET → PENDING_OUTBOUND (debit source wallet, CodePayout)
+//  2. PENDING_OUTBOUND → FEE_REVENUE (fee deduction if feeAmount > 0, CodeFee)
+//  3. PENDING_OUTBOUND → REGIONAL_SETTLEMENT (final payout, CodePayout)
+//
+// Every step references the PostgreSQL transfer.
 func SimpleTransferChain(
+	transferID uuid.UUID,
 	tenantID uint64,
 	currency Currency,
 	amount uint64,
 	feeAmount uint64,
-	transferCode uint16,
 ) ([]Transfer, error) {
 	builder := NewTransferBuilder()
 
@@ -295,22 +290,31 @@ func SimpleTransferChain(
 	ledger := uint32(currency)
 
 	// Step 1: Debit tenant wallet → pending outbound
-	if _, err := builder.Add(wallet, pendingOut, amount, ledger, transferCode); err != nil {
+	if _, err := builder.Add(wallet, pendingOut, amount, ledger, CodePayout); err != nil {
 		return nil, fmt.Errorf("add debit step: %w", err)
 	}
 
 	// Step 2: Fee deduction (if any)
 	if feeAmount > 0 {
-		if _, err := builder.Add(pendingOut, feeRevenue, feeAmount, ledger, transferCode); err != nil {
+		if _, err := builder.Add(pendingOut, feeRevenue, feeAmount, ledger, CodeFee); err != nil {
 			return nil, fmt.Errorf("add fee step: %w", err)
 		}
 	}
 
 	// Step 3: Final settlement
 	finalAmount := amount - feeAmount
-	if _, err := builder.Add(pendingOut, regionalSettlement, finalAmount, ledger, transferCode); err != nil {
+	if _, err := builder.Add(pendingOut, regionalSettlement, finalAmount, ledger, CodePayout); err != nil {
 		return nil, fmt.Errorf("add settlement step: %w", err)
 	}
 
-	return builder.BuildLinked(), nil
+	return referenceSteps(builder.BuildLinked(), transferID, 1), nil
+}
+
+// referenceSteps sets the reference of a PostgreSQL transfer on a chain,
+// numbering its steps from first.
+func referenceSteps(chain []Transfer, transferID uuid.UUID, first uint64) []Transfer {
+	for i := range chain {
+		chain[i] = chain[i].WithReference(Reference{Kind: RecordTransfer, ID: transferID, Step: first + uint64(i)})
+	}
+	return chain
 }
@@ -0,0 +1,98 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	tbtypes "github.com/tigerbeetle/tigerbeetle-go/pkg/types"
+)
+
+// Treasury moves funds between system accounts to rebalance them and books
+// the external FX deals that cover FX_SETTLEMENT positions. The legs of a
+// movement in one currency are posted as a linked chain; a movement spanning
+// currencies has one chain per currency, as for FX transfers. Every leg
+// references the movement, with its sequence as the step.
+//
+// Leg transfer IDs are assigned when the movement is requested, so posting
+// can be retried safely.
+
+// TreasuryLeg is one transfer of a treasury movement between system
+// accounts of a currency.
+type TreasuryLeg struct {
+	TransferID uuid.UUID
+	Seq        uint32
+	Debit      AccountType
+	Credit     AccountType
+	Currency   Currency
+	Amount     uint64
+}
+
+// PostTreasuryMovement posts the legs of a treasury movement with the given
+// transfer code.
+func (c *Client) PostTreasuryMovement(ctx context.Context, movementID uuid.UUID, code TransferCode, legs []TreasuryLeg) error {
+	var currencies []Currency
+	chains := make(map[Currency][]Transfer)
+	for _, leg := range legs {
+		debit := NewAccountID(SystemTenantID, leg.Debit, leg.Currency)
+		credit := NewAccountID(SystemTenantID, leg.Credit, leg.Currency)
+		if err := c.ensureAccounts(ctx, debit, credit); err != nil {
+			return err
+		}
+
+		if _, ok := chains[leg.Currency]; !ok {
+			currencies = append(currencies, leg.Currency)
+		}
+		transfer := Transfer{
+			ID:            leg.TransferID,
+			DebitAccount:  debit,
+			CreditAccount: credit,
+			Amount:        leg.Amount,
+			Ledger:        uint32(leg.Currency),
+			Code:          code,
+		}
+		chains[leg.Currency] = append(chains[leg.Currency], transfer.WithReference(Reference{
+			Kind: RecordTreasuryMovement,
+			ID:   movementID,
+			Step: uint64(leg.Seq),
+		}))
+	}
+
+	for _, currency := range currencies {
+		if err := c.createLinkedIdempotent(ctx, chains[currency], "post treasury movement"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createLinkedIdempotent creates a linked chain of transfers. A chain is
+// all-or-nothing, so if any of its transfers exists the whole chain was
+// created before.
+func (c *Client) createLinkedIdempotent(ctx context.Context, transfers []Transfer, op string) error {
+	tbTransfers := make([]tbtypes.Transfer, len(transfers))
+	for i, t := range transfers {
+		if i < len(transfers)-1 {
+			t = t.Linked()
+		}
+		tbTransfers[i] = t.toTigerBeetle()
+	}
+
+	results, err := c.tb.CreateTransfers(ctx, tbTransfers)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, result := range results {
+		if result.Result == tbtypes.TransferExists {
+			return nil
+		}
+	}
+	// The transfer that failed the chain; the others report linked_event_failed
+	for _, result := range results {
+		if result.Result != tbtypes.TransferLinkedEventFailed {
+			return fmt.Errorf("%s failed: transfer %d: %s", op, result.Index, createTransferResultString(result.Result))
+		}
+	}
+	return nil
+}
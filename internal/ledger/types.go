@@ -21,6 +21,12 @@ const (
 
 	// AccountTypeRegionalSettlement represents Nostro accounts for pre-funded settlement
 	AccountTypeRegionalSettlement AccountType = 0x06
+
+	// AccountTypeSuspense holds inbound FBO credits that match no expected deposit, per legal entity
+	AccountTypeSuspense AccountType = 0x07
+
+	// AccountTypeSentinel marks the cluster as bootstrapped; it never holds funds
+	AccountTypeSentinel AccountType = 0x08
 )
 
 // String returns a human-readable name for the account type.
@@ -38,6 +44,10 @@ func (t AccountType) String() string {
 		return "PENDING_OUTBOUND"
 	case AccountTypeRegionalSettlement:
 		return "REGIONAL_SETTLEMENT"
+	case AccountTypeSuspense:
+		return "SUSPENSE"
+	case AccountTypeSentinel:
+		return "SENTINEL"
 	default:
 		return "UNKNOWN"
 	}
@@ -110,6 +120,12 @@ const (
 
 	// TransferFlagVoidPending voids (cancels) a pending transfer
 	TransferFlagVoidPending TransferFlags = 1 << 3
+
+	// TransferFlagClosingDebit closes the debit account (reopened if the pending transfer is voided)
+	TransferFlagClosingDebit TransferFlags = 1 << 6
+
+	// TransferFlagClosingCredit closes the credit account (reopened if the pending transfer is voided)
+	TransferFlagClosingCredit TransferFlags = 1 << 7
 )
 
 // Balance represents an account balance.
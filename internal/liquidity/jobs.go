@@ -0,0 +1,47 @@
+package liquidity
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+)
+
+// CheckArgs are the arguments of the periodic liquidity check.
+type CheckArgs struct{}
+
+// Kind returns the job kind.
+func (CheckArgs) Kind() string { return "liquidity.check" }
+
+// InsertOpts returns the default insert options. One check covers every
+// settlement account, so checks are not queued twice.
+func (CheckArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3, UniqueKey: "liquidity.check"}
+}
+
+// CheckWorker runs liquidity checks.
+type CheckWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewCheckWorker creates a new liquidity check worker.
+func NewCheckWorker(service *Service, logger *zap.Logger) *CheckWorker {
+	return &CheckWorker{service: service, logger: logger}
+}
+
+// Work runs the check and logs a summary.
+func (w *CheckWorker) Work(ctx context.Context, _ *jobs.Job[CheckArgs]) error {
+	result, err := w.service.Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("liquidity check finished",
+		zap.Int("checked", result.Checked),
+		zap.Int("alerts", result.Alerts),
+		zap.Int("released", result.Released),
+	)
+	return nil
+}
@@ -0,0 +1,46 @@
+package liquidity
+
+import (
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+// newPosition values a settlement account. The ledger balance of a
+// REGIONAL_SETTLEMENT account is its debits less its credits: pre-funding
+// debits it and payouts credit it.
+func newPosition(settlement *models.RegionalSettlement, b ledger.Balance, admitted, queued decimal.Decimal) *models.LiquidityPosition {
+	balance := decimal.New(int64(b.Debits)-int64(b.Credits), -2)
+	return &models.LiquidityPosition{
+		Settlement: settlement,
+		Balance:    balance,
+		Admitted:   admitted,
+		Queued:     queued,
+		Projected:  balance.Sub(queued),
+	}
+}
+
+// fits reports whether a payout can be admitted: paying it and every
+// payout already admitted keeps the balance at or above the minimum.
+func fits(p *models.LiquidityPosition, amount decimal.Decimal) bool {
+	return p.Balance.Sub(p.Admitted).Sub(amount).GreaterThanOrEqual(p.Settlement.MinBalance)
+}
+
+// alertLevel returns the alert a position raises, or "" if the projected
+// balance is at or above the alert threshold.
+func alertLevel(p *models.LiquidityPosition) models.LiquidityAlertLevel {
+	switch {
+	case p.Projected.LessThan(p.Settlement.MinBalance):
+		return models.LiquidityAlertCritical
+	case p.Projected.LessThan(p.Settlement.AlertThreshold):
+		return models.LiquidityAlertLow
+	default:
+		return ""
+	}
+}
+
+// topUpAmount returns what brings the projected balance back to the target.
+func topUpAmount(p *models.LiquidityPosition) decimal.Decimal {
+	return p.Settlement.TargetBalance.Sub(p.Projected)
+}
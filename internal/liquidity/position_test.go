@@ -0,0 +1,62 @@
+package liquidity
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+func settlement(minBalance, alertThreshold, targetBalance string) *models.RegionalSettlement {
+	return &models.RegionalSettlement{
+		Currency:       "EUR",
+		MinBalance:     decimal.RequireFromString(minBalance),
+		AlertThreshold: decimal.RequireFromString(alertThreshold),
+		TargetBalance:  decimal.RequireFromString(targetBalance),
+	}
+}
+
+func TestNewPosition(t *testing.T) {
+	// Pre-funded 10,000.00, paid out 2,500.00
+	b := ledger.Balance{Debits: 1000000, Credits: 250000}
+	p := newPosition(settlement("1000", "3000", "10000"), b, decimal.RequireFromString("500"), decimal.RequireFromString("1500"))
+
+	assert.True(t, p.Balance.Equal(decimal.RequireFromString("7500")))
+	assert.True(t, p.Projected.Equal(decimal.RequireFromString("6000")))
+}
+
+func TestFits(t *testing.T) {
+	b := ledger.Balance{Debits: 500000}
+	p := newPosition(settlement("1000", "2000", "10000"), b, decimal.RequireFromString("3000"), decimal.RequireFromString("3000"))
+
+	// 5,000 less 3,000 admitted leaves 2,000; paying 1,000 keeps the minimum
+	assert.True(t, fits(p, decimal.RequireFromString("1000")))
+	assert.False(t, fits(p, decimal.RequireFromString("1000.01")))
+}
+
+func TestAlertLevelAndTopUp(t *testing.T) {
+	st := settlement("1000", "3000", "10000")
+
+	tests := []struct {
+		name   string
+		debits uint64
+		queued string
+		level  models.LiquidityAlertLevel
+		topUp  string
+	}{
+		{"healthy", 1000000, "2000", "", "2000"},
+		{"at threshold", 500000, "2000", "", "7000"},
+		{"low", 500000, "2500", models.LiquidityAlertLow, "7500"},
+		{"critical", 500000, "4500", models.LiquidityAlertCritical, "9500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPosition(st, ledger.Balance{Debits: tt.debits}, decimal.Zero, decimal.RequireFromString(tt.queued))
+			assert.Equal(t, tt.level, alertLevel(p))
+			assert.True(t, topUpAmount(p).Equal(decimal.RequireFromString(tt.topUp)), topUpAmount(p).String())
+		})
+	}
+}
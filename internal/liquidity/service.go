@@ -0,0 +1,402 @@
+// Package liquidity monitors the pre-funded Nostro accounts each legal
+// entity pays out from. It values every REGIONAL_SETTLEMENT account against
+// the payouts queued for it, raises alerts when the projected balance falls
+// below the configured threshold, instructs treasury to top the account up
+// to its target, and holds payouts that would take it below its minimum
+// until it is.
+package liquidity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+var (
+	ErrLegalEntityNotFound  = errors.New("legal entity not found")
+	ErrCurrencyNotSupported = errors.New("legal entity does not support the currency")
+	ErrInvalidLimits        = errors.New("limits must satisfy 0 <= min_balance <= alert_threshold <= target_balance")
+	ErrTopUpNotFound        = errors.New("top-up instruction not found")
+	ErrTopUpNotPending      = errors.New("top-up instruction is not pending")
+)
+
+// Ledger is the part of the ledger client liquidity reads.
+type Ledger interface {
+	GetBalances(ctx context.Context, ids []ledger.AccountID) (map[ledger.AccountID]ledger.Balance, error)
+}
+
+// CheckResult summarises a liquidity check.
+type CheckResult struct {
+	Checked  int
+	Alerts   int
+	Released int
+}
+
+// Service tracks settlement balances and admits payouts against them.
+type Service struct {
+	db              *db.DB
+	repo            *repository.LiquidityRepository
+	transferRepo    *repository.TransferRepository
+	outboxRepo      *repository.OutboxRepository
+	legalEntityRepo *repository.LegalEntityRepository
+	ledger          Ledger
+	trail           *audit.Trail
+	logger          *zap.Logger
+}
+
+// NewService creates a new liquidity service.
+func NewService(
+	database *db.DB,
+	repo *repository.LiquidityRepository,
+	transferRepo *repository.TransferRepository,
+	outboxRepo *repository.OutboxRepository,
+	legalEntityRepo *repository.LegalEntityRepository,
+	ledgerClient Ledger,
+	trail *audit.Trail,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		db:              database,
+		repo:            repo,
+		transferRepo:    transferRepo,
+		outboxRepo:      outboxRepo,
+		legalEntityRepo: legalEntityRepo,
+		ledger:          ledgerClient,
+		trail:           trail,
+		logger:          logger,
+	}
+}
+
+// Configure sets the limits of a legal entity's settlement account in a
+// currency.
+func (s *Service) Configure(ctx context.Context, params models.UpsertRegionalSettlementParams) (*models.RegionalSettlement, error) {
+	if params.MinBalance.IsNegative() ||
+		params.AlertThreshold.LessThan(params.MinBalance) ||
+		params.TargetBalance.LessThan(params.AlertThreshold) {
+		return nil, ErrInvalidLimits
+	}
+
+	le, err := s.legalEntityRepo.GetByID(ctx, params.LegalEntityID)
+	if err != nil {
+		return nil, fmt.Errorf("get legal entity: %w", err)
+	}
+	if le == nil {
+		return nil, ErrLegalEntityNotFound
+	}
+	code := ledger.CurrencyFromString(params.Currency)
+	if code == 0 || !slices.Contains(le.SupportedCurrencies, params.Currency) {
+		return nil, ErrCurrencyNotSupported
+	}
+	params.TBAccountID = ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, code).ToBigInt()
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.RegionalSettlement, error) {
+		repo := s.repo.WithTx(tx)
+		before, err := repo.GetSettlementByEntityForUpdate(ctx, params.LegalEntityID, params.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("get settlement: %w", err)
+		}
+
+		settlement, err := repo.UpsertSettlement(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("upsert settlement: %w", err)
+		}
+
+		action := audit.ActionCreate
+		if before != nil {
+			action = audit.ActionUpdate
+		}
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			Region:       models.ComplianceRegionForJurisdiction(le.Jurisdiction),
+			ResourceType: audit.ResourceRegionalSettlement,
+			ResourceID:   settlement.ID.String(),
+			Action:       action,
+			Before:       before,
+			After:        settlement,
+		}); err != nil {
+			return nil, err
+		}
+		return settlement, nil
+	})
+}
+
+// Positions values every settlement account against its queued payouts.
+func (s *Service) Positions(ctx context.Context) ([]*models.LiquidityPosition, error) {
+	settlements, err := s.repo.ListSettlements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list settlements: %w", err)
+	}
+
+	ids := make([]ledger.AccountID, len(settlements))
+	for i, st := range settlements {
+		ids[i] = ledger.FromBigInt(st.TBAccountID)
+	}
+	balances, err := s.ledger.GetBalances(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get ledger balances: %w", err)
+	}
+
+	positions := make([]*models.LiquidityPosition, len(settlements))
+	for i, st := range settlements {
+		admitted, queued, err := s.repo.SumQueuedPayouts(ctx, st.LegalEntityID, st.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("sum queued payouts: %w", err)
+		}
+		positions[i] = newPosition(st, balances[ids[i]], admitted, queued)
+	}
+	return positions, nil
+}
+
+// AdmitTx decides, within tx, whether a screened transfer may be paid out.
+// A payout is held when paying it would take its settlement account below
+// the minimum balance, or when earlier payouts are already held, so that
+// held payouts are released in order. Transfers to a legal entity without
+// configured limits are always admitted. A held transfer stays in
+// validating; Check moves it to processing once the account is topped up.
+func (s *Service) AdmitTx(ctx context.Context, tx pgx.Tx, transfer *models.Transfer) (bool, error) {
+	if transfer.DestLegalEntityID == nil {
+		return true, nil
+	}
+
+	repo := s.repo.WithTx(tx)
+	// Locked so that concurrent payouts are admitted one at a time
+	settlement, err := repo.GetSettlementByEntityForUpdate(ctx, *transfer.DestLegalEntityID, transfer.ToCurrency)
+	if err != nil {
+		return false, fmt.Errorf("get settlement: %w", err)
+	}
+	if settlement == nil {
+		return true, nil
+	}
+
+	held, err := repo.HasHolds(ctx, settlement.ID)
+	if err != nil {
+		return false, fmt.Errorf("check holds: %w", err)
+	}
+	if !held {
+		position, err := s.position(ctx, repo, settlement)
+		if err != nil {
+			return false, err
+		}
+		if fits(position, transfer.ToAmount) {
+			return true, nil
+		}
+	}
+
+	if _, err := repo.CreateHold(ctx, settlement.ID, transfer.ID, transfer.ToAmount); err != nil {
+		return false, fmt.Errorf("hold payout: %w", err)
+	}
+	s.logger.Warn("payout held for liquidity",
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("legal_entity_id", settlement.LegalEntityID.String()),
+		zap.String("currency", settlement.Currency),
+		zap.String("amount", transfer.ToAmount.String()),
+	)
+	return false, nil
+}
+
+// Check values every settlement account, releases the held payouts that
+// now fit, and raises, updates or resolves its alert and top-up
+// instruction.
+func (s *Service) Check(ctx context.Context) (*CheckResult, error) {
+	settlements, err := s.repo.ListSettlements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list settlements: %w", err)
+	}
+
+	result := &CheckResult{}
+	for _, st := range settlements {
+		alerted, released, err := s.check(ctx, st)
+		if err != nil {
+			return nil, fmt.Errorf("check %s %s: %w", st.LegalEntityID, st.Currency, err)
+		}
+		result.Checked++
+		result.Released += released
+		if alerted {
+			result.Alerts++
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) check(ctx context.Context, st *models.RegionalSettlement) (bool, int, error) {
+	var alerted bool
+	var released int
+
+	err := s.db.WithTx(ctx, func(tx pgx.Tx) error {
+		repo := s.repo.WithTx(tx)
+		settlement, err := repo.GetSettlementByEntityForUpdate(ctx, st.LegalEntityID, st.Currency)
+		if err != nil {
+			return fmt.Errorf("get settlement: %w", err)
+		}
+		if settlement == nil {
+			return nil
+		}
+
+		position, err := s.position(ctx, repo, settlement)
+		if err != nil {
+			return err
+		}
+		if err := repo.UpdateCachedBalance(ctx, settlement.ID, position.Balance); err != nil {
+			return fmt.Errorf("update cached balance: %w", err)
+		}
+
+		released, err = s.release(ctx, tx, position)
+		if err != nil {
+			return err
+		}
+
+		level := alertLevel(position)
+		if level == "" {
+			resolved, err := repo.ResolveAlert(ctx, settlement.ID)
+			if err != nil {
+				return fmt.Errorf("resolve alert: %w", err)
+			}
+			if resolved {
+				s.logger.Info("liquidity alert resolved",
+					zap.String("legal_entity_id", settlement.LegalEntityID.String()),
+					zap.String("currency", settlement.Currency),
+				)
+			}
+			return nil
+		}
+
+		alerted = true
+		if _, err := repo.UpsertAlert(ctx, level, position); err != nil {
+			return fmt.Errorf("raise alert: %w", err)
+		}
+		topUp, err := repo.UpsertTopUp(ctx, settlement.ID, topUpAmount(position), position.Projected)
+		if err != nil {
+			return fmt.Errorf("instruct top-up: %w", err)
+		}
+		s.logger.Warn("settlement account below alert threshold",
+			zap.String("legal_entity_id", settlement.LegalEntityID.String()),
+			zap.String("currency", settlement.Currency),
+			zap.String("level", string(level)),
+			zap.String("balance", position.Balance.String()),
+			zap.String("projected", position.Projected.String()),
+			zap.String("top_up", topUp.Amount.String()),
+		)
+		return nil
+	})
+	return alerted, released, err
+}
+
+// release moves held payouts to processing, oldest first, while they fit.
+func (s *Service) release(ctx context.Context, tx pgx.Tx, position *models.LiquidityPosition) (int, error) {
+	repo := s.repo.WithTx(tx)
+	holds, err := repo.ListHeldPayouts(ctx, position.Settlement.ID)
+	if err != nil {
+		return 0, fmt.Errorf("list held payouts: %w", err)
+	}
+
+	released := 0
+	for _, h := range holds {
+		transfer, err := s.transferRepo.WithTx(tx).GetForUpdate(ctx, h.TransferID)
+		if err != nil {
+			return released, fmt.Errorf("get transfer: %w", err)
+		}
+		// Cancelled while held
+		if transfer == nil || transfer.Status != models.TransferStatusValidating {
+			if err := repo.UpdateHoldStatus(ctx, h.ID, models.LiquidityHoldCancelled); err != nil {
+				return released, fmt.Errorf("cancel hold: %w", err)
+			}
+			continue
+		}
+
+		if !fits(position, h.Amount) {
+			break
+		}
+		if err := repo.UpdateHoldStatus(ctx, h.ID, models.LiquidityHoldReleased); err != nil {
+			return released, fmt.Errorf("release hold: %w", err)
+		}
+		if err := s.transferRepo.WithTx(tx).UpdateStatus(ctx, transfer.ID, models.TransferStatusProcessing, nil); err != nil {
+			return released, err
+		}
+		if err := appendStatusEvent(ctx, s.outboxRepo.WithTx(tx), transfer, models.TransferStatusProcessing); err != nil {
+			return released, err
+		}
+		position.Admitted = position.Admitted.Add(h.Amount)
+		released++
+
+		s.logger.Info("held payout released",
+			zap.String("transfer_id", transfer.ID.String()),
+		)
+	}
+	return released, nil
+}
+
+// DecideTopUp marks a pending top-up instruction completed once treasury
+// has funded the account, or cancels it.
+func (s *Service) DecideTopUp(ctx context.Context, id uuid.UUID, status models.LiquidityTopUpStatus, decidedBy string) (*models.LiquidityTopUp, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.LiquidityTopUp, error) {
+		repo := s.repo.WithTx(tx)
+		topUp, err := repo.GetTopUpForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get top-up: %w", err)
+		}
+		if topUp == nil {
+			return nil, ErrTopUpNotFound
+		}
+		if topUp.Status != models.LiquidityTopUpPending {
+			return nil, ErrTopUpNotPending
+		}
+
+		if err := repo.UpdateTopUpStatus(ctx, id, status, decidedBy); err != nil {
+			return nil, fmt.Errorf("update top-up: %w", err)
+		}
+		before := *topUp
+		topUp.Status = status
+		topUp.DecidedBy = &decidedBy
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			ResourceType: audit.ResourceLiquidityTopUp,
+			ResourceID:   id.String(),
+			Action:       audit.ActionDecide,
+			Before:       before,
+			After:        topUp,
+		}); err != nil {
+			return nil, err
+		}
+		return topUp, nil
+	})
+}
+
+// position values a settlement account as of now.
+func (s *Service) position(ctx context.Context, repo *repository.LiquidityRepository, settlement *models.RegionalSettlement) (*models.LiquidityPosition, error) {
+	id := ledger.FromBigInt(settlement.TBAccountID)
+	balances, err := s.ledger.GetBalances(ctx, []ledger.AccountID{id})
+	if err != nil {
+		return nil, fmt.Errorf("get ledger balance: %w", err)
+	}
+
+	admitted, queued, err := repo.SumQueuedPayouts(ctx, settlement.LegalEntityID, settlement.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("sum queued payouts: %w", err)
+	}
+	return newPosition(settlement, balances[id], admitted, queued), nil
+}
+
+// appendStatusEvent records a transfer.status_changed event for a transfer
+// moving from its current status to status.
+func appendStatusEvent(ctx context.Context, outboxRepo *repository.OutboxRepository, transfer *models.Transfer, status models.TransferStatus) error {
+	previous := transfer.Status
+	event, err := outbox.NewEvent(models.AggregateTransfer, transfer.ID, &transfer.TenantID,
+		string(models.WebhookEventTransferStatusChanged),
+		webhook.NewTransferStatusChanged(transfer, status, &previous, nil))
+	if err != nil {
+		return err
+	}
+	return outboxRepo.Append(ctx, event)
+}
@@ -0,0 +1,208 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+// RunArgs are the arguments of the automatic matching job. It is enqueued
+// after every statement import and expected deposit, and runs periodically
+// for transfers that settle after their statement was imported.
+type RunArgs struct{}
+
+// Kind returns the job kind.
+func (RunArgs) Kind() string { return "matching.run" }
+
+// InsertOpts returns the default insert options. One run matches every
+// open entry, so runs are not queued twice.
+func (RunArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3, UniqueKey: "matching.run"}
+}
+
+// RunWorker runs automatic matching.
+type RunWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewRunWorker creates a new matching worker.
+func NewRunWorker(service *Service, logger *zap.Logger) *RunWorker {
+	return &RunWorker{service: service, logger: logger}
+}
+
+// Work runs the matcher and logs a summary.
+func (w *RunWorker) Work(ctx context.Context, _ *jobs.Job[RunArgs]) error {
+	result, err := w.service.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("statement matching finished",
+		zap.Int("matched", result.Matched),
+		zap.Int("parked", result.Parked),
+		zap.Int("open", result.Open),
+	)
+	return nil
+}
+
+// BookEntryArgs are the arguments of the job that books a statement entry
+// in the ledger once it is parked or matched to an expected deposit.
+type BookEntryArgs struct {
+	EntryID uuid.UUID `json:"entry_id"`
+}
+
+// Kind returns the job kind.
+func (BookEntryArgs) Kind() string { return "matching.book_entry" }
+
+// InsertOpts returns the default insert options.
+func (BookEntryArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: jobs.DefaultQueue, MaxAttempts: 10}
+}
+
+// BookEntryWorker books statement entries in the ledger: it parks an entry
+// in suspense and, once the entry is matched to an expected deposit, credits
+// the tenant's wallet, from suspense if it was parked. It reads the entry's
+// current state and every booking is idempotent, so jobs may run in any
+// order and be retried. The tenant is notified of a credit once, when the
+// expected deposit is first marked credited.
+type BookEntryWorker struct {
+	db           *db.DB
+	repo         *repository.BankStatementRepository
+	depositRepo  *repository.ExpectedDepositRepository
+	walletRepo   *repository.WalletRepository
+	outboxRepo   *repository.OutboxRepository
+	ledgerClient *ledger.Client
+	logger       *zap.Logger
+}
+
+// NewBookEntryWorker creates a new ledger booking worker.
+func NewBookEntryWorker(
+	database *db.DB,
+	repo *repository.BankStatementRepository,
+	depositRepo *repository.ExpectedDepositRepository,
+	walletRepo *repository.WalletRepository,
+	outboxRepo *repository.OutboxRepository,
+	ledgerClient *ledger.Client,
+	logger *zap.Logger,
+) *BookEntryWorker {
+	return &BookEntryWorker{
+		db:           database,
+		repo:         repo,
+		depositRepo:  depositRepo,
+		walletRepo:   walletRepo,
+		outboxRepo:   outboxRepo,
+		ledgerClient: ledgerClient,
+		logger:       logger,
+	}
+}
+
+// Work books the entry.
+func (w *BookEntryWorker) Work(ctx context.Context, job *jobs.Job[BookEntryArgs]) error {
+	entry, err := w.repo.GetEntry(ctx, job.Args.EntryID)
+	if err != nil {
+		return fmt.Errorf("get entry: %w", err)
+	}
+	if entry == nil {
+		return jobs.Cancel(fmt.Errorf("statement entry %s not found", job.Args.EntryID))
+	}
+
+	currency := ledger.CurrencyFromString(entry.Currency)
+	if currency == 0 {
+		return jobs.Cancel(fmt.Errorf("unsupported currency %s", entry.Currency))
+	}
+	amount := uint64(entry.Amount.Shift(2).IntPart())
+
+	if entry.ParkedAt != nil {
+		if err := w.ledgerClient.ParkInSuspense(ctx, entry.ID, entry.LegalEntityID, currency, amount); err != nil {
+			return err
+		}
+	}
+	if entry.MatchStatus != models.EntryMatched {
+		return nil
+	}
+
+	matches, err := w.repo.ListMatches(ctx, entry.ID)
+	if err != nil {
+		return fmt.Errorf("list matches: %w", err)
+	}
+	for _, m := range matches {
+		if m.ExpectedDepositID == nil {
+			continue
+		}
+
+		deposit, err := w.depositRepo.GetByID(ctx, *m.ExpectedDepositID)
+		if err != nil {
+			return fmt.Errorf("get expected deposit: %w", err)
+		}
+		if deposit == nil {
+			return jobs.Cancel(fmt.Errorf("expected deposit %s not found", *m.ExpectedDepositID))
+		}
+		wallet, err := w.walletRepo.GetByTenantAndCurrency(ctx, deposit.TenantID, entry.Currency)
+		if err != nil {
+			return fmt.Errorf("get wallet: %w", err)
+		}
+		if wallet == nil {
+			return jobs.Cancel(fmt.Errorf("tenant %s has no %s wallet", deposit.TenantID, entry.Currency))
+		}
+
+		account := ledger.FromBigInt(wallet.TBAccountID)
+		if entry.ParkedAt != nil {
+			err = w.ledgerClient.ReleaseSuspense(ctx, entry.ID, entry.LegalEntityID, account, amount)
+		} else {
+			err = w.ledgerClient.CreditDeposit(ctx, entry.ID, account, amount)
+		}
+		if err != nil {
+			return err
+		}
+		if err := w.notifyCredited(ctx, entry, deposit, wallet); err != nil {
+			return err
+		}
+
+		w.logger.Info("deposit credited",
+			zap.String("entry_id", entry.ID.String()),
+			zap.String("expected_deposit_id", deposit.ID.String()),
+			zap.String("wallet_id", wallet.ID.String()),
+		)
+	}
+	return nil
+}
+
+// notifyCredited marks the expected deposit credited and records the
+// wallet.credited event, unless a previous run of the job already did.
+func (w *BookEntryWorker) notifyCredited(ctx context.Context, entry *models.BankStatementEntry, deposit *models.ExpectedDeposit, wallet *models.Wallet) error {
+	return w.db.WithTx(ctx, func(tx pgx.Tx) error {
+		marked, err := w.depositRepo.WithTx(tx).MarkCredited(ctx, deposit.ID)
+		if err != nil {
+			return fmt.Errorf("mark expected deposit credited: %w", err)
+		}
+		if !marked {
+			return nil
+		}
+
+		event, err := outbox.NewEvent(models.AggregateWallet, wallet.ID, &deposit.TenantID,
+			string(models.WebhookEventWalletCredited), webhook.WalletCredited{
+				WalletID:          wallet.ID,
+				Currency:          entry.Currency,
+				Amount:            entry.Amount,
+				Source:            webhook.CreditSourceDeposit,
+				Reference:         deposit.Reference,
+				ExpectedDepositID: &deposit.ID,
+			})
+		if err != nil {
+			return err
+		}
+		return w.outboxRepo.WithTx(tx).Append(ctx, event)
+	})
+}
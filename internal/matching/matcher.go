@@ -0,0 +1,293 @@
+// Package matching matches imported bank statement entries to what they
+// settle: Nostro debits to the payouts of transfers and FBO credits to the
+// deposits tenants announced. Entries no rule matches wait in a queue for
+// operations to match by hand; inbound FBO credits among them are parked in
+// the legal entity's suspense account in the ledger until they are.
+package matching
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+)
+
+// minReferenceLength is the shortest reference a fuzzy match looks for in
+// an entry's reference, so that short references do not match by accident.
+const minReferenceLength = 4
+
+// Line is a statement entry to match. Amount is unsigned.
+type Line struct {
+	ID     uuid.UUID
+	Amount decimal.Decimal
+	Date   time.Time
+	// References are the payment and bank references of the entry.
+	References []string
+}
+
+// Item is something a line can settle: a transfer or an expected deposit.
+type Item struct {
+	ID        uuid.UUID
+	Amount    decimal.Decimal
+	Date      time.Time
+	Reference string
+	// Group is the netting group or batch the item was paid out with. A
+	// line may settle a whole group.
+	Group *uuid.UUID
+}
+
+// Result is a line matched to one or more items.
+type Result struct {
+	LineID uuid.UUID
+	Items  []Item
+	Rule   models.MatchRule
+}
+
+// Rules configures matching.
+type Rules struct {
+	// DateWindow is how many days apart a line's value date and an item's
+	// date may be.
+	DateWindow time.Duration
+	// AmountTolerance is how far apart amounts may be for a fuzzy match.
+	// Exact matches need equal amounts.
+	AmountTolerance decimal.Decimal
+}
+
+// Match matches lines to items. Every rule only matches when exactly one
+// item or group qualifies; ambiguous lines are left for manual matching.
+// Each item is matched at most once.
+//
+// The rules run in order over all lines:
+//
+//   - exact: the item reference equals a line reference and the amounts
+//     are equal.
+//   - exact group: the items of a group add up to the line amount and a
+//     line reference holds the group ID or equals the reference of one of
+//     them.
+//   - fuzzy: the amounts are within the tolerance and the item reference
+//     appears in a line reference. Without such an item, an item whose
+//     amount no other line could take.
+//   - fuzzy group: the items of a group add up to the line amount within
+//     the tolerance.
+func Match(lines []Line, items []Item, rules Rules) []Result {
+	m := &matcher{rules: rules, items: items, used: make(map[uuid.UUID]bool)}
+
+	var results []Result
+	open := lines
+	for _, rule := range []func(Line, []Line) *Result{m.exact, m.exactGroup, m.fuzzy, m.fuzzyGroup} {
+		var rest []Line
+		for _, l := range open {
+			if r := rule(l, open); r != nil {
+				for _, it := range r.Items {
+					m.used[it.ID] = true
+				}
+				results = append(results, *r)
+				continue
+			}
+			rest = append(rest, l)
+		}
+		open = rest
+	}
+	return results
+}
+
+type matcher struct {
+	rules Rules
+	items []Item
+	used  map[uuid.UUID]bool
+}
+
+func (m *matcher) exact(l Line, _ []Line) *Result {
+	var found []Item
+	for _, it := range m.candidates(l, decimal.Zero) {
+		if it.Reference != "" && referenceEquals(l, it.Reference) {
+			found = append(found, it)
+		}
+	}
+	return single(l, found, models.MatchRuleExact)
+}
+
+func (m *matcher) exactGroup(l Line, _ []Line) *Result {
+	var found [][]Item
+	for _, g := range m.groups() {
+		if !m.groupFits(l, g, decimal.Zero) {
+			continue
+		}
+		if referenceContained(l, g[0].Group.String()) || anyReferenceEquals(l, g) {
+			found = append(found, g)
+		}
+	}
+	return singleGroup(l, found, models.MatchRuleExact)
+}
+
+func (m *matcher) fuzzy(l Line, open []Line) *Result {
+	candidates := m.candidates(l, m.rules.AmountTolerance)
+
+	var byReference []Item
+	for _, it := range candidates {
+		if referenceContained(l, it.Reference) {
+			byReference = append(byReference, it)
+		}
+	}
+	if len(byReference) > 0 {
+		return single(l, byReference, models.MatchRuleFuzzy)
+	}
+
+	// By amount alone only if neither side has another choice
+	if len(candidates) != 1 {
+		return nil
+	}
+	for _, other := range open {
+		if other.ID == l.ID {
+			continue
+		}
+		for _, it := range m.candidates(other, m.rules.AmountTolerance) {
+			if it.ID == candidates[0].ID {
+				return nil
+			}
+		}
+	}
+	return single(l, candidates, models.MatchRuleFuzzy)
+}
+
+func (m *matcher) fuzzyGroup(l Line, _ []Line) *Result {
+	var found [][]Item
+	for _, g := range m.groups() {
+		if m.groupFits(l, g, m.rules.AmountTolerance) {
+			found = append(found, g)
+		}
+	}
+	return singleGroup(l, found, models.MatchRuleFuzzy)
+}
+
+// candidates returns the unmatched items in the line's date window whose
+// amount is within tolerance of the line's.
+func (m *matcher) candidates(l Line, tolerance decimal.Decimal) []Item {
+	var out []Item
+	for _, it := range m.items {
+		if m.used[it.ID] || !m.inWindow(l, it) {
+			continue
+		}
+		if l.Amount.Sub(it.Amount).Abs().LessThanOrEqual(tolerance) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// groups returns the unmatched items of each group, in the order the
+// groups first appear.
+func (m *matcher) groups() [][]Item {
+	index := make(map[uuid.UUID]int)
+	var out [][]Item
+	for _, it := range m.items {
+		if it.Group == nil || m.used[it.ID] {
+			continue
+		}
+		i, ok := index[*it.Group]
+		if !ok {
+			i = len(out)
+			index[*it.Group] = i
+			out = append(out, nil)
+		}
+		out[i] = append(out[i], it)
+	}
+	return out
+}
+
+// groupFits reports whether every item of a group is in the line's date
+// window and their sum is within tolerance of the line amount.
+func (m *matcher) groupFits(l Line, group []Item, tolerance decimal.Decimal) bool {
+	sum := decimal.Zero
+	for _, it := range group {
+		if !m.inWindow(l, it) {
+			return false
+		}
+		sum = sum.Add(it.Amount)
+	}
+	return l.Amount.Sub(sum).Abs().LessThanOrEqual(tolerance)
+}
+
+func (m *matcher) inWindow(l Line, it Item) bool {
+	d := day(l.Date).Sub(day(it.Date))
+	if d < 0 {
+		d = -d
+	}
+	return d <= m.rules.DateWindow
+}
+
+func single(l Line, found []Item, rule models.MatchRule) *Result {
+	if len(found) != 1 {
+		return nil
+	}
+	return &Result{LineID: l.ID, Items: found, Rule: rule}
+}
+
+func singleGroup(l Line, found [][]Item, rule models.MatchRule) *Result {
+	if len(found) != 1 {
+		return nil
+	}
+	items := append([]Item(nil), found[0]...)
+	sort.Slice(items, func(i, j int) bool { return items[i].ID.String() < items[j].ID.String() })
+	return &Result{LineID: l.ID, Items: items, Rule: rule}
+}
+
+func anyReferenceEquals(l Line, items []Item) bool {
+	for _, it := range items {
+		if it.Reference != "" && referenceEquals(l, it.Reference) {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceEquals reports whether a line reference equals ref, ignoring
+// case, spaces and punctuation.
+func referenceEquals(l Line, ref string) bool {
+	n := normaliseReference(ref)
+	if n == "" {
+		return false
+	}
+	for _, r := range l.References {
+		if normaliseReference(r) == n {
+			return true
+		}
+	}
+	return false
+}
+
+// referenceContained reports whether ref appears in a line reference,
+// ignoring case, spaces and punctuation.
+func referenceContained(l Line, ref string) bool {
+	n := normaliseReference(ref)
+	if len(n) < minReferenceLength {
+		return false
+	}
+	for _, r := range l.References {
+		if strings.Contains(normaliseReference(r), n) {
+			return true
+		}
+	}
+	return false
+}
+
+// normaliseReference upper-cases a reference and keeps only its letters and
+// digits. Banks often reformat references on the way.
+func normaliseReference(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,119 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+var rules = Rules{
+	DateWindow:      3 * 24 * time.Hour,
+	AmountTolerance: decimal.RequireFromString("0.50"),
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func line(amount, day string, refs ...string) Line {
+	return Line{ID: uuid.New(), Amount: decimal.RequireFromString(amount), Date: date(day), References: refs}
+}
+
+func item(amount, day, ref string) Item {
+	return Item{ID: uuid.New(), Amount: decimal.RequireFromString(amount), Date: date(day), Reference: ref}
+}
+
+func TestMatchExact(t *testing.T) {
+	l := line("100.00", "2026-03-02", "PAY-0001")
+	want := item("100.00", "2026-03-01", "pay 0001")
+	other := item("100.00", "2026-03-01", "PAY-0002")
+
+	results := Match([]Line{l}, []Item{other, want}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, l.ID, results[0].LineID)
+	assert.Equal(t, models.MatchRuleExact, results[0].Rule)
+	require.Len(t, results[0].Items, 1)
+	assert.Equal(t, want.ID, results[0].Items[0].ID)
+}
+
+func TestMatchNettedGroup(t *testing.T) {
+	group := uuid.New()
+	a := item("60.00", "2026-03-02", "")
+	b := item("40.00", "2026-03-02", "")
+	a.Group, b.Group = &group, &group
+	l := line("100.00", "2026-03-03", "NET "+group.String())
+
+	results := Match([]Line{l}, []Item{a, b}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.MatchRuleExact, results[0].Rule)
+	assert.Len(t, results[0].Items, 2)
+}
+
+func TestMatchBatchByAmount(t *testing.T) {
+	batch := uuid.New()
+	a := item("10.00", "2026-03-02", "")
+	b := item("15.00", "2026-03-02", "")
+	a.Group, b.Group = &batch, &batch
+	l := line("25.00", "2026-03-02", "BULK PAYMENT")
+
+	results := Match([]Line{l}, []Item{a, b}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.MatchRuleFuzzy, results[0].Rule)
+	assert.Len(t, results[0].Items, 2)
+}
+
+func TestMatchFuzzyReference(t *testing.T) {
+	// The bank took a fee and wrapped the reference
+	l := line("99.75", "2026-03-04", "/ROC/TOPUP-42/INV")
+	want := item("100.00", "2026-03-02", "TOPUP-42")
+	other := item("100.00", "2026-03-02", "TOPUP-43")
+
+	results := Match([]Line{l}, []Item{want, other}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.MatchRuleFuzzy, results[0].Rule)
+	assert.Equal(t, want.ID, results[0].Items[0].ID)
+}
+
+func TestMatchFuzzyAmountOnlyWhenUnambiguous(t *testing.T) {
+	l := line("300.00", "2026-03-02", "unknown")
+	it := item("300.00", "2026-03-02", "SOMETHING")
+
+	results := Match([]Line{l}, []Item{it}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, models.MatchRuleFuzzy, results[0].Rule)
+
+	// Two lines could take the same item, so neither does
+	l2 := line("300.00", "2026-03-03", "also unknown")
+	assert.Empty(t, Match([]Line{l, l2}, []Item{it}, rules))
+
+	// Two items fit the line
+	it2 := item("300.00", "2026-03-01", "OTHER")
+	assert.Empty(t, Match([]Line{l}, []Item{it, it2}, rules))
+}
+
+func TestMatchOutsideWindow(t *testing.T) {
+	l := line("100.00", "2026-03-10", "PAY-0001")
+	it := item("100.00", "2026-03-02", "PAY-0001")
+
+	assert.Empty(t, Match([]Line{l}, []Item{it}, rules))
+}
+
+func TestMatchItemUsedOnce(t *testing.T) {
+	it := item("50.00", "2026-03-02", "REF-77")
+	l1 := line("50.00", "2026-03-02", "REF-77")
+	l2 := line("50.00", "2026-03-02", "REF-77")
+
+	results := Match([]Line{l1, l2}, []Item{it}, rules)
+	require.Len(t, results, 1)
+	assert.Equal(t, l1.ID, results[0].LineID)
+}
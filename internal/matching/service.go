@@ -0,0 +1,584 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrEntryNotFound     = errors.New("statement entry not found")
+	ErrEntryMatched      = errors.New("statement entry is already matched")
+	ErrTransferNotFound  = errors.New("transfer not found")
+	ErrDepositNotFound   = errors.New("expected deposit not found")
+	ErrDepositNotPending = errors.New("expected deposit is not pending")
+	ErrAlreadyMatched    = errors.New("already matched to another statement entry")
+	ErrAmountMismatch    = errors.New("matched amounts do not add up to the entry amount")
+	ErrInvalidMatch      = errors.New("invalid match")
+
+	ErrTenantNotFound   = errors.New("tenant not found")
+	ErrWalletNotFound   = errors.New("tenant has no wallet in the currency")
+	ErrInvalidDeposit   = errors.New("invalid expected deposit")
+	ErrDuplicateDeposit = errors.New("a deposit with this reference was already announced")
+)
+
+// AutoMatcher is recorded as the matcher of matches made by the rules.
+const AutoMatcher = "auto"
+
+const entryBatchSize = 1000
+
+// ManualMatch is an operator's match of a statement entry: either to
+// transfers, whose payouts must add up to the entry amount, or to an
+// expected deposit of the same amount.
+type ManualMatch struct {
+	TransferIDs       []uuid.UUID
+	ExpectedDepositID *uuid.UUID
+	MatchedBy         string
+}
+
+// RunResult summarises an automatic matching run.
+type RunResult struct {
+	Matched int
+	Parked  int
+	// Open is the number of entries left for manual matching, parked ones
+	// included.
+	Open int
+}
+
+// EntryDetail is a statement entry with what it was matched to.
+type EntryDetail struct {
+	*models.BankStatementEntry
+	Matches []*models.StatementMatch
+}
+
+// Service matches statement entries and manages expected deposits.
+type Service struct {
+	db              *db.DB
+	repo            *repository.BankStatementRepository
+	depositRepo     *repository.ExpectedDepositRepository
+	transferRepo    *repository.TransferRepository
+	tenantRepo      *repository.TenantRepository
+	walletRepo      *repository.WalletRepository
+	legalEntityRepo *repository.LegalEntityRepository
+	jobClient       *jobs.Client
+	trail           *audit.Trail
+	rules           Rules
+}
+
+// NewService creates a new matching service.
+func NewService(
+	database *db.DB,
+	repo *repository.BankStatementRepository,
+	depositRepo *repository.ExpectedDepositRepository,
+	transferRepo *repository.TransferRepository,
+	tenantRepo *repository.TenantRepository,
+	walletRepo *repository.WalletRepository,
+	legalEntityRepo *repository.LegalEntityRepository,
+	jobClient *jobs.Client,
+	trail *audit.Trail,
+	rules Rules,
+) *Service {
+	return &Service{
+		db:              database,
+		repo:            repo,
+		depositRepo:     depositRepo,
+		transferRepo:    transferRepo,
+		tenantRepo:      tenantRepo,
+		walletRepo:      walletRepo,
+		legalEntityRepo: legalEntityRepo,
+		jobClient:       jobClient,
+		trail:           trail,
+		rules:           rules,
+	}
+}
+
+// accountKey identifies the bank account of a statement entry.
+type accountKey struct {
+	legalEntityID uuid.UUID
+	kind          models.BankAccountKind
+	currency      string
+}
+
+// target is a transfer or expected deposit an entry is matched to.
+type target struct {
+	transferID *uuid.UUID
+	depositID  *uuid.UUID
+	amount     decimal.Decimal
+}
+
+// Run matches every open entry with the rules. Nostro debits are matched
+// to payouts, FBO credits to pending expected deposits. FBO credits left
+// unmatched are parked in suspense; the other entries wait for manual
+// matching.
+func (s *Service) Run(ctx context.Context) (*RunResult, error) {
+	groups := make(map[accountKey][]*models.BankStatementEntry)
+	after := uuid.Nil
+	for {
+		batch, err := s.repo.ListOpenEntriesAfter(ctx, after, entryBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("list open entries: %w", err)
+		}
+		for _, e := range batch {
+			key := accountKey{e.LegalEntityID, e.AccountKind, e.Currency}
+			groups[key] = append(groups[key], e)
+			after = e.ID
+		}
+		if len(batch) < entryBatchSize {
+			break
+		}
+	}
+
+	keys := make([]accountKey, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].legalEntityID != keys[j].legalEntityID {
+			return keys[i].legalEntityID.String() < keys[j].legalEntityID.String()
+		}
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	result := &RunResult{}
+	for _, key := range keys {
+		entries := groups[key]
+		matched, err := s.matchAccount(ctx, key, entries)
+		if err != nil {
+			return nil, err
+		}
+		result.Matched += len(matched)
+
+		for _, e := range entries {
+			if matched[e.ID] {
+				continue
+			}
+			if key.kind == models.BankAccountFBO && e.IsCredit() && e.MatchStatus == models.EntryUnmatched {
+				parked, err := s.park(ctx, e.ID)
+				if err != nil {
+					return nil, err
+				}
+				if parked {
+					result.Parked++
+				}
+			}
+			result.Open++
+		}
+	}
+	return result, nil
+}
+
+// matchAccount matches the open entries of one bank account and returns
+// the IDs of the entries it matched.
+func (s *Service) matchAccount(ctx context.Context, key accountKey, entries []*models.BankStatementEntry) (map[uuid.UUID]bool, error) {
+	var lines []Line
+	for _, e := range entries {
+		switch {
+		case key.kind == models.BankAccountNostro && !e.IsCredit() && e.MatchStatus == models.EntryUnmatched:
+		case key.kind == models.BankAccountFBO && e.IsCredit():
+		default:
+			continue
+		}
+		lines = append(lines, toLine(e))
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	var items []Item
+	kinds := make(map[uuid.UUID]bool) // true for expected deposits
+	if key.kind == models.BankAccountNostro {
+		from, to := lines[0].Date, lines[0].Date
+		for _, l := range lines {
+			from = minTime(from, l.Date)
+			to = maxTime(to, l.Date)
+		}
+		transfers, err := s.repo.ListMatchCandidateTransfers(ctx, key.legalEntityID, key.currency,
+			from.Add(-s.rules.DateWindow), to.Add(s.rules.DateWindow+24*time.Hour))
+		if err != nil {
+			return nil, fmt.Errorf("list candidate transfers: %w", err)
+		}
+		for _, t := range transfers {
+			items = append(items, transferItem(t))
+		}
+	} else {
+		deposits, err := s.depositRepo.ListPending(ctx, key.legalEntityID, key.currency)
+		if err != nil {
+			return nil, fmt.Errorf("list expected deposits: %w", err)
+		}
+		for _, d := range deposits {
+			items = append(items, Item{ID: d.ID, Amount: d.Amount, Date: d.ExpectedDate, Reference: d.Reference})
+			kinds[d.ID] = true
+		}
+	}
+
+	matched := make(map[uuid.UUID]bool)
+	for _, r := range Match(lines, items, s.rules) {
+		targets := make([]target, len(r.Items))
+		for i, it := range r.Items {
+			id := it.ID
+			if kinds[id] {
+				targets[i] = target{depositID: &id, amount: it.Amount}
+			} else {
+				targets[i] = target{transferID: &id, amount: it.Amount}
+			}
+		}
+
+		_, err := s.record(ctx, r.LineID, targets, r.Rule, AutoMatcher)
+		switch {
+		case err == nil:
+			matched[r.LineID] = true
+		case errors.Is(err, ErrEntryMatched), errors.Is(err, ErrAlreadyMatched), errors.Is(err, ErrDepositNotPending):
+			// Matched by hand in the meantime
+		default:
+			return nil, err
+		}
+	}
+	return matched, nil
+}
+
+// Match matches an entry by hand.
+func (s *Service) Match(ctx context.Context, entryID uuid.UUID, match ManualMatch) (*EntryDetail, error) {
+	if (len(match.TransferIDs) == 0) == (match.ExpectedDepositID == nil) {
+		return nil, fmt.Errorf("%w: give either transfers or an expected deposit", ErrInvalidMatch)
+	}
+
+	entry, err := s.repo.GetEntry(ctx, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("get entry: %w", err)
+	}
+	if entry == nil {
+		return nil, ErrEntryNotFound
+	}
+	if entry.MatchStatus == models.EntryMatched {
+		return nil, ErrEntryMatched
+	}
+
+	var targets []target
+	if match.ExpectedDepositID != nil {
+		if entry.AccountKind != models.BankAccountFBO || !entry.IsCredit() {
+			return nil, fmt.Errorf("%w: only FBO credits settle deposits", ErrInvalidMatch)
+		}
+		deposit, err := s.depositRepo.GetByID(ctx, *match.ExpectedDepositID)
+		if err != nil {
+			return nil, fmt.Errorf("get expected deposit: %w", err)
+		}
+		if deposit == nil {
+			return nil, ErrDepositNotFound
+		}
+		if !deposit.Amount.Equal(entry.Amount) {
+			return nil, ErrAmountMismatch
+		}
+		targets = append(targets, target{depositID: &deposit.ID, amount: deposit.Amount})
+	} else {
+		// Parked money belongs to a tenant; it leaves suspense through a deposit
+		if entry.MatchStatus == models.EntrySuspense {
+			return nil, fmt.Errorf("%w: parked entries can only be matched to an expected deposit", ErrInvalidMatch)
+		}
+		sum := decimal.Zero
+		for _, id := range match.TransferIDs {
+			t, err := s.transferRepo.GetByID(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("get transfer: %w", err)
+			}
+			if t == nil {
+				return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, id)
+			}
+			if t.ToCurrency != entry.Currency {
+				return nil, fmt.Errorf("%w: transfer %s pays out %s", ErrInvalidMatch, id, t.ToCurrency)
+			}
+			sum = sum.Add(t.ToAmount)
+			targets = append(targets, target{transferID: &t.ID, amount: t.ToAmount})
+		}
+		if !sum.Equal(entry.Amount.Abs()) {
+			return nil, ErrAmountMismatch
+		}
+	}
+
+	return s.record(ctx, entryID, targets, models.MatchRuleManual, match.MatchedBy)
+}
+
+// record stores a match, and books matched deposits in the ledger.
+func (s *Service) record(ctx context.Context, entryID uuid.UUID, targets []target, rule models.MatchRule, matchedBy string) (*EntryDetail, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*EntryDetail, error) {
+		repo := s.repo.WithTx(tx)
+		depositRepo := s.depositRepo.WithTx(tx)
+
+		entry, err := repo.GetEntryForUpdate(ctx, entryID)
+		if err != nil {
+			return nil, fmt.Errorf("get entry: %w", err)
+		}
+		if entry == nil {
+			return nil, ErrEntryNotFound
+		}
+		if entry.MatchStatus == models.EntryMatched {
+			return nil, ErrEntryMatched
+		}
+
+		book := false
+		matches := make([]*models.StatementMatch, 0, len(targets))
+		for _, t := range targets {
+			if t.depositID != nil {
+				deposit, err := depositRepo.GetByIDForUpdate(ctx, *t.depositID)
+				if err != nil {
+					return nil, fmt.Errorf("get expected deposit: %w", err)
+				}
+				if deposit == nil {
+					return nil, ErrDepositNotFound
+				}
+				if deposit.Status != models.ExpectedDepositPending {
+					return nil, ErrDepositNotPending
+				}
+				if deposit.LegalEntityID != entry.LegalEntityID || deposit.Currency != entry.Currency {
+					return nil, fmt.Errorf("%w: deposit is for another account", ErrInvalidMatch)
+				}
+				if err := depositRepo.UpdateStatus(ctx, deposit.ID, models.ExpectedDepositMatched); err != nil {
+					return nil, fmt.Errorf("update expected deposit: %w", err)
+				}
+				book = true
+			}
+
+			m, err := repo.CreateMatch(ctx, models.CreateStatementMatchParams{
+				EntryID:           entryID,
+				TransferID:        t.transferID,
+				ExpectedDepositID: t.depositID,
+				Amount:            t.amount,
+				Rule:              rule,
+				MatchedBy:         matchedBy,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("create match: %w", err)
+			}
+			if m == nil {
+				return nil, ErrAlreadyMatched
+			}
+			matches = append(matches, m)
+		}
+
+		if err := repo.SetEntryMatched(ctx, entryID); err != nil {
+			return nil, fmt.Errorf("update entry: %w", err)
+		}
+		if book {
+			if _, err := s.jobClient.InsertTx(ctx, tx, BookEntryArgs{EntryID: entryID}, nil); err != nil {
+				return nil, fmt.Errorf("enqueue ledger booking: %w", err)
+			}
+		}
+
+		matchedEntry := *entry
+		matchedEntry.MatchStatus = models.EntryMatched
+		detail := &EntryDetail{BankStatementEntry: &matchedEntry, Matches: matches}
+
+		if err := s.audit(ctx, tx, entry, audit.ActionMatch, entry, detail); err != nil {
+			return nil, err
+		}
+		return detail, nil
+	})
+}
+
+// park moves an unmatched inbound credit to suspense. It returns false if
+// the entry was matched or parked in the meantime.
+func (s *Service) park(ctx context.Context, entryID uuid.UUID) (bool, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (bool, error) {
+		repo := s.repo.WithTx(tx)
+		entry, err := repo.GetEntryForUpdate(ctx, entryID)
+		if err != nil {
+			return false, fmt.Errorf("get entry: %w", err)
+		}
+		if entry == nil || entry.MatchStatus != models.EntryUnmatched {
+			return false, nil
+		}
+
+		if err := repo.ParkEntry(ctx, entryID); err != nil {
+			return false, fmt.Errorf("park entry: %w", err)
+		}
+		if _, err := s.jobClient.InsertTx(ctx, tx, BookEntryArgs{EntryID: entryID}, nil); err != nil {
+			return false, fmt.Errorf("enqueue ledger booking: %w", err)
+		}
+
+		parked, err := repo.GetEntry(ctx, entryID)
+		if err != nil {
+			return false, err
+		}
+		if err := s.audit(ctx, tx, entry, audit.ActionPark, entry, parked); err != nil {
+			return false, err
+		}
+		return true, nil
+	})
+}
+
+func (s *Service) audit(ctx context.Context, tx pgx.Tx, entry *models.BankStatementEntry, action string, before, after any) error {
+	le, err := s.legalEntityRepo.GetByID(ctx, entry.LegalEntityID)
+	if err != nil {
+		return fmt.Errorf("get legal entity: %w", err)
+	}
+	var region models.ComplianceRegion
+	if le != nil {
+		region = models.ComplianceRegionForJurisdiction(le.Jurisdiction)
+	}
+
+	return s.trail.RecordTx(ctx, tx, audit.Entry{
+		Region:       region,
+		ResourceType: audit.ResourceStatementEntry,
+		ResourceID:   entry.ID.String(),
+		Action:       action,
+		Before:       before,
+		After:        after,
+	})
+}
+
+// GetEntry returns a statement entry with what it was matched to.
+func (s *Service) GetEntry(ctx context.Context, id uuid.UUID) (*EntryDetail, error) {
+	entry, err := s.repo.GetEntry(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get entry: %w", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+	matches, err := s.repo.ListMatches(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list matches: %w", err)
+	}
+	return &EntryDetail{BankStatementEntry: entry, Matches: matches}, nil
+}
+
+// CreateExpectedDeposit records a deposit a tenant is about to pay into
+// the FBO account of its legal entity, and matches it against the open
+// entries, parked ones included.
+func (s *Service) CreateExpectedDeposit(ctx context.Context, params models.CreateExpectedDepositParams) (*models.ExpectedDeposit, error) {
+	params.Reference = strings.TrimSpace(params.Reference)
+	if params.Reference == "" {
+		return nil, fmt.Errorf("%w: reference is required", ErrInvalidDeposit)
+	}
+	if !params.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidDeposit)
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, params.TenantID)
+	if err != nil {
+		return nil, fmt.Errorf("get tenant: %w", err)
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+	wallet, err := s.walletRepo.GetByTenantAndCurrency(ctx, tenant.ID, params.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("get wallet: %w", err)
+	}
+	if wallet == nil {
+		return nil, ErrWalletNotFound
+	}
+	params.LegalEntityID = tenant.LegalEntityID
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ExpectedDeposit, error) {
+		deposit, err := s.depositRepo.WithTx(tx).Create(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("create expected deposit: %w", err)
+		}
+		if deposit == nil {
+			return nil, ErrDuplicateDeposit
+		}
+
+		if _, err := s.jobClient.InsertTx(ctx, tx, RunArgs{}, nil); err != nil {
+			return nil, fmt.Errorf("enqueue matching: %w", err)
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &deposit.TenantID,
+			ResourceType: audit.ResourceExpectedDeposit,
+			ResourceID:   deposit.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        deposit,
+		}); err != nil {
+			return nil, err
+		}
+		return deposit, nil
+	})
+}
+
+// CancelExpectedDeposit cancels a pending expected deposit.
+func (s *Service) CancelExpectedDeposit(ctx context.Context, id uuid.UUID) (*models.ExpectedDeposit, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ExpectedDeposit, error) {
+		repo := s.depositRepo.WithTx(tx)
+		deposit, err := repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get expected deposit: %w", err)
+		}
+		if deposit == nil {
+			return nil, ErrDepositNotFound
+		}
+		if deposit.Status != models.ExpectedDepositPending {
+			return nil, ErrDepositNotPending
+		}
+
+		if err := repo.UpdateStatus(ctx, id, models.ExpectedDepositCancelled); err != nil {
+			return nil, fmt.Errorf("cancel expected deposit: %w", err)
+		}
+		cancelled, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			TenantID:     &deposit.TenantID,
+			ResourceType: audit.ResourceExpectedDeposit,
+			ResourceID:   id.String(),
+			Action:       audit.ActionChangeStatus,
+			Before:       deposit,
+			After:        cancelled,
+		}); err != nil {
+			return nil, err
+		}
+		return cancelled, nil
+	})
+}
+
+func toLine(e *models.BankStatementEntry) Line {
+	refs := []string{e.Reference}
+	if e.BankReference != nil {
+		refs = append(refs, *e.BankReference)
+	}
+	return Line{ID: e.ID, Amount: e.Amount.Abs(), Date: e.ValueDate, References: refs}
+}
+
+// transferItem makes a payout an item. Netted transfers settle with their
+// netting group, others with their batch, if any.
+func transferItem(t *models.MatchCandidateTransfer) Item {
+	it := Item{ID: t.ID, Amount: t.Amount, Date: t.SettledAt, Group: t.BatchID}
+	if t.NettingGroupID != nil {
+		it.Group = t.NettingGroupID
+	}
+	if t.RailReference != nil {
+		it.Reference = *t.RailReference
+	}
+	return it
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// queueQueryTimeout bounds the queries counting the queues on a scrape.
+const queueQueryTimeout = 5 * time.Second
+
+var (
+	poolConnections = prometheus.NewDesc(
+		"payment_rails_db_pool_connections",
+		"Connections of the PostgreSQL pools, by pool and state.",
+		[]string{"pool", "state"}, nil)
+	poolMaxConnections = prometheus.NewDesc(
+		"payment_rails_db_pool_max_connections",
+		"Maximum size of the PostgreSQL pools.",
+		[]string{"pool"}, nil)
+	poolAcquires = prometheus.NewDesc(
+		"payment_rails_db_pool_acquires_total",
+		"Connections acquired from the PostgreSQL pools.",
+		[]string{"pool"}, nil)
+	poolEmptyAcquires = prometheus.NewDesc(
+		"payment_rails_db_pool_empty_acquires_total",
+		"Acquires from the PostgreSQL pools that waited for a connection.",
+		[]string{"pool"}, nil)
+	poolCanceledAcquires = prometheus.NewDesc(
+		"payment_rails_db_pool_canceled_acquires_total",
+		"Acquires from the PostgreSQL pools cancelled while waiting.",
+		[]string{"pool"}, nil)
+	poolAcquireSeconds = prometheus.NewDesc(
+		"payment_rails_db_pool_acquire_seconds_total",
+		"Time spent acquiring connections from the PostgreSQL pools.",
+		[]string{"pool"}, nil)
+
+	jobsQueued = prometheus.NewDesc(
+		"river_jobs_total",
+		"Background jobs waiting or running, by queue and state.",
+		[]string{"queue", "state"}, nil)
+	webhooksQueued = prometheus.NewDesc(
+		"payment_rails_webhook_queue_depth",
+		"Webhook deliveries waiting for their next attempt.",
+		nil, nil)
+)
+
+// poolCollector reads the statistics of the connection pools of the
+// primary and regional databases.
+type poolCollector struct {
+	db *db.DB
+}
+
+// NewPoolCollector returns a collector of the statistics of the database's
+// connection pools, labelled "primary" or by region.
+func NewPoolCollector(database *db.DB) prometheus.Collector {
+	return &poolCollector{db: database}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConnections
+	ch <- poolMaxConnections
+	ch <- poolAcquires
+	ch <- poolEmptyAcquires
+	ch <- poolCanceledAcquires
+	ch <- poolAcquireSeconds
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	collectPool(ch, "primary", c.db.Pool())
+	for _, region := range c.db.Regions() {
+		collectPool(ch, string(region), c.db.PoolFor(c.db.InRegion(context.Background(), region)))
+	}
+}
+
+func collectPool(ch chan<- prometheus.Metric, name string, pool *pgxpool.Pool) {
+	s := pool.Stat()
+	ch <- prometheus.MustNewConstMetric(poolConnections, prometheus.GaugeValue, float64(s.AcquiredConns()), name, "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConnections, prometheus.GaugeValue, float64(s.IdleConns()), name, "idle")
+	ch <- prometheus.MustNewConstMetric(poolConnections, prometheus.GaugeValue, float64(s.ConstructingConns()), name, "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConnections, prometheus.GaugeValue, float64(s.MaxConns()), name)
+	ch <- prometheus.MustNewConstMetric(poolAcquires, prometheus.CounterValue, float64(s.AcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquires, prometheus.CounterValue, float64(s.EmptyAcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquires, prometheus.CounterValue, float64(s.CanceledAcquireCount()), name)
+	ch <- prometheus.MustNewConstMetric(poolAcquireSeconds, prometheus.CounterValue, s.AcquireDuration().Seconds(), name)
+}
+
+// queueCollector counts the background jobs and webhook deliveries waiting
+// in every database when scraped.
+type queueCollector struct {
+	db       *db.DB
+	jobs     *repository.JobRepository
+	webhooks *repository.WebhookDeliveryRepository
+}
+
+// NewQueueCollector returns a collector of the depth of the job and webhook
+// queues, summed over the primary and regional databases.
+func NewQueueCollector(database *db.DB, jobs *repository.JobRepository, webhooks *repository.WebhookDeliveryRepository) prometheus.Collector {
+	return &queueCollector{db: database, jobs: jobs, webhooks: webhooks}
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- jobsQueued
+	ch <- webhooksQueued
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueQueryTimeout)
+	defer cancel()
+
+	type queueState struct {
+		queue string
+		state models.JobState
+	}
+	jobs := make(map[queueState]int64)
+	var webhooks int64
+	for _, dbCtx := range c.db.Databases(ctx) {
+		counts, err := c.jobs.CountUnfinished(dbCtx)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(jobsQueued, err)
+			return
+		}
+		for _, jc := range counts {
+			jobs[queueState{jc.Queue, jc.State}] += jc.Count
+		}
+
+		pending, err := c.webhooks.CountPending(dbCtx)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(webhooksQueued, err)
+			return
+		}
+		webhooks += pending
+	}
+
+	for qs, n := range jobs {
+		ch <- prometheus.MustNewConstMetric(jobsQueued, prometheus.GaugeValue, float64(n), qs.queue, string(qs.state))
+	}
+	ch <- prometheus.MustNewConstMetric(webhooksQueued, prometheus.GaugeValue, float64(webhooks))
+}
@@ -0,0 +1,137 @@
+// Package metrics defines the Prometheus metrics of the service, named as in
+// docs/ARCHITECTURE.md, and serves them on the admin port.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registry holds the metrics of the service, and those of the Go runtime
+// and the process.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_rails_http_requests_total",
+		Help: "HTTP requests served, by route pattern, method and status code.",
+	}, []string{"endpoint", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "payment_rails_http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests, by route pattern and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint", "method"})
+
+	ledgerOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_rails_tigerbeetle_operations_total",
+		Help: "Accounts and transfers submitted to or looked up in TigerBeetle, by operation and result code.",
+	}, []string{"operation", "status"})
+
+	ledgerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "payment_rails_tigerbeetle_operation_duration_seconds",
+		Help:    "Time taken by TigerBeetle requests, by operation.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation"})
+
+	redisErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_rails_redis_errors_total",
+		Help: "Redis commands that failed, by command.",
+	}, []string{"command"})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_rails_webhook_deliveries_total",
+		Help: "Webhook delivery attempts, by resulting delivery status.",
+	}, []string{"status"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "river_job_duration_seconds",
+		Help:    "Time taken to run background jobs, by queue.",
+		Buckets: []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"queue"})
+
+	jobsFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "river_jobs_failed_total",
+		Help: "Background job runs that failed, by queue and kind of failure.",
+	}, []string{"queue", "error_type"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		ledgerOperations,
+		ledgerDuration,
+		redisErrors,
+		webhookDeliveries,
+		jobDuration,
+		jobsFailed,
+		transfers,
+		transferLatency,
+	)
+}
+
+// Register adds collectors reading state on demand, such as the connection
+// pools and queue depths, to the metrics served.
+func Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	// A failing collector, such as a queue count timing out, leaves out its
+	// metrics rather than failing the scrape
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError})
+}
+
+// ObserveHTTPRequest records a served request. endpoint is the route
+// pattern, so that the IDs in paths do not each get a series.
+func ObserveHTTPRequest(endpoint, method string, status int, d time.Duration) {
+	httpRequests.WithLabelValues(endpoint, method, strconv.Itoa(status)).Inc()
+	httpDuration.WithLabelValues(endpoint, method).Observe(d.Seconds())
+}
+
+// ObserveLedgerRequest records a request to TigerBeetle.
+func ObserveLedgerRequest(operation string, d time.Duration) {
+	ledgerDuration.WithLabelValues(operation).Observe(d.Seconds())
+}
+
+// AddLedgerResults counts n accounts or transfers of a TigerBeetle request
+// that ended with the given result code.
+func AddLedgerResults(operation, status string, n int) {
+	if n > 0 {
+		ledgerOperations.WithLabelValues(operation, status).Add(float64(n))
+	}
+}
+
+// RedisError counts a failed Redis command.
+func RedisError(command string) {
+	redisErrors.WithLabelValues(command).Inc()
+}
+
+// WebhookDelivered counts a webhook delivery attempt, by the status the
+// delivery ended up in: delivered, pending a retry, or dead.
+func WebhookDelivered(status string) {
+	webhookDeliveries.WithLabelValues(status).Inc()
+}
+
+// ObserveJob records a run of a background job. errorType is empty for a
+// run that succeeded.
+func ObserveJob(queue string, d time.Duration, errorType string) {
+	jobDuration.WithLabelValues(queue).Observe(d.Seconds())
+	if errorType != "" {
+		jobsFailed.WithLabelValues(queue, errorType).Inc()
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+func TestObserveTransferEvent(t *testing.T) {
+	id, err := uuid.NewV7()
+	require.NoError(t, err)
+	rail := models.Rail("SEPA_INSTANT")
+
+	payload, err := json.Marshal(map[string]any{
+		"transfer_id":   id,
+		"status":        models.TransferStatusCompleted,
+		"from_currency": "EUR",
+		"to_currency":   "IDR",
+		"from_amount":   "100.00",
+		"to_amount":     "1750000.00",
+		"rail":          rail,
+	})
+	require.NoError(t, err)
+
+	before := testutil.ToFloat64(transfers.WithLabelValues("EUR_IDR", "completed", "SEPA_INSTANT"))
+	err = ObserveTransferEvent(context.Background(), nil, &models.OutboxEvent{
+		EventType: string(models.WebhookEventTransferStatusChanged),
+		Payload:   payload,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, before+1, testutil.ToFloat64(transfers.WithLabelValues("EUR_IDR", "completed", "SEPA_INSTANT")))
+
+	// A transfer without a rail yet
+	ObserveTransfer("GBP", "GBP", nil, models.TransferStatusCreated, 0)
+	assert.Equal(t, float64(1), testutil.ToFloat64(transfers.WithLabelValues("GBP_GBP", "created", "none")))
+
+	err = ObserveTransferEvent(context.Background(), nil, &models.OutboxEvent{Payload: []byte("{")})
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	ObserveHTTPRequest("/api/v1/transfers/{id}", http.MethodGet, http.StatusOK, 20*time.Millisecond)
+	ObserveLedgerRequest("create_transfers", time.Millisecond)
+	AddLedgerResults("create_transfers", "TransferExceedsCredits", 2)
+	AddLedgerResults("create_transfers", "OK", 0)
+	RedisError("GET")
+	WebhookDelivered("dead")
+	ObserveJob("compliance", time.Second, "timeout")
+
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		`payment_rails_http_requests_total{endpoint="/api/v1/transfers/{id}",method="GET",status="200"} 1`,
+		`payment_rails_tigerbeetle_operations_total{operation="create_transfers",status="TransferExceedsCredits"} 2`,
+		`payment_rails_tigerbeetle_operation_duration_seconds_count{operation="create_transfers"} 1`,
+		`payment_rails_redis_errors_total{command="GET"} 1`,
+		`payment_rails_webhook_deliveries_total{status="dead"} 1`,
+		`river_jobs_failed_total{error_type="timeout",queue="compliance"} 1`,
+		"go_goroutines",
+	} {
+		assert.Contains(t, string(body), line)
+	}
+	assert.NotContains(t, string(body), `status="OK"`, "zero counts are not recorded")
+}
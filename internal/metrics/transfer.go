@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"kovra/internal/models"
+)
+
+var (
+	transfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "payment_rails_transfer_total",
+		Help: "Transfers that reached a status, by corridor, status and rail.",
+	}, []string{"corridor", "status", "rail"})
+
+	transferLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "payment_rails_transfer_latency_seconds",
+		Help:    "Time from the creation of transfers to their reaching a status, by corridor, rail and status.",
+		Buckets: []float64{.1, .5, 1, 5, 10, 30, 60, 300, 900, 3600, 4 * 3600, 24 * 3600},
+	}, []string{"corridor", "rail", "status"})
+)
+
+// transferStatusChanged holds the fields of a transfer.status_changed event
+// the transfer metrics are labelled with.
+type transferStatusChanged struct {
+	TransferID   uuid.UUID             `json:"transfer_id"`
+	Status       models.TransferStatus `json:"status"`
+	FromCurrency string                `json:"from_currency"`
+	ToCurrency   string                `json:"to_currency"`
+	Rail         *models.Rail          `json:"rail"`
+}
+
+// ObserveTransferEvent is an outbox handler counting the transfers reaching
+// each status, from their transfer.status_changed events. Counting committed
+// events rather than status updates leaves out transactions that rolled
+// back; a relay retrying an event counts it again.
+func ObserveTransferEvent(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error {
+	var data transferStatusChanged
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		return fmt.Errorf("decode %s: %w", event.EventType, err)
+	}
+	ObserveTransfer(data.FromCurrency, data.ToCurrency, data.Rail, data.Status, time.Since(transferCreatedAt(data.TransferID)))
+	return nil
+}
+
+// ObserveTransfer records a transfer reaching status after latency. Transfers
+// without a rail yet are labelled "none".
+func ObserveTransfer(from, to string, rail *models.Rail, status models.TransferStatus, latency time.Duration) {
+	corridor := from + "_" + to
+	railLabel := "none"
+	if rail != nil {
+		railLabel = string(*rail)
+	}
+	transfers.WithLabelValues(corridor, string(status), railLabel).Inc()
+	if status != models.TransferStatusCreated {
+		transferLatency.WithLabelValues(corridor, railLabel, string(status)).Observe(latency.Seconds())
+	}
+}
+
+// transferCreatedAt returns the creation time encoded in a transfer's
+// UUIDv7 ID.
+func transferCreatedAt(id uuid.UUID) time.Time {
+	sec, nsec := id.Time().UnixTime()
+	return time.Unix(sec, nsec)
+}
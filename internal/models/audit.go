@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntry is one row of the audit trail. Entries of a compliance region
+// form a hash chain: RowHash covers PrevHash and every other field.
+type AuditEntry struct {
+	ID               uuid.UUID
+	ComplianceRegion ComplianceRegion
+	Seq              int64
+	TenantID         *uuid.UUID
+	LegalEntityID    *uuid.UUID
+	ResourceType     string
+	ResourceID       string
+	Action           string
+	ActorType        string
+	ActorID          string
+	BeforeState      json.RawMessage
+	AfterState       json.RawMessage
+	IPAddress        *netip.Addr
+	RequestID        *string
+	CreatedAt        time.Time
+	PrevHash         []byte
+	RowHash          []byte
+}
+
+// AuditChainHead is the last entry of a region's audit chain.
+type AuditChainHead struct {
+	ComplianceRegion ComplianceRegion
+	Seq              int64
+	RowHash          []byte
+	UpdatedAt        time.Time
+}
+
+// AuditScope places a tenant's audit entries: its legal entity and the
+// compliance region of that entity's jurisdiction.
+type AuditScope struct {
+	LegalEntityID    uuid.UUID
+	ComplianceRegion ComplianceRegion
+}
+
+// AuditFilter contains filters for querying the audit trail.
+type AuditFilter struct {
+	ComplianceRegion *ComplianceRegion
+	TenantID         *uuid.UUID
+	ResourceType     *string
+	ResourceID       *string
+	ActorID          *string
+	RequestID        *string
+	From             *time.Time
+	To               *time.Time
+	Limit            int
+	Offset           int
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BankStatement is an imported statement of a legal entity's FBO or Nostro
+// account.
+type BankStatement struct {
+	ID                uuid.UUID
+	LegalEntityID     uuid.UUID
+	AccountKind       BankAccountKind
+	AccountIdentifier string
+	Currency          string
+	Format            string
+	ExternalID        string
+	OpeningBalance    *decimal.Decimal
+	ClosingBalance    *decimal.Decimal
+	FromDate          *time.Time
+	ToDate            time.Time
+	EntryCount        int
+	ImportedBy        string
+	CreatedAt         time.Time
+}
+
+// EntryMatchStatus is how far a statement entry is matched.
+type EntryMatchStatus string
+
+const (
+	EntryUnmatched EntryMatchStatus = "unmatched"
+	EntryMatched   EntryMatchStatus = "matched"
+	// EntrySuspense is an inbound credit parked in the suspense account
+	// until it is matched.
+	EntrySuspense EntryMatchStatus = "suspense"
+)
+
+// IsValid returns true if the match status is known.
+func (s EntryMatchStatus) IsValid() bool {
+	return s == EntryUnmatched || s == EntryMatched || s == EntrySuspense
+}
+
+// MatchRule is the rule that matched a statement entry.
+type MatchRule string
+
+const (
+	MatchRuleExact  MatchRule = "exact"
+	MatchRuleFuzzy  MatchRule = "fuzzy"
+	MatchRuleManual MatchRule = "manual"
+)
+
+// BankStatementEntry is a booked entry of a bank statement. Amount is
+// signed: credits are positive, debits negative.
+type BankStatementEntry struct {
+	ID            uuid.UUID
+	StatementID   uuid.UUID
+	LegalEntityID uuid.UUID
+	AccountKind   BankAccountKind
+	Amount        decimal.Decimal
+	Currency      string
+	ValueDate     time.Time
+	BookingDate   *time.Time
+	Reference     string
+	Counterparty  *string
+	BankReference *string
+	CreatedAt     time.Time
+	MatchStatus   EntryMatchStatus
+	// ParkedAt is when the entry was parked in the suspense account. It is
+	// kept once the entry is matched.
+	ParkedAt *time.Time
+}
+
+// IsCredit returns true if money came into the account.
+func (e *BankStatementEntry) IsCredit() bool {
+	return e.Amount.IsPositive()
+}
+
+// StatementMatch is a transfer or expected deposit a statement entry was
+// matched to. Netted and batched entries have one match per transfer.
+type StatementMatch struct {
+	ID                uuid.UUID
+	EntryID           uuid.UUID
+	TransferID        *uuid.UUID
+	ExpectedDepositID *uuid.UUID
+	Amount            decimal.Decimal
+	Rule              MatchRule
+	MatchedBy         string
+	CreatedAt         time.Time
+}
+
+// CreateStatementMatchParams contains parameters for recording a match.
+type CreateStatementMatchParams struct {
+	EntryID           uuid.UUID
+	TransferID        *uuid.UUID
+	ExpectedDepositID *uuid.UUID
+	Amount            decimal.Decimal
+	Rule              MatchRule
+	MatchedBy         string
+}
+
+// CreateBankStatementParams contains parameters for importing a statement.
+type CreateBankStatementParams struct {
+	LegalEntityID     uuid.UUID
+	AccountKind       BankAccountKind
+	AccountIdentifier string
+	Currency          string
+	Format            string
+	ExternalID        string
+	OpeningBalance    *decimal.Decimal
+	ClosingBalance    *decimal.Decimal
+	FromDate          *time.Time
+	ToDate            time.Time
+	ImportedBy        string
+	Entries           []CreateBankStatementEntryParams
+}
+
+// CreateBankStatementEntryParams contains parameters for a statement entry.
+type CreateBankStatementEntryParams struct {
+	Amount        decimal.Decimal
+	Currency      string
+	ValueDate     time.Time
+	BookingDate   *time.Time
+	Reference     string
+	Counterparty  *string
+	BankReference *string
+}
+
+// BankStatementFilter contains filters for listing statements.
+type BankStatementFilter struct {
+	LegalEntityID *uuid.UUID
+	AccountKind   *BankAccountKind
+	Limit         int
+	Offset        int
+}
+
+// StatementEntryFilter contains filters for listing entries waiting for a
+// match.
+type StatementEntryFilter struct {
+	MatchStatus   *EntryMatchStatus
+	LegalEntityID *uuid.UUID
+	Limit         int
+	Offset        int
+}
+
+// MatchCandidateTransfer is an unmatched payout a Nostro debit may settle.
+type MatchCandidateTransfer struct {
+	ID             uuid.UUID
+	BatchID        *uuid.UUID
+	NettingGroupID *uuid.UUID
+	Amount         decimal.Decimal
+	RailReference  *string
+	SettledAt      time.Time
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceStatus values of transfers.compliance_status.
+const (
+	ComplianceStatusPending  = "pending"
+	ComplianceStatusCleared  = "cleared"
+	ComplianceStatusReview   = "review"
+	ComplianceStatusRejected = "rejected"
+)
+
+// ScreeningType identifies the kind of compliance check.
+type ScreeningType string
+
+const (
+	ScreeningTypeSanctions    ScreeningType = "sanctions"
+	ScreeningTypeMonitoring   ScreeningType = "monitoring"
+	ScreeningTypeManualReview ScreeningType = "manual_review"
+)
+
+// ScreeningResult is the outcome of a compliance check.
+type ScreeningResult string
+
+const (
+	ScreeningResultClear ScreeningResult = "clear"
+	ScreeningResultHit   ScreeningResult = "hit"
+)
+
+// Results of manual review actions, logged with ScreeningTypeManualReview.
+const (
+	ScreeningResultCaseOpened        ScreeningResult = "case_opened"
+	ScreeningResultAssigned          ScreeningResult = "assigned"
+	ScreeningResultNoted             ScreeningResult = "noted"
+	ScreeningResultApprovalRequested ScreeningResult = "approval_requested"
+	ScreeningResultApproved          ScreeningResult = "approved"
+	ScreeningResultRejected          ScreeningResult = "rejected"
+	ScreeningResultEscalated         ScreeningResult = "escalated"
+	ScreeningResultSLABreached       ScreeningResult = "sla_breached"
+)
+
+// ComplianceLog records a screening run or a review action on a transfer.
+type ComplianceLog struct {
+	ID               uuid.UUID
+	ComplianceRegion ComplianceRegion
+	TransferID       uuid.UUID
+	TenantID         uuid.UUID
+	CaseID           *uuid.UUID
+	ScreeningType    ScreeningType
+	Result           ScreeningResult
+	RiskScore        *int
+	RawResponse      json.RawMessage
+	ScreenedBy       string
+	ScreenedAt       time.Time
+}
+
+// CreateComplianceLogParams contains parameters for recording a compliance log.
+type CreateComplianceLogParams struct {
+	ComplianceRegion ComplianceRegion
+	TransferID       uuid.UUID
+	TenantID         uuid.UUID
+	CaseID           *uuid.UUID
+	ScreeningType    ScreeningType
+	Result           ScreeningResult
+	RiskScore        *int
+	RawResponse      json.RawMessage
+	ScreenedBy       string
+}
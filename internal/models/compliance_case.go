@@ -0,0 +1,116 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CaseStatus represents the state of a compliance review case.
+type CaseStatus string
+
+const (
+	CaseStatusOpen            CaseStatus = "open"
+	CaseStatusEscalated       CaseStatus = "escalated"
+	CaseStatusPendingApproval CaseStatus = "pending_approval"
+	CaseStatusApproved        CaseStatus = "approved"
+	CaseStatusRejected        CaseStatus = "rejected"
+)
+
+// IsClosed returns true if the case has a final decision.
+func (s CaseStatus) IsClosed() bool {
+	return s == CaseStatusApproved || s == CaseStatusRejected
+}
+
+// CasePriority determines the SLA of a case.
+type CasePriority string
+
+const (
+	CasePriorityNormal CasePriority = "normal"
+	CasePriorityHigh   CasePriority = "high"
+)
+
+// CaseDecision is a reviewer's decision on a case.
+type CaseDecision string
+
+const (
+	CaseDecisionApprove  CaseDecision = "approve"
+	CaseDecisionReject   CaseDecision = "reject"
+	CaseDecisionEscalate CaseDecision = "escalate"
+)
+
+// IsValid returns true if the decision is known.
+func (d CaseDecision) IsValid() bool {
+	switch d {
+	case CaseDecisionApprove, CaseDecisionReject, CaseDecisionEscalate:
+		return true
+	}
+	return false
+}
+
+// CaseReason is why a case was opened.
+type CaseReason string
+
+const (
+	CaseReasonSanctionsHit    CaseReason = "sanctions_hit"
+	CaseReasonMonitoringAlert CaseReason = "monitoring_alert"
+)
+
+// ComplianceCase is a manual review of a transfer held by compliance.
+type ComplianceCase struct {
+	ID                     uuid.UUID
+	TransferID             uuid.UUID
+	TenantID               uuid.UUID
+	ComplianceRegion       ComplianceRegion
+	Reason                 CaseReason
+	Status                 CaseStatus
+	Priority               CasePriority
+	RiskScore              *int
+	AssignedTo             *string
+	Decision               *CaseDecision
+	DecisionReason         *string
+	DecidedBy              *string
+	RequiresSecondApproval bool
+	ApprovedBy             *string
+	DueAt                  time.Time
+	SLABreachedAt          *time.Time
+	ClosedAt               *time.Time
+	UpdatedAt              time.Time
+}
+
+// IsOverdue returns true if an open case is past its SLA.
+func (c *ComplianceCase) IsOverdue(now time.Time) bool {
+	return c.ClosedAt == nil && now.After(c.DueAt)
+}
+
+// CreateComplianceCaseParams contains parameters for opening a case.
+type CreateComplianceCaseParams struct {
+	TransferID             uuid.UUID
+	TenantID               uuid.UUID
+	ComplianceRegion       ComplianceRegion
+	Reason                 CaseReason
+	Priority               CasePriority
+	RiskScore              *int
+	RequiresSecondApproval bool
+	DueAt                  time.Time
+}
+
+// ComplianceCaseFilter contains filters for listing cases.
+type ComplianceCaseFilter struct {
+	Status     *CaseStatus
+	Open       *bool
+	TenantID   *uuid.UUID
+	AssignedTo *string
+	Overdue    *bool
+	Limit      int
+	Offset     int
+}
+
+// ComplianceCaseNote is a reviewer's note on a case.
+type ComplianceCaseNote struct {
+	ID        uuid.UUID
+	CaseID    uuid.UUID
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
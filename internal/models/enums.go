@@ -19,6 +19,16 @@ const (
 	TenantStatusClosed     TenantStatus = "closed"
 )
 
+// IsValid returns true if the status is a known tenant status.
+func (s TenantStatus) IsValid() bool {
+	switch s {
+	case TenantStatusPendingKYC, TenantStatusActive, TenantStatusSuspended, TenantStatusClosed:
+		return true
+	default:
+		return false
+	}
+}
+
 // TransferStatus represents the state machine status of a transfer.
 type TransferStatus string
 
@@ -32,6 +42,17 @@ const (
 	TransferStatusCancelled  TransferStatus = "cancelled"
 )
 
+// IsValid returns true if the status is a known transfer status.
+func (s TransferStatus) IsValid() bool {
+	switch s {
+	case TransferStatusCreated, TransferStatusValidating, TransferStatusRejected, TransferStatusProcessing,
+		TransferStatusCompleted, TransferStatusRolledBack, TransferStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
 // IsTerminal returns true if the status is a terminal state.
 func (s TransferStatus) IsTerminal() bool {
 	switch s {
@@ -55,6 +76,16 @@ const (
 	RailSWIFT       Rail = "SWIFT"
 )
 
+// IsValid returns true if the rail is a known payment rail.
+func (r Rail) IsValid() bool {
+	switch r {
+	case RailSEPAInstant, RailSEPASCT, RailFPS, RailCHAPS, RailBIFast, RailRTGS, RailSWIFT:
+		return true
+	default:
+		return false
+	}
+}
+
 // LicenseType represents the type of financial license.
 type LicenseType string
 
@@ -98,3 +129,26 @@ func DeriveComplianceRegion(fromCurrency, toCurrency string) ComplianceRegion {
 	}
 	return ComplianceRegionUnknown
 }
+
+// euJurisdictions are the EU member states (ISO 3166-1 alpha-2).
+var euJurisdictions = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "CY": true, "CZ": true, "DE": true, "DK": true,
+	"EE": true, "ES": true, "FI": true, "FR": true, "GR": true, "HR": true, "HU": true,
+	"IE": true, "IT": true, "LT": true, "LU": true, "LV": true, "MT": true, "NL": true,
+	"PL": true, "PT": true, "RO": true, "SE": true, "SI": true, "SK": true,
+}
+
+// ComplianceRegionForJurisdiction returns the compliance region of a legal
+// entity's jurisdiction.
+func ComplianceRegionForJurisdiction(jurisdiction string) ComplianceRegion {
+	switch {
+	case jurisdiction == "ID":
+		return ComplianceRegionID
+	case jurisdiction == "GB":
+		return ComplianceRegionUK
+	case euJurisdictions[jurisdiction]:
+		return ComplianceRegionEU
+	default:
+		return ComplianceRegionUnknown
+	}
+}
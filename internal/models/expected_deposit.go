@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ExpectedDepositStatus represents the state of an expected deposit.
+type ExpectedDepositStatus string
+
+const (
+	ExpectedDepositPending   ExpectedDepositStatus = "pending"
+	ExpectedDepositMatched   ExpectedDepositStatus = "matched"
+	ExpectedDepositCancelled ExpectedDepositStatus = "cancelled"
+)
+
+// IsValid returns true if the status is known.
+func (s ExpectedDepositStatus) IsValid() bool {
+	return s == ExpectedDepositPending || s == ExpectedDepositMatched || s == ExpectedDepositCancelled
+}
+
+// ExpectedDeposit is a deposit a tenant announced before paying into the
+// FBO account of its legal entity. Once a statement credit is matched to it,
+// the amount is credited to the tenant's wallet.
+type ExpectedDeposit struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	LegalEntityID uuid.UUID
+	Currency      string
+	Amount        decimal.Decimal
+	// Reference is what the tenant puts on the payment.
+	Reference    string
+	ExpectedDate time.Time
+	Status       ExpectedDepositStatus
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// CreditedAt is when the matched deposit was credited to the wallet.
+	CreditedAt *time.Time
+}
+
+// CreateExpectedDepositParams contains parameters for announcing a deposit.
+type CreateExpectedDepositParams struct {
+	TenantID      uuid.UUID
+	LegalEntityID uuid.UUID
+	Currency      string
+	Amount        decimal.Decimal
+	Reference     string
+	ExpectedDate  time.Time
+	CreatedBy     string
+}
+
+// ExpectedDepositFilter contains filters for listing expected deposits.
+type ExpectedDepositFilter struct {
+	TenantID *uuid.UUID
+	Status   *ExpectedDepositStatus
+	Limit    int
+	Offset   int
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FXQuoteMargin is the margin captured on the quote of an FX transfer,
+// against the mid rate implied by the USD reference rates at creation.
+type FXQuoteMargin struct {
+	TransferID   uuid.UUID
+	FromCurrency string
+	ToCurrency   string
+	FromAmount   decimal.Decimal
+	ToAmount     decimal.Decimal
+	FXRate       decimal.Decimal
+	MidRate      decimal.Decimal
+	FromUSDRate  decimal.Decimal
+	ToUSDRate    decimal.Decimal
+	// Margin is in the destination currency.
+	Margin    decimal.Decimal
+	MarginUSD decimal.Decimal
+	CreatedAt time.Time
+}
+
+// FXCostBasis is the USD cost of the FX_SETTLEMENT position in a currency.
+type FXCostBasis struct {
+	Currency string
+	CostUSD  decimal.Decimal
+}
+
+// FXPosition is the net FX_SETTLEMENT position in one currency. It is kept
+// in the positions of a snapshot.
+type FXPosition struct {
+	Currency string `json:"currency"`
+	// Position is long if positive: the account's credits less its debits.
+	Position decimal.Decimal `json:"position"`
+	CostUSD  decimal.Decimal `json:"cost_usd"`
+	// USDRate and the valuations are nil if the currency has no reference
+	// rate; such positions are left out of the totals.
+	USDRate          *decimal.Decimal `json:"usd_rate"`
+	ValueUSD         *decimal.Decimal `json:"value_usd"`
+	ValueEUR         *decimal.Decimal `json:"value_eur"`
+	UnrealisedPnLUSD *decimal.Decimal `json:"unrealised_pnl_usd"`
+}
+
+// FXExposure is the FX_SETTLEMENT exposure across currencies. P&L is
+// cumulative: realised is the margin captured on every posted quote,
+// unrealised the revaluation of the open positions since.
+type FXExposure struct {
+	// ID is set once the exposure is stored as a snapshot.
+	ID               *uuid.UUID
+	TakenAt          time.Time
+	EURUSDRate       decimal.Decimal
+	TotalValueUSD    decimal.Decimal
+	TotalValueEUR    decimal.Decimal
+	RealisedPnLUSD   decimal.Decimal
+	UnrealisedPnLUSD decimal.Decimal
+	Positions        []FXPosition
+}
+
+// FXExposureFilter contains filters for listing exposure snapshots.
+type FXExposureFilter struct {
+	From   *time.Time
+	To     *time.Time
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobState represents the execution state of a background job.
+type JobState string
+
+const (
+	JobStateAvailable JobState = "available"
+	JobStateRunning   JobState = "running"
+	JobStateCompleted JobState = "completed"
+	JobStateDiscarded JobState = "discarded"
+)
+
+// Job represents a background job row.
+type Job struct {
+	ID          uuid.UUID
+	Kind        string
+	Queue       string
+	Args        json.RawMessage
+	Priority    int
+	State       JobState
+	Attempt     int
+	MaxAttempts int
+	ScheduledAt time.Time
+	AttemptedAt *time.Time
+	LockedUntil *time.Time
+	FinalizedAt *time.Time
+	LastError   *string
+	UniqueKey   *string
+	CreatedAt   time.Time
+	// TraceContext is the encoded trace context of the operation that
+	// enqueued the job, if it was traced.
+	TraceContext []byte
+}
+
+// JobCount is the number of jobs of a queue in a state.
+type JobCount struct {
+	Queue string
+	State JobState
+	Count int64
+}
+
+// InsertJobParams contains parameters for enqueuing a job.
+type InsertJobParams struct {
+	Kind        string
+	Queue       string
+	Args        json.RawMessage
+	Priority    int
+	MaxAttempts int
+	ScheduledAt time.Time
+	UniqueKey   *string
+}
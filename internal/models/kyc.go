@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// KYCSubmissionStatus represents the review state of a KYC submission.
+type KYCSubmissionStatus string
+
+const (
+	KYCSubmissionStatusPending  KYCSubmissionStatus = "pending"
+	KYCSubmissionStatusApproved KYCSubmissionStatus = "approved"
+	KYCSubmissionStatusRejected KYCSubmissionStatus = "rejected"
+)
+
+// KYCDecision is a reviewer's decision on a KYC submission.
+type KYCDecision string
+
+const (
+	KYCDecisionApprove KYCDecision = "approve"
+	KYCDecisionReject  KYCDecision = "reject"
+)
+
+// IsValid returns true if the decision is known.
+func (d KYCDecision) IsValid() bool {
+	return d == KYCDecisionApprove || d == KYCDecisionReject
+}
+
+// KYCDocument is the metadata of a document submitted for KYC. The file
+// itself is kept in document storage and referenced by Reference.
+type KYCDocument struct {
+	Type          string     `json:"type"`
+	Reference     string     `json:"reference"`
+	SHA256        string     `json:"sha256,omitempty"`
+	IssuedCountry string     `json:"issued_country,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+}
+
+// KYCSubmission is a set of documents submitted to raise a tenant's KYC level.
+type KYCSubmission struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	RequestedLevel KYCLevel
+	Documents      []KYCDocument
+	SubmittedBy    string
+	Status         KYCSubmissionStatus
+	ReviewedBy     *string
+	ReviewReason   *string
+	ReviewedAt     *time.Time
+	SubmittedAt    time.Time
+}
+
+// CreateKYCSubmissionParams contains parameters for submitting KYC documents.
+type CreateKYCSubmissionParams struct {
+	TenantID       uuid.UUID
+	RequestedLevel KYCLevel
+	Documents      []KYCDocument
+	SubmittedBy    string
+}
+
+// TenantStatusChange records a tenant lifecycle transition.
+type TenantStatusChange struct {
+	ID         uuid.UUID
+	TenantID   uuid.UUID
+	FromStatus TenantStatus
+	ToStatus   TenantStatus
+	Reason     string
+	ChangedBy  string
+	CreatedAt  time.Time
+}
+
+// CreateTenantStatusChangeParams contains parameters for recording a transition.
+type CreateTenantStatusChangeParams struct {
+	TenantID   uuid.UUID
+	FromStatus TenantStatus
+	ToStatus   TenantStatus
+	Reason     string
+	ChangedBy  string
+}
+
+// KYCTierLimit holds the transaction caps of a KYC level in USD. Nil means
+// the tier adds no cap and only the tenant's limit policy applies.
+type KYCTierLimit struct {
+	KYCLevel            KYCLevel
+	PerTransferLimitUSD *decimal.Decimal
+	DailyLimitUSD       *decimal.Decimal
+	MonthlyLimitUSD     *decimal.Decimal
+	UpdatedAt           time.Time
+}
+
+// LimitPolicy holds a tenant's volume and rate limits in USD.
+type LimitPolicy struct {
+	ID                  uuid.UUID
+	TenantID            uuid.UUID
+	DailyLimitUSD       decimal.Decimal
+	MonthlyLimitUSD     decimal.Decimal
+	PerTransferLimitUSD decimal.Decimal
+	RateLimitRPM        int
+	RateLimitBurst      int
+	MaxBatchSize        int
+	MaxBatchAmountUSD   decimal.Decimal
+	EffectiveFrom       time.Time
+	UpdatedAt           time.Time
+}
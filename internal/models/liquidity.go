@@ -0,0 +1,153 @@
+package models
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RegionalSettlement holds the liquidity limits of a legal entity's
+// pre-funded Nostro account in one currency.
+type RegionalSettlement struct {
+	ID            uuid.UUID
+	LegalEntityID uuid.UUID
+	Currency      string
+	// TBAccountID is the REGIONAL_SETTLEMENT account of the currency.
+	TBAccountID *big.Int
+	// CachedBalance is the ledger balance as of CachedAt, the last check.
+	CachedBalance decimal.Decimal
+	CachedAt      time.Time
+	// MinBalance is never to be breached: payouts that would are held.
+	MinBalance decimal.Decimal
+	// TargetBalance is what top-ups bring the account back to.
+	TargetBalance decimal.Decimal
+	// AlertThreshold raises an alert when the projected balance falls below it.
+	AlertThreshold decimal.Decimal
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// UpsertRegionalSettlementParams contains parameters for configuring the
+// limits of a settlement account.
+type UpsertRegionalSettlementParams struct {
+	LegalEntityID  uuid.UUID
+	Currency       string
+	TBAccountID    *big.Int
+	MinBalance     decimal.Decimal
+	TargetBalance  decimal.Decimal
+	AlertThreshold decimal.Decimal
+}
+
+// LiquidityPosition is a settlement account's balance against its queued
+// payouts.
+type LiquidityPosition struct {
+	Settlement *RegionalSettlement
+	Balance    decimal.Decimal
+	// Admitted are processing payouts not yet posted to the ledger.
+	Admitted decimal.Decimal
+	// Queued are all payouts not yet posted, including those still screening.
+	Queued decimal.Decimal
+	// Projected is the balance once every queued payout is paid.
+	Projected decimal.Decimal
+}
+
+// LiquidityAlertLevel represents how far a projected balance has fallen.
+type LiquidityAlertLevel string
+
+const (
+	// LiquidityAlertLow is below the alert threshold.
+	LiquidityAlertLow LiquidityAlertLevel = "low"
+	// LiquidityAlertCritical is below the minimum balance.
+	LiquidityAlertCritical LiquidityAlertLevel = "critical"
+)
+
+// LiquidityAlertStatus represents the state of a liquidity alert.
+type LiquidityAlertStatus string
+
+const (
+	LiquidityAlertOpen     LiquidityAlertStatus = "open"
+	LiquidityAlertResolved LiquidityAlertStatus = "resolved"
+)
+
+// IsValid returns true if the status is known.
+func (s LiquidityAlertStatus) IsValid() bool {
+	return s == LiquidityAlertOpen || s == LiquidityAlertResolved
+}
+
+// LiquidityAlert is raised when a settlement account is projected to fall
+// below its alert threshold. It is resolved once the projection recovers.
+type LiquidityAlert struct {
+	ID               uuid.UUID
+	SettlementID     uuid.UUID
+	Level            LiquidityAlertLevel
+	Balance          decimal.Decimal
+	QueuedOutflow    decimal.Decimal
+	ProjectedBalance decimal.Decimal
+	Status           LiquidityAlertStatus
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	ResolvedAt       *time.Time
+}
+
+// LiquidityTopUpStatus represents the state of a top-up instruction.
+type LiquidityTopUpStatus string
+
+const (
+	LiquidityTopUpPending   LiquidityTopUpStatus = "pending"
+	LiquidityTopUpCompleted LiquidityTopUpStatus = "completed"
+	LiquidityTopUpCancelled LiquidityTopUpStatus = "cancelled"
+)
+
+// IsValid returns true if the status is known.
+func (s LiquidityTopUpStatus) IsValid() bool {
+	return s == LiquidityTopUpPending || s == LiquidityTopUpCompleted || s == LiquidityTopUpCancelled
+}
+
+// LiquidityTopUp instructs treasury to pre-fund a settlement account back
+// to its target balance.
+type LiquidityTopUp struct {
+	ID               uuid.UUID
+	SettlementID     uuid.UUID
+	Amount           decimal.Decimal
+	ProjectedBalance decimal.Decimal
+	Status           LiquidityTopUpStatus
+	DecidedBy        *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// LiquidityHoldStatus represents the state of a held payout.
+type LiquidityHoldStatus string
+
+const (
+	LiquidityHoldHeld      LiquidityHoldStatus = "held"
+	LiquidityHoldReleased  LiquidityHoldStatus = "released"
+	LiquidityHoldCancelled LiquidityHoldStatus = "cancelled"
+)
+
+// IsValid returns true if the status is known.
+func (s LiquidityHoldStatus) IsValid() bool {
+	return s == LiquidityHoldHeld || s == LiquidityHoldReleased || s == LiquidityHoldCancelled
+}
+
+// LiquidityHold is a payout held because paying it would take a settlement
+// account below its minimum balance.
+type LiquidityHold struct {
+	ID           uuid.UUID
+	SettlementID uuid.UUID
+	TransferID   uuid.UUID
+	Amount       decimal.Decimal
+	Status       LiquidityHoldStatus
+	CreatedAt    time.Time
+	ReleasedAt   *time.Time
+}
+
+// LiquidityFilter contains filters for listing liquidity alerts, top-ups
+// and holds. Status is one of the respective statuses.
+type LiquidityFilter struct {
+	Status *string
+	Limit  int
+	Offset int
+}
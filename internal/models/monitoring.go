@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonitoringAlert is a transaction monitoring rule matched by a transfer.
+type MonitoringAlert struct {
+	ID               uuid.UUID
+	TransferID       uuid.UUID
+	TenantID         uuid.UUID
+	ComplianceRegion ComplianceRegion
+	RuleID           string
+	RuleType         string
+	Score            int
+	Details          json.RawMessage
+	CreatedAt        time.Time
+}
+
+// CreateMonitoringAlertParams contains parameters for recording an alert.
+type CreateMonitoringAlertParams struct {
+	TransferID       uuid.UUID
+	TenantID         uuid.UUID
+	ComplianceRegion ComplianceRegion
+	RuleID           string
+	RuleType         string
+	Score            int
+	Details          json.RawMessage
+}
+
+// MonitoringAlertFilter contains filters for listing alerts.
+type MonitoringAlertFilter struct {
+	TenantID         *uuid.UUID
+	TransferID       *uuid.UUID
+	RuleType         *string
+	ComplianceRegion *ComplianceRegion
+	Limit            int
+	Offset           int
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operator is a member of staff calling the API with their own key. A
+// regional operator sees only the transfers of their compliance region.
+type Operator struct {
+	ID               uuid.UUID
+	Name             string
+	APIKeyHash       string
+	ComplianceRegion *ComplianceRegion
+	CreatedAt        time.Time
+}
+
+// CreateOperatorParams contains parameters for creating an operator.
+type CreateOperatorParams struct {
+	Name             string
+	APIKeyHash       string
+	ComplianceRegion *ComplianceRegion
+}
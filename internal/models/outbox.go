@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AggregateType identifies the kind of entity an outbox event belongs to.
+type AggregateType string
+
+const (
+	AggregateTransfer AggregateType = "transfer"
+	AggregateWallet   AggregateType = "wallet"
+	AggregateBatch    AggregateType = "batch"
+)
+
+// OutboxEvent represents a domain event recorded in the transactional outbox.
+type OutboxEvent struct {
+	ID            uuid.UUID
+	Seq           int64
+	AggregateType AggregateType
+	AggregateID   uuid.UUID
+	TenantID      *uuid.UUID
+	EventType     string
+	Payload       json.RawMessage
+	Attempts      int
+	AvailableAt   time.Time
+	LastError     *string
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+	// TraceContext is the encoded trace context of the transaction that
+	// appended the event, if it was traced.
+	TraceContext []byte
+}
+
+// CreateOutboxEventParams contains parameters for appending an outbox event.
+type CreateOutboxEventParams struct {
+	AggregateType AggregateType
+	AggregateID   uuid.UUID
+	TenantID      *uuid.UUID
+	EventType     string
+	Payload       json.RawMessage
+}
+
+// OutboxStats summarizes unpublished outbox events.
+type OutboxStats struct {
+	Pending         int64
+	Failing         int64
+	OldestPendingAt *time.Time
+}
@@ -0,0 +1,18 @@
+package models
+
+import "github.com/google/uuid"
+
+// Page is one page of a list ordered by ID, newest first. IDs are UUIDv7,
+// so the order is the order of creation and is stable as rows are added.
+type Page[T any] struct {
+	Items []T
+	// Next is the ID the following page starts after; nil on the last page.
+	Next *uuid.UUID
+}
+
+// PageFilter selects a page of a list.
+type PageFilter struct {
+	// After is the cursor: the page starts after this ID.
+	After *uuid.UUID
+	Limit int
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RailCallbackStatus is the payout outcome a rail reports in a callback.
+type RailCallbackStatus string
+
+const (
+	// RailCallbackAccepted means the rail took the payout and assigned it a
+	// reference.
+	RailCallbackAccepted RailCallbackStatus = "accepted"
+	// RailCallbackSettled means the payout reached the beneficiary bank.
+	RailCallbackSettled RailCallbackStatus = "settled"
+	// RailCallbackRejected means the rail refused the payout before
+	// settling it.
+	RailCallbackRejected RailCallbackStatus = "rejected"
+	// RailCallbackReturned means a settled payout came back, e.g. a SEPA
+	// R-transaction.
+	RailCallbackReturned RailCallbackStatus = "returned"
+)
+
+// IsValid returns true if the status is a known callback status.
+func (s RailCallbackStatus) IsValid() bool {
+	switch s {
+	case RailCallbackAccepted, RailCallbackSettled, RailCallbackRejected, RailCallbackReturned:
+		return true
+	default:
+		return false
+	}
+}
+
+// RailCallbackOutcome records what a callback did to its transfer.
+type RailCallbackOutcome string
+
+const (
+	// RailCallbackApplied moved the transfer on.
+	RailCallbackApplied RailCallbackOutcome = "applied"
+	// RailCallbackIgnored arrived after the transfer had moved past it.
+	RailCallbackIgnored RailCallbackOutcome = "ignored"
+)
+
+// RailCallback is a status callback received from a payment rail.
+type RailCallback struct {
+	ID            uuid.UUID
+	Rail          Rail
+	EventID       string
+	TransferID    uuid.UUID
+	RailReference *string
+	Status        RailCallbackStatus
+	ReturnCode    *string
+	Reason        *string
+	Outcome       RailCallbackOutcome
+	Payload       json.RawMessage
+	OccurredAt    *time.Time
+	ReceivedAt    time.Time
+}
+
+// CreateRailCallbackParams contains parameters for recording a rail
+// callback.
+type CreateRailCallbackParams struct {
+	Rail          Rail
+	EventID       string
+	TransferID    uuid.UUID
+	RailReference *string
+	Status        RailCallbackStatus
+	ReturnCode    *string
+	Reason        *string
+	Outcome       RailCallbackOutcome
+	Payload       json.RawMessage
+	OccurredAt    *time.Time
+}
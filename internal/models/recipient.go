@@ -0,0 +1,30 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Recipient represents the beneficiary of a tenant's transfers.
+type Recipient struct {
+	ID            uuid.UUID
+	TenantID      uuid.UUID
+	Name          string
+	Country       string
+	AccountNumber *string
+	BankCode      *string
+	Metadata      json.RawMessage
+	UpdatedAt     time.Time
+}
+
+// CreateRecipientParams contains parameters for creating a new recipient.
+type CreateRecipientParams struct {
+	TenantID      uuid.UUID
+	Name          string
+	Country       string
+	AccountNumber *string
+	BankCode      *string
+	Metadata      json.RawMessage
+}
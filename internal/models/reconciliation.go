@@ -0,0 +1,126 @@
+package models
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// BankAccountKind is which of a legal entity's bank accounts a balance is for.
+type BankAccountKind string
+
+const (
+	// BankAccountFBO holds pooled client funds; it backs the tenant wallets.
+	BankAccountFBO BankAccountKind = "fbo"
+	// BankAccountNostro is the pre-funded settlement account; it backs the
+	// REGIONAL_SETTLEMENT ledger accounts.
+	BankAccountNostro BankAccountKind = "nostro"
+)
+
+// IsValid returns true if the account kind is known.
+func (k BankAccountKind) IsValid() bool {
+	return k == BankAccountFBO || k == BankAccountNostro
+}
+
+// BankBalance is the closing balance of a bank account on a statement date.
+type BankBalance struct {
+	ID            uuid.UUID
+	LegalEntityID uuid.UUID
+	AccountKind   BankAccountKind
+	Currency      string
+	Balance       decimal.Decimal
+	AsOf          time.Time
+	Source        string
+	RecordedBy    string
+	CreatedAt     time.Time
+}
+
+// CreateBankBalanceParams contains parameters for recording a bank balance.
+type CreateBankBalanceParams struct {
+	LegalEntityID uuid.UUID
+	AccountKind   BankAccountKind
+	Currency      string
+	Balance       decimal.Decimal
+	AsOf          time.Time
+	Source        string
+	RecordedBy    string
+}
+
+// ReconciliationStatus represents the review state of a reconciliation report.
+type ReconciliationStatus string
+
+const (
+	ReconciliationStatusPendingReview ReconciliationStatus = "pending_review"
+	ReconciliationStatusSignedOff     ReconciliationStatus = "signed_off"
+)
+
+// DiscrepancyKind is the check a reconciliation discrepancy failed.
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyFBOMismatch: the tenant wallets do not sum to the FBO balance.
+	DiscrepancyFBOMismatch DiscrepancyKind = "fbo_mismatch"
+	// DiscrepancyNostroMismatch: REGIONAL_SETTLEMENT does not match the Nostro balance.
+	DiscrepancyNostroMismatch DiscrepancyKind = "nostro_mismatch"
+	// DiscrepancyMissingBankBalance: the ledger holds funds but no bank balance was recorded.
+	DiscrepancyMissingBankBalance DiscrepancyKind = "missing_bank_balance"
+	// DiscrepancyMissingLedgerAccount: a wallet's ledger account does not exist.
+	DiscrepancyMissingLedgerAccount DiscrepancyKind = "missing_ledger_account"
+	// DiscrepancyMissingLedgerTransfer: a transfer references a ledger transfer that does not exist.
+	DiscrepancyMissingLedgerTransfer DiscrepancyKind = "missing_ledger_transfer"
+)
+
+// ReconciliationReport is the result of one reconciliation run.
+type ReconciliationReport struct {
+	ID               uuid.UUID
+	BusinessDate     time.Time
+	Status           ReconciliationStatus
+	BalancesChecked  int
+	TransfersChecked int
+	DiscrepancyCount int
+	SignedOffBy      *string
+	SignOffNote      *string
+	SignedOffAt      *time.Time
+	CreatedAt        time.Time
+}
+
+// ReconciliationDiscrepancy is one difference found by a reconciliation run.
+// Amounts are in major units; Difference is LedgerAmount - BankAmount.
+type ReconciliationDiscrepancy struct {
+	ID              uuid.UUID
+	ReportID        uuid.UUID
+	Kind            DiscrepancyKind
+	LegalEntityID   *uuid.UUID
+	Currency        *string
+	TransferID      *uuid.UUID
+	LedgerAmount    *decimal.Decimal
+	BankAmount      *decimal.Decimal
+	Difference      *decimal.Decimal
+	BankBalanceAsOf *time.Time
+	Detail          string
+	CreatedAt       time.Time
+}
+
+// CreateReconciliationReportParams contains a finished reconciliation run.
+type CreateReconciliationReportParams struct {
+	BusinessDate     time.Time
+	BalancesChecked  int
+	TransfersChecked int
+	Discrepancies    []ReconciliationDiscrepancy
+}
+
+// WalletLedgerAccount is a wallet's ledger account and the legal entity
+// holding its funds.
+type WalletLedgerAccount struct {
+	TBAccountID   *big.Int
+	Currency      string
+	LegalEntityID uuid.UUID
+}
+
+// TransferLedgerIDs are the ledger transfers recorded for a transfer.
+type TransferLedgerIDs struct {
+	TransferID    uuid.UUID
+	TBTransferIDs []*big.Int
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RefundStatus represents the state of a refund.
+type RefundStatus string
+
+const (
+	// RefundPending is recorded but not yet posted to the ledger.
+	RefundPending RefundStatus = "pending"
+	// RefundPosted has been credited back to the tenant's wallet.
+	RefundPosted RefundStatus = "posted"
+)
+
+// Refund returns part or all of a completed transfer to the tenant's
+// wallet. Amount is the refunded part of the payout and FeeAmount the
+// reversed part of the fee, both in the source currency; DestAmount is the
+// refunded payout in the destination currency at the rate of the transfer.
+type Refund struct {
+	ID         uuid.UUID
+	TransferID uuid.UUID
+	TenantID   uuid.UUID
+	// Seq is the position of the refund among the refunds of the transfer,
+	// from 1.
+	Seq            int
+	Currency       string
+	Amount         decimal.Decimal
+	FeeAmount      decimal.Decimal
+	DestCurrency   string
+	DestAmount     decimal.Decimal
+	Reason         string
+	ReturnCode     *string
+	IdempotencyKey *string
+	Status         RefundStatus
+	CreatedAt      time.Time
+	PostedAt       *time.Time
+}
+
+// Total returns the amount credited back to the wallet.
+func (r *Refund) Total() decimal.Decimal {
+	return r.Amount.Add(r.FeeAmount)
+}
+
+// CreateRefundParams contains parameters for recording a refund.
+type CreateRefundParams struct {
+	TransferID     uuid.UUID
+	TenantID       uuid.UUID
+	Seq            int
+	Currency       string
+	Amount         decimal.Decimal
+	FeeAmount      decimal.Decimal
+	DestCurrency   string
+	DestAmount     decimal.Decimal
+	Reason         string
+	ReturnCode     *string
+	IdempotencyKey *string
+}
+
+// RefundTotals is how much of a transfer has been refunded so far.
+type RefundTotals struct {
+	Refunds   int
+	Amount    decimal.Decimal
+	FeeAmount decimal.Decimal
+}
@@ -22,7 +22,7 @@ type Tenant struct {
 	NettingWindowMinutes int
 	APIKeyHash           *string
 	WebhookURL           *string
-	WebhookSecretHash    *string
+	WebhookSigningKey    *string
 	Metadata             json.RawMessage
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
@@ -38,6 +38,24 @@ func (t *Tenant) CanTransact() bool {
 	return t.TenantStatus == TenantStatusActive && t.KYCLevel != KYCLevelBasic
 }
 
+// tenantTransitions lists the allowed tenant lifecycle transitions.
+var tenantTransitions = map[TenantStatus][]TenantStatus{
+	TenantStatusPendingKYC: {TenantStatusActive, TenantStatusClosed},
+	TenantStatusActive:     {TenantStatusSuspended, TenantStatusClosed},
+	TenantStatusSuspended:  {TenantStatusActive, TenantStatusClosed},
+}
+
+// CanTransitionTo returns true if a tenant may move from s to next.
+// Closed is final.
+func (s TenantStatus) CanTransitionTo(next TenantStatus) bool {
+	for _, allowed := range tenantTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateTenantParams contains parameters for creating a new tenant.
 type CreateTenantParams struct {
 	DisplayName    string
@@ -60,3 +78,10 @@ type UpdateTenantParams struct {
 	WebhookURL           *string
 	Metadata             json.RawMessage
 }
+
+// TenantFilter contains filter parameters for querying tenants.
+type TenantFilter struct {
+	PageFilter
+	Status  *TenantStatus
+	Country *string
+}
@@ -28,6 +28,7 @@ type Transfer struct {
 	FailureReason        *string
 	Rail                 *Rail
 	RailReference        *string
+	RailAcknowledgedAt   *time.Time
 	NettingGroupID       *uuid.UUID
 	IsNetted             bool
 	TBTransferIDs        []*big.Int
@@ -44,11 +45,32 @@ func (t *Transfer) IsFXTransfer() bool {
 	return t.FromCurrency != t.ToCurrency
 }
 
+// CreatedAt returns the creation time encoded in the transfer's UUIDv7 ID.
+func (t *Transfer) CreatedAt() time.Time {
+	sec, nsec := t.ID.Time().UnixTime()
+	return time.Unix(sec, nsec).UTC()
+}
+
 // IsComplete returns true if the transfer has completed.
 func (t *Transfer) IsComplete() bool {
 	return t.Status == TransferStatusCompleted
 }
 
+// IsCancellable returns true if the transfer can still be cancelled: it has
+// not been screened through, or it is being paid out but the rail has not
+// acknowledged it yet. A rail may acknowledge a payout without assigning it
+// a reference, so the acknowledgement is recorded on its own.
+func (t *Transfer) IsCancellable() bool {
+	switch t.Status {
+	case TransferStatusCreated, TransferStatusValidating:
+		return true
+	case TransferStatusProcessing:
+		return t.RailAcknowledgedAt == nil
+	default:
+		return false
+	}
+}
+
 // IsFailed returns true if the transfer has failed.
 func (t *Transfer) IsFailed() bool {
 	return t.Status == TransferStatusRejected ||
@@ -74,13 +96,31 @@ type CreateTransferParams struct {
 }
 
 // TransferFilter contains filter parameters for querying transfers.
+// Amount bounds apply to the source amount; creation time bounds are
+// inclusive.
 type TransferFilter struct {
+	PageFilter
 	Status           *TransferStatus
 	FromCurrency     *string
 	ToCurrency       *string
 	ComplianceRegion *ComplianceRegion
+	ComplianceStatus *string
+	Rail             *Rail
+	RecipientID      *uuid.UUID
+	BatchID          *uuid.UUID
+	MinAmount        *decimal.Decimal
+	MaxAmount        *decimal.Decimal
+	CreatedFrom      *time.Time
+	CreatedTo        *time.Time
 	UpdatedAfter     *time.Time
 	UpdatedBefore    *time.Time
-	Limit            int
-	Offset           int
+}
+
+// TransferActivity is the part of a transfer used by transaction monitoring.
+type TransferActivity struct {
+	ID          uuid.UUID
+	RecipientID *uuid.UUID
+	Currency    string
+	Amount      decimal.Decimal
+	CreatedAt   time.Time
 }
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TreasuryMovementKind represents what a treasury movement books.
+type TreasuryMovementKind string
+
+const (
+	// TreasuryMovementRebalance moves funds between system accounts.
+	TreasuryMovementRebalance TreasuryMovementKind = "rebalance"
+	// TreasuryMovementFXDeal books an external FX deal covering
+	// FX_SETTLEMENT positions.
+	TreasuryMovementFXDeal TreasuryMovementKind = "fx_deal"
+)
+
+// IsValid returns true if the kind is known.
+func (k TreasuryMovementKind) IsValid() bool {
+	return k == TreasuryMovementRebalance || k == TreasuryMovementFXDeal
+}
+
+// TreasuryMovementStatus represents the state of a treasury movement.
+type TreasuryMovementStatus string
+
+const (
+	TreasuryMovementPending  TreasuryMovementStatus = "pending"
+	TreasuryMovementApproved TreasuryMovementStatus = "approved"
+	TreasuryMovementRejected TreasuryMovementStatus = "rejected"
+	TreasuryMovementPosted   TreasuryMovementStatus = "posted"
+)
+
+// IsValid returns true if the status is known.
+func (s TreasuryMovementStatus) IsValid() bool {
+	switch s {
+	case TreasuryMovementPending, TreasuryMovementApproved, TreasuryMovementRejected, TreasuryMovementPosted:
+		return true
+	}
+	return false
+}
+
+// TreasuryMovement is a movement of funds between system accounts. It is
+// requested by one operator and posted to the ledger once another approves
+// it.
+type TreasuryMovement struct {
+	ID     uuid.UUID
+	Kind   TreasuryMovementKind
+	Status TreasuryMovementStatus
+	Reason string
+	// Counterparty and DealReference identify the deal of an FX deal.
+	Counterparty   *string
+	DealReference  *string
+	RequestedBy    string
+	DecidedBy      *string
+	DecisionReason *string
+	Legs           []TreasuryMovementLeg
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DecidedAt      *time.Time
+	PostedAt       *time.Time
+}
+
+// TreasuryMovementLeg is one ledger transfer of a movement, between the
+// system accounts of a currency.
+type TreasuryMovementLeg struct {
+	Seq           int
+	TBTransferID  uuid.UUID
+	Currency      string
+	DebitAccount  string
+	CreditAccount string
+	Amount        decimal.Decimal
+	// USDRate is the reference rate when the movement was requested.
+	USDRate *decimal.Decimal
+}
+
+// CreateTreasuryMovementParams contains parameters for requesting a
+// treasury movement.
+type CreateTreasuryMovementParams struct {
+	Kind          TreasuryMovementKind
+	Reason        string
+	Counterparty  *string
+	DealReference *string
+	RequestedBy   string
+	Legs          []TreasuryMovementLeg
+}
+
+// TreasuryMovementFilter contains filters for listing treasury movements.
+type TreasuryMovementFilter struct {
+	Status *TreasuryMovementStatus
+	Kind   *TreasuryMovementKind
+	Limit  int
+	Offset int
+}
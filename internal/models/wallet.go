@@ -8,23 +8,32 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Wallet statuses. A frozen wallet's ledger account is closed by a pending
+// closing transfer (LedgerFreezeID) until the freeze is voided.
+const (
+	WalletStatusActive = "active"
+	WalletStatusFrozen = "frozen"
+	WalletStatusClosed = "closed"
+)
+
 // Wallet represents a tenant's currency wallet linked to TigerBeetle.
 type Wallet struct {
-	ID            uuid.UUID
-	TenantID      uuid.UUID
-	Currency      string
-	TBAccountID   *big.Int // 128-bit TigerBeetle account ID
-	CachedBalance decimal.Decimal
-	CachedPending decimal.Decimal
-	CachedAt      time.Time
-	Status        string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	Currency       string
+	TBAccountID    *big.Int // 128-bit TigerBeetle account ID
+	CachedBalance  decimal.Decimal
+	CachedPending  decimal.Decimal
+	CachedAt       time.Time
+	Status         string
+	LedgerFreezeID *uuid.UUID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 // IsActive returns true if the wallet is active.
 func (w *Wallet) IsActive() bool {
-	return w.Status == "active"
+	return w.Status == WalletStatusActive
 }
 
 // AvailableBalance returns the available balance (cached - pending).
@@ -50,3 +59,10 @@ type WalletBalance struct {
 	Pending   decimal.Decimal
 	Total     decimal.Decimal
 }
+
+// WalletFilter contains filter parameters for querying wallets.
+type WalletFilter struct {
+	PageFilter
+	Currency *string
+	Status   *string
+}
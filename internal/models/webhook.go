@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType identifies the kind of event delivered to a tenant.
+type WebhookEventType string
+
+// There is no batch.completed event yet: transfers carry a batch ID, but
+// batches are not submitted or tracked as such.
+const (
+	WebhookEventTransferStatusChanged WebhookEventType = "transfer.status_changed"
+	WebhookEventWalletCredited        WebhookEventType = "wallet.credited"
+)
+
+// WebhookDeliveryStatus represents the delivery state of a webhook.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+)
+
+// WebhookEvent is the envelope sent to tenant webhook endpoints.
+type WebhookEvent struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// WebhookDelivery represents a webhook queued for (or already) delivered to a tenant.
+type WebhookDelivery struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	EventID        uuid.UUID
+	EventType      WebhookEventType
+	URL            string
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	UpdatedAt      time.Time
+	// TraceContext is the encoded trace context of the relay run that
+	// created the delivery, if it was traced. It is not part of the API.
+	TraceContext []byte `json:"-"`
+}
+
+// IsDead returns true if the delivery exhausted its retries.
+func (d *WebhookDelivery) IsDead() bool {
+	return d.Status == WebhookDeliveryStatusDead
+}
+
+// WebhookDeliveryAttempt is a single HTTP attempt in the delivery log.
+type WebhookDeliveryAttempt struct {
+	ID          uuid.UUID
+	DeliveryID  uuid.UUID
+	Attempt     int
+	StatusCode  *int
+	Error       *string
+	DurationMs  int
+	AttemptedAt time.Time
+}
+
+// CreateWebhookDeliveryParams contains parameters for enqueuing a webhook.
+type CreateWebhookDeliveryParams struct {
+	TenantID  uuid.UUID
+	EventID   uuid.UUID
+	EventType WebhookEventType
+	URL       string
+	Payload   json.RawMessage
+}
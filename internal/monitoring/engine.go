@@ -0,0 +1,228 @@
+package monitoring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+)
+
+// Alert is a rule that matched a transfer.
+type Alert struct {
+	RuleID  string         `json:"rule_id"`
+	Type    RuleType       `json:"type"`
+	Score   int            `json:"score"`
+	Details map[string]any `json:"details"`
+}
+
+// Result is the outcome of evaluating a transfer.
+type Result struct {
+	// Score is the highest score of the alerts, 0 without alerts.
+	Score  int     `json:"score"`
+	Alerts []Alert `json:"alerts"`
+	// Review is true if the score reaches the region's review threshold.
+	Review bool `json:"review"`
+}
+
+// Input is a transfer together with the context rules need.
+type Input struct {
+	Region   models.ComplianceRegion
+	Transfer models.TransferActivity
+	// History holds the tenant's earlier transfers, at least Lookback(Region) back.
+	History []models.TransferActivity
+	// WalletCreatedAt is when the source wallet was opened, if known.
+	WalletCreatedAt *time.Time
+}
+
+// Engine evaluates the rules of a region.
+type Engine struct {
+	rules *Rules
+}
+
+// NewEngine creates an engine for the given rules.
+func NewEngine(rules *Rules) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Lookback returns how much history the rules of a region need.
+func (e *Engine) Lookback(region models.ComplianceRegion) time.Duration {
+	var lookback time.Duration
+	for _, rule := range e.rules.Regions[region].Rules {
+		if rule.Window > lookback {
+			lookback = rule.Window
+		}
+	}
+	return lookback
+}
+
+// Evaluate runs the region's rules on a transfer. Regions without rules
+// produce an empty result.
+func (e *Engine) Evaluate(in Input) Result {
+	set := e.rules.Regions[in.Region]
+
+	// Only earlier transfers count, regardless of the order they are passed in
+	history := make([]models.TransferActivity, 0, len(in.History))
+	for _, t := range in.History {
+		if t.ID != in.Transfer.ID && !t.CreatedAt.After(in.Transfer.CreatedAt) {
+			history = append(history, t)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].CreatedAt.After(history[j].CreatedAt) })
+
+	result := Result{Alerts: []Alert{}}
+	for _, rule := range set.Rules {
+		if rule.Currency != in.Transfer.Currency {
+			continue
+		}
+
+		details, ok := rule.evaluate(in, history)
+		if !ok {
+			continue
+		}
+
+		result.Alerts = append(result.Alerts, Alert{
+			RuleID:  rule.ID,
+			Type:    rule.Type,
+			Score:   rule.Score,
+			Details: details,
+		})
+		if rule.Score > result.Score {
+			result.Score = rule.Score
+		}
+	}
+
+	result.Review = len(result.Alerts) > 0 && result.Score >= set.ReviewThreshold
+	return result
+}
+
+// evaluate returns the alert details if the rule matches. history is sorted
+// newest first and excludes the transfer itself.
+func (r Rule) evaluate(in Input, history []models.TransferActivity) (map[string]any, bool) {
+	t := in.Transfer
+	since := t.CreatedAt.Add(-r.Window)
+
+	// window yields the transfers in the rule's currency and window, the
+	// evaluated transfer included
+	window := func(match func(models.TransferActivity) bool) []models.TransferActivity {
+		out := []models.TransferActivity{t}
+		for _, h := range history {
+			if h.CreatedAt.Before(since) {
+				break
+			}
+			if h.Currency == r.Currency && match(h) {
+				out = append(out, h)
+			}
+		}
+		return out
+	}
+
+	switch r.Type {
+	case RuleTypeVelocity:
+		match := func(models.TransferActivity) bool { return true }
+		if r.Scope == ScopeRecipient {
+			if t.RecipientID == nil {
+				return nil, false
+			}
+			match = func(h models.TransferActivity) bool {
+				return h.RecipientID != nil && *h.RecipientID == *t.RecipientID
+			}
+		}
+
+		txns := window(match)
+		total := sum(txns)
+		if (r.MaxAmount.IsPositive() && total.GreaterThan(r.MaxAmount)) ||
+			(r.MaxCount > 0 && len(txns) > r.MaxCount) {
+			return map[string]any{
+				"scope":      r.Scope,
+				"window":     r.Window.String(),
+				"count":      len(txns),
+				"total":      total.String(),
+				"max_amount": r.MaxAmount.String(),
+				"max_count":  r.MaxCount,
+			}, true
+		}
+
+	case RuleTypeStructuring:
+		floor := r.Threshold.Mul(decimal.NewFromFloat(1 - r.Margin))
+		inBand := func(h models.TransferActivity) bool {
+			return h.Amount.GreaterThanOrEqual(floor) && h.Amount.LessThan(r.Threshold)
+		}
+		if !inBand(t) {
+			return nil, false
+		}
+
+		txns := window(inBand)
+		if len(txns) >= r.MinCount {
+			return map[string]any{
+				"threshold": r.Threshold.String(),
+				"floor":     floor.String(),
+				"window":    r.Window.String(),
+				"count":     len(txns),
+				"total":     sum(txns).String(),
+			}, true
+		}
+
+	case RuleTypeNewRecipient:
+		if t.RecipientID == nil || t.Amount.LessThan(r.MinAmount) {
+			return nil, false
+		}
+		for _, h := range history {
+			if h.CreatedAt.Before(since) {
+				break
+			}
+			if h.RecipientID != nil && *h.RecipientID == *t.RecipientID {
+				return nil, false
+			}
+		}
+		return map[string]any{
+			"recipient_id": t.RecipientID.String(),
+			"amount":       t.Amount.String(),
+			"window":       r.Window.String(),
+		}, true
+
+	case RuleTypeDormantSpike:
+		if t.Amount.LessThan(r.MinAmount) {
+			return nil, false
+		}
+		// A wallet opened within the window is new, not dormant
+		if in.WalletCreatedAt != nil && in.WalletCreatedAt.After(since) {
+			return nil, false
+		}
+
+		var last *time.Time
+		for _, h := range history {
+			if h.Currency == r.Currency {
+				last = &h.CreatedAt
+				break
+			}
+		}
+		if last != nil && !last.Before(since) {
+			return nil, false
+		}
+		// No activity at all: only dormant if the wallet is known to be old
+		if last == nil && in.WalletCreatedAt == nil {
+			return nil, false
+		}
+
+		details := map[string]any{
+			"amount": t.Amount.String(),
+			"window": r.Window.String(),
+		}
+		if last != nil {
+			details["last_activity_at"] = last.Format(time.RFC3339)
+		}
+		return details, true
+	}
+
+	return nil, false
+}
+
+func sum(txns []models.TransferActivity) decimal.Decimal {
+	total := decimal.Zero
+	for _, t := range txns {
+		total = total.Add(t.Amount)
+	}
+	return total
+}
@@ -0,0 +1,201 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+var t0 = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+// stream builds a fixture transfer stream; offsets are relative to t0.
+type stream []models.TransferActivity
+
+func (s stream) add(offset time.Duration, currency, amount string, recipient *uuid.UUID) stream {
+	return append(s, models.TransferActivity{
+		ID:          uuid.New(),
+		RecipientID: recipient,
+		Currency:    currency,
+		Amount:      decimal.RequireFromString(amount),
+		CreatedAt:   t0.Add(offset),
+	})
+}
+
+func evaluate(t *testing.T, region models.ComplianceRegion, s stream, walletCreatedAt *time.Time) Result {
+	t.Helper()
+	rules, err := DefaultRules()
+	require.NoError(t, err)
+
+	last := s[len(s)-1]
+	return NewEngine(rules).Evaluate(Input{
+		Region:          region,
+		Transfer:        last,
+		History:         s[:len(s)-1],
+		WalletCreatedAt: walletCreatedAt,
+	})
+}
+
+func ruleIDs(r Result) []string {
+	ids := make([]string, len(r.Alerts))
+	for i, a := range r.Alerts {
+		ids[i] = a.RuleID
+	}
+	return ids
+}
+
+func TestDefaultRulesValid(t *testing.T) {
+	rules, err := DefaultRules()
+	require.NoError(t, err)
+
+	for _, region := range []models.ComplianceRegion{models.ComplianceRegionID, models.ComplianceRegionEU, models.ComplianceRegionUK} {
+		assert.NotEmpty(t, rules.Regions[region].Rules, region)
+	}
+	assert.Equal(t, 90*24*time.Hour, NewEngine(rules).Lookback(models.ComplianceRegionEU))
+}
+
+func TestParseRulesRejectsInvalid(t *testing.T) {
+	_, err := ParseRules([]byte(`
+regions:
+  EU:
+    rules:
+      - id: bad
+        type: structuring
+        currency: EUR
+        window: 24h
+        threshold: 15000
+        score: 50
+`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "margin")
+}
+
+func TestClearTransfer(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.
+		add(-48*time.Hour, "EUR", "1200", &r).
+		add(0, "EUR", "1500", &r)
+
+	result := evaluate(t, models.ComplianceRegionEU, s, nil)
+	assert.Empty(t, result.Alerts)
+	assert.Zero(t, result.Score)
+	assert.False(t, result.Review)
+}
+
+func TestTenantVelocity(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.
+		add(-20*time.Hour, "EUR", "20000", &r).
+		add(-30*time.Hour, "EUR", "40000", &r). // outside the 24h window
+		add(-2*time.Hour, "EUR", "20000", &r).
+		add(0, "EUR", "15000", &r)
+
+	result := evaluate(t, models.ComplianceRegionEU, s, nil)
+	assert.Equal(t, []string{"eu-velocity-tenant-24h"}, ruleIDs(result))
+	assert.Equal(t, "55000", result.Alerts[0].Details["total"])
+	assert.Equal(t, 60, result.Score)
+	assert.True(t, result.Review)
+}
+
+func TestRecipientVelocity(t *testing.T) {
+	r := uuid.New()
+	s := stream{}
+	for i := 10; i > 0; i-- {
+		s = s.add(-time.Duration(i)*time.Hour, "EUR", "10", &r)
+	}
+	s = s.add(0, "EUR", "10", &r)
+
+	result := evaluate(t, models.ComplianceRegionEU, s, nil)
+	assert.Equal(t, []string{"eu-velocity-recipient-24h"}, ruleIDs(result))
+}
+
+func TestStructuring(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.
+		add(-60*time.Hour, "GBP", "9800", &r).
+		add(-30*time.Hour, "GBP", "9500", &r).
+		add(-10*time.Hour, "GBP", "4000", &r). // below the band
+		add(0, "GBP", "9900", &r)
+
+	result := evaluate(t, models.ComplianceRegionUK, s, nil)
+	assert.Contains(t, ruleIDs(result), "uk-structuring-sar")
+	assert.Equal(t, 85, result.Score)
+	assert.True(t, result.Review)
+}
+
+func TestStructuringNeedsTransferInBand(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.
+		add(-60*time.Hour, "GBP", "9800", &r).
+		add(-30*time.Hour, "GBP", "9500", &r).
+		add(0, "GBP", "500", &r)
+
+	result := evaluate(t, models.ComplianceRegionUK, s, nil)
+	assert.NotContains(t, ruleIDs(result), "uk-structuring-sar")
+}
+
+func TestNewRecipientLargeAmount(t *testing.T) {
+	known, unknown := uuid.New(), uuid.New()
+	s := stream{}.
+		add(-24*time.Hour, "IDR", "5000000", &known).
+		add(0, "IDR", "200000000", &unknown)
+
+	result := evaluate(t, models.ComplianceRegionID, s, nil)
+	assert.Equal(t, []string{"id-new-recipient-large"}, ruleIDs(result))
+	assert.False(t, result.Review, "score 30 is below the review threshold")
+
+	s = stream{}.
+		add(-24*time.Hour, "IDR", "5000000", &known).
+		add(0, "IDR", "200000000", &known)
+	assert.Empty(t, evaluate(t, models.ComplianceRegionID, s, nil).Alerts)
+}
+
+func TestDormantWalletSpike(t *testing.T) {
+	r := uuid.New()
+	opened := t0.Add(-365 * 24 * time.Hour)
+
+	// Last activity 120 days ago
+	s := stream{}.
+		add(-120*24*time.Hour, "EUR", "500", &r).
+		add(0, "EUR", "12000", &r)
+	result := evaluate(t, models.ComplianceRegionEU, s, &opened)
+	assert.Equal(t, []string{"eu-new-recipient-large", "eu-dormant-spike"}, ruleIDs(result))
+
+	// Never used, but opened long ago
+	s = stream{}.add(0, "EUR", "12000", &r)
+	result = evaluate(t, models.ComplianceRegionEU, s, &opened)
+	assert.Contains(t, ruleIDs(result), "eu-dormant-spike")
+
+	// New wallet is not dormant
+	recent := t0.Add(-24 * time.Hour)
+	result = evaluate(t, models.ComplianceRegionEU, s, &recent)
+	assert.NotContains(t, ruleIDs(result), "eu-dormant-spike")
+
+	// Unknown wallet age without history is not dormant
+	result = evaluate(t, models.ComplianceRegionEU, s, nil)
+	assert.NotContains(t, ruleIDs(result), "eu-dormant-spike")
+}
+
+func TestOtherCurrenciesIgnored(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.
+		add(-2*time.Hour, "EUR", "60000", &r).
+		add(0, "USD", "60000", &r)
+
+	result := evaluate(t, models.ComplianceRegionEU, s, nil)
+	assert.Empty(t, result.Alerts)
+}
+
+func TestUnknownRegionHasNoRules(t *testing.T) {
+	r := uuid.New()
+	s := stream{}.add(0, "EUR", "1000000", &r)
+
+	result := evaluate(t, models.ComplianceRegionUnknown, s, nil)
+	assert.Empty(t, result.Alerts)
+	assert.False(t, result.Review)
+}
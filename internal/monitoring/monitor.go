@@ -0,0 +1,90 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// Monitor evaluates stored transfers and records their alerts.
+type Monitor struct {
+	engine       *Engine
+	transferRepo *repository.TransferRepository
+	walletRepo   *repository.WalletRepository
+	alertRepo    *repository.MonitoringAlertRepository
+}
+
+// NewMonitor creates a new monitor.
+func NewMonitor(engine *Engine, transferRepo *repository.TransferRepository, walletRepo *repository.WalletRepository, alertRepo *repository.MonitoringAlertRepository) *Monitor {
+	return &Monitor{
+		engine:       engine,
+		transferRepo: transferRepo,
+		walletRepo:   walletRepo,
+		alertRepo:    alertRepo,
+	}
+}
+
+// Evaluate runs the rules of the transfer's region against the tenant's recent transfers.
+func (m *Monitor) Evaluate(ctx context.Context, t *models.Transfer) (Result, error) {
+	subject := models.TransferActivity{
+		ID:          t.ID,
+		RecipientID: t.RecipientID,
+		Currency:    t.FromCurrency,
+		Amount:      t.FromAmount,
+		CreatedAt:   t.CreatedAt(),
+	}
+
+	lookback := m.engine.Lookback(t.ComplianceRegion)
+	if lookback == 0 {
+		return m.engine.Evaluate(Input{Region: t.ComplianceRegion, Transfer: subject}), nil
+	}
+
+	history, err := m.transferRepo.ListActivitySince(ctx, t.TenantID, subject.CreatedAt.Add(-lookback))
+	if err != nil {
+		return Result{}, fmt.Errorf("list transfer activity: %w", err)
+	}
+
+	in := Input{
+		Region:   t.ComplianceRegion,
+		Transfer: subject,
+		History:  history,
+	}
+
+	wallet, err := m.walletRepo.GetByTenantAndCurrency(ctx, t.TenantID, t.FromCurrency)
+	if err != nil {
+		return Result{}, fmt.Errorf("get source wallet: %w", err)
+	}
+	if wallet != nil {
+		in.WalletCreatedAt = &wallet.CreatedAt
+	}
+
+	return m.engine.Evaluate(in), nil
+}
+
+// RecordTx stores the alerts of a result in tx.
+func (m *Monitor) RecordTx(ctx context.Context, tx pgx.Tx, t *models.Transfer, result Result) error {
+	alerts := m.alertRepo.WithTx(tx)
+	for _, a := range result.Alerts {
+		details, err := json.Marshal(a.Details)
+		if err != nil {
+			return fmt.Errorf("marshal alert details: %w", err)
+		}
+		if _, err := alerts.Create(ctx, models.CreateMonitoringAlertParams{
+			TransferID:       t.ID,
+			TenantID:         t.TenantID,
+			ComplianceRegion: t.ComplianceRegion,
+			RuleID:           a.RuleID,
+			RuleType:         string(a.Type),
+			Score:            a.Score,
+			Details:          details,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
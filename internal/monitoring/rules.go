@@ -0,0 +1,175 @@
+// Package monitoring evaluates transaction monitoring rules on transfers.
+//
+// Rules are declarative and configured per compliance region (see
+// rules.yaml). Each rule looks at the transfer being evaluated together with
+// the tenant's recent transfers in a rolling window, and raises an alert with
+// a score when it matches. The engine is pure: it takes the transfer stream
+// as input, so rules can be tested against fixture streams.
+package monitoring
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	"kovra/internal/models"
+)
+
+//go:embed rules.yaml
+var defaultRules []byte
+
+// RuleType identifies what a rule checks.
+type RuleType string
+
+const (
+	RuleTypeVelocity     RuleType = "velocity"
+	RuleTypeStructuring  RuleType = "structuring"
+	RuleTypeNewRecipient RuleType = "new_recipient"
+	RuleTypeDormantSpike RuleType = "dormant_spike"
+)
+
+// Scope is what a velocity rule aggregates over.
+type Scope string
+
+const (
+	ScopeTenant    Scope = "tenant"
+	ScopeRecipient Scope = "recipient"
+)
+
+// Rule is a single monitoring rule. Which fields apply depends on Type.
+type Rule struct {
+	ID       string        `yaml:"id"`
+	Type     RuleType      `yaml:"type"`
+	Currency string        `yaml:"currency"`
+	Window   time.Duration `yaml:"window"`
+	Score    int           `yaml:"score"`
+
+	// velocity
+	Scope     Scope           `yaml:"scope"`
+	MaxAmount decimal.Decimal `yaml:"max_amount"`
+	MaxCount  int             `yaml:"max_count"`
+
+	// structuring: MinCount transfers within Margin below Threshold
+	Threshold decimal.Decimal `yaml:"threshold"`
+	Margin    float64         `yaml:"margin"`
+	MinCount  int             `yaml:"min_count"`
+
+	// new_recipient, dormant_spike
+	MinAmount decimal.Decimal `yaml:"min_amount"`
+}
+
+// RuleSet holds the rules of one compliance region.
+type RuleSet struct {
+	ReviewThreshold int    `yaml:"review_threshold"`
+	Rules           []Rule `yaml:"rules"`
+}
+
+// Rules holds the rule sets of all regions.
+type Rules struct {
+	Regions map[models.ComplianceRegion]RuleSet `yaml:"regions"`
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() (*Rules, error) {
+	return ParseRules(defaultRules)
+}
+
+// LoadRules reads rules from a YAML file, or the built-in rules if path is empty.
+func LoadRules(path string) (*Rules, error) {
+	if path == "" {
+		return DefaultRules()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open monitoring rules: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read monitoring rules: %w", err)
+	}
+	return ParseRules(data)
+}
+
+// ParseRules parses and validates YAML rules.
+func ParseRules(data []byte) (*Rules, error) {
+	var rules Rules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse monitoring rules: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// Validate checks that every rule has the fields its type needs.
+func (r *Rules) Validate() error {
+	var errs []error
+	seen := make(map[string]bool)
+
+	for region, set := range r.Regions {
+		for _, rule := range set.Rules {
+			if rule.ID == "" {
+				errs = append(errs, fmt.Errorf("region %s: rule without id", region))
+				continue
+			}
+			if seen[rule.ID] {
+				errs = append(errs, fmt.Errorf("rule %s: duplicate id", rule.ID))
+			}
+			seen[rule.ID] = true
+
+			if err := rule.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r Rule) validate() error {
+	if r.Currency == "" {
+		return errors.New("currency is required")
+	}
+	if r.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if r.Score <= 0 || r.Score > 100 {
+		return errors.New("score must be between 1 and 100")
+	}
+
+	switch r.Type {
+	case RuleTypeVelocity:
+		if r.Scope != ScopeTenant && r.Scope != ScopeRecipient {
+			return fmt.Errorf("unknown scope %q", r.Scope)
+		}
+		if !r.MaxAmount.IsPositive() && r.MaxCount <= 0 {
+			return errors.New("max_amount or max_count is required")
+		}
+	case RuleTypeStructuring:
+		if !r.Threshold.IsPositive() {
+			return errors.New("threshold is required")
+		}
+		if r.Margin <= 0 || r.Margin >= 1 {
+			return errors.New("margin must be between 0 and 1")
+		}
+		if r.MinCount <= 1 {
+			return errors.New("min_count must be at least 2")
+		}
+	case RuleTypeNewRecipient, RuleTypeDormantSpike:
+		if !r.MinAmount.IsPositive() {
+			return errors.New("min_amount is required")
+		}
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+	return nil
+}
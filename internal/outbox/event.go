@@ -0,0 +1,26 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+)
+
+// NewEvent builds outbox parameters for an event with a JSON-encoded payload.
+func NewEvent(aggregateType models.AggregateType, aggregateID uuid.UUID, tenantID *uuid.UUID, eventType string, data any) (models.CreateOutboxEventParams, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return models.CreateOutboxEventParams{}, fmt.Errorf("marshal %s payload: %w", eventType, err)
+	}
+
+	return models.CreateOutboxEventParams{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		TenantID:      tenantID,
+		EventType:     eventType,
+		Payload:       payload,
+	}, nil
+}
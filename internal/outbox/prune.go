@@ -0,0 +1,47 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+	"kovra/internal/repository"
+)
+
+// PruneArgs are the arguments of the outbox prune job.
+type PruneArgs struct {
+	Retention time.Duration `json:"retention"`
+}
+
+// Kind returns the job kind.
+func (PruneArgs) Kind() string { return "outbox.prune" }
+
+// InsertOpts returns the default insert options.
+func (PruneArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3}
+}
+
+// PruneWorker deletes published outbox events older than the retention.
+type PruneWorker struct {
+	repo   *repository.OutboxRepository
+	logger *zap.Logger
+}
+
+// NewPruneWorker creates a new outbox prune worker.
+func NewPruneWorker(repo *repository.OutboxRepository, logger *zap.Logger) *PruneWorker {
+	return &PruneWorker{repo: repo, logger: logger}
+}
+
+// Work runs the prune job.
+func (w *PruneWorker) Work(ctx context.Context, job *jobs.Job[PruneArgs]) error {
+	deleted, err := w.repo.DeletePublished(ctx, time.Now().Add(-job.Args.Retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		w.logger.Info("pruned outbox events", zap.Int64("count", deleted))
+	}
+	return nil
+}
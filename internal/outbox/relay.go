@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"kovra/internal/db"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/tracing"
+)
+
+const maxRetryDelay = time.Minute
+
+// Config holds relay configuration.
+type Config struct {
+	BatchSize    int
+	PollInterval time.Duration
+}
+
+// Relay publishes outbox events to sinks.
+//
+// Each batch runs in one transaction: events are claimed with
+// FOR UPDATE SKIP LOCKED, so several relays can run concurrently, and only the
+// oldest unpublished event of each aggregate is eligible, which keeps delivery
+// ordered per aggregate. A failed event blocks later events of its aggregate
+// until it is retried successfully.
+type Relay struct {
+	db     *db.DB
+	repo   *repository.OutboxRepository
+	sinks  []Sink
+	cfg    Config
+	logger *zap.Logger
+}
+
+// NewRelay creates a new outbox relay.
+func NewRelay(database *db.DB, repo *repository.OutboxRepository, sinks []Sink, cfg Config, logger *zap.Logger) *Relay {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 500 * time.Millisecond
+	}
+
+	return &Relay{
+		db:     database,
+		repo:   repo,
+		sinks:  sinks,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// RetryDelay returns the delay before an event is retried after the given
+// number of failed attempts.
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	if attempts > 6 {
+		return maxRetryDelay
+	}
+	d := time.Second << (attempts - 1)
+	if d > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return d
+}
+
+// Run relays events until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) error {
+	r.logger.Info("starting outbox relay",
+		zap.Duration("poll_interval", r.cfg.PollInterval),
+		zap.Int("sinks", len(r.sinks)),
+	)
+
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain full batches before waiting for the next tick
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.logger.Error("outbox relay failed", zap.Error(err))
+			}
+			if err != nil || n < r.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of events and returns the number claimed.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	return db.WithTxResult(ctx, r.db, func(tx pgx.Tx) (int, error) {
+		repo := r.repo.WithTx(tx)
+
+		events, err := repo.Claim(ctx, r.cfg.BatchSize)
+		if err != nil {
+			return 0, fmt.Errorf("claim outbox events: %w", err)
+		}
+
+		for _, event := range events {
+			if err := r.publish(ctx, tx, event); err != nil {
+				attempts := event.Attempts + 1
+				r.logger.Warn("outbox publish failed",
+					zap.String("event_id", event.ID.String()),
+					zap.String("event_type", event.EventType),
+					zap.Int("attempts", attempts),
+					zap.Error(err),
+				)
+				if err := repo.MarkFailed(ctx, event.ID, err.Error(), time.Now().Add(RetryDelay(attempts))); err != nil {
+					return 0, fmt.Errorf("mark outbox event failed: %w", err)
+				}
+				continue
+			}
+
+			if err := repo.MarkPublished(ctx, event.ID); err != nil {
+				return 0, fmt.Errorf("mark outbox event published: %w", err)
+			}
+		}
+
+		return len(events), nil
+	})
+}
+
+// publish hands the event to every sink inside a savepoint, so a failing sink
+// rolls back the writes of the others without aborting the batch transaction.
+// It runs in a span of the trace that appended the event, so the webhook
+// deliveries and jobs the sinks create continue that trace.
+func (r *Relay) publish(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) (err error) {
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, event.TraceContext), "outbox publish "+event.EventType,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("outbox.event_id", event.ID.String()),
+			attribute.String("outbox.event_type", event.EventType),
+			attribute.Int("outbox.attempt", event.Attempts+1),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin savepoint: %w", err)
+	}
+
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, sp, event); err != nil {
+			if rbErr := sp.Rollback(ctx); rbErr != nil {
+				return fmt.Errorf("rollback savepoint: %v (sink %s: %w)", rbErr, sink.Name(), err)
+			}
+			return fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+	}
+
+	return sp.Commit(ctx)
+}
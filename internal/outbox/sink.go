@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/models"
+)
+
+// Sink receives published outbox events.
+//
+// Publish runs inside the relay's transaction (as a savepoint), so sinks that
+// write to PostgreSQL commit atomically with the event being marked published.
+// Delivery is at-least-once: a sink may see the same event again if a later
+// sink fails, and must be idempotent on event.ID.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error
+}
+
+// HandlerFunc handles an event delivered by the in-process sink.
+type HandlerFunc func(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error
+
+// InProcessSink dispatches events to handlers registered in this process.
+type InProcessSink struct {
+	mu       sync.RWMutex
+	handlers map[string][]HandlerFunc
+}
+
+// NewInProcessSink creates an empty in-process sink.
+func NewInProcessSink() *InProcessSink {
+	return &InProcessSink{handlers: make(map[string][]HandlerFunc)}
+}
+
+// Subscribe registers fn for events of the given type.
+func (s *InProcessSink) Subscribe(eventType string, fn HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[eventType] = append(s.handlers[eventType], fn)
+}
+
+// Name returns the sink name.
+func (s *InProcessSink) Name() string {
+	return "in_process"
+}
+
+// Publish calls every handler subscribed to the event type, in registration order.
+func (s *InProcessSink) Publish(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error {
+	s.mu.RLock()
+	handlers := s.handlers[event.EventType]
+	s.mu.RUnlock()
+
+	for i, fn := range handlers {
+		if err := fn(ctx, tx, event); err != nil {
+			return fmt.Errorf("handler %d for %s: %w", i, event.EventType, err)
+		}
+	}
+	return nil
+}
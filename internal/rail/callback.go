@@ -0,0 +1,74 @@
+package rail
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/webhook"
+)
+
+// HeaderSignature carries the signature of a callback, in the format of
+// webhook.Sign, keyed with the rail's callback secret.
+const HeaderSignature = "Rail-Signature"
+
+var (
+	ErrUnknownRail          = errors.New("unknown rail")
+	ErrInvalidCallback      = errors.New("invalid callback")
+	ErrInvalidSignature     = errors.New("invalid callback signature")
+	ErrTransferNotFound     = errors.New("no transfer matches the callback")
+	ErrReferenceMismatch    = errors.New("callback reference does not match the transfer")
+	ErrTransferNotCancelled = errors.New("transfer is not cancelled")
+)
+
+// Callback is the body of a status callback from a rail. The rail finds the
+// transfer by the reference it assigned to the payout; the end-to-end ID we
+// sent with the payout, which is the transfer ID, identifies the transfer
+// when the rail reports its acceptance.
+type Callback struct {
+	// EventID identifies the callback at the rail; a repeated event ID is a
+	// retry of the same callback.
+	EventID    string                    `json:"event_id"`
+	Status     models.RailCallbackStatus `json:"status"`
+	Reference  string                    `json:"reference,omitempty"`
+	EndToEndID *uuid.UUID                `json:"end_to_end_id,omitempty"`
+	ReturnCode *string                   `json:"return_code,omitempty"`
+	Reason     *string                   `json:"reason,omitempty"`
+	OccurredAt *time.Time                `json:"occurred_at,omitempty"`
+}
+
+// Validate checks that the callback can be applied.
+func (c *Callback) Validate() error {
+	if c.EventID == "" {
+		return fmt.Errorf("%w: event_id is required", ErrInvalidCallback)
+	}
+	if !c.Status.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidCallback, c.Status)
+	}
+	if c.Reference == "" && c.EndToEndID == nil {
+		return fmt.Errorf("%w: reference or end_to_end_id is required", ErrInvalidCallback)
+	}
+	if c.Status == models.RailCallbackAccepted && c.Reference == "" {
+		return fmt.Errorf("%w: an accepted payout needs a reference", ErrInvalidCallback)
+	}
+	return nil
+}
+
+// Secrets holds the callback signing secret of each rail. A rail without a
+// secret cannot send callbacks.
+type Secrets map[models.Rail]string
+
+// Verify checks the signature of a callback body from a rail.
+func (s Secrets) Verify(rail models.Rail, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	secret, ok := s[rail]
+	if !ok || secret == "" {
+		return ErrUnknownRail
+	}
+	if err := webhook.Verify(secret, header, body, tolerance, now); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	return nil
+}
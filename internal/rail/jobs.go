@@ -0,0 +1,51 @@
+package rail
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+)
+
+// VoidHoldArgs are the arguments of the job that voids the payout hold of a
+// cancelled transfer. It is enqueued with the cancellation, so the hold is
+// voided only once the transfer can no longer be paid out.
+type VoidHoldArgs struct {
+	TransferID uuid.UUID `json:"transfer_id"`
+}
+
+// Kind returns the job kind.
+func (VoidHoldArgs) Kind() string { return "rail.void_hold" }
+
+// InsertOpts returns the default insert options.
+func (VoidHoldArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: jobs.DefaultQueue, MaxAttempts: 10}
+}
+
+// VoidHoldWorker voids the payout holds of cancelled transfers.
+type VoidHoldWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewVoidHoldWorker creates a new payout hold voiding worker.
+func NewVoidHoldWorker(service *Service, logger *zap.Logger) *VoidHoldWorker {
+	return &VoidHoldWorker{service: service, logger: logger}
+}
+
+// Work voids the hold.
+func (w *VoidHoldWorker) Work(ctx context.Context, job *jobs.Job[VoidHoldArgs]) error {
+	err := w.service.VoidHold(ctx, job.Args.TransferID)
+	if errors.Is(err, ErrTransferNotFound) || errors.Is(err, ErrTransferNotCancelled) {
+		return jobs.Cancel(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("payout hold voided", zap.String("transfer_id", job.Args.TransferID.String()))
+	return nil
+}
@@ -0,0 +1,390 @@
+// Package rail ingests the status callbacks of the payment rails. A
+// callback is matched to its transfer by the rail's reference and drives the
+// transfer on: an accepted payout is held in the ledger, a settled one
+// posted and completed, a rejected one voided and rolled back, and a
+// returned one refunded to the tenant. Rails retry callbacks and do not
+// order them, so repeated events are recorded once and callbacks the
+// transfer has moved past are recorded as ignored.
+package rail
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"kovra/internal/db"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/refund"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+// returnReversesFee is the fee policy of rail returns. A returned payout was
+// still sent, and the rail charged for sending it, so its return refunds the
+// payout but keeps the fee; the fee can be refunded through the refunds API.
+const returnReversesFee = false
+
+// Ledger is the part of the ledger client callbacks use.
+type Ledger interface {
+	HoldPayout(ctx context.Context, p ledger.Payout) error
+	SettlePayout(ctx context.Context, p ledger.Payout) ([]uuid.UUID, error)
+	VoidPayoutHold(ctx context.Context, transferID uuid.UUID) error
+}
+
+// Service applies rail callbacks to transfers.
+type Service struct {
+	db           *db.DB
+	repo         *repository.RailCallbackRepository
+	transferRepo *repository.TransferRepository
+	walletRepo   *repository.WalletRepository
+	outboxRepo   *repository.OutboxRepository
+	refunds      *refund.Service
+	ledger       Ledger
+	secrets      Secrets
+	tolerance    time.Duration
+	logger       *zap.Logger
+}
+
+// NewService creates a new rail callback service. tolerance bounds the age
+// of a callback signature.
+func NewService(
+	database *db.DB,
+	repo *repository.RailCallbackRepository,
+	transferRepo *repository.TransferRepository,
+	walletRepo *repository.WalletRepository,
+	outboxRepo *repository.OutboxRepository,
+	refunds *refund.Service,
+	ledgerClient Ledger,
+	secrets Secrets,
+	tolerance time.Duration,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		db:           database,
+		repo:         repo,
+		transferRepo: transferRepo,
+		walletRepo:   walletRepo,
+		outboxRepo:   outboxRepo,
+		refunds:      refunds,
+		ledger:       ledgerClient,
+		secrets:      secrets,
+		tolerance:    tolerance,
+		logger:       logger,
+	}
+}
+
+// Verify checks the signature of a callback body from a rail.
+func (s *Service) Verify(rail models.Rail, header string, body []byte) error {
+	return s.secrets.Verify(rail, header, body, s.tolerance, time.Now())
+}
+
+// Handle applies a verified callback from a rail to its transfer and records
+// it. A callback the rail sent before is not applied again; its record is
+// returned as it stands.
+func (s *Service) Handle(ctx context.Context, rail models.Rail, cb Callback, payload []byte) (*models.RailCallback, error) {
+	if err := cb.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, err := s.db.Locate(ctx, func(ctx context.Context) (bool, error) {
+		return s.holdsTransfer(ctx, rail, cb)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("locate transfer: %w", err)
+	}
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.RailCallback, error) {
+		repo := s.repo.WithTx(tx)
+
+		// Callbacks of a transfer are applied one at a time under its lock,
+		// so a retry racing the original finds it recorded
+		transfer, err := s.lockTransfer(ctx, tx, rail, cb)
+		if err != nil {
+			return nil, err
+		}
+
+		existing, err := repo.GetByEvent(ctx, rail, cb.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("get callback: %w", err)
+		}
+		if existing != nil {
+			return existing, nil
+		}
+
+		steps := plan(transfer.Status, cb.Status)
+		outcome := models.RailCallbackApplied
+		if len(steps) == 0 {
+			outcome = models.RailCallbackIgnored
+			s.logger.Warn("rail callback ignored",
+				zap.String("rail", string(rail)),
+				zap.String("event_id", cb.EventID),
+				zap.String("transfer_id", transfer.ID.String()),
+				zap.String("transfer_status", string(transfer.Status)),
+				zap.String("callback_status", string(cb.Status)))
+		}
+
+		for _, step := range steps {
+			if err := s.apply(ctx, tx, transfer, step, cb); err != nil {
+				return nil, err
+			}
+			if transfer, err = s.transferRepo.WithTx(tx).GetByID(ctx, transfer.ID); err != nil {
+				return nil, fmt.Errorf("get transfer: %w", err)
+			}
+		}
+
+		var reference *string
+		if cb.Reference != "" {
+			reference = &cb.Reference
+		}
+		recorded, err := repo.Create(ctx, models.CreateRailCallbackParams{
+			Rail:          rail,
+			EventID:       cb.EventID,
+			TransferID:    transfer.ID,
+			RailReference: reference,
+			Status:        cb.Status,
+			ReturnCode:    cb.ReturnCode,
+			Reason:        cb.Reason,
+			Outcome:       outcome,
+			Payload:       json.RawMessage(payload),
+			OccurredAt:    cb.OccurredAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("record callback: %w", err)
+		}
+		return recorded, nil
+	})
+}
+
+// ListByTransfer returns the callbacks received for a transfer, oldest
+// first.
+func (s *Service) ListByTransfer(ctx context.Context, transferID uuid.UUID) ([]*models.RailCallback, error) {
+	return s.repo.ListByTransfer(ctx, transferID)
+}
+
+// holdsTransfer reports whether the database of ctx holds the transfer a
+// callback is about, looked up as lockTransfer does.
+func (s *Service) holdsTransfer(ctx context.Context, rail models.Rail, cb Callback) (bool, error) {
+	if cb.Reference != "" {
+		region, err := s.transferRepo.GetRegionByRailReference(ctx, rail, cb.Reference)
+		if err != nil || region != nil {
+			return region != nil, err
+		}
+	}
+	if cb.EndToEndID == nil {
+		return false, nil
+	}
+	region, err := s.transferRepo.GetRegion(ctx, *cb.EndToEndID)
+	return region != nil, err
+}
+
+// lockTransfer finds and locks the transfer of a callback, by the rail's
+// reference or else by the end-to-end ID. A transfer found by its ID takes
+// the rail's reference if it has none yet.
+func (s *Service) lockTransfer(ctx context.Context, tx pgx.Tx, rail models.Rail, cb Callback) (*models.Transfer, error) {
+	repo := s.transferRepo.WithTx(tx)
+
+	if cb.Reference != "" {
+		transfer, err := repo.GetByRailReferenceForUpdate(ctx, rail, cb.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("get transfer by reference: %w", err)
+		}
+		if transfer != nil {
+			return transfer, nil
+		}
+	}
+	if cb.EndToEndID == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	transfer, err := repo.GetForUpdate(ctx, *cb.EndToEndID)
+	if err != nil {
+		return nil, fmt.Errorf("get transfer: %w", err)
+	}
+	if transfer == nil || transfer.Rail == nil || *transfer.Rail != rail {
+		return nil, ErrTransferNotFound
+	}
+	if cb.Reference == "" {
+		return transfer, nil
+	}
+	if transfer.RailReference != nil {
+		return nil, ErrReferenceMismatch
+	}
+
+	if err := repo.UpdateRailReference(ctx, transfer.ID, cb.Reference); err != nil {
+		return nil, fmt.Errorf("update rail reference: %w", err)
+	}
+	transfer.RailReference = &cb.Reference
+	return transfer, nil
+}
+
+// plan returns the steps a callback takes a transfer through, in order. A
+// payout is accepted, then settled or rejected, and a settled payout may be
+// returned. A callback may overtake the ones before it: a settlement
+// arriving before the acceptance settles the payout, and a return arriving
+// before the settlement settles it first. A callback the transfer has moved
+// past, or one for a transfer not being paid out, takes no steps.
+func plan(status models.TransferStatus, cb models.RailCallbackStatus) []models.RailCallbackStatus {
+	switch status {
+	case models.TransferStatusProcessing:
+		if cb == models.RailCallbackReturned {
+			return []models.RailCallbackStatus{models.RailCallbackSettled, models.RailCallbackReturned}
+		}
+		return []models.RailCallbackStatus{cb}
+	case models.TransferStatusCompleted:
+		if cb == models.RailCallbackReturned {
+			return []models.RailCallbackStatus{cb}
+		}
+	}
+	return nil
+}
+
+// apply takes a locked transfer through one step. The ledger transfers are
+// made while the transfer is locked and derive their IDs from it, so
+// applying a step again after a failed commit is harmless.
+func (s *Service) apply(ctx context.Context, tx pgx.Tx, transfer *models.Transfer, step models.RailCallbackStatus, cb Callback) error {
+	repo := s.transferRepo.WithTx(tx)
+
+	switch step {
+	case models.RailCallbackAccepted:
+		payout, err := s.payout(ctx, tx, transfer)
+		if err != nil {
+			return err
+		}
+		if err := s.ledger.HoldPayout(ctx, payout); err != nil {
+			return fmt.Errorf("hold payout: %w", err)
+		}
+		// Accepted payouts can no longer be cancelled, whether or not the
+		// rail assigned a reference
+		if err := repo.AcknowledgeRail(ctx, transfer.ID); err != nil {
+			return fmt.Errorf("acknowledge payout: %w", err)
+		}
+		return nil
+
+	case models.RailCallbackSettled:
+		payout, err := s.payout(ctx, tx, transfer)
+		if err != nil {
+			return err
+		}
+		if err := s.ledger.HoldPayout(ctx, payout); err != nil {
+			return fmt.Errorf("hold payout: %w", err)
+		}
+		ids, err := s.ledger.SettlePayout(ctx, payout)
+		if err != nil {
+			return fmt.Errorf("settle payout: %w", err)
+		}
+
+		tbIDs := make([]*big.Int, len(ids))
+		for i, id := range ids {
+			tbIDs[i] = ledger.TransferIDToBigInt(id)
+		}
+		if err := repo.UpdateTBTransferIDs(ctx, transfer.ID, tbIDs); err != nil {
+			return fmt.Errorf("update ledger transfer IDs: %w", err)
+		}
+		return s.transition(ctx, tx, transfer, models.TransferStatusCompleted, nil)
+
+	case models.RailCallbackRejected:
+		if err := s.ledger.VoidPayoutHold(ctx, transfer.ID); err != nil {
+			return fmt.Errorf("void payout hold: %w", err)
+		}
+		return s.transition(ctx, tx, transfer, models.TransferStatusRolledBack, failureReason("rejected by rail", cb))
+
+	case models.RailCallbackReturned:
+		// The event ID is the rail's, so it is hashed into a key that fits
+		key := "rail-return:" + uuid.NewSHA1(transfer.ID, []byte(cb.EventID)).String()
+		if _, err := s.refunds.RefundTx(ctx, tx, transfer.ID, refund.Request{
+			Reason:         *failureReason("returned by rail", cb),
+			ReverseFee:     returnReversesFee,
+			ReturnCode:     cb.ReturnCode,
+			IdempotencyKey: &key,
+		}); err != nil {
+			return fmt.Errorf("refund returned payout: %w", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidCallback, step)
+	}
+}
+
+// VoidHold voids the payout hold of a cancelled transfer, if it has one. It
+// runs after the cancellation commits: a hold voided before would leave a
+// transfer whose cancellation failed to commit unable to settle.
+func (s *Service) VoidHold(ctx context.Context, transferID uuid.UUID) error {
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		return fmt.Errorf("get transfer: %w", err)
+	}
+	if transfer == nil {
+		return ErrTransferNotFound
+	}
+	if transfer.Status != models.TransferStatusCancelled {
+		return fmt.Errorf("%w: %s is %s", ErrTransferNotCancelled, transferID, transfer.Status)
+	}
+	if err := s.ledger.VoidPayoutHold(ctx, transferID); err != nil {
+		return fmt.Errorf("void payout hold: %w", err)
+	}
+	return nil
+}
+
+// transition moves a locked transfer to status and records the event.
+func (s *Service) transition(ctx context.Context, tx pgx.Tx, transfer *models.Transfer, status models.TransferStatus, reason *string) error {
+	if err := s.transferRepo.WithTx(tx).UpdateStatus(ctx, transfer.ID, status, reason); err != nil {
+		return fmt.Errorf("update transfer status: %w", err)
+	}
+
+	previous := transfer.Status
+	event, err := outbox.NewEvent(models.AggregateTransfer, transfer.ID, &transfer.TenantID,
+		string(models.WebhookEventTransferStatusChanged),
+		webhook.NewTransferStatusChanged(transfer, status, &previous, reason))
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.WithTx(tx).Append(ctx, event)
+}
+
+// payout returns the ledger view of a transfer's payout.
+func (s *Service) payout(ctx context.Context, tx pgx.Tx, transfer *models.Transfer) (ledger.Payout, error) {
+	wallet, err := s.walletRepo.WithTx(tx).GetByTenantAndCurrency(ctx, transfer.TenantID, transfer.FromCurrency)
+	if err != nil {
+		return ledger.Payout{}, fmt.Errorf("get wallet: %w", err)
+	}
+	if wallet == nil {
+		return ledger.Payout{}, fmt.Errorf("no %s wallet for tenant %s", transfer.FromCurrency, transfer.TenantID)
+	}
+
+	return ledger.Payout{
+		TransferID:   transfer.ID,
+		Wallet:       ledger.FromBigInt(wallet.TBAccountID),
+		Currency:     ledger.CurrencyFromString(transfer.FromCurrency),
+		Amount:       minorUnits(transfer.FromAmount.Sub(transfer.TotalFee)),
+		FeeAmount:    minorUnits(transfer.TotalFee),
+		DestCurrency: ledger.CurrencyFromString(transfer.ToCurrency),
+		DestAmount:   minorUnits(transfer.ToAmount),
+	}, nil
+}
+
+// failureReason describes a rejection or return, with the rail's return
+// code and reason when it gives them.
+func failureReason(prefix string, cb Callback) *string {
+	reason := prefix
+	if cb.ReturnCode != nil {
+		reason += " (" + *cb.ReturnCode + ")"
+	}
+	if cb.Reason != nil && *cb.Reason != "" {
+		reason += ": " + *cb.Reason
+	}
+	return &reason
+}
+
+func minorUnits(d decimal.Decimal) uint64 {
+	return uint64(d.Shift(2).IntPart())
+}
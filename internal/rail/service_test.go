@@ -0,0 +1,118 @@
+package rail
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+	"kovra/internal/webhook"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		transfer models.TransferStatus
+		callback models.RailCallbackStatus
+		want     []models.RailCallbackStatus
+	}{
+		{"accepted while processing", models.TransferStatusProcessing, models.RailCallbackAccepted,
+			[]models.RailCallbackStatus{models.RailCallbackAccepted}},
+		{"settled while processing", models.TransferStatusProcessing, models.RailCallbackSettled,
+			[]models.RailCallbackStatus{models.RailCallbackSettled}},
+		{"rejected while processing", models.TransferStatusProcessing, models.RailCallbackRejected,
+			[]models.RailCallbackStatus{models.RailCallbackRejected}},
+		{"return overtaking settlement", models.TransferStatusProcessing, models.RailCallbackReturned,
+			[]models.RailCallbackStatus{models.RailCallbackSettled, models.RailCallbackReturned}},
+		{"return of completed transfer", models.TransferStatusCompleted, models.RailCallbackReturned,
+			[]models.RailCallbackStatus{models.RailCallbackReturned}},
+		{"acceptance after settlement", models.TransferStatusCompleted, models.RailCallbackAccepted, nil},
+		{"rejection after settlement", models.TransferStatusCompleted, models.RailCallbackRejected, nil},
+		{"settlement after rejection", models.TransferStatusRolledBack, models.RailCallbackSettled, nil},
+		{"callback before screening", models.TransferStatusValidating, models.RailCallbackAccepted, nil},
+		{"callback after cancellation", models.TransferStatusCancelled, models.RailCallbackSettled, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, plan(tt.transfer, tt.callback))
+		})
+	}
+}
+
+func TestCallbackValidate(t *testing.T) {
+	id := uuid.New()
+
+	assert.NoError(t, (&Callback{EventID: "e1", Status: models.RailCallbackAccepted, Reference: "R1", EndToEndID: &id}).Validate())
+	assert.NoError(t, (&Callback{EventID: "e2", Status: models.RailCallbackSettled, Reference: "R1"}).Validate())
+	assert.NoError(t, (&Callback{EventID: "e3", Status: models.RailCallbackRejected, EndToEndID: &id}).Validate())
+
+	assert.ErrorIs(t, (&Callback{Status: models.RailCallbackSettled, Reference: "R1"}).Validate(), ErrInvalidCallback)
+	assert.ErrorIs(t, (&Callback{EventID: "e4", Status: "paid", Reference: "R1"}).Validate(), ErrInvalidCallback)
+	assert.ErrorIs(t, (&Callback{EventID: "e5", Status: models.RailCallbackSettled}).Validate(), ErrInvalidCallback)
+	assert.ErrorIs(t, (&Callback{EventID: "e6", Status: models.RailCallbackAccepted, EndToEndID: &id}).Validate(), ErrInvalidCallback)
+}
+
+func TestSimulatorSignsCallbacks(t *testing.T) {
+	secrets := Secrets{models.RailSEPAInstant: "sepa-secret"}
+	transferID := uuid.New()
+
+	var received []Callback
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/rails/sepa_instant/callbacks", r.URL.Path)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err := secrets.Verify(models.RailSEPAInstant, r.Header.Get(HeaderSignature), body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var cb Callback
+		require.NoError(t, json.Unmarshal(body, &cb))
+		received = append(received, cb)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	sim := NewSimulator(srv.URL, models.RailSEPAInstant, "sepa-secret")
+
+	reference, err := sim.Accept(ctx, transferID)
+	require.NoError(t, err)
+	require.NoError(t, sim.Settle(ctx, reference))
+	require.NoError(t, sim.Return(ctx, reference, "AC04", "account closed"))
+
+	require.Len(t, received, 3)
+	assert.Equal(t, models.RailCallbackAccepted, received[0].Status)
+	assert.Equal(t, &transferID, received[0].EndToEndID)
+	assert.Equal(t, reference, received[1].Reference)
+	assert.Equal(t, models.RailCallbackReturned, received[2].Status)
+	assert.Equal(t, "AC04", *received[2].ReturnCode)
+	assert.NotEqual(t, received[1].EventID, received[2].EventID)
+
+	// A simulator holding the wrong secret is turned away
+	forged := NewSimulator(srv.URL, models.RailSEPAInstant, "guessed")
+	assert.Error(t, forged.Settle(ctx, reference))
+	assert.Len(t, received, 3)
+}
+
+func TestSecretsVerify(t *testing.T) {
+	secrets := Secrets{models.RailFPS: "fps-secret"}
+	body := []byte(`{"event_id":"e1"}`)
+	now := time.Now()
+
+	header := webhook.Sign("fps-secret", now, body)
+	assert.NoError(t, secrets.Verify(models.RailFPS, header, body, time.Minute, now))
+	assert.ErrorIs(t, secrets.Verify(models.RailFPS, header, []byte(`{"event_id":"e2"}`), time.Minute, now), ErrInvalidSignature)
+	assert.ErrorIs(t, secrets.Verify(models.RailFPS, header, body, time.Minute, now.Add(time.Hour)), ErrInvalidSignature)
+	assert.ErrorIs(t, secrets.Verify(models.RailCHAPS, header, body, time.Minute, now), ErrUnknownRail)
+}
@@ -0,0 +1,115 @@
+package rail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/webhook"
+)
+
+// Simulator plays a rail in local runs and tests: it signs callbacks with
+// the rail's secret and sends them to the callback endpoint of an API.
+type Simulator struct {
+	baseURL string
+	rail    models.Rail
+	secret  string
+	client  *http.Client
+	now     func() time.Time
+}
+
+// NewSimulator creates a simulator of rail sending callbacks to the API at
+// baseURL, e.g. http://localhost:8080.
+func NewSimulator(baseURL string, rail models.Rail, secret string) *Simulator {
+	return &Simulator{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		rail:    rail,
+		secret:  secret,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		now:     time.Now,
+	}
+}
+
+// Accept reports the payout of a transfer accepted under a new reference,
+// which it returns.
+func (s *Simulator) Accept(ctx context.Context, transferID uuid.UUID) (string, error) {
+	reference := "SIM-" + strings.ToUpper(uuid.NewString()[:8])
+	return reference, s.Send(ctx, Callback{
+		EventID:    uuid.NewString(),
+		Status:     models.RailCallbackAccepted,
+		Reference:  reference,
+		EndToEndID: &transferID,
+	})
+}
+
+// Settle reports the payout with the given reference settled.
+func (s *Simulator) Settle(ctx context.Context, reference string) error {
+	return s.Send(ctx, Callback{
+		EventID:   uuid.NewString(),
+		Status:    models.RailCallbackSettled,
+		Reference: reference,
+	})
+}
+
+// Reject reports the payout with the given reference rejected.
+func (s *Simulator) Reject(ctx context.Context, reference, returnCode, reason string) error {
+	return s.Send(ctx, Callback{
+		EventID:    uuid.NewString(),
+		Status:     models.RailCallbackRejected,
+		Reference:  reference,
+		ReturnCode: &returnCode,
+		Reason:     &reason,
+	})
+}
+
+// Return reports the settled payout with the given reference returned.
+func (s *Simulator) Return(ctx context.Context, reference, returnCode, reason string) error {
+	return s.Send(ctx, Callback{
+		EventID:    uuid.NewString(),
+		Status:     models.RailCallbackReturned,
+		Reference:  reference,
+		ReturnCode: &returnCode,
+		Reason:     &reason,
+	})
+}
+
+// Send signs and sends a callback. Sending the same callback again
+// simulates a rail retry.
+func (s *Simulator) Send(ctx context.Context, cb Callback) error {
+	if cb.OccurredAt == nil {
+		now := s.now().UTC()
+		cb.OccurredAt = &now
+	}
+	body, err := json.Marshal(cb)
+	if err != nil {
+		return fmt.Errorf("encode callback: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v1/rails/%s/callbacks", s.baseURL, strings.ToLower(string(s.rail)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, webhook.Sign(s.secret, s.now(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send callback: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("callback rejected: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
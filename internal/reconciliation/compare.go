@@ -0,0 +1,226 @@
+package reconciliation
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+// Ledger balances are kept in minor units with two decimals.
+const minorUnitExp = -2
+
+// accountKey identifies a bank account balance.
+type accountKey struct {
+	legalEntityID uuid.UUID
+	kind          models.BankAccountKind
+	currency      string
+}
+
+// indexBankBalances maps the bank balances of a date by account.
+func indexBankBalances(bank []*models.BankBalance) map[accountKey]*models.BankBalance {
+	m := make(map[accountKey]*models.BankBalance, len(bank))
+	for _, b := range bank {
+		m[accountKey{b.LegalEntityID, b.AccountKind, b.Currency}] = b
+	}
+	return m
+}
+
+// compareFBO checks, per legal entity and currency, that the tenant wallets
+// (the FBO sub-ledger) and the legal entity's suspense account, which holds
+// unmatched inbound credits, sum to the FBO bank balance. Both are
+// liabilities, so their balance is credits - debits. Posted amounts only:
+// held funds have not left the bank yet.
+func compareFBO(wallets []*models.WalletLedgerAccount, entities []*models.LegalEntity, balances map[ledger.AccountID]ledger.Balance, bank []*models.BankBalance) (int, []models.ReconciliationDiscrepancy) {
+	var out []models.ReconciliationDiscrepancy
+	totals := make(map[accountKey]int64)
+
+	for _, w := range wallets {
+		key := accountKey{w.LegalEntityID, models.BankAccountFBO, w.Currency}
+		id := ledger.FromBigInt(w.TBAccountID)
+		b, ok := balances[id]
+		if !ok {
+			out = append(out, models.ReconciliationDiscrepancy{
+				Kind:          models.DiscrepancyMissingLedgerAccount,
+				LegalEntityID: &w.LegalEntityID,
+				Currency:      &w.Currency,
+				Detail:        fmt.Sprintf("ledger account %s of a wallet does not exist", id.Hex()),
+			})
+		}
+		totals[key] += b.Total()
+	}
+
+	for _, le := range entities {
+		for _, c := range le.SupportedCurrencies {
+			code := ledger.CurrencyFromString(c)
+			if code == 0 {
+				continue
+			}
+			if b, ok := balances[ledger.SuspenseAccountID(le.ID, code)]; ok {
+				totals[accountKey{le.ID, models.BankAccountFBO, c}] += b.Total()
+			}
+		}
+	}
+
+	// FBO accounts without wallets must hold nothing
+	byKey := indexBankBalances(bank)
+	for key := range byKey {
+		if _, ok := totals[key]; !ok && key.kind == models.BankAccountFBO {
+			totals[key] = 0
+		}
+	}
+
+	out = append(out, compareTotals(totals, byKey, models.DiscrepancyFBOMismatch)...)
+	return len(totals), out
+}
+
+// compareNostro checks, per currency, that the REGIONAL_SETTLEMENT ledger
+// account matches the Nostro bank balances of the legal entities settling
+// that currency. The ledger keeps one settlement account per currency, not
+// per legal entity, so it is compared with the sum of their Nostro
+// balances, and only once every one of them is recorded: a partial sum
+// would report a mismatch that is not there. Settlement accounts are
+// assets, so their balance is debits - credits.
+func compareNostro(entities []*models.LegalEntity, balances map[ledger.AccountID]ledger.Balance, bank []*models.BankBalance) (int, []models.ReconciliationDiscrepancy) {
+	var out []models.ReconciliationDiscrepancy
+	byKey := indexBankBalances(bank)
+
+	holders := make(map[string][]uuid.UUID)
+	for _, le := range entities {
+		for _, c := range le.SupportedCurrencies {
+			holders[c] = append(holders[c], le.ID)
+		}
+	}
+
+	currencies := make([]string, 0, len(holders))
+	for c := range holders {
+		currencies = append(currencies, c)
+	}
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		code := ledger.CurrencyFromString(currency)
+		if code == 0 {
+			continue
+		}
+		b := balances[ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, code)]
+		ledgerAmount := minorToMajor(int64(b.Debits) - int64(b.Credits))
+
+		var legalEntityID *uuid.UUID
+		if ids := holders[currency]; len(ids) == 1 {
+			legalEntityID = &ids[0]
+		}
+
+		bankAmount := decimal.Zero
+		var asOf *time.Time
+		var missing []uuid.UUID
+		for _, id := range holders[currency] {
+			bb, ok := byKey[accountKey{id, models.BankAccountNostro, currency}]
+			if !ok {
+				missing = append(missing, id)
+				continue
+			}
+			bankAmount = bankAmount.Add(bb.Balance)
+			asOf = &bb.AsOf
+		}
+
+		cur := currency
+		switch {
+		case asOf == nil:
+			if !ledgerAmount.IsZero() {
+				out = append(out, models.ReconciliationDiscrepancy{
+					Kind:          models.DiscrepancyMissingBankBalance,
+					LegalEntityID: legalEntityID,
+					Currency:      &cur,
+					LedgerAmount:  &ledgerAmount,
+					Detail:        "no Nostro balance recorded for " + currency,
+				})
+			}
+		case len(missing) > 0:
+			for _, id := range missing {
+				out = append(out, models.ReconciliationDiscrepancy{
+					Kind:          models.DiscrepancyMissingBankBalance,
+					LegalEntityID: &id,
+					Currency:      &cur,
+					LedgerAmount:  &ledgerAmount,
+					Detail: fmt.Sprintf("no Nostro balance recorded for %s, which REGIONAL_SETTLEMENT %s is compared with together with those of the %d other legal entities settling it",
+						currency, currency, len(holders[currency])-1),
+				})
+			}
+		case !ledgerAmount.Equal(bankAmount):
+			diff := ledgerAmount.Sub(bankAmount)
+			out = append(out, models.ReconciliationDiscrepancy{
+				Kind:            models.DiscrepancyNostroMismatch,
+				LegalEntityID:   legalEntityID,
+				Currency:        &cur,
+				LedgerAmount:    &ledgerAmount,
+				BankAmount:      &bankAmount,
+				Difference:      &diff,
+				BankBalanceAsOf: asOf,
+				Detail:          fmt.Sprintf("REGIONAL_SETTLEMENT %s is %s, Nostro balance is %s", currency, ledgerAmount.StringFixed(2), bankAmount.StringFixed(2)),
+			})
+		}
+	}
+
+	return len(currencies), out
+}
+
+// compareTotals compares ledger totals in minor units with bank balances.
+func compareTotals(totals map[accountKey]int64, bank map[accountKey]*models.BankBalance, mismatch models.DiscrepancyKind) []models.ReconciliationDiscrepancy {
+	keys := make([]accountKey, 0, len(totals))
+	for k := range totals {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].legalEntityID != keys[j].legalEntityID {
+			return keys[i].legalEntityID.String() < keys[j].legalEntityID.String()
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	var out []models.ReconciliationDiscrepancy
+	for _, key := range keys {
+		legalEntityID, currency := key.legalEntityID, key.currency
+		ledgerAmount := minorToMajor(totals[key])
+
+		b, ok := bank[key]
+		if !ok {
+			if !ledgerAmount.IsZero() {
+				out = append(out, models.ReconciliationDiscrepancy{
+					Kind:          models.DiscrepancyMissingBankBalance,
+					LegalEntityID: &legalEntityID,
+					Currency:      &currency,
+					LedgerAmount:  &ledgerAmount,
+					Detail:        fmt.Sprintf("no %s balance recorded for %s", key.kind, currency),
+				})
+			}
+			continue
+		}
+
+		if !ledgerAmount.Equal(b.Balance) {
+			bankAmount := b.Balance
+			diff := ledgerAmount.Sub(bankAmount)
+			asOf := b.AsOf
+			out = append(out, models.ReconciliationDiscrepancy{
+				Kind:            mismatch,
+				LegalEntityID:   &legalEntityID,
+				Currency:        &currency,
+				LedgerAmount:    &ledgerAmount,
+				BankAmount:      &bankAmount,
+				Difference:      &diff,
+				BankBalanceAsOf: &asOf,
+				Detail:          fmt.Sprintf("ledger %s is %s, %s balance is %s", currency, ledgerAmount.StringFixed(2), key.kind, bankAmount.StringFixed(2)),
+			})
+		}
+	}
+	return out
+}
+
+func minorToMajor(minor int64) decimal.Decimal {
+	return decimal.New(minor, minorUnitExp)
+}
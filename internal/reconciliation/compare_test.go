@@ -0,0 +1,170 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+var asOf = time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+func wallet(tenant uint64, currency string, legalEntityID uuid.UUID) (*models.WalletLedgerAccount, ledger.AccountID) {
+	id := ledger.NewAccountID(tenant, ledger.AccountTypeTenantWallet, ledger.CurrencyFromString(currency))
+	return &models.WalletLedgerAccount{TBAccountID: id.ToBigInt(), Currency: currency, LegalEntityID: legalEntityID}, id
+}
+
+func bankBalance(legalEntityID uuid.UUID, kind models.BankAccountKind, currency, balance string) *models.BankBalance {
+	return &models.BankBalance{
+		LegalEntityID: legalEntityID,
+		AccountKind:   kind,
+		Currency:      currency,
+		Balance:       decimal.RequireFromString(balance),
+		AsOf:          asOf,
+	}
+}
+
+func TestCompareFBOMatches(t *testing.T) {
+	le := uuid.New()
+	w1, id1 := wallet(1, "USD", le)
+	w2, id2 := wallet(2, "USD", le)
+	balances := map[ledger.AccountID]ledger.Balance{
+		id1: {Credits: 150000, Debits: 50000},
+		id2: {Credits: 2550, Pending: 1000},
+	}
+	bank := []*models.BankBalance{bankBalance(le, models.BankAccountFBO, "USD", "1025.50")}
+
+	checked, discrepancies := compareFBO([]*models.WalletLedgerAccount{w1, w2}, nil, balances, bank)
+	assert.Equal(t, 1, checked)
+	assert.Empty(t, discrepancies)
+}
+
+func TestCompareFBOMismatch(t *testing.T) {
+	le := uuid.New()
+	w, id := wallet(1, "EUR", le)
+	balances := map[ledger.AccountID]ledger.Balance{id: {Credits: 10000}}
+	bank := []*models.BankBalance{bankBalance(le, models.BankAccountFBO, "EUR", "99.00")}
+
+	_, discrepancies := compareFBO([]*models.WalletLedgerAccount{w}, nil, balances, bank)
+	require.Len(t, discrepancies, 1)
+
+	d := discrepancies[0]
+	assert.Equal(t, models.DiscrepancyFBOMismatch, d.Kind)
+	assert.Equal(t, le, *d.LegalEntityID)
+	assert.Equal(t, "100.00", d.LedgerAmount.StringFixed(2))
+	assert.Equal(t, "99.00", d.BankAmount.StringFixed(2))
+	assert.Equal(t, "1.00", d.Difference.StringFixed(2))
+	assert.Equal(t, asOf, *d.BankBalanceAsOf)
+}
+
+func TestCompareFBOMissingBalances(t *testing.T) {
+	le := uuid.New()
+	funded, fundedID := wallet(1, "USD", le)
+	empty, _ := wallet(2, "GBP", le)
+	balances := map[ledger.AccountID]ledger.Balance{fundedID: {Credits: 500}}
+
+	// A funded wallet without a bank balance, and a wallet missing from the ledger
+	_, discrepancies := compareFBO([]*models.WalletLedgerAccount{funded, empty}, nil, balances, nil)
+
+	kinds := make([]models.DiscrepancyKind, len(discrepancies))
+	for i, d := range discrepancies {
+		kinds[i] = d.Kind
+	}
+	assert.ElementsMatch(t, []models.DiscrepancyKind{
+		models.DiscrepancyMissingLedgerAccount,
+		models.DiscrepancyMissingBankBalance,
+	}, kinds)
+}
+
+func TestCompareFBOBankBalanceWithoutWallets(t *testing.T) {
+	le := uuid.New()
+	bank := []*models.BankBalance{
+		bankBalance(le, models.BankAccountFBO, "SGD", "12.34"),
+		bankBalance(le, models.BankAccountNostro, "SGD", "500.00"),
+	}
+
+	checked, discrepancies := compareFBO(nil, nil, nil, bank)
+	assert.Equal(t, 1, checked)
+	require.Len(t, discrepancies, 1)
+	assert.Equal(t, models.DiscrepancyFBOMismatch, discrepancies[0].Kind)
+	assert.Equal(t, "-12.34", discrepancies[0].Difference.StringFixed(2))
+}
+
+func TestCompareFBOIncludesSuspense(t *testing.T) {
+	le := &models.LegalEntity{ID: uuid.New(), SupportedCurrencies: []string{"EUR"}}
+	w, id := wallet(1, "EUR", le.ID)
+	balances := map[ledger.AccountID]ledger.Balance{
+		id: {Credits: 10000},
+		ledger.SuspenseAccountID(le.ID, ledger.CurrencyEUR): {Credits: 2500},
+	}
+	bank := []*models.BankBalance{bankBalance(le.ID, models.BankAccountFBO, "EUR", "125.00")}
+
+	_, discrepancies := compareFBO([]*models.WalletLedgerAccount{w}, []*models.LegalEntity{le}, balances, bank)
+	assert.Empty(t, discrepancies)
+}
+
+func TestCompareNostroSumsLegalEntities(t *testing.T) {
+	eu, uk := uuid.New(), uuid.New()
+	entities := []*models.LegalEntity{
+		{ID: eu, SupportedCurrencies: []string{"EUR"}},
+		{ID: uk, SupportedCurrencies: []string{"EUR", "GBP"}},
+	}
+	eurSettlement := ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, ledger.CurrencyFromString("EUR"))
+	gbpSettlement := ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, ledger.CurrencyFromString("GBP"))
+	balances := map[ledger.AccountID]ledger.Balance{
+		eurSettlement: {Debits: 300000, Credits: 50000},
+		gbpSettlement: {Debits: 10000},
+	}
+	bank := []*models.BankBalance{
+		bankBalance(eu, models.BankAccountNostro, "EUR", "1500.00"),
+		bankBalance(uk, models.BankAccountNostro, "EUR", "1000.00"),
+		bankBalance(uk, models.BankAccountNostro, "GBP", "90.00"),
+	}
+
+	checked, discrepancies := compareNostro(entities, balances, bank)
+	assert.Equal(t, 2, checked)
+	require.Len(t, discrepancies, 1)
+
+	d := discrepancies[0]
+	assert.Equal(t, models.DiscrepancyNostroMismatch, d.Kind)
+	assert.Equal(t, "GBP", *d.Currency)
+	assert.Equal(t, uk, *d.LegalEntityID)
+	assert.Equal(t, "10.00", d.Difference.StringFixed(2))
+}
+
+func TestCompareNostroMissingBankBalance(t *testing.T) {
+	eu, uk := uuid.New(), uuid.New()
+	entities := []*models.LegalEntity{
+		{ID: eu, SupportedCurrencies: []string{"EUR"}},
+		{ID: uk, SupportedCurrencies: []string{"EUR"}},
+	}
+	settlement := ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, ledger.CurrencyFromString("EUR"))
+	balances := map[ledger.AccountID]ledger.Balance{settlement: {Debits: 100}}
+
+	_, discrepancies := compareNostro(entities, balances, nil)
+	require.Len(t, discrepancies, 1)
+	assert.Equal(t, models.DiscrepancyMissingBankBalance, discrepancies[0].Kind)
+	assert.Nil(t, discrepancies[0].LegalEntityID)
+}
+
+func TestCompareNostroPartialBankBalances(t *testing.T) {
+	eu, uk := uuid.New(), uuid.New()
+	entities := []*models.LegalEntity{
+		{ID: eu, SupportedCurrencies: []string{"EUR"}},
+		{ID: uk, SupportedCurrencies: []string{"EUR"}},
+	}
+	settlement := ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, ledger.CurrencyFromString("EUR"))
+	balances := map[ledger.AccountID]ledger.Balance{settlement: {Debits: 250000}}
+	bank := []*models.BankBalance{bankBalance(eu, models.BankAccountNostro, "EUR", "1500.00")}
+
+	_, discrepancies := compareNostro(entities, balances, bank)
+	require.Len(t, discrepancies, 1, "the settlement account is not compared with the EU Nostro alone")
+	assert.Equal(t, models.DiscrepancyMissingBankBalance, discrepancies[0].Kind)
+	assert.Equal(t, uk, *discrepancies[0].LegalEntityID)
+}
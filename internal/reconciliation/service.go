@@ -0,0 +1,344 @@
+// Package reconciliation checks the ledger against the bank and PostgreSQL:
+// the tenant wallets and suspense accounts against the FBO bank accounts,
+// the regional settlement accounts against the Nostro accounts, and the
+// ledger transfers recorded on every transfer against the ledger itself.
+// Each run stores a report of the discrepancies for operations to review
+// and sign off.
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrReportNotFound  = errors.New("reconciliation report not found")
+	ErrReportSignedOff = errors.New("reconciliation report is already signed off")
+	ErrNoteRequired    = errors.New("a note is required to sign off a report with discrepancies")
+
+	ErrBusinessDateOpen = errors.New("business date has not ended")
+
+	ErrLegalEntityNotFound  = errors.New("legal entity not found")
+	ErrCurrencyNotSupported = errors.New("legal entity does not support the currency")
+)
+
+const transferBatchSize = 1000
+
+// Ledger is the part of the ledger client reconciliation reads.
+type Ledger interface {
+	BalancesAsOf(ctx context.Context, ids []ledger.AccountID, cutoff time.Time) (map[ledger.AccountID]ledger.Balance, error)
+	ExistingTransfers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// SignOff is a reviewer's sign-off of a report.
+type SignOff struct {
+	Reviewer string
+	Note     *string
+}
+
+// Service runs reconciliations and manages their review.
+type Service struct {
+	db              *db.DB
+	repo            *repository.ReconciliationRepository
+	legalEntityRepo *repository.LegalEntityRepository
+	ledger          Ledger
+	trail           *audit.Trail
+	now             func() time.Time
+}
+
+// NewService creates a new reconciliation service.
+func NewService(
+	database *db.DB,
+	repo *repository.ReconciliationRepository,
+	legalEntityRepo *repository.LegalEntityRepository,
+	ledgerClient Ledger,
+	trail *audit.Trail,
+) *Service {
+	return &Service{
+		db:              database,
+		repo:            repo,
+		legalEntityRepo: legalEntityRepo,
+		ledger:          ledgerClient,
+		trail:           trail,
+		now:             time.Now,
+	}
+}
+
+// Run reconciles the ledger for a business date, which must have ended, and
+// stores the report. Ledger balances are read as of the cut-off at the end
+// of the date, UTC, and compared with the closing bank balances recorded for
+// that date, so that payments booked after the statement was drawn do not
+// show as discrepancies.
+func (s *Service) Run(ctx context.Context, businessDate time.Time) (*models.ReconciliationReport, error) {
+	businessDate = time.Date(businessDate.Year(), businessDate.Month(), businessDate.Day(), 0, 0, 0, 0, time.UTC)
+	cutoff := businessDate.AddDate(0, 0, 1)
+	if cutoff.After(s.now()) {
+		return nil, ErrBusinessDateOpen
+	}
+
+	bank, err := s.repo.ListBankBalancesAsOf(ctx, businessDate)
+	if err != nil {
+		return nil, fmt.Errorf("list bank balances: %w", err)
+	}
+
+	wallets, err := s.repo.ListWalletLedgerAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list wallet accounts: %w", err)
+	}
+	entities, err := s.legalEntityRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list legal entities: %w", err)
+	}
+
+	// One lookup for every wallet, suspense and settlement account
+	ids := make([]ledger.AccountID, 0, len(wallets)+len(entities))
+	for _, w := range wallets {
+		ids = append(ids, ledger.FromBigInt(w.TBAccountID))
+	}
+	for _, le := range entities {
+		for _, c := range le.SupportedCurrencies {
+			if code := ledger.CurrencyFromString(c); code != 0 {
+				ids = append(ids,
+					ledger.SuspenseAccountID(le.ID, code),
+					ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeRegionalSettlement, code),
+				)
+			}
+		}
+	}
+	balances, err := s.ledger.BalancesAsOf(ctx, ids, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("get ledger balances: %w", err)
+	}
+
+	fboChecked, discrepancies := compareFBO(wallets, entities, balances, bank)
+	nostroChecked, nostro := compareNostro(entities, balances, bank)
+	discrepancies = append(discrepancies, nostro...)
+
+	transfersChecked, missing, err := s.checkTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	discrepancies = append(discrepancies, missing...)
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ReconciliationReport, error) {
+		return s.repo.WithTx(tx).CreateReport(ctx, models.CreateReconciliationReportParams{
+			BusinessDate:     businessDate,
+			BalancesChecked:  fboChecked + nostroChecked,
+			TransfersChecked: transfersChecked,
+			Discrepancies:    discrepancies,
+		})
+	})
+}
+
+// checkTransfers checks that every ledger transfer recorded on a transfer
+// exists in the ledger, reading the transfers of the primary and of every
+// regional database.
+func (s *Service) checkTransfers(ctx context.Context) (int, []models.ReconciliationDiscrepancy, error) {
+	var out []models.ReconciliationDiscrepancy
+	checked := 0
+	for _, dbCtx := range s.db.Databases(ctx) {
+		n, missing, err := s.checkDatabaseTransfers(ctx, dbCtx)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s: %w", s.db.Name(dbCtx), err)
+		}
+		checked += n
+		out = append(out, missing...)
+	}
+	return checked, out, nil
+}
+
+// checkDatabaseTransfers checks the transfers of the database dbCtx is routed
+// to against the ledger.
+func (s *Service) checkDatabaseTransfers(ctx, dbCtx context.Context) (int, []models.ReconciliationDiscrepancy, error) {
+	var out []models.ReconciliationDiscrepancy
+	checked := 0
+	after := uuid.Nil
+
+	for {
+		batch, err := s.repo.ListTransferLedgerIDs(dbCtx, after, transferBatchSize)
+		if err != nil {
+			return 0, nil, fmt.Errorf("list transfer ledger ids: %w", err)
+		}
+
+		var ids []uuid.UUID
+		for _, t := range batch {
+			for _, n := range t.TBTransferIDs {
+				ids = append(ids, ledger.TransferIDFromBigInt(n))
+			}
+		}
+		found, err := s.ledger.ExistingTransfers(ctx, ids)
+		if err != nil {
+			return 0, nil, fmt.Errorf("lookup ledger transfers: %w", err)
+		}
+
+		for _, t := range batch {
+			var missing []string
+			for _, n := range t.TBTransferIDs {
+				if id := ledger.TransferIDFromBigInt(n); !found[id] {
+					missing = append(missing, id.String())
+				}
+			}
+			if len(missing) > 0 {
+				transferID := t.TransferID
+				out = append(out, models.ReconciliationDiscrepancy{
+					Kind:       models.DiscrepancyMissingLedgerTransfer,
+					TransferID: &transferID,
+					Detail:     "ledger transfers not found: " + strings.Join(missing, ", "),
+				})
+			}
+			after = t.TransferID
+		}
+		checked += len(batch)
+
+		if len(batch) < transferBatchSize {
+			return checked, out, nil
+		}
+	}
+}
+
+// RecordBankBalance records the closing balance of a legal entity's FBO or
+// Nostro account, as read from a bank statement.
+func (s *Service) RecordBankBalance(ctx context.Context, params models.CreateBankBalanceParams) (*models.BankBalance, error) {
+	le, err := s.legalEntityRepo.GetByID(ctx, params.LegalEntityID)
+	if err != nil {
+		return nil, fmt.Errorf("get legal entity: %w", err)
+	}
+	if le == nil {
+		return nil, ErrLegalEntityNotFound
+	}
+	if !slices.Contains(le.SupportedCurrencies, params.Currency) {
+		return nil, ErrCurrencyNotSupported
+	}
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.BankBalance, error) {
+		balance, err := s.repo.WithTx(tx).RecordBankBalance(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("record bank balance: %w", err)
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			Region:       models.ComplianceRegionForJurisdiction(le.Jurisdiction),
+			ResourceType: audit.ResourceBankBalance,
+			ResourceID:   balance.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        balance,
+		}); err != nil {
+			return nil, err
+		}
+		return balance, nil
+	})
+}
+
+// SignOff marks a report as reviewed. Reports with discrepancies need a
+// note explaining how they were resolved.
+func (s *Service) SignOff(ctx context.Context, id uuid.UUID, signOff SignOff) (*models.ReconciliationReport, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.ReconciliationReport, error) {
+		repo := s.repo.WithTx(tx)
+		report, err := repo.GetReportForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get report: %w", err)
+		}
+		if report == nil {
+			return nil, ErrReportNotFound
+		}
+		if report.Status == models.ReconciliationStatusSignedOff {
+			return nil, ErrReportSignedOff
+		}
+		if report.DiscrepancyCount > 0 && (signOff.Note == nil || strings.TrimSpace(*signOff.Note) == "") {
+			return nil, ErrNoteRequired
+		}
+
+		if err := repo.SignOff(ctx, id, signOff.Reviewer, signOff.Note); err != nil {
+			return nil, fmt.Errorf("sign off report: %w", err)
+		}
+		signed, err := repo.GetReport(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			ResourceType: audit.ResourceReconciliationReport,
+			ResourceID:   id.String(),
+			Action:       audit.ActionSignOff,
+			Before:       report,
+			After:        signed,
+		}); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	})
+}
+
+// RunArgs are the arguments of the reconciliation job.
+type RunArgs struct {
+	// BusinessDate is the date reconciled, as YYYY-MM-DD. Empty means the
+	// previous UTC date, the last one whose statements are closed.
+	BusinessDate string `json:"business_date,omitempty"`
+}
+
+// Kind returns the job kind.
+func (RunArgs) Kind() string { return "reconciliation.run" }
+
+// InsertOpts returns the default insert options.
+func (RunArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3}
+}
+
+// RunWorker runs a reconciliation.
+type RunWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewRunWorker creates a new reconciliation worker.
+func NewRunWorker(service *Service, logger *zap.Logger) *RunWorker {
+	return &RunWorker{service: service, logger: logger}
+}
+
+// Work runs the reconciliation and logs a summary.
+func (w *RunWorker) Work(ctx context.Context, job *jobs.Job[RunArgs]) error {
+	date := w.service.now().UTC().AddDate(0, 0, -1)
+	if job.Args.BusinessDate != "" {
+		d, err := time.Parse(time.DateOnly, job.Args.BusinessDate)
+		if err != nil {
+			return jobs.Cancel(fmt.Errorf("invalid business date: %w", err))
+		}
+		date = d
+	}
+
+	report, err := w.service.Run(ctx, date)
+	if err != nil {
+		if errors.Is(err, ErrBusinessDateOpen) {
+			return jobs.Cancel(err)
+		}
+		return err
+	}
+
+	log := w.logger.Info
+	if report.DiscrepancyCount > 0 {
+		log = w.logger.Warn
+	}
+	log("reconciliation finished",
+		zap.String("report_id", report.ID.String()),
+		zap.String("business_date", report.BusinessDate.Format(time.DateOnly)),
+		zap.Int("balances_checked", report.BalancesChecked),
+		zap.Int("transfers_checked", report.TransfersChecked),
+		zap.Int("discrepancies", report.DiscrepancyCount),
+	)
+	return nil
+}
@@ -0,0 +1,49 @@
+package refund
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+)
+
+// PostArgs are the arguments of the job that posts a refund to the ledger.
+type PostArgs struct {
+	RefundID uuid.UUID `json:"refund_id"`
+}
+
+// Kind returns the job kind.
+func (PostArgs) Kind() string { return "refund.post" }
+
+// InsertOpts returns the default insert options.
+func (PostArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: jobs.DefaultQueue, MaxAttempts: 10}
+}
+
+// PostWorker posts pending refunds.
+type PostWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewPostWorker creates a new refund posting worker.
+func NewPostWorker(service *Service, logger *zap.Logger) *PostWorker {
+	return &PostWorker{service: service, logger: logger}
+}
+
+// Work posts the refund.
+func (w *PostWorker) Work(ctx context.Context, job *jobs.Job[PostArgs]) error {
+	err := w.service.Post(ctx, job.Args.RefundID)
+	if errors.Is(err, ErrRefundNotFound) {
+		return jobs.Cancel(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("refund posted", zap.String("refund_id", job.Args.RefundID.String()))
+	return nil
+}
@@ -0,0 +1,293 @@
+// Package refund returns completed transfers to the tenant's wallet, in
+// full or in part, for customer requests and for rail returns such as SEPA
+// R-transactions. A refund is recorded under a lock on its transfer, so the
+// refunds of a transfer never exceed its payout and fee, and posted to the
+// ledger in the background as transfers reversing the payout.
+package refund
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/outbox"
+	"kovra/internal/repository"
+	"kovra/internal/webhook"
+)
+
+var (
+	ErrTransferNotFound     = errors.New("transfer not found")
+	ErrTransferNotCompleted = errors.New("only completed transfers can be refunded")
+	ErrExceedsRefundable    = errors.New("refund exceeds the refundable remainder of the transfer")
+	ErrInvalidRefund        = errors.New("invalid refund")
+	ErrNoWallet             = errors.New("tenant has no wallet in the source currency")
+	ErrRefundNotFound       = errors.New("refund not found")
+)
+
+// Ledger is the part of the ledger client refunds use.
+type Ledger interface {
+	PostRefund(ctx context.Context, r ledger.Refund) error
+}
+
+// Request is a request to refund a transfer.
+type Request struct {
+	// Amount is the part of the payout to refund in the source currency;
+	// nil refunds all that remains.
+	Amount *decimal.Decimal
+	// ReverseFee also refunds what remains of the fee.
+	ReverseFee bool
+	Reason     string
+	// ReturnCode is the return reason code of the rail, for rail returns.
+	ReturnCode     *string
+	IdempotencyKey *string
+}
+
+// Service records and posts refunds.
+type Service struct {
+	db           *db.DB
+	repo         *repository.RefundRepository
+	transferRepo *repository.TransferRepository
+	walletRepo   *repository.WalletRepository
+	outboxRepo   *repository.OutboxRepository
+	ledger       Ledger
+	jobClient    *jobs.Client
+	trail        *audit.Trail
+}
+
+// NewService creates a new refund service.
+func NewService(
+	database *db.DB,
+	repo *repository.RefundRepository,
+	transferRepo *repository.TransferRepository,
+	walletRepo *repository.WalletRepository,
+	outboxRepo *repository.OutboxRepository,
+	ledgerClient Ledger,
+	jobClient *jobs.Client,
+	trail *audit.Trail,
+) *Service {
+	return &Service{
+		db:           database,
+		repo:         repo,
+		transferRepo: transferRepo,
+		walletRepo:   walletRepo,
+		outboxRepo:   outboxRepo,
+		ledger:       ledgerClient,
+		jobClient:    jobClient,
+		trail:        trail,
+	}
+}
+
+// Refund records a refund of a completed transfer and enqueues its posting.
+// A request repeating the idempotency key of an earlier refund of the
+// transfer returns that refund.
+func (s *Service) Refund(ctx context.Context, transferID uuid.UUID, req Request) (*models.Refund, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.Refund, error) {
+		return s.RefundTx(ctx, tx, transferID, req)
+	})
+}
+
+// RefundTx is Refund within the caller's transaction, for callers that
+// refund a transfer as part of a larger change, such as a rail return.
+func (s *Service) RefundTx(ctx context.Context, tx pgx.Tx, transferID uuid.UUID, req Request) (*models.Refund, error) {
+	if req.Reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidRefund)
+	}
+
+	repo := s.repo.WithTx(tx)
+
+	transfer, err := s.transferRepo.WithTx(tx).GetForUpdate(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("get transfer: %w", err)
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	if req.IdempotencyKey != nil {
+		existing, err := repo.GetByIdempotencyKey(ctx, transferID, *req.IdempotencyKey)
+		if err != nil {
+			return nil, fmt.Errorf("get refund by idempotency key: %w", err)
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	if transfer.Status != models.TransferStatusCompleted {
+		return nil, ErrTransferNotCompleted
+	}
+
+	totals, err := repo.Totals(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("get refund totals: %w", err)
+	}
+	amount, fee, err := refundable(transfer, totals, req)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.WithTx(tx).GetByTenantAndCurrency(ctx, transfer.TenantID, transfer.FromCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("get wallet: %w", err)
+	}
+	if wallet == nil {
+		return nil, ErrNoWallet
+	}
+
+	refund, err := repo.Create(ctx, models.CreateRefundParams{
+		TransferID:     transfer.ID,
+		TenantID:       transfer.TenantID,
+		Seq:            totals.Refunds + 1,
+		Currency:       transfer.FromCurrency,
+		Amount:         amount,
+		FeeAmount:      fee,
+		DestCurrency:   transfer.ToCurrency,
+		DestAmount:     destAmount(transfer, amount),
+		Reason:         req.Reason,
+		ReturnCode:     req.ReturnCode,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create refund: %w", err)
+	}
+
+	if _, err := s.jobClient.InsertTx(ctx, tx, PostArgs{RefundID: refund.ID}, nil); err != nil {
+		return nil, fmt.Errorf("enqueue posting: %w", err)
+	}
+
+	if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+		TenantID:     &transfer.TenantID,
+		Region:       transfer.ComplianceRegion,
+		ResourceType: audit.ResourceRefund,
+		ResourceID:   refund.ID.String(),
+		Action:       audit.ActionCreate,
+		After:        refund,
+	}); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// Post posts a pending refund to the ledger and marks it posted. The ledger
+// transfer IDs derive from the refund ID, so posting again is harmless.
+func (s *Service) Post(ctx context.Context, id uuid.UUID) error {
+	refund, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get refund: %w", err)
+	}
+	if refund == nil {
+		return ErrRefundNotFound
+	}
+	if refund.Status == models.RefundPosted {
+		return nil
+	}
+
+	wallet, err := s.walletRepo.GetByTenantAndCurrency(ctx, refund.TenantID, refund.Currency)
+	if err != nil {
+		return fmt.Errorf("get wallet: %w", err)
+	}
+	if wallet == nil {
+		return ErrNoWallet
+	}
+
+	if err := s.ledger.PostRefund(ctx, ledger.Refund{
+		ID:           refund.ID,
+		TransferID:   refund.TransferID,
+		Seq:          uint32(refund.Seq),
+		Wallet:       ledger.FromBigInt(wallet.TBAccountID),
+		Currency:     ledger.CurrencyFromString(refund.Currency),
+		Amount:       uint64(refund.Amount.Shift(2).IntPart()),
+		FeeAmount:    uint64(refund.FeeAmount.Shift(2).IntPart()),
+		DestCurrency: ledger.CurrencyFromString(refund.DestCurrency),
+		DestAmount:   uint64(refund.DestAmount.Shift(2).IntPart()),
+	}); err != nil {
+		return fmt.Errorf("post refund: %w", err)
+	}
+
+	// Marking the refund posted only once records the event only once
+	return s.db.WithTx(ctx, func(tx pgx.Tx) error {
+		posted, err := s.repo.WithTx(tx).MarkPosted(ctx, refund.ID)
+		if err != nil {
+			return fmt.Errorf("mark refund posted: %w", err)
+		}
+		if !posted {
+			return nil
+		}
+
+		event, err := outbox.NewEvent(models.AggregateWallet, wallet.ID, &refund.TenantID,
+			string(models.WebhookEventWalletCredited), webhook.WalletCredited{
+				WalletID:   wallet.ID,
+				Currency:   refund.Currency,
+				Amount:     refund.Total(),
+				Source:     webhook.CreditSourceRefund,
+				TransferID: &refund.TransferID,
+				RefundID:   &refund.ID,
+			})
+		if err != nil {
+			return err
+		}
+		return s.outboxRepo.WithTx(tx).Append(ctx, event)
+	})
+}
+
+// ListByTransfer returns the refunds of a transfer in order.
+func (s *Service) ListByTransfer(ctx context.Context, transferID uuid.UUID) ([]*models.Refund, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("get transfer: %w", err)
+	}
+	if transfer == nil {
+		return nil, ErrTransferNotFound
+	}
+	return s.repo.ListByTransfer(ctx, transferID)
+}
+
+// refundable returns the payout and fee a request refunds, given what has
+// been refunded of the transfer so far. The payout is the source amount
+// less the fee.
+func refundable(transfer *models.Transfer, totals models.RefundTotals, req Request) (amount, fee decimal.Decimal, err error) {
+	remaining := transfer.FromAmount.Sub(transfer.TotalFee).Sub(totals.Amount)
+	feeRemaining := transfer.TotalFee.Sub(totals.FeeAmount)
+
+	amount = remaining
+	if req.Amount != nil {
+		amount = *req.Amount
+		if !amount.IsPositive() {
+			return decimal.Zero, decimal.Zero, fmt.Errorf("%w: amount must be positive", ErrInvalidRefund)
+		}
+		if !amount.Equal(amount.Round(2)) {
+			return decimal.Zero, decimal.Zero, fmt.Errorf("%w: amount must have at most 2 decimal places", ErrInvalidRefund)
+		}
+		if amount.GreaterThan(remaining) {
+			return decimal.Zero, decimal.Zero, ErrExceedsRefundable
+		}
+	}
+
+	fee = decimal.Zero
+	if req.ReverseFee {
+		fee = feeRemaining
+	}
+
+	if !amount.Add(fee).IsPositive() {
+		return decimal.Zero, decimal.Zero, ErrExceedsRefundable
+	}
+	return amount, fee, nil
+}
+
+// destAmount returns the refunded payout in the destination currency, at
+// the rate of the transfer.
+func destAmount(transfer *models.Transfer, amount decimal.Decimal) decimal.Decimal {
+	if !transfer.IsFXTransfer() {
+		return amount
+	}
+	return amount.Mul(transfer.FXRate).Round(2)
+}
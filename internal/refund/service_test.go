@@ -0,0 +1,85 @@
+package refund
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+func d(s string) decimal.Decimal { return decimal.RequireFromString(s) }
+
+func TestRefundable(t *testing.T) {
+	// A payout of 995.00 EUR and a fee of 5.00
+	transfer := &models.Transfer{
+		FromCurrency: "EUR",
+		ToCurrency:   "IDR",
+		FromAmount:   d("1000.00"),
+		TotalFee:     d("5.00"),
+		FXRate:       d("17250.5"),
+	}
+
+	t.Run("full refund with fee", func(t *testing.T) {
+		amount, fee, err := refundable(transfer, models.RefundTotals{}, Request{ReverseFee: true})
+		require.NoError(t, err)
+		assert.True(t, amount.Equal(d("995")))
+		assert.True(t, fee.Equal(d("5")))
+	})
+
+	t.Run("rest after a partial refund", func(t *testing.T) {
+		totals := models.RefundTotals{Refunds: 1, Amount: d("400"), FeeAmount: d("5")}
+		amount, fee, err := refundable(transfer, totals, Request{ReverseFee: true})
+		require.NoError(t, err)
+		assert.True(t, amount.Equal(d("595")))
+		assert.True(t, fee.IsZero())
+	})
+
+	t.Run("partial refund within remainder", func(t *testing.T) {
+		amount := d("595")
+		totals := models.RefundTotals{Refunds: 1, Amount: d("400")}
+		got, fee, err := refundable(transfer, totals, Request{Amount: &amount})
+		require.NoError(t, err)
+		assert.True(t, got.Equal(amount))
+		assert.True(t, fee.IsZero())
+	})
+
+	t.Run("partial refund over remainder", func(t *testing.T) {
+		amount := d("595.01")
+		totals := models.RefundTotals{Refunds: 1, Amount: d("400")}
+		_, _, err := refundable(transfer, totals, Request{Amount: &amount})
+		assert.ErrorIs(t, err, ErrExceedsRefundable)
+	})
+
+	t.Run("nothing left", func(t *testing.T) {
+		totals := models.RefundTotals{Refunds: 2, Amount: d("995"), FeeAmount: d("5")}
+		_, _, err := refundable(transfer, totals, Request{ReverseFee: true})
+		assert.ErrorIs(t, err, ErrExceedsRefundable)
+	})
+
+	t.Run("fee only", func(t *testing.T) {
+		totals := models.RefundTotals{Refunds: 1, Amount: d("995")}
+		amount, fee, err := refundable(transfer, totals, Request{ReverseFee: true})
+		require.NoError(t, err)
+		assert.True(t, amount.IsZero())
+		assert.True(t, fee.Equal(d("5")))
+	})
+
+	for _, s := range []string{"0", "-1", "10.005"} {
+		t.Run("invalid amount "+s, func(t *testing.T) {
+			amount := d(s)
+			_, _, err := refundable(transfer, models.RefundTotals{}, Request{Amount: &amount})
+			assert.ErrorIs(t, err, ErrInvalidRefund)
+		})
+	}
+}
+
+func TestDestAmount(t *testing.T) {
+	fx := &models.Transfer{FromCurrency: "EUR", ToCurrency: "IDR", FXRate: d("17250.555")}
+	assert.True(t, destAmount(fx, d("10.00")).Equal(d("172505.55")))
+
+	same := &models.Transfer{FromCurrency: "EUR", ToCurrency: "EUR", FXRate: d("1")}
+	assert.True(t, destAmount(same, d("10.00")).Equal(d("10")))
+}
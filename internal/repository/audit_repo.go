@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// AuditRepository handles audit trail data access.
+type AuditRepository struct {
+	q *queries.Queries
+}
+
+// NewAuditRepository creates a new audit repository.
+func NewAuditRepository(pool *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *AuditRepository) WithTx(tx pgx.Tx) *AuditRepository {
+	return &AuditRepository{q: r.q.WithTx(tx)}
+}
+
+// Create appends an entry. The caller computes its chain position and hash.
+func (r *AuditRepository) Create(ctx context.Context, e *models.AuditEntry) error {
+	return r.q.CreateAuditEntry(ctx, queries.CreateAuditEntryParams{
+		ID:               e.ID,
+		ComplianceRegion: string(e.ComplianceRegion),
+		Seq:              e.Seq,
+		TenantID:         uuidToNullable(e.TenantID),
+		LegalEntityID:    uuidToNullable(e.LegalEntityID),
+		ResourceType:     e.ResourceType,
+		ResourceID:       e.ResourceID,
+		Action:           e.Action,
+		ActorType:        e.ActorType,
+		ActorID:          e.ActorID,
+		BeforeState:      e.BeforeState,
+		AfterState:       e.AfterState,
+		IpAddress:        e.IPAddress,
+		RequestID:        stringPtrToNullable(e.RequestID),
+		CreatedAt:        e.CreatedAt,
+		PrevHash:         e.PrevHash,
+		RowHash:          e.RowHash,
+	})
+}
+
+// GetChainHead retrieves the head of a region's chain.
+func (r *AuditRepository) GetChainHead(ctx context.Context, region models.ComplianceRegion) (*models.AuditChainHead, error) {
+	row, err := r.q.GetAuditChainHead(ctx, string(region))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.headToModel(row), nil
+}
+
+// GetChainHeadForUpdate retrieves the head of a region's chain and locks it
+// until the transaction ends.
+func (r *AuditRepository) GetChainHeadForUpdate(ctx context.Context, region models.ComplianceRegion) (*models.AuditChainHead, error) {
+	row, err := r.q.GetAuditChainHeadForUpdate(ctx, string(region))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.headToModel(row), nil
+}
+
+// ListChainHeads retrieves the head of every region's chain.
+func (r *AuditRepository) ListChainHeads(ctx context.Context) ([]*models.AuditChainHead, error) {
+	rows, err := r.q.ListAuditChainHeads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.AuditChainHead, len(rows))
+	for i, row := range rows {
+		result[i] = r.headToModel(row)
+	}
+	return result, nil
+}
+
+// ListChain retrieves up to limit entries of a region's chain after seq, in order.
+func (r *AuditRepository) ListChain(ctx context.Context, region models.ComplianceRegion, afterSeq int64, limit int) ([]*models.AuditEntry, error) {
+	rows, err := r.q.ListAuditChain(ctx, queries.ListAuditChainParams{
+		ComplianceRegion: string(region),
+		Seq:              afterSeq,
+		Limit:            int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// List retrieves entries matching the filter, newest first.
+func (r *AuditRepository) List(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEntry, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.q.ListAuditEntries(ctx, queries.ListAuditEntriesParams{
+		Limit:            int32(limit),
+		Offset:           int32(offset),
+		ComplianceRegion: complianceRegionToNullable(filter.ComplianceRegion),
+		TenantID:         uuidToNullable(filter.TenantID),
+		ResourceType:     stringPtrToNullable(filter.ResourceType),
+		ResourceID:       stringPtrToNullable(filter.ResourceID),
+		ActorID:          stringPtrToNullable(filter.ActorID),
+		RequestID:        stringPtrToNullable(filter.RequestID),
+		From:             timeToNullable(filter.From),
+		To:               timeToNullable(filter.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// GetTenantScope retrieves the legal entity and compliance region of a tenant.
+func (r *AuditRepository) GetTenantScope(ctx context.Context, tenantID uuid.UUID) (*models.AuditScope, error) {
+	row, err := r.q.GetTenantAuditScope(ctx, tenantID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuditScope{
+		LegalEntityID:    row.LegalEntityID,
+		ComplianceRegion: models.ComplianceRegionForJurisdiction(row.Jurisdiction),
+	}, nil
+}
+
+func (r *AuditRepository) toModel(row queries.AuditTrail) *models.AuditEntry {
+	e := &models.AuditEntry{
+		ID:               row.ID,
+		ComplianceRegion: models.ComplianceRegion(row.ComplianceRegion),
+		Seq:              row.Seq,
+		ResourceType:     row.ResourceType,
+		ResourceID:       row.ResourceID,
+		Action:           row.Action,
+		ActorType:        row.ActorType,
+		ActorID:          row.ActorID,
+		IPAddress:        row.IpAddress,
+		CreatedAt:        row.CreatedAt,
+		PrevHash:         row.PrevHash,
+		RowHash:          row.RowHash,
+	}
+
+	if row.TenantID.Valid {
+		id := uuid.UUID(row.TenantID.Bytes)
+		e.TenantID = &id
+	}
+	if row.LegalEntityID.Valid {
+		id := uuid.UUID(row.LegalEntityID.Bytes)
+		e.LegalEntityID = &id
+	}
+	if row.BeforeState != nil {
+		e.BeforeState = json.RawMessage(row.BeforeState)
+	}
+	if row.AfterState != nil {
+		e.AfterState = json.RawMessage(row.AfterState)
+	}
+	if row.RequestID.Valid {
+		e.RequestID = &row.RequestID.String
+	}
+
+	return e
+}
+
+func (r *AuditRepository) toModels(rows []queries.AuditTrail) []*models.AuditEntry {
+	result := make([]*models.AuditEntry, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result
+}
+
+func (r *AuditRepository) headToModel(row queries.AuditChainHead) *models.AuditChainHead {
+	return &models.AuditChainHead{
+		ComplianceRegion: models.ComplianceRegion(row.ComplianceRegion),
+		Seq:              row.Seq,
+		RowHash:          row.RowHash,
+		UpdatedAt:        row.UpdatedAt,
+	}
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// BankStatementRepository handles imported bank statements.
+type BankStatementRepository struct {
+	q *queries.Queries
+}
+
+// NewBankStatementRepository creates a new bank statement repository.
+func NewBankStatementRepository(pool *pgxpool.Pool) *BankStatementRepository {
+	return &BankStatementRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *BankStatementRepository) WithTx(tx pgx.Tx) *BankStatementRepository {
+	return &BankStatementRepository{q: r.q.WithTx(tx)}
+}
+
+// Create stores a statement and its entries. It returns nil if the statement
+// was already imported. Call it in a transaction.
+func (r *BankStatementRepository) Create(ctx context.Context, params models.CreateBankStatementParams) (*models.BankStatement, error) {
+	row, err := r.q.CreateBankStatement(ctx, queries.CreateBankStatementParams{
+		LegalEntityID:     params.LegalEntityID,
+		AccountKind:       string(params.AccountKind),
+		AccountIdentifier: params.AccountIdentifier,
+		Currency:          params.Currency,
+		Format:            params.Format,
+		ExternalID:        params.ExternalID,
+		OpeningBalance:    decimalPtrToNumeric(params.OpeningBalance),
+		ClosingBalance:    decimalPtrToNumeric(params.ClosingBalance),
+		FromDate:          datePtrToPg(params.FromDate),
+		ToDate:            dateToPg(params.ToDate),
+		EntryCount:        int32(len(params.Entries)),
+		ImportedBy:        params.ImportedBy,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range params.Entries {
+		err := r.q.CreateBankStatementEntry(ctx, queries.CreateBankStatementEntryParams{
+			StatementID:   row.ID,
+			LegalEntityID: row.LegalEntityID,
+			AccountKind:   row.AccountKind,
+			Amount:        decimalToNumeric(e.Amount),
+			Currency:      e.Currency,
+			ValueDate:     dateToPg(e.ValueDate),
+			BookingDate:   datePtrToPg(e.BookingDate),
+			Reference:     e.Reference,
+			Counterparty:  stringPtrToNullable(e.Counterparty),
+			BankReference: stringPtrToNullable(e.BankReference),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bankStatementToModel(row), nil
+}
+
+// GetByID retrieves a statement by ID.
+func (r *BankStatementRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.BankStatement, error) {
+	row, err := r.q.GetBankStatement(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementToModel(row), nil
+}
+
+// List returns statements, newest first.
+func (r *BankStatementRepository) List(ctx context.Context, filter models.BankStatementFilter) ([]*models.BankStatement, error) {
+	var kind pgtype.Text
+	if filter.AccountKind != nil {
+		kind = pgtype.Text{String: string(*filter.AccountKind), Valid: true}
+	}
+
+	rows, err := r.q.ListBankStatements(ctx, queries.ListBankStatementsParams{
+		Limit:         int32(filter.Limit),
+		Offset:        int32(filter.Offset),
+		LegalEntityID: uuidToNullable(filter.LegalEntityID),
+		AccountKind:   kind,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.BankStatement, len(rows))
+	for i, row := range rows {
+		result[i] = bankStatementToModel(row)
+	}
+	return result, nil
+}
+
+// ListEntries returns the entries of a statement in value date order.
+func (r *BankStatementRepository) ListEntries(ctx context.Context, statementID uuid.UUID) ([]*models.BankStatementEntry, error) {
+	rows, err := r.q.ListBankStatementEntries(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementEntriesToModels(rows), nil
+}
+
+// GetEntry retrieves a statement entry by ID.
+func (r *BankStatementRepository) GetEntry(ctx context.Context, id uuid.UUID) (*models.BankStatementEntry, error) {
+	row, err := r.q.GetBankStatementEntry(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementEntryToModel(row), nil
+}
+
+// GetEntryForUpdate retrieves a statement entry and locks it until the
+// transaction ends.
+func (r *BankStatementRepository) GetEntryForUpdate(ctx context.Context, id uuid.UUID) (*models.BankStatementEntry, error) {
+	row, err := r.q.GetBankStatementEntryForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementEntryToModel(row), nil
+}
+
+// ListOpenEntries returns the entries waiting for a match, oldest first.
+func (r *BankStatementRepository) ListOpenEntries(ctx context.Context, filter models.StatementEntryFilter) ([]*models.BankStatementEntry, error) {
+	var status pgtype.Text
+	if filter.MatchStatus != nil {
+		status = pgtype.Text{String: string(*filter.MatchStatus), Valid: true}
+	}
+
+	rows, err := r.q.ListOpenBankStatementEntries(ctx, queries.ListOpenBankStatementEntriesParams{
+		Limit:         int32(filter.Limit),
+		Offset:        int32(filter.Offset),
+		MatchStatus:   status,
+		LegalEntityID: uuidToNullable(filter.LegalEntityID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementEntriesToModels(rows), nil
+}
+
+// ListOpenEntriesAfter returns up to limit entries waiting for a match with
+// an ID after the given one, in ID order.
+func (r *BankStatementRepository) ListOpenEntriesAfter(ctx context.Context, after uuid.UUID, limit int) ([]*models.BankStatementEntry, error) {
+	rows, err := r.q.ListOpenBankStatementEntriesAfter(ctx, queries.ListOpenBankStatementEntriesAfterParams{
+		ID:    after,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bankStatementEntriesToModels(rows), nil
+}
+
+// ParkEntry marks an inbound credit as parked in the suspense account.
+func (r *BankStatementRepository) ParkEntry(ctx context.Context, id uuid.UUID) error {
+	return r.q.ParkBankStatementEntry(ctx, id)
+}
+
+// SetEntryMatched marks an entry as matched.
+func (r *BankStatementRepository) SetEntryMatched(ctx context.Context, id uuid.UUID) error {
+	return r.q.SetBankStatementEntryMatched(ctx, id)
+}
+
+// CreateMatch records what an entry was matched to. It returns nil if the
+// transfer or expected deposit is already matched to an entry.
+func (r *BankStatementRepository) CreateMatch(ctx context.Context, params models.CreateStatementMatchParams) (*models.StatementMatch, error) {
+	row, err := r.q.CreateStatementMatch(ctx, queries.CreateStatementMatchParams{
+		EntryID:           params.EntryID,
+		TransferID:        uuidToNullable(params.TransferID),
+		ExpectedDepositID: uuidToNullable(params.ExpectedDepositID),
+		Amount:            decimalToNumeric(params.Amount),
+		Rule:              string(params.Rule),
+		MatchedBy:         params.MatchedBy,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return statementMatchToModel(row), nil
+}
+
+// ListMatches returns what an entry was matched to.
+func (r *BankStatementRepository) ListMatches(ctx context.Context, entryID uuid.UUID) ([]*models.StatementMatch, error) {
+	rows, err := r.q.ListStatementMatches(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.StatementMatch, len(rows))
+	for i, row := range rows {
+		result[i] = statementMatchToModel(row)
+	}
+	return result, nil
+}
+
+// ListMatchCandidateTransfers returns the unmatched payouts to a legal
+// entity in a currency settled in [from, to).
+func (r *BankStatementRepository) ListMatchCandidateTransfers(ctx context.Context, legalEntityID uuid.UUID, currency string, from, to time.Time) ([]*models.MatchCandidateTransfer, error) {
+	rows, err := r.q.ListMatchCandidateTransfers(ctx, queries.ListMatchCandidateTransfersParams{
+		DestLegalEntityID: uuidToNullable(&legalEntityID),
+		ToCurrency:        currency,
+		SettledFrom:       from,
+		SettledTo:         to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.MatchCandidateTransfer, len(rows))
+	for i, row := range rows {
+		t := &models.MatchCandidateTransfer{
+			ID:        row.ID,
+			Amount:    numericToDecimal(row.ToAmount),
+			SettledAt: row.SettledAt,
+		}
+		if row.BatchID.Valid {
+			id := uuid.UUID(row.BatchID.Bytes)
+			t.BatchID = &id
+		}
+		if row.NettingGroupID.Valid {
+			id := uuid.UUID(row.NettingGroupID.Bytes)
+			t.NettingGroupID = &id
+		}
+		if row.RailReference.Valid {
+			t.RailReference = &row.RailReference.String
+		}
+		result[i] = t
+	}
+	return result, nil
+}
+
+func bankStatementToModel(row queries.BankStatement) *models.BankStatement {
+	s := &models.BankStatement{
+		ID:                row.ID,
+		LegalEntityID:     row.LegalEntityID,
+		AccountKind:       models.BankAccountKind(row.AccountKind),
+		AccountIdentifier: row.AccountIdentifier,
+		Currency:          row.Currency,
+		Format:            row.Format,
+		ExternalID:        row.ExternalID,
+		OpeningBalance:    numericToDecimalPtr(row.OpeningBalance),
+		ClosingBalance:    numericToDecimalPtr(row.ClosingBalance),
+		ToDate:            row.ToDate.Time,
+		EntryCount:        int(row.EntryCount),
+		ImportedBy:        row.ImportedBy,
+		CreatedAt:         row.CreatedAt,
+	}
+	if row.FromDate.Valid {
+		s.FromDate = &row.FromDate.Time
+	}
+	return s
+}
+
+func bankStatementEntryToModel(row queries.BankStatementEntry) *models.BankStatementEntry {
+	e := &models.BankStatementEntry{
+		ID:            row.ID,
+		StatementID:   row.StatementID,
+		LegalEntityID: row.LegalEntityID,
+		AccountKind:   models.BankAccountKind(row.AccountKind),
+		Amount:        numericToDecimal(row.Amount),
+		Currency:      row.Currency,
+		ValueDate:     row.ValueDate.Time,
+		Reference:     row.Reference,
+		CreatedAt:     row.CreatedAt,
+		MatchStatus:   models.EntryMatchStatus(row.MatchStatus),
+	}
+	if row.BookingDate.Valid {
+		e.BookingDate = &row.BookingDate.Time
+	}
+	if row.Counterparty.Valid {
+		e.Counterparty = &row.Counterparty.String
+	}
+	if row.BankReference.Valid {
+		e.BankReference = &row.BankReference.String
+	}
+	if row.ParkedAt.Valid {
+		e.ParkedAt = &row.ParkedAt.Time
+	}
+	return e
+}
+
+func bankStatementEntriesToModels(rows []queries.BankStatementEntry) []*models.BankStatementEntry {
+	result := make([]*models.BankStatementEntry, len(rows))
+	for i, row := range rows {
+		result[i] = bankStatementEntryToModel(row)
+	}
+	return result
+}
+
+func statementMatchToModel(row queries.StatementMatch) *models.StatementMatch {
+	m := &models.StatementMatch{
+		ID:        row.ID,
+		EntryID:   row.EntryID,
+		Amount:    numericToDecimal(row.Amount),
+		Rule:      models.MatchRule(row.Rule),
+		MatchedBy: row.MatchedBy,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.TransferID.Valid {
+		id := uuid.UUID(row.TransferID.Bytes)
+		m.TransferID = &id
+	}
+	if row.ExpectedDepositID.Valid {
+		id := uuid.UUID(row.ExpectedDepositID.Bytes)
+		m.ExpectedDepositID = &id
+	}
+	return m
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// ComplianceCaseRepository handles compliance case data access.
+type ComplianceCaseRepository struct {
+	q *queries.Queries
+}
+
+// NewComplianceCaseRepository creates a new compliance case repository.
+func NewComplianceCaseRepository(pool *pgxpool.Pool) *ComplianceCaseRepository {
+	return &ComplianceCaseRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *ComplianceCaseRepository) WithTx(tx pgx.Tx) *ComplianceCaseRepository {
+	return &ComplianceCaseRepository{q: r.q.WithTx(tx)}
+}
+
+// Create opens a new case.
+func (r *ComplianceCaseRepository) Create(ctx context.Context, params models.CreateComplianceCaseParams) (*models.ComplianceCase, error) {
+	priority := params.Priority
+	if priority == "" {
+		priority = models.CasePriorityNormal
+	}
+
+	row, err := r.q.CreateComplianceCase(ctx, queries.CreateComplianceCaseParams{
+		TransferID:             params.TransferID,
+		TenantID:               params.TenantID,
+		ComplianceRegion:       string(params.ComplianceRegion),
+		Reason:                 string(params.Reason),
+		Priority:               string(priority),
+		RiskScore:              intToNullable(params.RiskScore),
+		RequiresSecondApproval: params.RequiresSecondApproval,
+		DueAt:                  params.DueAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByID retrieves a case by ID.
+func (r *ComplianceCaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ComplianceCase, error) {
+	row, err := r.q.GetComplianceCaseByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByIDForUpdate retrieves a case and locks it until the transaction ends.
+func (r *ComplianceCaseRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.ComplianceCase, error) {
+	row, err := r.q.GetComplianceCaseByIDForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// List retrieves cases matching the filter, high priority and earliest due first.
+func (r *ComplianceCaseRepository) List(ctx context.Context, filter models.ComplianceCaseFilter) ([]*models.ComplianceCase, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var status pgtype.Text
+	if filter.Status != nil {
+		status = pgtype.Text{String: string(*filter.Status), Valid: true}
+	}
+
+	rows, err := r.q.ListComplianceCases(ctx, queries.ListComplianceCasesParams{
+		Limit:      int32(limit),
+		Offset:     int32(offset),
+		Status:     status,
+		Open:       boolToNullable(filter.Open),
+		TenantID:   uuidToNullable(filter.TenantID),
+		AssignedTo: stringPtrToNullable(filter.AssignedTo),
+		Overdue:    boolToNullable(filter.Overdue),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// Assign sets the reviewer of a case. A nil assignee unassigns it.
+func (r *ComplianceCaseRepository) Assign(ctx context.Context, id uuid.UUID, assignee *string) error {
+	return r.q.AssignComplianceCase(ctx, queries.AssignComplianceCaseParams{
+		ID:         id,
+		AssignedTo: stringToNullable(assignee),
+	})
+}
+
+// RequestApproval records the first reviewer's decision of a four-eyes case.
+func (r *ComplianceCaseRepository) RequestApproval(ctx context.Context, id uuid.UUID, decision models.CaseDecision, reason *string, decidedBy string) error {
+	d := string(decision)
+	return r.q.RequestComplianceCaseApproval(ctx, queries.RequestComplianceCaseApprovalParams{
+		ID:             id,
+		Decision:       stringToNullable(&d),
+		DecisionReason: stringToNullable(reason),
+		DecidedBy:      stringToNullable(&decidedBy),
+	})
+}
+
+// Close records the final decision of a case.
+func (r *ComplianceCaseRepository) Close(ctx context.Context, id uuid.UUID, status models.CaseStatus, decision models.CaseDecision, reason *string, decidedBy string, approvedBy *string) error {
+	d := string(decision)
+	return r.q.CloseComplianceCase(ctx, queries.CloseComplianceCaseParams{
+		ID:             id,
+		Status:         string(status),
+		Decision:       stringToNullable(&d),
+		DecisionReason: stringToNullable(reason),
+		DecidedBy:      stringToNullable(&decidedBy),
+		ApprovedBy:     stringToNullable(approvedBy),
+	})
+}
+
+// Escalate raises a case to high priority with a new due time.
+func (r *ComplianceCaseRepository) Escalate(ctx context.Context, id uuid.UUID, dueAt time.Time) error {
+	return r.q.EscalateComplianceCase(ctx, queries.EscalateComplianceCaseParams{
+		ID:    id,
+		DueAt: dueAt,
+	})
+}
+
+// MarkOverdue flags open cases past their SLA and returns them.
+func (r *ComplianceCaseRepository) MarkOverdue(ctx context.Context) ([]*models.ComplianceCase, error) {
+	rows, err := r.q.MarkOverdueComplianceCases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// AddNote adds a reviewer note to a case.
+func (r *ComplianceCaseRepository) AddNote(ctx context.Context, caseID uuid.UUID, author, body string) (*models.ComplianceCaseNote, error) {
+	row, err := r.q.CreateComplianceCaseNote(ctx, queries.CreateComplianceCaseNoteParams{
+		CaseID: caseID,
+		Author: author,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return noteToModel(row), nil
+}
+
+// ListNotes retrieves the notes of a case, oldest first.
+func (r *ComplianceCaseRepository) ListNotes(ctx context.Context, caseID uuid.UUID) ([]*models.ComplianceCaseNote, error) {
+	rows, err := r.q.ListComplianceCaseNotes(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.ComplianceCaseNote, len(rows))
+	for i, row := range rows {
+		result[i] = noteToModel(row)
+	}
+	return result, nil
+}
+
+func (r *ComplianceCaseRepository) toModel(row queries.ComplianceCase) *models.ComplianceCase {
+	c := &models.ComplianceCase{
+		ID:                     row.ID,
+		TransferID:             row.TransferID,
+		TenantID:               row.TenantID,
+		ComplianceRegion:       models.ComplianceRegion(row.ComplianceRegion),
+		Reason:                 models.CaseReason(row.Reason),
+		Status:                 models.CaseStatus(row.Status),
+		Priority:               models.CasePriority(row.Priority),
+		RequiresSecondApproval: row.RequiresSecondApproval,
+		DueAt:                  row.DueAt,
+		UpdatedAt:              row.UpdatedAt,
+	}
+
+	if row.RiskScore.Valid {
+		score := int(row.RiskScore.Int32)
+		c.RiskScore = &score
+	}
+	if row.AssignedTo.Valid {
+		c.AssignedTo = &row.AssignedTo.String
+	}
+	if row.Decision.Valid {
+		d := models.CaseDecision(row.Decision.String)
+		c.Decision = &d
+	}
+	if row.DecisionReason.Valid {
+		c.DecisionReason = &row.DecisionReason.String
+	}
+	if row.DecidedBy.Valid {
+		c.DecidedBy = &row.DecidedBy.String
+	}
+	if row.ApprovedBy.Valid {
+		c.ApprovedBy = &row.ApprovedBy.String
+	}
+	if row.SlaBreachedAt.Valid {
+		c.SLABreachedAt = &row.SlaBreachedAt.Time
+	}
+	if row.ClosedAt.Valid {
+		c.ClosedAt = &row.ClosedAt.Time
+	}
+
+	return c
+}
+
+func (r *ComplianceCaseRepository) toModels(rows []queries.ComplianceCase) []*models.ComplianceCase {
+	result := make([]*models.ComplianceCase, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result
+}
+
+func noteToModel(row queries.ComplianceCaseNote) *models.ComplianceCaseNote {
+	return &models.ComplianceCaseNote{
+		ID:        row.ID,
+		CaseID:    row.CaseID,
+		Author:    row.Author,
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+func boolToNullable(b *bool) pgtype.Bool {
+	if b == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *b, Valid: true}
+}
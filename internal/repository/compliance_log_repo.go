@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// ComplianceLogRepository handles compliance log data access.
+type ComplianceLogRepository struct {
+	q *queries.Queries
+}
+
+// NewComplianceLogRepository creates a new compliance log repository.
+func NewComplianceLogRepository(pool *pgxpool.Pool) *ComplianceLogRepository {
+	return &ComplianceLogRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *ComplianceLogRepository) WithTx(tx pgx.Tx) *ComplianceLogRepository {
+	return &ComplianceLogRepository{q: r.q.WithTx(tx)}
+}
+
+// Create records a screening run.
+func (r *ComplianceLogRepository) Create(ctx context.Context, params models.CreateComplianceLogParams) (*models.ComplianceLog, error) {
+	region := params.ComplianceRegion
+	if region == "" {
+		region = models.ComplianceRegionUnknown
+	}
+
+	row, err := r.q.CreateComplianceLog(ctx, queries.CreateComplianceLogParams{
+		ComplianceRegion: string(region),
+		TransferID:       params.TransferID,
+		TenantID:         params.TenantID,
+		CaseID:           uuidToNullable(params.CaseID),
+		ScreeningType:    string(params.ScreeningType),
+		Result:           string(params.Result),
+		RiskScore:        intToNullable(params.RiskScore),
+		RawResponse:      params.RawResponse,
+		ScreenedBy:       params.ScreenedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// ListByTransfer retrieves the screening history of a transfer.
+func (r *ComplianceLogRepository) ListByTransfer(ctx context.Context, transferID uuid.UUID) ([]*models.ComplianceLog, error) {
+	rows, err := r.q.ListComplianceLogsByTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// ListByCase retrieves the action history of a review case.
+func (r *ComplianceLogRepository) ListByCase(ctx context.Context, caseID uuid.UUID) ([]*models.ComplianceLog, error) {
+	rows, err := r.q.ListComplianceLogsByCase(ctx, uuidToNullable(&caseID))
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+func (r *ComplianceLogRepository) toModel(row queries.ComplianceLog) *models.ComplianceLog {
+	l := &models.ComplianceLog{
+		ID:               row.ID,
+		ComplianceRegion: models.ComplianceRegion(row.ComplianceRegion),
+		TransferID:       row.TransferID,
+		TenantID:         row.TenantID,
+		ScreeningType:    models.ScreeningType(row.ScreeningType),
+		Result:           models.ScreeningResult(row.Result),
+		RawResponse:      json.RawMessage(row.RawResponse),
+		ScreenedBy:       row.ScreenedBy,
+		ScreenedAt:       row.ScreenedAt,
+	}
+
+	if row.CaseID.Valid {
+		id := uuid.UUID(row.CaseID.Bytes)
+		l.CaseID = &id
+	}
+
+	if row.RiskScore.Valid {
+		score := int(row.RiskScore.Int32)
+		l.RiskScore = &score
+	}
+
+	return l
+}
+
+func (r *ComplianceLogRepository) toModels(rows []queries.ComplianceLog) []*models.ComplianceLog {
+	result := make([]*models.ComplianceLog, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result
+}
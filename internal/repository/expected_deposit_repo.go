@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// ExpectedDepositRepository handles deposits announced by tenants.
+type ExpectedDepositRepository struct {
+	q *queries.Queries
+}
+
+// NewExpectedDepositRepository creates a new expected deposit repository.
+func NewExpectedDepositRepository(pool *pgxpool.Pool) *ExpectedDepositRepository {
+	return &ExpectedDepositRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *ExpectedDepositRepository) WithTx(tx pgx.Tx) *ExpectedDepositRepository {
+	return &ExpectedDepositRepository{q: r.q.WithTx(tx)}
+}
+
+// Create stores an expected deposit. It returns nil if the tenant already
+// announced a deposit with the same reference.
+func (r *ExpectedDepositRepository) Create(ctx context.Context, params models.CreateExpectedDepositParams) (*models.ExpectedDeposit, error) {
+	row, err := r.q.CreateExpectedDeposit(ctx, queries.CreateExpectedDepositParams{
+		TenantID:      params.TenantID,
+		LegalEntityID: params.LegalEntityID,
+		Currency:      params.Currency,
+		Amount:        decimalToNumeric(params.Amount),
+		Reference:     params.Reference,
+		ExpectedDate:  dateToPg(params.ExpectedDate),
+		CreatedBy:     params.CreatedBy,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return expectedDepositToModel(row), nil
+}
+
+// GetByID retrieves an expected deposit by ID.
+func (r *ExpectedDepositRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.ExpectedDeposit, error) {
+	row, err := r.q.GetExpectedDeposit(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return expectedDepositToModel(row), nil
+}
+
+// GetByIDForUpdate retrieves an expected deposit and locks it until the
+// transaction ends.
+func (r *ExpectedDepositRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.ExpectedDeposit, error) {
+	row, err := r.q.GetExpectedDepositForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return expectedDepositToModel(row), nil
+}
+
+// List returns expected deposits, newest first.
+func (r *ExpectedDepositRepository) List(ctx context.Context, filter models.ExpectedDepositFilter) ([]*models.ExpectedDeposit, error) {
+	var status pgtype.Text
+	if filter.Status != nil {
+		status = pgtype.Text{String: string(*filter.Status), Valid: true}
+	}
+
+	rows, err := r.q.ListExpectedDeposits(ctx, queries.ListExpectedDepositsParams{
+		Limit:    int32(filter.Limit),
+		Offset:   int32(filter.Offset),
+		TenantID: uuidToNullable(filter.TenantID),
+		Status:   status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expectedDepositsToModels(rows), nil
+}
+
+// ListPending returns the pending deposits into a legal entity's FBO
+// account in a currency, by expected date.
+func (r *ExpectedDepositRepository) ListPending(ctx context.Context, legalEntityID uuid.UUID, currency string) ([]*models.ExpectedDeposit, error) {
+	rows, err := r.q.ListPendingExpectedDeposits(ctx, queries.ListPendingExpectedDepositsParams{
+		LegalEntityID: legalEntityID,
+		Currency:      currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expectedDepositsToModels(rows), nil
+}
+
+// UpdateStatus updates the status of an expected deposit.
+func (r *ExpectedDepositRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.ExpectedDepositStatus) error {
+	return r.q.UpdateExpectedDepositStatus(ctx, queries.UpdateExpectedDepositStatusParams{
+		ID:     id,
+		Status: string(status),
+	})
+}
+
+// MarkCredited records that an expected deposit was credited to its
+// tenant's wallet. It returns false if it already was.
+func (r *ExpectedDepositRepository) MarkCredited(ctx context.Context, id uuid.UUID) (bool, error) {
+	n, err := r.q.MarkExpectedDepositCredited(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func expectedDepositToModel(row queries.ExpectedDeposit) *models.ExpectedDeposit {
+	d := &models.ExpectedDeposit{
+		ID:            row.ID,
+		TenantID:      row.TenantID,
+		LegalEntityID: row.LegalEntityID,
+		Currency:      row.Currency,
+		Amount:        numericToDecimal(row.Amount),
+		Reference:     row.Reference,
+		ExpectedDate:  row.ExpectedDate.Time,
+		Status:        models.ExpectedDepositStatus(row.Status),
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+	if row.CreditedAt.Valid {
+		d.CreditedAt = &row.CreditedAt.Time
+	}
+	return d
+}
+
+func expectedDepositsToModels(rows []queries.ExpectedDeposit) []*models.ExpectedDeposit {
+	result := make([]*models.ExpectedDeposit, len(rows))
+	for i, row := range rows {
+		result[i] = expectedDepositToModel(row)
+	}
+	return result
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// FXExposureRepository handles FX quote margins, reference rates and
+// exposure snapshots.
+type FXExposureRepository struct {
+	q *queries.Queries
+}
+
+// NewFXExposureRepository creates a new FX exposure repository.
+func NewFXExposureRepository(pool *pgxpool.Pool) *FXExposureRepository {
+	return &FXExposureRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *FXExposureRepository) WithTx(tx pgx.Tx) *FXExposureRepository {
+	return &FXExposureRepository{q: r.q.WithTx(tx)}
+}
+
+// GetQuoteMargin retrieves the quote margin of a transfer.
+func (r *FXExposureRepository) GetQuoteMargin(ctx context.Context, transferID uuid.UUID) (*models.FXQuoteMargin, error) {
+	row, err := r.q.GetFXQuoteMargin(ctx, transferID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &models.FXQuoteMargin{
+		TransferID:   row.TransferID,
+		FromCurrency: row.FromCurrency,
+		ToCurrency:   row.ToCurrency,
+		FromAmount:   numericToDecimal(row.FromAmount),
+		ToAmount:     numericToDecimal(row.ToAmount),
+		FXRate:       numericToDecimal(row.FxRate),
+		MidRate:      numericToDecimal(row.MidRate),
+		FromUSDRate:  numericToDecimal(row.FromUsdRate),
+		ToUSDRate:    numericToDecimal(row.ToUsdRate),
+		Margin:       numericToDecimal(row.Margin),
+		MarginUSD:    numericToDecimal(row.MarginUsd),
+		CreatedAt:    row.CreatedAt,
+	}, nil
+}
+
+// ListCostBasis returns the USD cost of the position in each currency,
+// over transfers posted to the ledger.
+func (r *FXExposureRepository) ListCostBasis(ctx context.Context) ([]models.FXCostBasis, error) {
+	rows, err := r.q.ListFXCostBasis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.FXCostBasis, len(rows))
+	for i, row := range rows {
+		result[i] = models.FXCostBasis{
+			Currency: row.Currency,
+			CostUSD:  numericToDecimal(row.CostUsd),
+		}
+	}
+	return result, nil
+}
+
+// ListUSDRates returns the USD reference rate of every currency.
+func (r *FXExposureRepository) ListUSDRates(ctx context.Context) (map[string]decimal.Decimal, error) {
+	rows, err := r.q.ListUSDReferenceRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]decimal.Decimal, len(rows))
+	for _, row := range rows {
+		rates[row.Currency] = numericToDecimal(row.UsdRate)
+	}
+	return rates, nil
+}
+
+// CreateSnapshot stores an exposure report.
+func (r *FXExposureRepository) CreateSnapshot(ctx context.Context, exposure *models.FXExposure) (*models.FXExposure, error) {
+	positions := exposure.Positions
+	if positions == nil {
+		positions = []models.FXPosition{}
+	}
+	encoded, err := json.Marshal(positions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal fx positions: %w", err)
+	}
+
+	row, err := r.q.CreateFXExposureSnapshot(ctx, queries.CreateFXExposureSnapshotParams{
+		EurUsdRate:       decimalToNumeric(exposure.EURUSDRate),
+		TotalValueUsd:    decimalToNumeric(exposure.TotalValueUSD),
+		TotalValueEur:    decimalToNumeric(exposure.TotalValueEUR),
+		RealisedPnlUsd:   decimalToNumeric(exposure.RealisedPnLUSD),
+		UnrealisedPnlUsd: decimalToNumeric(exposure.UnrealisedPnLUSD),
+		Positions:        encoded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshotToModel(row)
+}
+
+// GetSnapshot retrieves an exposure snapshot by ID.
+func (r *FXExposureRepository) GetSnapshot(ctx context.Context, id uuid.UUID) (*models.FXExposure, error) {
+	row, err := r.q.GetFXExposureSnapshot(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshotToModel(row)
+}
+
+// ListSnapshots returns exposure snapshots, newest first.
+func (r *FXExposureRepository) ListSnapshots(ctx context.Context, filter models.FXExposureFilter) ([]*models.FXExposure, error) {
+	rows, err := r.q.ListFXExposureSnapshots(ctx, queries.ListFXExposureSnapshotsParams{
+		Limit:       int32(filter.Limit),
+		Offset:      int32(filter.Offset),
+		TakenAfter:  timeToNullable(filter.From),
+		TakenBefore: timeToNullable(filter.To),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.FXExposure, len(rows))
+	for i, row := range rows {
+		if result[i], err = snapshotToModel(row); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func snapshotToModel(row queries.FxExposureSnapshot) (*models.FXExposure, error) {
+	e := &models.FXExposure{
+		ID:               &row.ID,
+		TakenAt:          row.TakenAt,
+		EURUSDRate:       numericToDecimal(row.EurUsdRate),
+		TotalValueUSD:    numericToDecimal(row.TotalValueUsd),
+		TotalValueEUR:    numericToDecimal(row.TotalValueEur),
+		RealisedPnLUSD:   numericToDecimal(row.RealisedPnlUsd),
+		UnrealisedPnLUSD: numericToDecimal(row.UnrealisedPnlUsd),
+	}
+
+	if err := json.Unmarshal(row.Positions, &e.Positions); err != nil {
+		return nil, fmt.Errorf("decode fx positions: %w", err)
+	}
+
+	return e, nil
+}
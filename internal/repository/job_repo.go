@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+	"kovra/internal/tracing"
+)
+
+// JobRepository handles background job data access.
+type JobRepository struct {
+	q *queries.Queries
+}
+
+// NewJobRepository creates a new job repository.
+func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
+	return &JobRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *JobRepository) WithTx(tx pgx.Tx) *JobRepository {
+	return &JobRepository{q: r.q.WithTx(tx)}
+}
+
+// Insert enqueues a job, with the trace context of ctx for the worker to
+// run it in. It returns nil if a unique job with the same kind and unique key
+// is already available or running.
+func (r *JobRepository) Insert(ctx context.Context, params models.InsertJobParams) (*models.Job, error) {
+	row, err := r.q.InsertJob(ctx, queries.InsertJobParams{
+		Kind:         params.Kind,
+		Queue:        params.Queue,
+		Args:         params.Args,
+		Priority:     int16(params.Priority),
+		MaxAttempts:  int32(params.MaxAttempts),
+		ScheduledAt:  params.ScheduledAt,
+		UniqueKey:    stringToNullable(params.UniqueKey),
+		TraceContext: tracing.Inject(ctx),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByID retrieves a job by ID.
+func (r *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	row, err := r.q.GetJobByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// Claim leases up to batchSize available jobs from a queue and marks them running.
+func (r *JobRepository) Claim(ctx context.Context, queue string, batchSize int, lease time.Duration) ([]*models.Job, error) {
+	rows, err := r.q.ClaimJobs(ctx, queries.ClaimJobsParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		Queue:        queue,
+		BatchSize:    int32(batchSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Job, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result, nil
+}
+
+// Complete marks a job running the given attempt as completed.
+func (r *JobRepository) Complete(ctx context.Context, id uuid.UUID, attempt int) error {
+	return r.q.CompleteJob(ctx, queries.CompleteJobParams{
+		ID:      id,
+		Attempt: int32(attempt),
+	})
+}
+
+// Retry returns a job running the given attempt to the queue, to run again
+// at scheduledAt.
+func (r *JobRepository) Retry(ctx context.Context, id uuid.UUID, attempt int, scheduledAt time.Time, lastError string) error {
+	return r.q.RetryJob(ctx, queries.RetryJobParams{
+		ID:          id,
+		Attempt:     int32(attempt),
+		ScheduledAt: scheduledAt,
+		LastError:   pgtype.Text{String: lastError, Valid: lastError != ""},
+	})
+}
+
+// Discard marks a job running the given attempt as permanently failed.
+func (r *JobRepository) Discard(ctx context.Context, id uuid.UUID, attempt int, lastError string) error {
+	return r.q.DiscardJob(ctx, queries.DiscardJobParams{
+		ID:        id,
+		Attempt:   int32(attempt),
+		LastError: pgtype.Text{String: lastError, Valid: lastError != ""},
+	})
+}
+
+// RescueStuck requeues running jobs whose lease has expired.
+func (r *JobRepository) RescueStuck(ctx context.Context) (int64, error) {
+	return r.q.RescueStuckJobs(ctx)
+}
+
+// DeleteFinalized removes completed and discarded jobs finalized before the cutoff.
+func (r *JobRepository) DeleteFinalized(ctx context.Context, before time.Time) (int64, error) {
+	return r.q.DeleteFinalizedJobs(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+// CountUnfinished counts the available and running jobs of each queue.
+func (r *JobRepository) CountUnfinished(ctx context.Context) ([]models.JobCount, error) {
+	rows, err := r.q.CountUnfinishedJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]models.JobCount, len(rows))
+	for i, row := range rows {
+		counts[i] = models.JobCount{Queue: row.Queue, State: models.JobState(row.State), Count: row.Count}
+	}
+	return counts, nil
+}
+
+func (r *JobRepository) toModel(row queries.Job) *models.Job {
+	j := &models.Job{
+		ID:           row.ID,
+		Kind:         row.Kind,
+		Queue:        row.Queue,
+		Args:         row.Args,
+		Priority:     int(row.Priority),
+		State:        models.JobState(row.State),
+		Attempt:      int(row.Attempt),
+		MaxAttempts:  int(row.MaxAttempts),
+		ScheduledAt:  row.ScheduledAt,
+		CreatedAt:    row.CreatedAt,
+		TraceContext: row.TraceContext,
+	}
+
+	if row.AttemptedAt.Valid {
+		j.AttemptedAt = &row.AttemptedAt.Time
+	}
+	if row.LockedUntil.Valid {
+		j.LockedUntil = &row.LockedUntil.Time
+	}
+	if row.FinalizedAt.Valid {
+		j.FinalizedAt = &row.FinalizedAt.Time
+	}
+	if row.LastError.Valid {
+		j.LastError = &row.LastError.String
+	}
+	if row.UniqueKey.Valid {
+		j.UniqueKey = &row.UniqueKey.String
+	}
+
+	return j
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// KYCRepository handles KYC submissions, tier limits and tenant status history.
+type KYCRepository struct {
+	q *queries.Queries
+}
+
+// NewKYCRepository creates a new KYC repository.
+func NewKYCRepository(pool *pgxpool.Pool) *KYCRepository {
+	return &KYCRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *KYCRepository) WithTx(tx pgx.Tx) *KYCRepository {
+	return &KYCRepository{q: r.q.WithTx(tx)}
+}
+
+// CreateSubmission records a KYC submission pending review.
+func (r *KYCRepository) CreateSubmission(ctx context.Context, params models.CreateKYCSubmissionParams) (*models.KYCSubmission, error) {
+	documents := params.Documents
+	if documents == nil {
+		documents = []models.KYCDocument{}
+	}
+	encoded, err := json.Marshal(documents)
+	if err != nil {
+		return nil, fmt.Errorf("marshal kyc documents: %w", err)
+	}
+
+	row, err := r.q.CreateKYCSubmission(ctx, queries.CreateKYCSubmissionParams{
+		TenantID:       params.TenantID,
+		RequestedLevel: queries.KycLevelEnum(params.RequestedLevel),
+		Documents:      encoded,
+		SubmittedBy:    params.SubmittedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row)
+}
+
+// GetSubmission retrieves a submission by ID.
+func (r *KYCRepository) GetSubmission(ctx context.Context, id uuid.UUID) (*models.KYCSubmission, error) {
+	row, err := r.q.GetKYCSubmissionByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row)
+}
+
+// GetSubmissionForUpdate retrieves a submission and locks it until the transaction ends.
+func (r *KYCRepository) GetSubmissionForUpdate(ctx context.Context, id uuid.UUID) (*models.KYCSubmission, error) {
+	row, err := r.q.GetKYCSubmissionByIDForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row)
+}
+
+// ListSubmissionsByTenant retrieves a tenant's submissions, newest first.
+func (r *KYCRepository) ListSubmissionsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*models.KYCSubmission, error) {
+	rows, err := r.q.ListKYCSubmissionsByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.KYCSubmission, len(rows))
+	for i, row := range rows {
+		if result[i], err = r.toModel(row); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// DecideSubmission records the reviewer decision on a submission.
+func (r *KYCRepository) DecideSubmission(ctx context.Context, id uuid.UUID, status models.KYCSubmissionStatus, reviewedBy string, reason *string) error {
+	return r.q.DecideKYCSubmission(ctx, queries.DecideKYCSubmissionParams{
+		ID:           id,
+		Status:       string(status),
+		ReviewedBy:   stringToNullable(&reviewedBy),
+		ReviewReason: stringPtrToNullable(reason),
+	})
+}
+
+// CreateStatusChange records a tenant lifecycle transition.
+func (r *KYCRepository) CreateStatusChange(ctx context.Context, params models.CreateTenantStatusChangeParams) (*models.TenantStatusChange, error) {
+	row, err := r.q.CreateTenantStatusChange(ctx, queries.CreateTenantStatusChangeParams{
+		TenantID:   params.TenantID,
+		FromStatus: queries.TenantStatusEnum(params.FromStatus),
+		ToStatus:   queries.TenantStatusEnum(params.ToStatus),
+		Reason:     params.Reason,
+		ChangedBy:  params.ChangedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statusChangeToModel(row), nil
+}
+
+// ListStatusChanges retrieves a tenant's lifecycle history, newest first.
+func (r *KYCRepository) ListStatusChanges(ctx context.Context, tenantID uuid.UUID) ([]*models.TenantStatusChange, error) {
+	rows, err := r.q.ListTenantStatusChanges(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.TenantStatusChange, len(rows))
+	for i, row := range rows {
+		result[i] = statusChangeToModel(row)
+	}
+	return result, nil
+}
+
+// GetTierLimit retrieves the transaction caps of a KYC level.
+func (r *KYCRepository) GetTierLimit(ctx context.Context, level models.KYCLevel) (*models.KYCTierLimit, error) {
+	row, err := r.q.GetKYCTierLimit(ctx, queries.KycLevelEnum(level))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tierLimitToModel(row), nil
+}
+
+// ListTierLimits retrieves the transaction caps of every KYC level.
+func (r *KYCRepository) ListTierLimits(ctx context.Context) ([]*models.KYCTierLimit, error) {
+	rows, err := r.q.ListKYCTierLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.KYCTierLimit, len(rows))
+	for i, row := range rows {
+		result[i] = tierLimitToModel(row)
+	}
+	return result, nil
+}
+
+func (r *KYCRepository) toModel(row queries.KycSubmission) (*models.KYCSubmission, error) {
+	s := &models.KYCSubmission{
+		ID:             row.ID,
+		TenantID:       row.TenantID,
+		RequestedLevel: models.KYCLevel(row.RequestedLevel),
+		SubmittedBy:    row.SubmittedBy,
+		Status:         models.KYCSubmissionStatus(row.Status),
+		SubmittedAt:    row.SubmittedAt,
+	}
+
+	if err := json.Unmarshal(row.Documents, &s.Documents); err != nil {
+		return nil, fmt.Errorf("decode kyc documents: %w", err)
+	}
+	if row.ReviewedBy.Valid {
+		s.ReviewedBy = &row.ReviewedBy.String
+	}
+	if row.ReviewReason.Valid {
+		s.ReviewReason = &row.ReviewReason.String
+	}
+	if row.ReviewedAt.Valid {
+		s.ReviewedAt = &row.ReviewedAt.Time
+	}
+
+	return s, nil
+}
+
+func statusChangeToModel(row queries.TenantStatusChange) *models.TenantStatusChange {
+	return &models.TenantStatusChange{
+		ID:         row.ID,
+		TenantID:   row.TenantID,
+		FromStatus: models.TenantStatus(row.FromStatus),
+		ToStatus:   models.TenantStatus(row.ToStatus),
+		Reason:     row.Reason,
+		ChangedBy:  row.ChangedBy,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
+func tierLimitToModel(row queries.KycTierLimit) *models.KYCTierLimit {
+	return &models.KYCTierLimit{
+		KYCLevel:            models.KYCLevel(row.KycLevel),
+		PerTransferLimitUSD: numericToDecimalPtr(row.PerTransferLimitUsd),
+		DailyLimitUSD:       numericToDecimalPtr(row.DailyLimitUsd),
+		MonthlyLimitUSD:     numericToDecimalPtr(row.MonthlyLimitUsd),
+		UpdatedAt:           row.UpdatedAt,
+	}
+}
@@ -18,7 +18,7 @@ type LegalEntityRepository struct {
 
 // NewLegalEntityRepository creates a new legal entity repository.
 func NewLegalEntityRepository(pool *pgxpool.Pool) *LegalEntityRepository {
-	return &LegalEntityRepository{q: queries.New(pool)}
+	return &LegalEntityRepository{q: newQueries(pool)}
 }
 
 // GetByID retrieves a legal entity by ID.
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// LimitRepository handles limit policies and the data limits are checked against.
+type LimitRepository struct {
+	q *queries.Queries
+}
+
+// NewLimitRepository creates a new limit repository.
+func NewLimitRepository(pool *pgxpool.Pool) *LimitRepository {
+	return &LimitRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *LimitRepository) WithTx(tx pgx.Tx) *LimitRepository {
+	return &LimitRepository{q: r.q.WithTx(tx)}
+}
+
+// GetPolicyByTenant retrieves a tenant's limit policy.
+func (r *LimitRepository) GetPolicyByTenant(ctx context.Context, tenantID uuid.UUID) (*models.LimitPolicy, error) {
+	row, err := r.q.GetLimitPolicyByTenant(ctx, tenantID)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LimitPolicy{
+		ID:                  row.ID,
+		TenantID:            row.TenantID,
+		DailyLimitUSD:       numericToDecimal(row.DailyLimitUsd),
+		MonthlyLimitUSD:     numericToDecimal(row.MonthlyLimitUsd),
+		PerTransferLimitUSD: numericToDecimal(row.PerTransferLimitUsd),
+		RateLimitRPM:        int(row.RateLimitRpm),
+		RateLimitBurst:      int(row.RateLimitBurst),
+		MaxBatchSize:        int(row.MaxBatchSize),
+		MaxBatchAmountUSD:   numericToDecimal(row.MaxBatchAmountUsd),
+		EffectiveFrom:       row.EffectiveFrom.Time,
+		UpdatedAt:           row.UpdatedAt,
+	}, nil
+}
+
+// GetUSDRate returns the USD value of one unit of currency, or nil if the
+// currency has no reference rate.
+func (r *LimitRepository) GetUSDRate(ctx context.Context, currency string) (*decimal.Decimal, error) {
+	row, err := r.q.GetUSDReferenceRate(ctx, currency)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	rate := numericToDecimal(row.UsdRate)
+	return &rate, nil
+}
+
+// SumVolumeUSD returns the USD value of a tenant's transfers created since
+// the given time, excluding rejected, rolled back and cancelled ones.
+func (r *LimitRepository) SumVolumeUSD(ctx context.Context, tenantID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	volume, err := r.q.SumTransferVolumeUSD(ctx, queries.SumTransferVolumeUSDParams{
+		TenantID: tenantID,
+		Since:    pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return numericToDecimal(volume), nil
+}
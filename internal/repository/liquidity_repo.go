@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// LiquidityRepository handles settlement account limits, liquidity alerts,
+// top-up instructions and held payouts.
+type LiquidityRepository struct {
+	q *queries.Queries
+}
+
+// NewLiquidityRepository creates a new liquidity repository.
+func NewLiquidityRepository(pool *pgxpool.Pool) *LiquidityRepository {
+	return &LiquidityRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *LiquidityRepository) WithTx(tx pgx.Tx) *LiquidityRepository {
+	return &LiquidityRepository{q: r.q.WithTx(tx)}
+}
+
+// UpsertSettlement creates the limits of a settlement account or updates
+// them.
+func (r *LiquidityRepository) UpsertSettlement(ctx context.Context, params models.UpsertRegionalSettlementParams) (*models.RegionalSettlement, error) {
+	row, err := r.q.UpsertRegionalSettlement(ctx, queries.UpsertRegionalSettlementParams{
+		LegalEntityID:  params.LegalEntityID,
+		Currency:       params.Currency,
+		TbAccountID:    bigIntToNumeric(params.TBAccountID),
+		MinBalance:     decimalToNumeric(params.MinBalance),
+		TargetBalance:  decimalToNumeric(params.TargetBalance),
+		AlertThreshold: decimalToNumeric(params.AlertThreshold),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return settlementToModel(row), nil
+}
+
+// GetSettlement retrieves a settlement account by ID.
+func (r *LiquidityRepository) GetSettlement(ctx context.Context, id uuid.UUID) (*models.RegionalSettlement, error) {
+	row, err := r.q.GetRegionalSettlement(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settlementToModel(row), nil
+}
+
+// GetSettlementByEntity retrieves the settlement account of a legal entity
+// in a currency.
+func (r *LiquidityRepository) GetSettlementByEntity(ctx context.Context, legalEntityID uuid.UUID, currency string) (*models.RegionalSettlement, error) {
+	row, err := r.q.GetRegionalSettlementByEntity(ctx, queries.GetRegionalSettlementByEntityParams{
+		LegalEntityID: legalEntityID,
+		Currency:      currency,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settlementToModel(row), nil
+}
+
+// GetSettlementByEntityForUpdate retrieves the settlement account of a
+// legal entity in a currency and locks it until the transaction ends.
+func (r *LiquidityRepository) GetSettlementByEntityForUpdate(ctx context.Context, legalEntityID uuid.UUID, currency string) (*models.RegionalSettlement, error) {
+	row, err := r.q.GetRegionalSettlementByEntityForUpdate(ctx, queries.GetRegionalSettlementByEntityForUpdateParams{
+		LegalEntityID: legalEntityID,
+		Currency:      currency,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settlementToModel(row), nil
+}
+
+// ListSettlements returns every settlement account.
+func (r *LiquidityRepository) ListSettlements(ctx context.Context) ([]*models.RegionalSettlement, error) {
+	rows, err := r.q.ListRegionalSettlements(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.RegionalSettlement, len(rows))
+	for i, row := range rows {
+		result[i] = settlementToModel(row)
+	}
+	return result, nil
+}
+
+// UpdateCachedBalance records the ledger balance of a settlement account.
+func (r *LiquidityRepository) UpdateCachedBalance(ctx context.Context, id uuid.UUID, balance decimal.Decimal) error {
+	return r.q.UpdateRegionalSettlementBalance(ctx, queries.UpdateRegionalSettlementBalanceParams{
+		ID:            id,
+		CachedBalance: decimalToNumeric(balance),
+	})
+}
+
+// SumQueuedPayouts returns the payouts to a legal entity in a currency not
+// yet posted to the ledger: the admitted ones and all queued ones.
+func (r *LiquidityRepository) SumQueuedPayouts(ctx context.Context, legalEntityID uuid.UUID, currency string) (admitted, queued decimal.Decimal, err error) {
+	row, err := r.q.SumQueuedPayouts(ctx, queries.SumQueuedPayoutsParams{
+		DestLegalEntityID: uuidToNullable(&legalEntityID),
+		ToCurrency:        currency,
+	})
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	return numericToDecimal(row.Admitted), numericToDecimal(row.Queued), nil
+}
+
+// UpsertAlert opens an alert for a settlement account or updates its open
+// one.
+func (r *LiquidityRepository) UpsertAlert(ctx context.Context, level models.LiquidityAlertLevel, position *models.LiquidityPosition) (*models.LiquidityAlert, error) {
+	row, err := r.q.UpsertLiquidityAlert(ctx, queries.UpsertLiquidityAlertParams{
+		SettlementID:     position.Settlement.ID,
+		Level:            string(level),
+		Balance:          decimalToNumeric(position.Balance),
+		QueuedOutflow:    decimalToNumeric(position.Queued),
+		ProjectedBalance: decimalToNumeric(position.Projected),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return liquidityAlertToModel(row), nil
+}
+
+// ResolveAlert resolves the open alert of a settlement account. It reports
+// whether there was one.
+func (r *LiquidityRepository) ResolveAlert(ctx context.Context, settlementID uuid.UUID) (bool, error) {
+	n, err := r.q.ResolveLiquidityAlert(ctx, settlementID)
+	return n > 0, err
+}
+
+// ListAlerts returns liquidity alerts, newest first.
+func (r *LiquidityRepository) ListAlerts(ctx context.Context, filter models.LiquidityFilter) ([]*models.LiquidityAlert, error) {
+	rows, err := r.q.ListLiquidityAlerts(ctx, queries.ListLiquidityAlertsParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+		Status: stringPtrToNullable(filter.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.LiquidityAlert, len(rows))
+	for i, row := range rows {
+		result[i] = liquidityAlertToModel(row)
+	}
+	return result, nil
+}
+
+// UpsertTopUp creates a top-up instruction for a settlement account or
+// updates the amount of its pending one.
+func (r *LiquidityRepository) UpsertTopUp(ctx context.Context, settlementID uuid.UUID, amount, projected decimal.Decimal) (*models.LiquidityTopUp, error) {
+	row, err := r.q.UpsertLiquidityTopUp(ctx, queries.UpsertLiquidityTopUpParams{
+		SettlementID:     settlementID,
+		Amount:           decimalToNumeric(amount),
+		ProjectedBalance: decimalToNumeric(projected),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return liquidityTopUpToModel(row), nil
+}
+
+// GetTopUpForUpdate retrieves a top-up instruction and locks it until the
+// transaction ends.
+func (r *LiquidityRepository) GetTopUpForUpdate(ctx context.Context, id uuid.UUID) (*models.LiquidityTopUp, error) {
+	row, err := r.q.GetLiquidityTopUpForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return liquidityTopUpToModel(row), nil
+}
+
+// ListTopUps returns top-up instructions, newest first.
+func (r *LiquidityRepository) ListTopUps(ctx context.Context, filter models.LiquidityFilter) ([]*models.LiquidityTopUp, error) {
+	rows, err := r.q.ListLiquidityTopUps(ctx, queries.ListLiquidityTopUpsParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+		Status: stringPtrToNullable(filter.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.LiquidityTopUp, len(rows))
+	for i, row := range rows {
+		result[i] = liquidityTopUpToModel(row)
+	}
+	return result, nil
+}
+
+// UpdateTopUpStatus completes or cancels a top-up instruction.
+func (r *LiquidityRepository) UpdateTopUpStatus(ctx context.Context, id uuid.UUID, status models.LiquidityTopUpStatus, decidedBy string) error {
+	return r.q.UpdateLiquidityTopUpStatus(ctx, queries.UpdateLiquidityTopUpStatusParams{
+		ID:        id,
+		Status:    string(status),
+		DecidedBy: stringToNullable(&decidedBy),
+	})
+}
+
+// CreateHold holds a payout. It returns nil if the transfer is already held.
+func (r *LiquidityRepository) CreateHold(ctx context.Context, settlementID, transferID uuid.UUID, amount decimal.Decimal) (*models.LiquidityHold, error) {
+	row, err := r.q.CreateLiquidityHold(ctx, queries.CreateLiquidityHoldParams{
+		SettlementID: settlementID,
+		TransferID:   transferID,
+		Amount:       decimalToNumeric(amount),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return liquidityHoldToModel(row), nil
+}
+
+// HasHolds reports whether a settlement account has held payouts.
+func (r *LiquidityRepository) HasHolds(ctx context.Context, settlementID uuid.UUID) (bool, error) {
+	return r.q.HasLiquidityHolds(ctx, settlementID)
+}
+
+// ListHeldPayouts returns the held payouts of a settlement account, oldest
+// first.
+func (r *LiquidityRepository) ListHeldPayouts(ctx context.Context, settlementID uuid.UUID) ([]*models.LiquidityHold, error) {
+	rows, err := r.q.ListHeldPayouts(ctx, settlementID)
+	if err != nil {
+		return nil, err
+	}
+	return liquidityHoldsToModels(rows), nil
+}
+
+// ListHolds returns held payouts, newest first.
+func (r *LiquidityRepository) ListHolds(ctx context.Context, filter models.LiquidityFilter) ([]*models.LiquidityHold, error) {
+	rows, err := r.q.ListLiquidityHolds(ctx, queries.ListLiquidityHoldsParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+		Status: stringPtrToNullable(filter.Status),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return liquidityHoldsToModels(rows), nil
+}
+
+// UpdateHoldStatus releases or cancels a held payout.
+func (r *LiquidityRepository) UpdateHoldStatus(ctx context.Context, id uuid.UUID, status models.LiquidityHoldStatus) error {
+	return r.q.UpdateLiquidityHoldStatus(ctx, queries.UpdateLiquidityHoldStatusParams{
+		ID:     id,
+		Status: string(status),
+	})
+}
+
+// CancelHoldByTransfer cancels the hold of a payout, if it is held.
+func (r *LiquidityRepository) CancelHoldByTransfer(ctx context.Context, transferID uuid.UUID) error {
+	return r.q.CancelLiquidityHold(ctx, transferID)
+}
+
+func settlementToModel(row queries.RegionalSettlement) *models.RegionalSettlement {
+	return &models.RegionalSettlement{
+		ID:             row.ID,
+		LegalEntityID:  row.LegalEntityID,
+		Currency:       row.Currency,
+		TBAccountID:    numericToBigInt(row.TbAccountID),
+		CachedBalance:  numericToDecimal(row.CachedBalance),
+		CachedAt:       row.CachedAt,
+		MinBalance:     numericToDecimal(row.MinBalance),
+		TargetBalance:  numericToDecimal(row.TargetBalance),
+		AlertThreshold: numericToDecimal(row.AlertThreshold),
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
+
+func liquidityAlertToModel(row queries.LiquidityAlert) *models.LiquidityAlert {
+	a := &models.LiquidityAlert{
+		ID:               row.ID,
+		SettlementID:     row.SettlementID,
+		Level:            models.LiquidityAlertLevel(row.Level),
+		Balance:          numericToDecimal(row.Balance),
+		QueuedOutflow:    numericToDecimal(row.QueuedOutflow),
+		ProjectedBalance: numericToDecimal(row.ProjectedBalance),
+		Status:           models.LiquidityAlertStatus(row.Status),
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}
+	if row.ResolvedAt.Valid {
+		a.ResolvedAt = &row.ResolvedAt.Time
+	}
+	return a
+}
+
+func liquidityTopUpToModel(row queries.LiquidityTopUp) *models.LiquidityTopUp {
+	t := &models.LiquidityTopUp{
+		ID:               row.ID,
+		SettlementID:     row.SettlementID,
+		Amount:           numericToDecimal(row.Amount),
+		ProjectedBalance: numericToDecimal(row.ProjectedBalance),
+		Status:           models.LiquidityTopUpStatus(row.Status),
+		CreatedAt:        row.CreatedAt,
+		UpdatedAt:        row.UpdatedAt,
+	}
+	if row.DecidedBy.Valid {
+		t.DecidedBy = &row.DecidedBy.String
+	}
+	return t
+}
+
+func liquidityHoldToModel(row queries.LiquidityHold) *models.LiquidityHold {
+	h := &models.LiquidityHold{
+		ID:           row.ID,
+		SettlementID: row.SettlementID,
+		TransferID:   row.TransferID,
+		Amount:       numericToDecimal(row.Amount),
+		Status:       models.LiquidityHoldStatus(row.Status),
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.ReleasedAt.Valid {
+		h.ReleasedAt = &row.ReleasedAt.Time
+	}
+	return h
+}
+
+func liquidityHoldsToModels(rows []queries.LiquidityHold) []*models.LiquidityHold {
+	result := make([]*models.LiquidityHold, len(rows))
+	for i, row := range rows {
+		result[i] = liquidityHoldToModel(row)
+	}
+	return result
+}
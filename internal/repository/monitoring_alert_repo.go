@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// MonitoringAlertRepository handles monitoring alert data access.
+type MonitoringAlertRepository struct {
+	q *queries.Queries
+}
+
+// NewMonitoringAlertRepository creates a new monitoring alert repository.
+func NewMonitoringAlertRepository(pool *pgxpool.Pool) *MonitoringAlertRepository {
+	return &MonitoringAlertRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *MonitoringAlertRepository) WithTx(tx pgx.Tx) *MonitoringAlertRepository {
+	return &MonitoringAlertRepository{q: r.q.WithTx(tx)}
+}
+
+// Create records an alert.
+func (r *MonitoringAlertRepository) Create(ctx context.Context, params models.CreateMonitoringAlertParams) (*models.MonitoringAlert, error) {
+	details := params.Details
+	if details == nil {
+		details = []byte("{}")
+	}
+
+	row, err := r.q.CreateMonitoringAlert(ctx, queries.CreateMonitoringAlertParams{
+		TransferID:       params.TransferID,
+		TenantID:         params.TenantID,
+		ComplianceRegion: string(params.ComplianceRegion),
+		RuleID:           params.RuleID,
+		RuleType:         params.RuleType,
+		Score:            int32(params.Score),
+		Details:          details,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// List retrieves alerts matching the filter, newest first.
+func (r *MonitoringAlertRepository) List(ctx context.Context, filter models.MonitoringAlertFilter) ([]*models.MonitoringAlert, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	offset := filter.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	rows, err := r.q.ListMonitoringAlerts(ctx, queries.ListMonitoringAlertsParams{
+		Limit:            int32(limit),
+		Offset:           int32(offset),
+		TenantID:         uuidToNullable(filter.TenantID),
+		TransferID:       uuidToNullable(filter.TransferID),
+		RuleType:         stringPtrToNullable(filter.RuleType),
+		ComplianceRegion: complianceRegionToNullable(filter.ComplianceRegion),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.MonitoringAlert, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result, nil
+}
+
+func (r *MonitoringAlertRepository) toModel(row queries.MonitoringAlert) *models.MonitoringAlert {
+	return &models.MonitoringAlert{
+		ID:               row.ID,
+		TransferID:       row.TransferID,
+		TenantID:         row.TenantID,
+		ComplianceRegion: models.ComplianceRegion(row.ComplianceRegion),
+		RuleID:           row.RuleID,
+		RuleType:         row.RuleType,
+		Score:            int(row.Score),
+		Details:          json.RawMessage(row.Details),
+		CreatedAt:        row.CreatedAt,
+	}
+}
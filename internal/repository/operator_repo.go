@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// OperatorRepository handles operator data access.
+type OperatorRepository struct {
+	q *queries.Queries
+}
+
+// NewOperatorRepository creates a new operator repository.
+func NewOperatorRepository(pool *pgxpool.Pool) *OperatorRepository {
+	return &OperatorRepository{q: newQueries(pool)}
+}
+
+// Create creates a new operator.
+func (r *OperatorRepository) Create(ctx context.Context, params models.CreateOperatorParams) (*models.Operator, error) {
+	row, err := r.q.CreateOperator(ctx, queries.CreateOperatorParams{
+		Name:             params.Name,
+		ApiKeyHash:       params.APIKeyHash,
+		ComplianceRegion: complianceRegionToNullable(params.ComplianceRegion),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByAPIKeyHash retrieves the operator that owns an API key.
+func (r *OperatorRepository) GetByAPIKeyHash(ctx context.Context, apiKeyHash string) (*models.Operator, error) {
+	row, err := r.q.GetOperatorByAPIKeyHash(ctx, apiKeyHash)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+func (r *OperatorRepository) toModel(row queries.Operator) *models.Operator {
+	o := &models.Operator{
+		ID:         row.ID,
+		Name:       row.Name,
+		APIKeyHash: row.ApiKeyHash,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.ComplianceRegion.Valid {
+		region := models.ComplianceRegion(row.ComplianceRegion.String)
+		o.ComplianceRegion = &region
+	}
+	return o
+}
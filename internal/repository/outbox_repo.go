@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+	"kovra/internal/tracing"
+)
+
+// OutboxRepository handles outbox data access.
+type OutboxRepository struct {
+	q *queries.Queries
+}
+
+// NewOutboxRepository creates a new outbox repository.
+func NewOutboxRepository(pool *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *OutboxRepository) WithTx(tx pgx.Tx) *OutboxRepository {
+	return &OutboxRepository{q: r.q.WithTx(tx)}
+}
+
+// Append records an event, with the trace context of ctx for the relay to
+// publish it in. It must be called with a transaction-bound repository so the
+// event commits together with the state change.
+func (r *OutboxRepository) Append(ctx context.Context, params models.CreateOutboxEventParams) error {
+	return r.q.CreateOutboxEvent(ctx, queries.CreateOutboxEventParams{
+		AggregateType: string(params.AggregateType),
+		AggregateID:   params.AggregateID,
+		TenantID:      uuidToNullable(params.TenantID),
+		EventType:     params.EventType,
+		Payload:       params.Payload,
+		TraceContext:  tracing.Inject(ctx),
+	})
+}
+
+// Claim locks up to limit head-of-line events for publishing.
+// Locks are held until the surrounding transaction ends.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	rows, err := r.q.ClaimOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.OutboxEvent, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result, nil
+}
+
+// MarkPublished marks an event as published.
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id uuid.UUID) error {
+	return r.q.MarkOutboxEventPublished(ctx, id)
+}
+
+// MarkFailed records a publish failure and defers the event until retryAt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, lastError string, retryAt time.Time) error {
+	return r.q.MarkOutboxEventFailed(ctx, queries.MarkOutboxEventFailedParams{
+		ID:          id,
+		LastError:   pgtype.Text{String: lastError, Valid: lastError != ""},
+		AvailableAt: retryAt,
+	})
+}
+
+// Stats returns the unpublished backlog.
+func (r *OutboxRepository) Stats(ctx context.Context) (*models.OutboxStats, error) {
+	row, err := r.q.GetOutboxStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &models.OutboxStats{
+		Pending: row.Pending,
+		Failing: row.Failing,
+	}
+	if row.OldestPendingAt.Valid {
+		stats.OldestPendingAt = &row.OldestPendingAt.Time
+	}
+	return stats, nil
+}
+
+// DeletePublished removes events published before the cutoff.
+func (r *OutboxRepository) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	return r.q.DeletePublishedOutboxEvents(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func (r *OutboxRepository) toModel(row queries.Outbox) *models.OutboxEvent {
+	e := &models.OutboxEvent{
+		ID:            row.ID,
+		Seq:           row.Seq,
+		AggregateType: models.AggregateType(row.AggregateType),
+		AggregateID:   row.AggregateID,
+		EventType:     row.EventType,
+		Payload:       row.Payload,
+		Attempts:      int(row.Attempts),
+		AvailableAt:   row.AvailableAt,
+		CreatedAt:     row.CreatedAt,
+		TraceContext:  row.TraceContext,
+	}
+
+	if row.TenantID.Valid {
+		id := uuid.UUID(row.TenantID.Bytes)
+		e.TenantID = &id
+	}
+	if row.LastError.Valid {
+		e.LastError = &row.LastError.String
+	}
+	if row.PublishedAt.Valid {
+		e.PublishedAt = &row.PublishedAt.Time
+	}
+
+	return e
+}
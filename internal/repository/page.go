@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+)
+
+// Page sizes of keyset-paginated lists.
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// pageLimit returns the page size to use for a requested limit.
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	return min(limit, maxPageLimit)
+}
+
+// newPage builds a page from rows fetched with one row more than the limit;
+// the extra row only tells that another page follows.
+func newPage[T any](items []T, limit int, id func(T) uuid.UUID) models.Page[T] {
+	if len(items) <= limit {
+		return models.Page[T]{Items: items}
+	}
+	items = items[:limit]
+	next := id(items[limit-1])
+	return models.Page[T]{Items: items, Next: &next}
+}
+
+// MergePages merges pages of the same list read from several databases into
+// one page of at most limit items, newest first.
+func MergePages[T any](pages []models.Page[T], limit int, id func(T) uuid.UUID) models.Page[T] {
+	limit = pageLimit(limit)
+
+	var items []T
+	more := false
+	for _, p := range pages {
+		items = append(items, p.Items...)
+		more = more || p.Next != nil
+	}
+	slices.SortFunc(items, func(a, b T) int {
+		ida, idb := id(a), id(b)
+		return bytes.Compare(idb[:], ida[:])
+	})
+
+	if len(items) <= limit && !more {
+		return models.Page[T]{Items: items}
+	}
+	items = items[:min(len(items), limit)]
+	next := id(items[len(items)-1])
+	return models.Page[T]{Items: items, Next: &next}
+}
+
+// MergeOffset merges an offset-paginated list read from several databases
+// into the limit items at offset of the whole list, ordered by cmp. Each
+// database's list is read from its start with offset+limit items, as any of
+// them may hold every item of the page.
+func MergeOffset[T any](lists [][]T, offset, limit int, cmp func(a, b T) int) []T {
+	var items []T
+	for _, l := range lists {
+		items = append(items, l...)
+	}
+	slices.SortStableFunc(items, cmp)
+
+	if offset >= len(items) {
+		return []T{}
+	}
+	return items[offset:min(len(items), offset+limit)]
+}
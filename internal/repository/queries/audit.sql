@@ -0,0 +1,54 @@
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_trail (id, compliance_region, seq, tenant_id, legal_entity_id,
+    resource_type, resource_id, action, actor_type, actor_id,
+    before_state, after_state, ip_address, request_id, created_at, prev_hash, row_hash)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17);
+
+-- name: GetAuditChainHead :one
+SELECT compliance_region, seq, row_hash, updated_at
+FROM audit_chain_heads
+WHERE compliance_region = $1;
+
+-- name: GetAuditChainHeadForUpdate :one
+-- Locks the head through lock_audit_chain_head, as the regional roles may
+-- only read it; inserting the next entry advances it.
+SELECT compliance_region, seq, row_hash, updated_at
+FROM lock_audit_chain_head($1);
+
+-- name: ListAuditChainHeads :many
+SELECT compliance_region, seq, row_hash, updated_at
+FROM audit_chain_heads
+ORDER BY compliance_region;
+
+-- Rows of one region's chain in order, for verification
+-- name: ListAuditChain :many
+SELECT id, compliance_region, seq, tenant_id, legal_entity_id,
+    resource_type, resource_id, action, actor_type, actor_id,
+    before_state, after_state, ip_address, request_id, created_at, prev_hash, row_hash
+FROM audit_trail
+WHERE compliance_region = $1 AND seq > $2
+ORDER BY seq
+LIMIT $3;
+
+-- name: ListAuditEntries :many
+SELECT id, compliance_region, seq, tenant_id, legal_entity_id,
+    resource_type, resource_id, action, actor_type, actor_id,
+    before_state, after_state, ip_address, request_id, created_at, prev_hash, row_hash
+FROM audit_trail
+WHERE (sqlc.narg('compliance_region')::text IS NULL OR compliance_region = sqlc.narg('compliance_region'))
+    AND (sqlc.narg('tenant_id')::uuid IS NULL OR tenant_id = sqlc.narg('tenant_id'))
+    AND (sqlc.narg('resource_type')::text IS NULL OR resource_type = sqlc.narg('resource_type'))
+    AND (sqlc.narg('resource_id')::text IS NULL OR resource_id = sqlc.narg('resource_id'))
+    AND (sqlc.narg('actor_id')::text IS NULL OR actor_id = sqlc.narg('actor_id'))
+    AND (sqlc.narg('request_id')::text IS NULL OR request_id = sqlc.narg('request_id'))
+    AND (sqlc.narg('from')::timestamptz IS NULL OR created_at >= sqlc.narg('from'))
+    AND (sqlc.narg('to')::timestamptz IS NULL OR created_at < sqlc.narg('to'))
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2;
+
+-- Legal entity and jurisdiction of a tenant, which place its audit rows in a region
+-- name: GetTenantAuditScope :one
+SELECT t.legal_entity_id, le.jurisdiction
+FROM tenants t
+JOIN legal_entities le ON le.id = t.legal_entity_id
+WHERE t.id = $1;
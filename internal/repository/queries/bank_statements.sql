@@ -0,0 +1,84 @@
+-- name: CreateBankStatement :one
+INSERT INTO bank_statements (
+    legal_entity_id, account_kind, account_identifier, currency, format, external_id,
+    opening_balance, closing_balance, from_date, to_date, entry_count, imported_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (legal_entity_id, account_kind, currency, external_id) DO NOTHING
+RETURNING id, legal_entity_id, account_kind, account_identifier, currency, format, external_id,
+    opening_balance, closing_balance, from_date, to_date, entry_count, imported_by, created_at;
+
+-- name: CreateBankStatementEntry :exec
+INSERT INTO bank_statement_entries (
+    statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetBankStatement :one
+SELECT id, legal_entity_id, account_kind, account_identifier, currency, format, external_id,
+    opening_balance, closing_balance, from_date, to_date, entry_count, imported_by, created_at
+FROM bank_statements
+WHERE id = $1;
+
+-- name: GetBankStatementEntry :one
+SELECT id, statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference, created_at,
+    match_status, parked_at
+FROM bank_statement_entries
+WHERE id = $1;
+
+-- name: GetBankStatementEntryForUpdate :one
+SELECT id, statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference, created_at,
+    match_status, parked_at
+FROM bank_statement_entries
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListBankStatementEntries :many
+SELECT id, statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference, created_at,
+    match_status, parked_at
+FROM bank_statement_entries
+WHERE statement_id = $1
+ORDER BY value_date, id;
+
+-- name: ListBankStatements :many
+SELECT id, legal_entity_id, account_kind, account_identifier, currency, format, external_id,
+    opening_balance, closing_balance, from_date, to_date, entry_count, imported_by, created_at
+FROM bank_statements
+WHERE (sqlc.narg('legal_entity_id')::uuid IS NULL OR legal_entity_id = sqlc.narg('legal_entity_id'))
+  AND (sqlc.narg('account_kind')::text IS NULL OR account_kind = sqlc.narg('account_kind'))
+ORDER BY to_date DESC, created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- Entries waiting for a match, for the manual-match queue.
+-- name: ListOpenBankStatementEntries :many
+SELECT id, statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference, created_at,
+    match_status, parked_at
+FROM bank_statement_entries
+WHERE match_status <> 'matched'
+  AND (sqlc.narg('match_status')::text IS NULL OR match_status = sqlc.narg('match_status'))
+  AND (sqlc.narg('legal_entity_id')::uuid IS NULL OR legal_entity_id = sqlc.narg('legal_entity_id'))
+ORDER BY value_date, id
+LIMIT $1 OFFSET $2;
+
+-- Entries waiting for a match, by ID for paging through all of them.
+-- name: ListOpenBankStatementEntriesAfter :many
+SELECT id, statement_id, legal_entity_id, account_kind, amount, currency,
+    value_date, booking_date, reference, counterparty, bank_reference, created_at,
+    match_status, parked_at
+FROM bank_statement_entries
+WHERE match_status <> 'matched' AND id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: ParkBankStatementEntry :exec
+UPDATE bank_statement_entries
+SET match_status = 'suspense', parked_at = NOW()
+WHERE id = $1;
+
+-- name: SetBankStatementEntryMatched :exec
+UPDATE bank_statement_entries
+SET match_status = 'matched'
+WHERE id = $1;
@@ -0,0 +1,79 @@
+-- name: AssignComplianceCase :exec
+UPDATE compliance_cases
+SET assigned_to = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CloseComplianceCase :exec
+UPDATE compliance_cases
+SET status = $2, decision = $3, decision_reason = $4, decided_by = $5, approved_by = $6,
+    closed_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: CreateComplianceCase :one
+INSERT INTO compliance_cases (
+    transfer_id, tenant_id, compliance_region, reason, priority, risk_score, requires_second_approval, due_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, transfer_id, tenant_id, compliance_region, reason, status, priority, risk_score,
+    assigned_to, decision, decision_reason, decided_by, requires_second_approval, approved_by,
+    due_at, sla_breached_at, closed_at, updated_at;
+
+-- name: CreateComplianceCaseNote :one
+INSERT INTO compliance_case_notes (case_id, author, body)
+VALUES ($1, $2, $3)
+RETURNING id, case_id, author, body, created_at;
+
+-- Escalation raises priority, restarts the SLA and always requires four-eyes approval.
+-- name: EscalateComplianceCase :exec
+UPDATE compliance_cases
+SET status = 'escalated', priority = 'high', requires_second_approval = TRUE,
+    assigned_to = NULL, decision = NULL, decision_reason = NULL, decided_by = NULL,
+    due_at = $2, sla_breached_at = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetComplianceCaseByID :one
+SELECT id, transfer_id, tenant_id, compliance_region, reason, status, priority, risk_score,
+    assigned_to, decision, decision_reason, decided_by, requires_second_approval, approved_by,
+    due_at, sla_breached_at, closed_at, updated_at
+FROM compliance_cases
+WHERE id = $1;
+
+-- name: GetComplianceCaseByIDForUpdate :one
+SELECT id, transfer_id, tenant_id, compliance_region, reason, status, priority, risk_score,
+    assigned_to, decision, decision_reason, decided_by, requires_second_approval, approved_by,
+    due_at, sla_breached_at, closed_at, updated_at
+FROM compliance_cases
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListComplianceCaseNotes :many
+SELECT id, case_id, author, body, created_at
+FROM compliance_case_notes
+WHERE case_id = $1
+ORDER BY created_at;
+
+-- name: ListComplianceCases :many
+SELECT id, transfer_id, tenant_id, compliance_region, reason, status, priority, risk_score,
+    assigned_to, decision, decision_reason, decided_by, requires_second_approval, approved_by,
+    due_at, sla_breached_at, closed_at, updated_at
+FROM compliance_cases
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('open')::boolean IS NULL OR (closed_at IS NULL) = sqlc.narg('open'))
+    AND (sqlc.narg('tenant_id')::uuid IS NULL OR tenant_id = sqlc.narg('tenant_id'))
+    AND (sqlc.narg('assigned_to')::text IS NULL OR assigned_to = sqlc.narg('assigned_to'))
+    AND (sqlc.narg('overdue')::boolean IS NULL OR (closed_at IS NULL AND due_at < NOW()) = sqlc.narg('overdue'))
+ORDER BY priority = 'high' DESC, due_at
+LIMIT $1 OFFSET $2;
+
+-- Flags open cases past their SLA; each case is flagged once.
+-- name: MarkOverdueComplianceCases :many
+UPDATE compliance_cases
+SET sla_breached_at = NOW(), updated_at = NOW()
+WHERE closed_at IS NULL AND sla_breached_at IS NULL AND due_at < NOW()
+RETURNING id, transfer_id, tenant_id, compliance_region, reason, status, priority, risk_score,
+    assigned_to, decision, decision_reason, decided_by, requires_second_approval, approved_by,
+    due_at, sla_breached_at, closed_at, updated_at;
+
+-- name: RequestComplianceCaseApproval :exec
+UPDATE compliance_cases
+SET status = 'pending_approval', decision = $2, decision_reason = $3, decided_by = $4, updated_at = NOW()
+WHERE id = $1;
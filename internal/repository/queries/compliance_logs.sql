@@ -0,0 +1,16 @@
+-- name: CreateComplianceLog :one
+INSERT INTO compliance_logs (compliance_region, transfer_id, tenant_id, case_id, screening_type, result, risk_score, raw_response, screened_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, compliance_region, transfer_id, tenant_id, case_id, screening_type, result, risk_score, raw_response, screened_by, screened_at;
+
+-- name: ListComplianceLogsByCase :many
+SELECT id, compliance_region, transfer_id, tenant_id, case_id, screening_type, result, risk_score, raw_response, screened_by, screened_at
+FROM compliance_logs
+WHERE case_id = $1
+ORDER BY screened_at;
+
+-- name: ListComplianceLogsByTransfer :many
+SELECT id, compliance_region, transfer_id, tenant_id, case_id, screening_type, result, risk_score, raw_response, screened_by, screened_at
+FROM compliance_logs
+WHERE transfer_id = $1
+ORDER BY screened_at;
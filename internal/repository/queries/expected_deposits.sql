@@ -0,0 +1,46 @@
+-- name: CreateExpectedDeposit :one
+INSERT INTO expected_deposits (
+    tenant_id, legal_entity_id, currency, amount, reference, expected_date, created_by
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (tenant_id, reference) DO NOTHING
+RETURNING id, tenant_id, legal_entity_id, currency, amount, reference, expected_date,
+    status, created_by, created_at, updated_at, credited_at;
+
+-- name: GetExpectedDeposit :one
+SELECT id, tenant_id, legal_entity_id, currency, amount, reference, expected_date,
+    status, created_by, created_at, updated_at, credited_at
+FROM expected_deposits
+WHERE id = $1;
+
+-- name: GetExpectedDepositForUpdate :one
+SELECT id, tenant_id, legal_entity_id, currency, amount, reference, expected_date,
+    status, created_by, created_at, updated_at, credited_at
+FROM expected_deposits
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListExpectedDeposits :many
+SELECT id, tenant_id, legal_entity_id, currency, amount, reference, expected_date,
+    status, created_by, created_at, updated_at, credited_at
+FROM expected_deposits
+WHERE (sqlc.narg('tenant_id')::uuid IS NULL OR tenant_id = sqlc.narg('tenant_id'))
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListPendingExpectedDeposits :many
+SELECT id, tenant_id, legal_entity_id, currency, amount, reference, expected_date,
+    status, created_by, created_at, updated_at, credited_at
+FROM expected_deposits
+WHERE legal_entity_id = $1 AND currency = $2 AND status = 'pending'
+ORDER BY expected_date, id;
+
+-- name: MarkExpectedDepositCredited :execrows
+UPDATE expected_deposits
+SET credited_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND credited_at IS NULL;
+
+-- name: UpdateExpectedDepositStatus :exec
+UPDATE expected_deposits
+SET status = $2, updated_at = NOW()
+WHERE id = $1;
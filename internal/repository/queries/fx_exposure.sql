@@ -0,0 +1,79 @@
+-- Records the margin on the quote of an FX transfer. Transfers in a single
+-- currency, or in a currency without a reference rate, record nothing.
+-- name: RecordFXQuoteMargin :exec
+INSERT INTO fx_quote_margins (
+    transfer_id, from_currency, to_currency, from_amount, to_amount, fx_rate,
+    mid_rate, from_usd_rate, to_usd_rate, margin, margin_usd
+)
+SELECT t.id, t.from_currency, t.to_currency, t.from_amount, t.to_amount, t.fx_rate,
+    f.usd_rate / d.usd_rate, f.usd_rate, d.usd_rate,
+    ROUND(t.from_amount * f.usd_rate / d.usd_rate - t.to_amount, 2),
+    ROUND(t.from_amount * f.usd_rate - t.to_amount * d.usd_rate, 2)
+FROM transfers t
+JOIN usd_reference_rates f ON f.currency = t.from_currency
+JOIN usd_reference_rates d ON d.currency = t.to_currency
+WHERE t.id = $1 AND t.from_currency <> t.to_currency
+ON CONFLICT (transfer_id) DO NOTHING;
+
+-- Releases the quote of a transfer cancelled before it was paid out.
+-- name: DeleteFXQuoteMargin :exec
+DELETE FROM fx_quote_margins
+WHERE transfer_id = $1;
+
+-- name: GetFXQuoteMargin :one
+SELECT transfer_id, from_currency, to_currency, from_amount, to_amount, fx_rate,
+    mid_rate, from_usd_rate, to_usd_rate, margin, margin_usd, created_at
+FROM fx_quote_margins
+WHERE transfer_id = $1;
+
+-- USD cost of the FX_SETTLEMENT position in each currency: what the
+-- transfers and treasury movements posted to the ledger moved it at.
+-- name: ListFXCostBasis :many
+SELECT c.currency::text AS currency, SUM(c.cost_usd)::numeric AS cost_usd
+FROM (
+    SELECT m.from_currency AS currency, m.from_amount * m.from_usd_rate AS cost_usd
+    FROM fx_quote_margins m
+    JOIN transfers t ON t.id = m.transfer_id
+    WHERE COALESCE(cardinality(t.tb_transfer_ids), 0) > 0 AND t.status <> 'rolled_back'
+    UNION ALL
+    SELECT m.to_currency, -(m.to_amount * m.to_usd_rate)
+    FROM fx_quote_margins m
+    JOIN transfers t ON t.id = m.transfer_id
+    WHERE COALESCE(cardinality(t.tb_transfer_ids), 0) > 0 AND t.status <> 'rolled_back'
+    UNION ALL
+    SELECT l.currency,
+        CASE WHEN l.credit_account = 'FX_SETTLEMENT' THEN 1 ELSE -1 END * l.amount * l.usd_rate
+    FROM treasury_movement_legs l
+    JOIN treasury_movements mv ON mv.id = l.movement_id
+    WHERE mv.status = 'posted' AND l.usd_rate IS NOT NULL
+      AND 'FX_SETTLEMENT' IN (l.debit_account, l.credit_account)
+) c
+GROUP BY c.currency
+ORDER BY c.currency;
+
+-- name: ListUSDReferenceRates :many
+SELECT currency, usd_rate, updated_at
+FROM usd_reference_rates
+ORDER BY currency;
+
+-- name: CreateFXExposureSnapshot :one
+INSERT INTO fx_exposure_snapshots (
+    eur_usd_rate, total_value_usd, total_value_eur, realised_pnl_usd, unrealised_pnl_usd, positions
+) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, taken_at, eur_usd_rate, total_value_usd, total_value_eur,
+    realised_pnl_usd, unrealised_pnl_usd, positions;
+
+-- name: GetFXExposureSnapshot :one
+SELECT id, taken_at, eur_usd_rate, total_value_usd, total_value_eur,
+    realised_pnl_usd, unrealised_pnl_usd, positions
+FROM fx_exposure_snapshots
+WHERE id = $1;
+
+-- name: ListFXExposureSnapshots :many
+SELECT id, taken_at, eur_usd_rate, total_value_usd, total_value_eur,
+    realised_pnl_usd, unrealised_pnl_usd, positions
+FROM fx_exposure_snapshots
+WHERE (sqlc.narg('taken_after')::timestamptz IS NULL OR taken_at >= sqlc.narg('taken_after'))
+  AND (sqlc.narg('taken_before')::timestamptz IS NULL OR taken_at < sqlc.narg('taken_before'))
+ORDER BY taken_at DESC
+LIMIT $1 OFFSET $2;
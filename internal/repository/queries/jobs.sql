@@ -0,0 +1,67 @@
+-- name: InsertJob :one
+INSERT INTO jobs (kind, queue, args, priority, max_attempts, scheduled_at, unique_key, trace_context)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (kind, unique_key) WHERE unique_key IS NOT NULL AND state IN ('available', 'running')
+DO NOTHING
+RETURNING id, kind, queue, args, priority, state, attempt, max_attempts, scheduled_at,
+    attempted_at, locked_until, finalized_at, last_error, unique_key, created_at, trace_context;
+
+-- name: GetJobByID :one
+SELECT id, kind, queue, args, priority, state, attempt, max_attempts, scheduled_at,
+    attempted_at, locked_until, finalized_at, last_error, unique_key, created_at, trace_context
+FROM jobs
+WHERE id = $1;
+
+-- name: ClaimJobs :many
+UPDATE jobs
+SET state = 'running', attempt = attempt + 1, attempted_at = NOW(),
+    locked_until = NOW() + (sqlc.arg('lease_seconds')::int * INTERVAL '1 second')
+WHERE id IN (
+    SELECT j.id FROM jobs j
+    WHERE j.state = 'available'
+        AND j.queue = sqlc.arg('queue')
+        AND j.scheduled_at <= NOW()
+    ORDER BY j.priority, j.scheduled_at
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, kind, queue, args, priority, state, attempt, max_attempts, scheduled_at,
+    attempted_at, locked_until, finalized_at, last_error, unique_key, created_at, trace_context;
+
+-- Records the outcome of the attempt that ran a job, unless its lease expired
+-- and the job was rescued since; as do RetryJob and DiscardJob.
+-- name: CompleteJob :exec
+UPDATE jobs
+SET state = 'completed', locked_until = NULL, finalized_at = NOW()
+WHERE id = $1 AND attempt = $2 AND state = 'running';
+
+-- name: RetryJob :exec
+UPDATE jobs
+SET state = 'available', locked_until = NULL, scheduled_at = $3, last_error = $4
+WHERE id = $1 AND attempt = $2 AND state = 'running';
+
+-- name: DiscardJob :exec
+UPDATE jobs
+SET state = 'discarded', locked_until = NULL, finalized_at = NOW(), last_error = $3
+WHERE id = $1 AND attempt = $2 AND state = 'running';
+
+-- name: RescueStuckJobs :execrows
+-- Returns jobs whose worker lease expired (e.g. the process crashed) to the queue.
+UPDATE jobs
+SET state = CASE WHEN attempt >= max_attempts THEN 'discarded' ELSE 'available' END,
+    finalized_at = CASE WHEN attempt >= max_attempts THEN NOW() ELSE NULL END,
+    locked_until = NULL,
+    last_error = 'worker lease expired'
+WHERE state = 'running' AND locked_until < NOW();
+
+-- name: CountUnfinishedJobs :many
+-- Counts the available and running jobs of each queue.
+SELECT queue, state, COUNT(*) AS count
+FROM jobs
+WHERE state IN ('available', 'running')
+GROUP BY queue, state
+ORDER BY queue, state;
+
+-- name: DeleteFinalizedJobs :execrows
+DELETE FROM jobs
+WHERE finalized_at IS NOT NULL AND finalized_at < $1;
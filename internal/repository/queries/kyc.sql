@@ -0,0 +1,51 @@
+-- name: CreateKYCSubmission :one
+INSERT INTO kyc_submissions (tenant_id, requested_level, documents, submitted_by)
+VALUES ($1, $2, $3, $4)
+RETURNING id, tenant_id, requested_level, documents, submitted_by, status,
+    reviewed_by, review_reason, reviewed_at, submitted_at;
+
+-- name: CreateTenantStatusChange :one
+INSERT INTO tenant_status_changes (tenant_id, from_status, to_status, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, tenant_id, from_status, to_status, reason, changed_by, created_at;
+
+-- name: DecideKYCSubmission :exec
+UPDATE kyc_submissions
+SET status = $2, reviewed_by = $3, review_reason = $4, reviewed_at = NOW()
+WHERE id = $1;
+
+-- name: GetKYCSubmissionByID :one
+SELECT id, tenant_id, requested_level, documents, submitted_by, status,
+    reviewed_by, review_reason, reviewed_at, submitted_at
+FROM kyc_submissions
+WHERE id = $1;
+
+-- name: GetKYCSubmissionByIDForUpdate :one
+SELECT id, tenant_id, requested_level, documents, submitted_by, status,
+    reviewed_by, review_reason, reviewed_at, submitted_at
+FROM kyc_submissions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetKYCTierLimit :one
+SELECT kyc_level, per_transfer_limit_usd, daily_limit_usd, monthly_limit_usd, updated_at
+FROM kyc_tier_limits
+WHERE kyc_level = $1;
+
+-- name: ListKYCSubmissionsByTenant :many
+SELECT id, tenant_id, requested_level, documents, submitted_by, status,
+    reviewed_by, review_reason, reviewed_at, submitted_at
+FROM kyc_submissions
+WHERE tenant_id = $1
+ORDER BY submitted_at DESC;
+
+-- name: ListKYCTierLimits :many
+SELECT kyc_level, per_transfer_limit_usd, daily_limit_usd, monthly_limit_usd, updated_at
+FROM kyc_tier_limits
+ORDER BY kyc_level;
+
+-- name: ListTenantStatusChanges :many
+SELECT id, tenant_id, from_status, to_status, reason, changed_by, created_at
+FROM tenant_status_changes
+WHERE tenant_id = $1
+ORDER BY created_at DESC;
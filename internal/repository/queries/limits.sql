@@ -0,0 +1,19 @@
+-- name: GetLimitPolicyByTenant :one
+SELECT id, tenant_id, daily_limit_usd, monthly_limit_usd, per_transfer_limit_usd,
+    rate_limit_rpm, rate_limit_burst, max_batch_size, max_batch_amount_usd,
+    effective_from, updated_at
+FROM limit_policies
+WHERE tenant_id = $1;
+
+-- name: GetUSDReferenceRate :one
+SELECT currency, usd_rate, updated_at
+FROM usd_reference_rates
+WHERE currency = $1;
+
+-- USD value of a tenant's transfers since a point in time, excluding failed ones.
+-- name: SumTransferVolumeUSD :one
+SELECT COALESCE(SUM(t.from_amount * r.usd_rate), 0)::numeric AS volume_usd
+FROM transfers t
+JOIN usd_reference_rates r ON r.currency = t.from_currency
+WHERE t.tenant_id = sqlc.arg('tenant_id') AND t.id >= ts_to_uuid_min(sqlc.arg('since')::timestamptz)
+    AND t.status NOT IN ('rejected', 'rolled_back', 'cancelled');
@@ -0,0 +1,146 @@
+-- name: UpsertRegionalSettlement :one
+INSERT INTO regional_settlements (
+    legal_entity_id, currency, tb_account_id, min_balance, target_balance, alert_threshold
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (legal_entity_id, currency) DO UPDATE
+SET min_balance = EXCLUDED.min_balance,
+    target_balance = EXCLUDED.target_balance,
+    alert_threshold = EXCLUDED.alert_threshold,
+    updated_at = NOW()
+RETURNING id, legal_entity_id, currency, tb_account_id, cached_balance, cached_at,
+    min_balance, target_balance, alert_threshold, created_at, updated_at;
+
+-- name: GetRegionalSettlement :one
+SELECT id, legal_entity_id, currency, tb_account_id, cached_balance, cached_at,
+    min_balance, target_balance, alert_threshold, created_at, updated_at
+FROM regional_settlements
+WHERE id = $1;
+
+-- name: GetRegionalSettlementByEntity :one
+SELECT id, legal_entity_id, currency, tb_account_id, cached_balance, cached_at,
+    min_balance, target_balance, alert_threshold, created_at, updated_at
+FROM regional_settlements
+WHERE legal_entity_id = $1 AND currency = $2;
+
+-- Locks a settlement so payouts against it are admitted one at a time.
+-- name: GetRegionalSettlementByEntityForUpdate :one
+SELECT id, legal_entity_id, currency, tb_account_id, cached_balance, cached_at,
+    min_balance, target_balance, alert_threshold, created_at, updated_at
+FROM regional_settlements
+WHERE legal_entity_id = $1 AND currency = $2
+FOR UPDATE;
+
+-- name: ListRegionalSettlements :many
+SELECT id, legal_entity_id, currency, tb_account_id, cached_balance, cached_at,
+    min_balance, target_balance, alert_threshold, created_at, updated_at
+FROM regional_settlements
+ORDER BY legal_entity_id, currency;
+
+-- name: UpdateRegionalSettlementBalance :exec
+UPDATE regional_settlements
+SET cached_balance = $2, cached_at = NOW()
+WHERE id = $1;
+
+-- Payouts to a legal entity in a currency not yet posted to the ledger:
+-- admitted ones are processing, queued ones include those still screening.
+-- name: SumQueuedPayouts :one
+SELECT COALESCE(SUM(to_amount) FILTER (WHERE status = 'processing'), 0)::numeric AS admitted,
+    COALESCE(SUM(to_amount), 0)::numeric AS queued
+FROM transfers
+WHERE dest_legal_entity_id = $1 AND to_currency = $2
+  AND status IN ('created', 'validating', 'processing')
+  AND COALESCE(cardinality(tb_transfer_ids), 0) = 0;
+
+-- name: UpsertLiquidityAlert :one
+INSERT INTO liquidity_alerts (
+    settlement_id, level, balance, queued_outflow, projected_balance
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (settlement_id) WHERE status = 'open' DO UPDATE
+SET level = EXCLUDED.level,
+    balance = EXCLUDED.balance,
+    queued_outflow = EXCLUDED.queued_outflow,
+    projected_balance = EXCLUDED.projected_balance,
+    updated_at = NOW()
+RETURNING id, settlement_id, level, balance, queued_outflow, projected_balance,
+    status, created_at, updated_at, resolved_at;
+
+-- name: ResolveLiquidityAlert :execrows
+UPDATE liquidity_alerts
+SET status = 'resolved', resolved_at = NOW(), updated_at = NOW()
+WHERE settlement_id = $1 AND status = 'open';
+
+-- name: ListLiquidityAlerts :many
+SELECT id, settlement_id, level, balance, queued_outflow, projected_balance,
+    status, created_at, updated_at, resolved_at
+FROM liquidity_alerts
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: UpsertLiquidityTopUp :one
+INSERT INTO liquidity_top_ups (
+    settlement_id, amount, projected_balance
+) VALUES ($1, $2, $3)
+ON CONFLICT (settlement_id) WHERE status = 'pending' DO UPDATE
+SET amount = EXCLUDED.amount,
+    projected_balance = EXCLUDED.projected_balance,
+    updated_at = NOW()
+RETURNING id, settlement_id, amount, projected_balance, status, decided_by,
+    created_at, updated_at;
+
+-- name: GetLiquidityTopUpForUpdate :one
+SELECT id, settlement_id, amount, projected_balance, status, decided_by,
+    created_at, updated_at
+FROM liquidity_top_ups
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListLiquidityTopUps :many
+SELECT id, settlement_id, amount, projected_balance, status, decided_by,
+    created_at, updated_at
+FROM liquidity_top_ups
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateLiquidityTopUpStatus :exec
+UPDATE liquidity_top_ups
+SET status = $2, decided_by = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- Cancels the hold of a payout that will not be paid.
+-- name: CancelLiquidityHold :exec
+UPDATE liquidity_holds
+SET status = 'cancelled', released_at = NOW()
+WHERE transfer_id = $1 AND status = 'held';
+
+-- name: CreateLiquidityHold :one
+INSERT INTO liquidity_holds (
+    settlement_id, transfer_id, amount
+) VALUES ($1, $2, $3)
+ON CONFLICT (transfer_id) WHERE status = 'held' DO NOTHING
+RETURNING id, settlement_id, transfer_id, amount, status, created_at, released_at;
+
+-- name: HasLiquidityHolds :one
+SELECT EXISTS (
+    SELECT 1 FROM liquidity_holds WHERE settlement_id = $1 AND status = 'held'
+) AS held;
+
+-- Held payouts of a settlement, oldest first, in the order they are released.
+-- name: ListHeldPayouts :many
+SELECT id, settlement_id, transfer_id, amount, status, created_at, released_at
+FROM liquidity_holds
+WHERE settlement_id = $1 AND status = 'held'
+ORDER BY id;
+
+-- name: ListLiquidityHolds :many
+SELECT id, settlement_id, transfer_id, amount, status, created_at, released_at
+FROM liquidity_holds
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateLiquidityHoldStatus :exec
+UPDATE liquidity_holds
+SET status = $2, released_at = NOW()
+WHERE id = $1;
@@ -7,6 +7,7 @@ package queries
 import (
 	"database/sql/driver"
 	"fmt"
+	"net/netip"
 	"time"
 
 	"github.com/google/uuid"
@@ -280,6 +281,283 @@ func (ns NullTransferStatusEnum) Value() (driver.Value, error) {
 	return string(ns.TransferStatusEnum), nil
 }
 
+type AuditChainHead struct {
+	ComplianceRegion string    `json:"compliance_region"`
+	Seq              int64     `json:"seq"`
+	RowHash          []byte    `json:"row_hash"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type AuditTrail struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	Seq              int64       `json:"seq"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	LegalEntityID    pgtype.UUID `json:"legal_entity_id"`
+	ResourceType     string      `json:"resource_type"`
+	ResourceID       string      `json:"resource_id"`
+	Action           string      `json:"action"`
+	ActorType        string      `json:"actor_type"`
+	ActorID          string      `json:"actor_id"`
+	BeforeState      []byte      `json:"before_state"`
+	AfterState       []byte      `json:"after_state"`
+	IpAddress        *netip.Addr `json:"ip_address"`
+	RequestID        pgtype.Text `json:"request_id"`
+	CreatedAt        time.Time   `json:"created_at"`
+	PrevHash         []byte      `json:"prev_hash"`
+	RowHash          []byte      `json:"row_hash"`
+}
+
+type AuditTrailEu struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	Seq              int64       `json:"seq"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	LegalEntityID    pgtype.UUID `json:"legal_entity_id"`
+	ResourceType     string      `json:"resource_type"`
+	ResourceID       string      `json:"resource_id"`
+	Action           string      `json:"action"`
+	ActorType        string      `json:"actor_type"`
+	ActorID          string      `json:"actor_id"`
+	BeforeState      []byte      `json:"before_state"`
+	AfterState       []byte      `json:"after_state"`
+	IpAddress        *netip.Addr `json:"ip_address"`
+	RequestID        pgtype.Text `json:"request_id"`
+	CreatedAt        time.Time   `json:"created_at"`
+	PrevHash         []byte      `json:"prev_hash"`
+	RowHash          []byte      `json:"row_hash"`
+}
+
+type AuditTrailID struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	Seq              int64       `json:"seq"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	LegalEntityID    pgtype.UUID `json:"legal_entity_id"`
+	ResourceType     string      `json:"resource_type"`
+	ResourceID       string      `json:"resource_id"`
+	Action           string      `json:"action"`
+	ActorType        string      `json:"actor_type"`
+	ActorID          string      `json:"actor_id"`
+	BeforeState      []byte      `json:"before_state"`
+	AfterState       []byte      `json:"after_state"`
+	IpAddress        *netip.Addr `json:"ip_address"`
+	RequestID        pgtype.Text `json:"request_id"`
+	CreatedAt        time.Time   `json:"created_at"`
+	PrevHash         []byte      `json:"prev_hash"`
+	RowHash          []byte      `json:"row_hash"`
+}
+
+type AuditTrailUk struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	Seq              int64       `json:"seq"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	LegalEntityID    pgtype.UUID `json:"legal_entity_id"`
+	ResourceType     string      `json:"resource_type"`
+	ResourceID       string      `json:"resource_id"`
+	Action           string      `json:"action"`
+	ActorType        string      `json:"actor_type"`
+	ActorID          string      `json:"actor_id"`
+	BeforeState      []byte      `json:"before_state"`
+	AfterState       []byte      `json:"after_state"`
+	IpAddress        *netip.Addr `json:"ip_address"`
+	RequestID        pgtype.Text `json:"request_id"`
+	CreatedAt        time.Time   `json:"created_at"`
+	PrevHash         []byte      `json:"prev_hash"`
+	RowHash          []byte      `json:"row_hash"`
+}
+
+type AuditTrailUnknown struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	Seq              int64       `json:"seq"`
+	TenantID         pgtype.UUID `json:"tenant_id"`
+	LegalEntityID    pgtype.UUID `json:"legal_entity_id"`
+	ResourceType     string      `json:"resource_type"`
+	ResourceID       string      `json:"resource_id"`
+	Action           string      `json:"action"`
+	ActorType        string      `json:"actor_type"`
+	ActorID          string      `json:"actor_id"`
+	BeforeState      []byte      `json:"before_state"`
+	AfterState       []byte      `json:"after_state"`
+	IpAddress        *netip.Addr `json:"ip_address"`
+	RequestID        pgtype.Text `json:"request_id"`
+	CreatedAt        time.Time   `json:"created_at"`
+	PrevHash         []byte      `json:"prev_hash"`
+	RowHash          []byte      `json:"row_hash"`
+}
+
+type BankBalance struct {
+	ID            uuid.UUID      `json:"id"`
+	LegalEntityID uuid.UUID      `json:"legal_entity_id"`
+	AccountKind   string         `json:"account_kind"`
+	Currency      string         `json:"currency"`
+	Balance       pgtype.Numeric `json:"balance"`
+	AsOf          pgtype.Date    `json:"as_of"`
+	Source        string         `json:"source"`
+	RecordedBy    string         `json:"recorded_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type BankStatement struct {
+	ID                uuid.UUID      `json:"id"`
+	LegalEntityID     uuid.UUID      `json:"legal_entity_id"`
+	AccountKind       string         `json:"account_kind"`
+	AccountIdentifier string         `json:"account_identifier"`
+	Currency          string         `json:"currency"`
+	Format            string         `json:"format"`
+	ExternalID        string         `json:"external_id"`
+	OpeningBalance    pgtype.Numeric `json:"opening_balance"`
+	ClosingBalance    pgtype.Numeric `json:"closing_balance"`
+	FromDate          pgtype.Date    `json:"from_date"`
+	ToDate            pgtype.Date    `json:"to_date"`
+	EntryCount        int32          `json:"entry_count"`
+	ImportedBy        string         `json:"imported_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
+type BankStatementEntry struct {
+	ID            uuid.UUID          `json:"id"`
+	StatementID   uuid.UUID          `json:"statement_id"`
+	LegalEntityID uuid.UUID          `json:"legal_entity_id"`
+	AccountKind   string             `json:"account_kind"`
+	Amount        pgtype.Numeric     `json:"amount"`
+	Currency      string             `json:"currency"`
+	ValueDate     pgtype.Date        `json:"value_date"`
+	BookingDate   pgtype.Date        `json:"booking_date"`
+	Reference     string             `json:"reference"`
+	Counterparty  pgtype.Text        `json:"counterparty"`
+	BankReference pgtype.Text        `json:"bank_reference"`
+	CreatedAt     time.Time          `json:"created_at"`
+	MatchStatus   string             `json:"match_status"`
+	ParkedAt      pgtype.Timestamptz `json:"parked_at"`
+}
+
+type ComplianceCase struct {
+	ID                     uuid.UUID          `json:"id"`
+	TransferID             uuid.UUID          `json:"transfer_id"`
+	TenantID               uuid.UUID          `json:"tenant_id"`
+	ComplianceRegion       string             `json:"compliance_region"`
+	Reason                 string             `json:"reason"`
+	Status                 string             `json:"status"`
+	Priority               string             `json:"priority"`
+	RiskScore              pgtype.Int4        `json:"risk_score"`
+	AssignedTo             pgtype.Text        `json:"assigned_to"`
+	Decision               pgtype.Text        `json:"decision"`
+	DecisionReason         pgtype.Text        `json:"decision_reason"`
+	DecidedBy              pgtype.Text        `json:"decided_by"`
+	RequiresSecondApproval bool               `json:"requires_second_approval"`
+	ApprovedBy             pgtype.Text        `json:"approved_by"`
+	DueAt                  time.Time          `json:"due_at"`
+	SlaBreachedAt          pgtype.Timestamptz `json:"sla_breached_at"`
+	ClosedAt               pgtype.Timestamptz `json:"closed_at"`
+	UpdatedAt              time.Time          `json:"updated_at"`
+}
+
+type ComplianceCaseNote struct {
+	ID        uuid.UUID `json:"id"`
+	CaseID    uuid.UUID `json:"case_id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ComplianceLog struct {
+	ID               uuid.UUID   `json:"id"`
+	ComplianceRegion string      `json:"compliance_region"`
+	TransferID       uuid.UUID   `json:"transfer_id"`
+	TenantID         uuid.UUID   `json:"tenant_id"`
+	CaseID           pgtype.UUID `json:"case_id"`
+	ScreeningType    string      `json:"screening_type"`
+	Result           string      `json:"result"`
+	RiskScore        pgtype.Int4 `json:"risk_score"`
+	RawResponse      []byte      `json:"raw_response"`
+	ScreenedBy       string      `json:"screened_by"`
+	ScreenedAt       time.Time   `json:"screened_at"`
+}
+
+type ExpectedDeposit struct {
+	ID            uuid.UUID          `json:"id"`
+	TenantID      uuid.UUID          `json:"tenant_id"`
+	LegalEntityID uuid.UUID          `json:"legal_entity_id"`
+	Currency      string             `json:"currency"`
+	Amount        pgtype.Numeric     `json:"amount"`
+	Reference     string             `json:"reference"`
+	ExpectedDate  pgtype.Date        `json:"expected_date"`
+	Status        string             `json:"status"`
+	CreatedBy     string             `json:"created_by"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	CreditedAt    pgtype.Timestamptz `json:"credited_at"`
+}
+
+type FxExposureSnapshot struct {
+	ID               uuid.UUID      `json:"id"`
+	TakenAt          time.Time      `json:"taken_at"`
+	EurUsdRate       pgtype.Numeric `json:"eur_usd_rate"`
+	TotalValueUsd    pgtype.Numeric `json:"total_value_usd"`
+	TotalValueEur    pgtype.Numeric `json:"total_value_eur"`
+	RealisedPnlUsd   pgtype.Numeric `json:"realised_pnl_usd"`
+	UnrealisedPnlUsd pgtype.Numeric `json:"unrealised_pnl_usd"`
+	Positions        []byte         `json:"positions"`
+}
+
+type FxQuoteMargin struct {
+	TransferID   uuid.UUID      `json:"transfer_id"`
+	FromCurrency string         `json:"from_currency"`
+	ToCurrency   string         `json:"to_currency"`
+	FromAmount   pgtype.Numeric `json:"from_amount"`
+	ToAmount     pgtype.Numeric `json:"to_amount"`
+	FxRate       pgtype.Numeric `json:"fx_rate"`
+	MidRate      pgtype.Numeric `json:"mid_rate"`
+	FromUsdRate  pgtype.Numeric `json:"from_usd_rate"`
+	ToUsdRate    pgtype.Numeric `json:"to_usd_rate"`
+	Margin       pgtype.Numeric `json:"margin"`
+	MarginUsd    pgtype.Numeric `json:"margin_usd"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type Job struct {
+	ID           uuid.UUID          `json:"id"`
+	Kind         string             `json:"kind"`
+	Queue        string             `json:"queue"`
+	Args         []byte             `json:"args"`
+	Priority     int16              `json:"priority"`
+	State        string             `json:"state"`
+	Attempt      int32              `json:"attempt"`
+	MaxAttempts  int32              `json:"max_attempts"`
+	ScheduledAt  time.Time          `json:"scheduled_at"`
+	AttemptedAt  pgtype.Timestamptz `json:"attempted_at"`
+	LockedUntil  pgtype.Timestamptz `json:"locked_until"`
+	FinalizedAt  pgtype.Timestamptz `json:"finalized_at"`
+	LastError    pgtype.Text        `json:"last_error"`
+	UniqueKey    pgtype.Text        `json:"unique_key"`
+	CreatedAt    time.Time          `json:"created_at"`
+	TraceContext []byte             `json:"trace_context"`
+}
+
+type KycSubmission struct {
+	ID             uuid.UUID          `json:"id"`
+	TenantID       uuid.UUID          `json:"tenant_id"`
+	RequestedLevel KycLevelEnum       `json:"requested_level"`
+	Documents      []byte             `json:"documents"`
+	SubmittedBy    string             `json:"submitted_by"`
+	Status         string             `json:"status"`
+	ReviewedBy     pgtype.Text        `json:"reviewed_by"`
+	ReviewReason   pgtype.Text        `json:"review_reason"`
+	ReviewedAt     pgtype.Timestamptz `json:"reviewed_at"`
+	SubmittedAt    time.Time          `json:"submitted_at"`
+}
+
+type KycTierLimit struct {
+	KycLevel            KycLevelEnum   `json:"kyc_level"`
+	PerTransferLimitUsd pgtype.Numeric `json:"per_transfer_limit_usd"`
+	DailyLimitUsd       pgtype.Numeric `json:"daily_limit_usd"`
+	MonthlyLimitUsd     pgtype.Numeric `json:"monthly_limit_usd"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
 type LegalEntity struct {
 	ID                  uuid.UUID       `json:"id"`
 	Code                string          `json:"code"`
@@ -315,6 +593,76 @@ type LimitPolicy struct {
 	UpdatedAt           time.Time      `json:"updated_at"`
 }
 
+type LiquidityAlert struct {
+	ID               uuid.UUID          `json:"id"`
+	SettlementID     uuid.UUID          `json:"settlement_id"`
+	Level            string             `json:"level"`
+	Balance          pgtype.Numeric     `json:"balance"`
+	QueuedOutflow    pgtype.Numeric     `json:"queued_outflow"`
+	ProjectedBalance pgtype.Numeric     `json:"projected_balance"`
+	Status           string             `json:"status"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+	ResolvedAt       pgtype.Timestamptz `json:"resolved_at"`
+}
+
+type LiquidityHold struct {
+	ID           uuid.UUID          `json:"id"`
+	SettlementID uuid.UUID          `json:"settlement_id"`
+	TransferID   uuid.UUID          `json:"transfer_id"`
+	Amount       pgtype.Numeric     `json:"amount"`
+	Status       string             `json:"status"`
+	CreatedAt    time.Time          `json:"created_at"`
+	ReleasedAt   pgtype.Timestamptz `json:"released_at"`
+}
+
+type LiquidityTopUp struct {
+	ID               uuid.UUID      `json:"id"`
+	SettlementID     uuid.UUID      `json:"settlement_id"`
+	Amount           pgtype.Numeric `json:"amount"`
+	ProjectedBalance pgtype.Numeric `json:"projected_balance"`
+	Status           string         `json:"status"`
+	DecidedBy        pgtype.Text    `json:"decided_by"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+type MonitoringAlert struct {
+	ID               uuid.UUID `json:"id"`
+	TransferID       uuid.UUID `json:"transfer_id"`
+	TenantID         uuid.UUID `json:"tenant_id"`
+	ComplianceRegion string    `json:"compliance_region"`
+	RuleID           string    `json:"rule_id"`
+	RuleType         string    `json:"rule_type"`
+	Score            int32     `json:"score"`
+	Details          []byte    `json:"details"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type Operator struct {
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	ApiKeyHash       string      `json:"api_key_hash"`
+	ComplianceRegion pgtype.Text `json:"compliance_region"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
+
+type Outbox struct {
+	ID            uuid.UUID          `json:"id"`
+	Seq           int64              `json:"seq"`
+	AggregateType string             `json:"aggregate_type"`
+	AggregateID   uuid.UUID          `json:"aggregate_id"`
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	EventType     string             `json:"event_type"`
+	Payload       []byte             `json:"payload"`
+	Attempts      int32              `json:"attempts"`
+	AvailableAt   time.Time          `json:"available_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	PublishedAt   pgtype.Timestamptz `json:"published_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	TraceContext  []byte             `json:"trace_context"`
+}
+
 type PricingPolicy struct {
 	ID                uuid.UUID          `json:"id"`
 	TenantID          uuid.UUID          `json:"tenant_id"`
@@ -325,6 +673,103 @@ type PricingPolicy struct {
 	ValidUntil        pgtype.Timestamptz `json:"valid_until"`
 }
 
+type RailCallback struct {
+	ID            uuid.UUID          `json:"id"`
+	Rail          RailEnum           `json:"rail"`
+	EventID       string             `json:"event_id"`
+	TransferID    uuid.UUID          `json:"transfer_id"`
+	RailReference pgtype.Text        `json:"rail_reference"`
+	Status        string             `json:"status"`
+	ReturnCode    pgtype.Text        `json:"return_code"`
+	Reason        pgtype.Text        `json:"reason"`
+	Outcome       string             `json:"outcome"`
+	Payload       []byte             `json:"payload"`
+	OccurredAt    pgtype.Timestamptz `json:"occurred_at"`
+	ReceivedAt    time.Time          `json:"received_at"`
+}
+
+type Recipient struct {
+	ID            uuid.UUID   `json:"id"`
+	TenantID      uuid.UUID   `json:"tenant_id"`
+	Name          string      `json:"name"`
+	Country       string      `json:"country"`
+	AccountNumber pgtype.Text `json:"account_number"`
+	BankCode      pgtype.Text `json:"bank_code"`
+	Metadata      []byte      `json:"metadata"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+type ReconciliationDiscrepancy struct {
+	ID              uuid.UUID      `json:"id"`
+	ReportID        uuid.UUID      `json:"report_id"`
+	Kind            string         `json:"kind"`
+	LegalEntityID   pgtype.UUID    `json:"legal_entity_id"`
+	Currency        pgtype.Text    `json:"currency"`
+	TransferID      pgtype.UUID    `json:"transfer_id"`
+	LedgerAmount    pgtype.Numeric `json:"ledger_amount"`
+	BankAmount      pgtype.Numeric `json:"bank_amount"`
+	Difference      pgtype.Numeric `json:"difference"`
+	BankBalanceAsOf pgtype.Date    `json:"bank_balance_as_of"`
+	Detail          string         `json:"detail"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+type ReconciliationReport struct {
+	ID               uuid.UUID          `json:"id"`
+	BusinessDate     pgtype.Date        `json:"business_date"`
+	Status           string             `json:"status"`
+	BalancesChecked  int32              `json:"balances_checked"`
+	TransfersChecked int32              `json:"transfers_checked"`
+	DiscrepancyCount int32              `json:"discrepancy_count"`
+	SignedOffBy      pgtype.Text        `json:"signed_off_by"`
+	SignOffNote      pgtype.Text        `json:"sign_off_note"`
+	SignedOffAt      pgtype.Timestamptz `json:"signed_off_at"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+type Refund struct {
+	ID             uuid.UUID          `json:"id"`
+	TransferID     uuid.UUID          `json:"transfer_id"`
+	TenantID       uuid.UUID          `json:"tenant_id"`
+	Seq            int32              `json:"seq"`
+	Currency       string             `json:"currency"`
+	Amount         pgtype.Numeric     `json:"amount"`
+	FeeAmount      pgtype.Numeric     `json:"fee_amount"`
+	DestCurrency   string             `json:"dest_currency"`
+	DestAmount     pgtype.Numeric     `json:"dest_amount"`
+	Reason         string             `json:"reason"`
+	ReturnCode     pgtype.Text        `json:"return_code"`
+	IdempotencyKey pgtype.Text        `json:"idempotency_key"`
+	Status         string             `json:"status"`
+	CreatedAt      time.Time          `json:"created_at"`
+	PostedAt       pgtype.Timestamptz `json:"posted_at"`
+}
+
+type RegionalSettlement struct {
+	ID             uuid.UUID      `json:"id"`
+	LegalEntityID  uuid.UUID      `json:"legal_entity_id"`
+	Currency       string         `json:"currency"`
+	TbAccountID    pgtype.Numeric `json:"tb_account_id"`
+	CachedBalance  pgtype.Numeric `json:"cached_balance"`
+	CachedAt       time.Time      `json:"cached_at"`
+	MinBalance     pgtype.Numeric `json:"min_balance"`
+	TargetBalance  pgtype.Numeric `json:"target_balance"`
+	AlertThreshold pgtype.Numeric `json:"alert_threshold"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+type StatementMatch struct {
+	ID                uuid.UUID      `json:"id"`
+	EntryID           uuid.UUID      `json:"entry_id"`
+	TransferID        pgtype.UUID    `json:"transfer_id"`
+	ExpectedDepositID pgtype.UUID    `json:"expected_deposit_id"`
+	Amount            pgtype.Numeric `json:"amount"`
+	Rule              string         `json:"rule"`
+	MatchedBy         string         `json:"matched_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+}
+
 type Tenant struct {
 	ID                   uuid.UUID        `json:"id"`
 	DisplayName          string           `json:"display_name"`
@@ -339,11 +784,21 @@ type Tenant struct {
 	NettingWindowMinutes int32            `json:"netting_window_minutes"`
 	ApiKeyHash           pgtype.Text      `json:"api_key_hash"`
 	WebhookUrl           pgtype.Text      `json:"webhook_url"`
-	WebhookSecretHash    pgtype.Text      `json:"webhook_secret_hash"`
+	WebhookSigningKey    pgtype.Text      `json:"webhook_signing_key"`
 	Metadata             []byte           `json:"metadata"`
 	UpdatedAt            time.Time        `json:"updated_at"`
 }
 
+type TenantStatusChange struct {
+	ID         uuid.UUID        `json:"id"`
+	TenantID   uuid.UUID        `json:"tenant_id"`
+	FromStatus TenantStatusEnum `json:"from_status"`
+	ToStatus   TenantStatusEnum `json:"to_status"`
+	Reason     string           `json:"reason"`
+	ChangedBy  string           `json:"changed_by"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
 type Transfer struct {
 	ID                  uuid.UUID          `json:"id"`
 	TenantID            uuid.UUID          `json:"tenant_id"`
@@ -372,6 +827,7 @@ type Transfer struct {
 	ComplianceRegion    string             `json:"compliance_region"`
 	UpdatedAt           time.Time          `json:"updated_at"`
 	CompletedAt         pgtype.Timestamptz `json:"completed_at"`
+	RailAcknowledgedAt  pgtype.Timestamptz `json:"rail_acknowledged_at"`
 }
 
 type TransfersEu struct {
@@ -402,6 +858,7 @@ type TransfersEu struct {
 	ComplianceRegion    string             `json:"compliance_region"`
 	UpdatedAt           time.Time          `json:"updated_at"`
 	CompletedAt         pgtype.Timestamptz `json:"completed_at"`
+	RailAcknowledgedAt  pgtype.Timestamptz `json:"rail_acknowledged_at"`
 }
 
 type TransfersID struct {
@@ -432,6 +889,7 @@ type TransfersID struct {
 	ComplianceRegion    string             `json:"compliance_region"`
 	UpdatedAt           time.Time          `json:"updated_at"`
 	CompletedAt         pgtype.Timestamptz `json:"completed_at"`
+	RailAcknowledgedAt  pgtype.Timestamptz `json:"rail_acknowledged_at"`
 }
 
 type TransfersUk struct {
@@ -462,6 +920,7 @@ type TransfersUk struct {
 	ComplianceRegion    string             `json:"compliance_region"`
 	UpdatedAt           time.Time          `json:"updated_at"`
 	CompletedAt         pgtype.Timestamptz `json:"completed_at"`
+	RailAcknowledgedAt  pgtype.Timestamptz `json:"rail_acknowledged_at"`
 }
 
 type TransfersUnknown struct {
@@ -492,16 +951,79 @@ type TransfersUnknown struct {
 	ComplianceRegion    string             `json:"compliance_region"`
 	UpdatedAt           time.Time          `json:"updated_at"`
 	CompletedAt         pgtype.Timestamptz `json:"completed_at"`
+	RailAcknowledgedAt  pgtype.Timestamptz `json:"rail_acknowledged_at"`
 }
 
-type Wallet struct {
-	ID            uuid.UUID      `json:"id"`
-	TenantID      uuid.UUID      `json:"tenant_id"`
+type TreasuryMovement struct {
+	ID             uuid.UUID          `json:"id"`
+	Kind           string             `json:"kind"`
+	Status         string             `json:"status"`
+	Reason         string             `json:"reason"`
+	Counterparty   pgtype.Text        `json:"counterparty"`
+	DealReference  pgtype.Text        `json:"deal_reference"`
+	RequestedBy    string             `json:"requested_by"`
+	DecidedBy      pgtype.Text        `json:"decided_by"`
+	DecisionReason pgtype.Text        `json:"decision_reason"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	DecidedAt      pgtype.Timestamptz `json:"decided_at"`
+	PostedAt       pgtype.Timestamptz `json:"posted_at"`
+}
+
+type TreasuryMovementLeg struct {
+	MovementID    uuid.UUID      `json:"movement_id"`
+	Seq           int32          `json:"seq"`
+	TbTransferID  uuid.UUID      `json:"tb_transfer_id"`
 	Currency      string         `json:"currency"`
-	TbAccountID   pgtype.Numeric `json:"tb_account_id"`
-	CachedBalance pgtype.Numeric `json:"cached_balance"`
-	CachedPending pgtype.Numeric `json:"cached_pending"`
-	CachedAt      time.Time      `json:"cached_at"`
-	Status        string         `json:"status"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	DebitAccount  string         `json:"debit_account"`
+	CreditAccount string         `json:"credit_account"`
+	Amount        pgtype.Numeric `json:"amount"`
+	UsdRate       pgtype.Numeric `json:"usd_rate"`
+}
+
+type UsdReferenceRate struct {
+	Currency  string         `json:"currency"`
+	UsdRate   pgtype.Numeric `json:"usd_rate"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type Wallet struct {
+	ID             uuid.UUID      `json:"id"`
+	TenantID       uuid.UUID      `json:"tenant_id"`
+	Currency       string         `json:"currency"`
+	TbAccountID    pgtype.Numeric `json:"tb_account_id"`
+	CachedBalance  pgtype.Numeric `json:"cached_balance"`
+	CachedPending  pgtype.Numeric `json:"cached_pending"`
+	CachedAt       time.Time      `json:"cached_at"`
+	Status         string         `json:"status"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	LedgerFreezeID pgtype.UUID    `json:"ledger_freeze_id"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID          `json:"id"`
+	TenantID       uuid.UUID          `json:"tenant_id"`
+	EventID        uuid.UUID          `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Url            string             `json:"url"`
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  time.Time          `json:"next_attempt_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+	LastStatusCode pgtype.Int4        `json:"last_status_code"`
+	LastError      pgtype.Text        `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	TraceContext   []byte             `json:"trace_context"`
+}
+
+type WebhookDeliveryAttempt struct {
+	ID          uuid.UUID   `json:"id"`
+	DeliveryID  uuid.UUID   `json:"delivery_id"`
+	Attempt     int32       `json:"attempt"`
+	StatusCode  pgtype.Int4 `json:"status_code"`
+	Error       pgtype.Text `json:"error"`
+	DurationMs  int32       `json:"duration_ms"`
+	AttemptedAt time.Time   `json:"attempted_at"`
 }
@@ -0,0 +1,14 @@
+-- name: CreateMonitoringAlert :one
+INSERT INTO monitoring_alerts (transfer_id, tenant_id, compliance_region, rule_id, rule_type, score, details)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, transfer_id, tenant_id, compliance_region, rule_id, rule_type, score, details, created_at;
+
+-- name: ListMonitoringAlerts :many
+SELECT id, transfer_id, tenant_id, compliance_region, rule_id, rule_type, score, details, created_at
+FROM monitoring_alerts
+WHERE (sqlc.narg('tenant_id')::uuid IS NULL OR tenant_id = sqlc.narg('tenant_id'))
+    AND (sqlc.narg('transfer_id')::uuid IS NULL OR transfer_id = sqlc.narg('transfer_id'))
+    AND (sqlc.narg('rule_type')::text IS NULL OR rule_type = sqlc.narg('rule_type'))
+    AND (sqlc.narg('compliance_region')::text IS NULL OR compliance_region = sqlc.narg('compliance_region'))
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
@@ -0,0 +1,9 @@
+-- name: CreateOperator :one
+INSERT INTO operators (name, api_key_hash, compliance_region)
+VALUES ($1, $2, $3)
+RETURNING id, name, api_key_hash, compliance_region, created_at;
+
+-- name: GetOperatorByAPIKeyHash :one
+SELECT id, name, api_key_hash, compliance_region, created_at
+FROM operators
+WHERE api_key_hash = $1;
@@ -0,0 +1,43 @@
+-- name: CreateOutboxEvent :exec
+INSERT INTO outbox (aggregate_type, aggregate_id, tenant_id, event_type, payload, trace_context)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ClaimOutboxEvents :many
+-- Claims the head-of-line event of each aggregate. Later events of the same
+-- aggregate stay behind until the earlier one is published.
+SELECT id, seq, aggregate_type, aggregate_id, tenant_id, event_type, payload,
+    attempts, available_at, last_error, published_at, created_at, trace_context
+FROM outbox o
+WHERE o.published_at IS NULL
+    AND o.available_at <= NOW()
+    AND NOT EXISTS (
+        SELECT 1 FROM outbox prev
+        WHERE prev.aggregate_type = o.aggregate_type
+            AND prev.aggregate_id = o.aggregate_id
+            AND prev.published_at IS NULL
+            AND prev.seq < o.seq
+    )
+ORDER BY o.seq
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox
+SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox
+SET attempts = attempts + 1, last_error = $2, available_at = $3
+WHERE id = $1;
+
+-- name: GetOutboxStats :one
+SELECT COUNT(*) AS pending,
+    COUNT(*) FILTER (WHERE attempts > 0) AS failing,
+    MIN(created_at)::timestamptz AS oldest_pending_at
+FROM outbox
+WHERE published_at IS NULL;
+
+-- name: DeletePublishedOutboxEvents :execrows
+DELETE FROM outbox
+WHERE published_at IS NOT NULL AND published_at < $1;
@@ -12,32 +12,238 @@ import (
 )
 
 type Querier interface {
+	// Records that the rail accepted the payout of a transfer, once.
+	AcknowledgeTransferRail(ctx context.Context, id uuid.UUID) error
+	AssignComplianceCase(ctx context.Context, arg AssignComplianceCaseParams) error
+	// Cancels the hold of a payout that will not be paid.
+	CancelLiquidityHold(ctx context.Context, transferID uuid.UUID) error
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ClaimJobs(ctx context.Context, arg ClaimJobsParams) ([]Job, error)
+	// Claims the head-of-line event of each aggregate. Later events of the same
+	// aggregate stay behind until the earlier one is published.
+	ClaimOutboxEvents(ctx context.Context, limit int32) ([]Outbox, error)
+	CloseComplianceCase(ctx context.Context, arg CloseComplianceCaseParams) error
+	// Records the outcome of the attempt that ran a job, unless its lease expired
+	// and the job was rescued since; as do RetryJob and DiscardJob.
+	CompleteJob(ctx context.Context, arg CompleteJobParams) error
+	CountPendingWebhookDeliveries(ctx context.Context) (int64, error)
+	// Counts the available and running jobs of each queue.
+	CountUnfinishedJobs(ctx context.Context) ([]CountUnfinishedJobsRow, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
+	CreateBankStatement(ctx context.Context, arg CreateBankStatementParams) (BankStatement, error)
+	CreateBankStatementEntry(ctx context.Context, arg CreateBankStatementEntryParams) error
+	CreateComplianceCase(ctx context.Context, arg CreateComplianceCaseParams) (ComplianceCase, error)
+	CreateComplianceCaseNote(ctx context.Context, arg CreateComplianceCaseNoteParams) (ComplianceCaseNote, error)
+	CreateComplianceLog(ctx context.Context, arg CreateComplianceLogParams) (ComplianceLog, error)
+	CreateExpectedDeposit(ctx context.Context, arg CreateExpectedDepositParams) (ExpectedDeposit, error)
+	CreateFXExposureSnapshot(ctx context.Context, arg CreateFXExposureSnapshotParams) (FxExposureSnapshot, error)
+	CreateKYCSubmission(ctx context.Context, arg CreateKYCSubmissionParams) (KycSubmission, error)
+	CreateLiquidityHold(ctx context.Context, arg CreateLiquidityHoldParams) (LiquidityHold, error)
+	CreateMonitoringAlert(ctx context.Context, arg CreateMonitoringAlertParams) (MonitoringAlert, error)
+	CreateOperator(ctx context.Context, arg CreateOperatorParams) (Operator, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) error
+	// Records a callback. A callback already recorded for the rail and event
+	// returns no row.
+	CreateRailCallback(ctx context.Context, arg CreateRailCallbackParams) (RailCallback, error)
+	CreateRecipient(ctx context.Context, arg CreateRecipientParams) (Recipient, error)
+	CreateReconciliationDiscrepancy(ctx context.Context, arg CreateReconciliationDiscrepancyParams) error
+	CreateReconciliationReport(ctx context.Context, arg CreateReconciliationReportParams) (ReconciliationReport, error)
+	CreateRefund(ctx context.Context, arg CreateRefundParams) (Refund, error)
+	CreateStatementMatch(ctx context.Context, arg CreateStatementMatchParams) (StatementMatch, error)
 	CreateTenant(ctx context.Context, arg CreateTenantParams) (Tenant, error)
+	CreateTenantStatusChange(ctx context.Context, arg CreateTenantStatusChangeParams) (TenantStatusChange, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTreasuryMovement(ctx context.Context, arg CreateTreasuryMovementParams) (TreasuryMovement, error)
+	// Adds a leg to a movement at the current USD reference rate of its
+	// currency.
+	CreateTreasuryMovementLeg(ctx context.Context, arg CreateTreasuryMovementLegParams) (TreasuryMovementLeg, error)
 	CreateWallet(ctx context.Context, arg CreateWalletParams) (Wallet, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	CreateWebhookDeliveryAttempt(ctx context.Context, arg CreateWebhookDeliveryAttemptParams) error
+	DecideKYCSubmission(ctx context.Context, arg DecideKYCSubmissionParams) error
+	DecideTreasuryMovement(ctx context.Context, arg DecideTreasuryMovementParams) (TreasuryMovement, error)
+	// Releases the quote of a transfer cancelled before it was paid out.
+	DeleteFXQuoteMargin(ctx context.Context, transferID uuid.UUID) error
+	DeleteFinalizedJobs(ctx context.Context, finalizedAt pgtype.Timestamptz) (int64, error)
+	DeletePublishedOutboxEvents(ctx context.Context, publishedAt pgtype.Timestamptz) (int64, error)
+	DiscardJob(ctx context.Context, arg DiscardJobParams) error
+	// Escalation raises priority, restarts the SLA and always requires four-eyes approval.
+	EscalateComplianceCase(ctx context.Context, arg EscalateComplianceCaseParams) error
+	GetAuditChainHead(ctx context.Context, complianceRegion string) (AuditChainHead, error)
+	// Locks the head through lock_audit_chain_head, as the regional roles may
+	// only read it; inserting the next entry advances it.
+	GetAuditChainHeadForUpdate(ctx context.Context, complianceRegion string) (AuditChainHead, error)
+	GetBankStatement(ctx context.Context, id uuid.UUID) (BankStatement, error)
+	GetBankStatementEntry(ctx context.Context, id uuid.UUID) (BankStatementEntry, error)
+	GetBankStatementEntryForUpdate(ctx context.Context, id uuid.UUID) (BankStatementEntry, error)
+	GetComplianceCaseByID(ctx context.Context, id uuid.UUID) (ComplianceCase, error)
+	GetComplianceCaseByIDForUpdate(ctx context.Context, id uuid.UUID) (ComplianceCase, error)
+	GetExpectedDeposit(ctx context.Context, id uuid.UUID) (ExpectedDeposit, error)
+	GetExpectedDepositForUpdate(ctx context.Context, id uuid.UUID) (ExpectedDeposit, error)
+	GetFXExposureSnapshot(ctx context.Context, id uuid.UUID) (FxExposureSnapshot, error)
+	GetFXQuoteMargin(ctx context.Context, transferID uuid.UUID) (FxQuoteMargin, error)
+	GetJobByID(ctx context.Context, id uuid.UUID) (Job, error)
+	GetKYCSubmissionByID(ctx context.Context, id uuid.UUID) (KycSubmission, error)
+	GetKYCSubmissionByIDForUpdate(ctx context.Context, id uuid.UUID) (KycSubmission, error)
+	GetKYCTierLimit(ctx context.Context, kycLevel KycLevelEnum) (KycTierLimit, error)
 	GetLegalEntityByCode(ctx context.Context, code string) (LegalEntity, error)
 	GetLegalEntityByID(ctx context.Context, id uuid.UUID) (LegalEntity, error)
+	GetLimitPolicyByTenant(ctx context.Context, tenantID uuid.UUID) (LimitPolicy, error)
+	GetLiquidityTopUpForUpdate(ctx context.Context, id uuid.UUID) (LiquidityTopUp, error)
+	GetOperatorByAPIKeyHash(ctx context.Context, apiKeyHash string) (Operator, error)
+	GetOutboxStats(ctx context.Context) (GetOutboxStatsRow, error)
+	GetRailCallbackByEvent(ctx context.Context, arg GetRailCallbackByEventParams) (RailCallback, error)
+	GetRecipientByID(ctx context.Context, id uuid.UUID) (Recipient, error)
+	GetReconciliationReport(ctx context.Context, id uuid.UUID) (ReconciliationReport, error)
+	GetReconciliationReportForUpdate(ctx context.Context, id uuid.UUID) (ReconciliationReport, error)
+	GetRefund(ctx context.Context, id uuid.UUID) (Refund, error)
+	GetRefundByIdempotencyKey(ctx context.Context, arg GetRefundByIdempotencyKeyParams) (Refund, error)
+	// How much of a transfer has been refunded so far.
+	GetRefundTotals(ctx context.Context, transferID uuid.UUID) (GetRefundTotalsRow, error)
+	GetRegionalSettlement(ctx context.Context, id uuid.UUID) (RegionalSettlement, error)
+	GetRegionalSettlementByEntity(ctx context.Context, arg GetRegionalSettlementByEntityParams) (RegionalSettlement, error)
+	// Locks a settlement so payouts against it are admitted one at a time.
+	GetRegionalSettlementByEntityForUpdate(ctx context.Context, arg GetRegionalSettlementByEntityForUpdateParams) (RegionalSettlement, error)
+	// Legal entity and jurisdiction of a tenant, which place its audit rows in a region
+	GetTenantAuditScope(ctx context.Context, id uuid.UUID) (GetTenantAuditScopeRow, error)
+	GetTenantByAPIKeyHash(ctx context.Context, apiKeyHash pgtype.Text) (Tenant, error)
 	GetTenantByID(ctx context.Context, id uuid.UUID) (Tenant, error)
+	GetTenantByIDForUpdate(ctx context.Context, id uuid.UUID) (Tenant, error)
 	GetTransferByID(ctx context.Context, id uuid.UUID) (Transfer, error)
 	GetTransferByIdempotencyKey(ctx context.Context, arg GetTransferByIdempotencyKeyParams) (Transfer, error)
+	// Finds and locks the transfer a rail knows by its reference.
+	GetTransferByRailReferenceForUpdate(ctx context.Context, arg GetTransferByRailReferenceForUpdateParams) (Transfer, error)
+	// Locks a transfer against concurrent changes of its state.
+	GetTransferForUpdate(ctx context.Context, id uuid.UUID) (Transfer, error)
+	// Finds the compliance region of a transfer, to route its queries to the
+	// database holding it.
+	GetTransferRegion(ctx context.Context, id uuid.UUID) (string, error)
+	GetTransferRegionByRailReference(ctx context.Context, arg GetTransferRegionByRailReferenceParams) (string, error)
+	GetTreasuryMovement(ctx context.Context, id uuid.UUID) (TreasuryMovement, error)
+	// The live booking of an FX deal, if it was booked before.
+	GetTreasuryMovementByDeal(ctx context.Context, arg GetTreasuryMovementByDealParams) (TreasuryMovement, error)
+	GetTreasuryMovementForUpdate(ctx context.Context, id uuid.UUID) (TreasuryMovement, error)
+	GetUSDReferenceRate(ctx context.Context, currency string) (UsdReferenceRate, error)
 	GetWalletByID(ctx context.Context, id uuid.UUID) (Wallet, error)
 	GetWalletByTenantAndCurrency(ctx context.Context, arg GetWalletByTenantAndCurrencyParams) (Wallet, error)
+	GetWebhookDeliveryByID(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	HasLiquidityHolds(ctx context.Context, settlementID uuid.UUID) (bool, error)
+	InsertJob(ctx context.Context, arg InsertJobParams) (Job, error)
 	ListActiveTenants(ctx context.Context, arg ListActiveTenantsParams) ([]Tenant, error)
+	// Rows of one region's chain in order, for verification
+	ListAuditChain(ctx context.Context, arg ListAuditChainParams) ([]AuditTrail, error)
+	ListAuditChainHeads(ctx context.Context) ([]AuditChainHead, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditTrail, error)
+	// The closing balance of each bank account and currency on a date.
+	ListBankBalancesAsOf(ctx context.Context, asOf pgtype.Date) ([]BankBalance, error)
+	ListBankStatementEntries(ctx context.Context, statementID uuid.UUID) ([]BankStatementEntry, error)
+	ListBankStatements(ctx context.Context, arg ListBankStatementsParams) ([]BankStatement, error)
+	ListComplianceCaseNotes(ctx context.Context, caseID uuid.UUID) ([]ComplianceCaseNote, error)
+	ListComplianceCases(ctx context.Context, arg ListComplianceCasesParams) ([]ComplianceCase, error)
+	ListComplianceLogsByCase(ctx context.Context, caseID pgtype.UUID) ([]ComplianceLog, error)
+	ListComplianceLogsByTransfer(ctx context.Context, transferID uuid.UUID) ([]ComplianceLog, error)
+	ListExpectedDeposits(ctx context.Context, arg ListExpectedDepositsParams) ([]ExpectedDeposit, error)
+	// USD cost of the FX_SETTLEMENT position in each currency: what the
+	// transfers and treasury movements posted to the ledger moved it at.
+	ListFXCostBasis(ctx context.Context) ([]ListFXCostBasisRow, error)
+	ListFXExposureSnapshots(ctx context.Context, arg ListFXExposureSnapshotsParams) ([]FxExposureSnapshot, error)
+	// Held payouts of a settlement, oldest first, in the order they are released.
+	ListHeldPayouts(ctx context.Context, settlementID uuid.UUID) ([]LiquidityHold, error)
+	ListKYCSubmissionsByTenant(ctx context.Context, tenantID uuid.UUID) ([]KycSubmission, error)
+	ListKYCTierLimits(ctx context.Context) ([]KycTierLimit, error)
 	ListLegalEntities(ctx context.Context) ([]LegalEntity, error)
 	ListLegalEntitiesByJurisdiction(ctx context.Context, jurisdiction string) ([]LegalEntity, error)
-	ListTenantsByLegalEntity(ctx context.Context, legalEntityID uuid.UUID) ([]Tenant, error)
+	ListLiquidityAlerts(ctx context.Context, arg ListLiquidityAlertsParams) ([]LiquidityAlert, error)
+	ListLiquidityHolds(ctx context.Context, arg ListLiquidityHoldsParams) ([]LiquidityHold, error)
+	ListLiquidityTopUps(ctx context.Context, arg ListLiquidityTopUpsParams) ([]LiquidityTopUp, error)
+	// Unmatched payouts to a legal entity settled in a time range, the
+	// candidates for its Nostro debits.
+	ListMatchCandidateTransfers(ctx context.Context, arg ListMatchCandidateTransfersParams) ([]ListMatchCandidateTransfersRow, error)
+	ListMonitoringAlerts(ctx context.Context, arg ListMonitoringAlertsParams) ([]MonitoringAlert, error)
+	// Entries waiting for a match, for the manual-match queue.
+	ListOpenBankStatementEntries(ctx context.Context, arg ListOpenBankStatementEntriesParams) ([]BankStatementEntry, error)
+	// Entries waiting for a match, by ID for paging through all of them.
+	ListOpenBankStatementEntriesAfter(ctx context.Context, arg ListOpenBankStatementEntriesAfterParams) ([]BankStatementEntry, error)
+	ListPendingExpectedDeposits(ctx context.Context, arg ListPendingExpectedDepositsParams) ([]ExpectedDeposit, error)
+	ListRailCallbacksByTransfer(ctx context.Context, transferID uuid.UUID) ([]RailCallback, error)
+	ListRecipientsByTenant(ctx context.Context, arg ListRecipientsByTenantParams) ([]Recipient, error)
+	ListReconciliationDiscrepancies(ctx context.Context, reportID uuid.UUID) ([]ReconciliationDiscrepancy, error)
+	ListReconciliationReports(ctx context.Context, arg ListReconciliationReportsParams) ([]ReconciliationReport, error)
+	ListRefundsByTransfer(ctx context.Context, transferID uuid.UUID) ([]Refund, error)
+	ListRegionalSettlements(ctx context.Context) ([]RegionalSettlement, error)
+	ListStatementMatches(ctx context.Context, entryID uuid.UUID) ([]StatementMatch, error)
+	ListTenantStatusChanges(ctx context.Context, tenantID uuid.UUID) ([]TenantStatusChange, error)
+	// Lists the tenants of a legal entity newest first, starting after the cursor ID.
+	ListTenantsByLegalEntity(ctx context.Context, arg ListTenantsByLegalEntityParams) ([]Tenant, error)
 	ListTenantsByParent(ctx context.Context, parentTenantID pgtype.UUID) ([]Tenant, error)
+	// Tenants whose webhook signing key is stored in plaintext, from before keys
+	// were sealed. Sealed keys start with their format version.
+	ListTenantsWithUnsealedWebhookKey(ctx context.Context) ([]Tenant, error)
+	// Recent transfers of a tenant for transaction monitoring, by UUIDv7 time range.
+	ListTransferActivity(ctx context.Context, arg ListTransferActivityParams) ([]ListTransferActivityRow, error)
+	// Transfers that reference ledger transfers, in id order after the given id.
+	ListTransferLedgerIDs(ctx context.Context, arg ListTransferLedgerIDsParams) ([]ListTransferLedgerIDsRow, error)
+	// Lists a tenant's transfers newest first, starting after the cursor ID.
+	// Creation time bounds are ID bounds, as IDs are UUIDv7.
 	ListTransfersByTenant(ctx context.Context, arg ListTransfersByTenantParams) ([]Transfer, error)
 	ListTransfersByTenantAndStatus(ctx context.Context, arg ListTransfersByTenantAndStatusParams) ([]Transfer, error)
+	ListTreasuryMovementLegs(ctx context.Context, movementID uuid.UUID) ([]TreasuryMovementLeg, error)
+	ListTreasuryMovements(ctx context.Context, arg ListTreasuryMovementsParams) ([]TreasuryMovement, error)
+	ListUSDReferenceRates(ctx context.Context) ([]UsdReferenceRate, error)
+	// Every wallet's ledger account with the legal entity holding its funds.
+	ListWalletLedgerAccounts(ctx context.Context) ([]ListWalletLedgerAccountsRow, error)
 	ListWalletsByTenant(ctx context.Context, tenantID uuid.UUID) ([]Wallet, error)
+	// Lists a tenant's wallets newest first, starting after the cursor ID.
+	ListWalletsByTenantPage(ctx context.Context, arg ListWalletsByTenantPageParams) ([]Wallet, error)
+	ListWebhookDeliveriesByTenant(ctx context.Context, arg ListWebhookDeliveriesByTenantParams) ([]WebhookDelivery, error)
+	ListWebhookDeliveryAttempts(ctx context.Context, deliveryID uuid.UUID) ([]WebhookDeliveryAttempt, error)
+	MarkExpectedDepositCredited(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventPublished(ctx context.Context, id uuid.UUID) error
+	// Flags open cases past their SLA; each case is flagged once.
+	MarkOverdueComplianceCases(ctx context.Context) ([]ComplianceCase, error)
+	MarkRefundPosted(ctx context.Context, id uuid.UUID) (int64, error)
+	MarkTreasuryMovementPosted(ctx context.Context, id uuid.UUID) error
+	MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	ParkBankStatementEntry(ctx context.Context, id uuid.UUID) error
+	// Records the margin on the quote of an FX transfer. Transfers in a single
+	// currency, or in a currency without a reference rate, record nothing.
+	RecordFXQuoteMargin(ctx context.Context, id uuid.UUID) error
+	ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	RequestComplianceCaseApproval(ctx context.Context, arg RequestComplianceCaseApprovalParams) error
+	// Returns jobs whose worker lease expired (e.g. the process crashed) to the queue.
+	RescueStuckJobs(ctx context.Context) (int64, error)
+	ResolveLiquidityAlert(ctx context.Context, settlementID uuid.UUID) (int64, error)
+	RetryJob(ctx context.Context, arg RetryJobParams) error
+	// Replaces a plaintext signing key with its sealed form, unless the key was
+	// rotated meanwhile.
+	SealTenantWebhookSigningKey(ctx context.Context, arg SealTenantWebhookSigningKeyParams) error
+	SetBankStatementEntryMatched(ctx context.Context, id uuid.UUID) error
+	SignOffReconciliationReport(ctx context.Context, arg SignOffReconciliationReportParams) error
+	// Payouts to a legal entity in a currency not yet posted to the ledger:
+	// admitted ones are processing, queued ones include those still screening.
+	SumQueuedPayouts(ctx context.Context, arg SumQueuedPayoutsParams) (SumQueuedPayoutsRow, error)
+	// USD value of a tenant's transfers since a point in time, excluding failed ones.
+	SumTransferVolumeUSD(ctx context.Context, arg SumTransferVolumeUSDParams) (pgtype.Numeric, error)
+	UpdateExpectedDepositStatus(ctx context.Context, arg UpdateExpectedDepositStatusParams) error
+	UpdateLiquidityHoldStatus(ctx context.Context, arg UpdateLiquidityHoldStatusParams) error
+	UpdateLiquidityTopUpStatus(ctx context.Context, arg UpdateLiquidityTopUpStatusParams) error
+	UpdateRegionalSettlementBalance(ctx context.Context, arg UpdateRegionalSettlementBalanceParams) error
 	UpdateTenant(ctx context.Context, arg UpdateTenantParams) (Tenant, error)
+	UpdateTenantWebhookSigningKey(ctx context.Context, arg UpdateTenantWebhookSigningKeyParams) error
 	UpdateTransferComplianceStatus(ctx context.Context, arg UpdateTransferComplianceStatusParams) error
 	UpdateTransferNetting(ctx context.Context, arg UpdateTransferNettingParams) error
 	UpdateTransferRailReference(ctx context.Context, arg UpdateTransferRailReferenceParams) error
 	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) error
 	UpdateTransferTBTransferIDs(ctx context.Context, arg UpdateTransferTBTransferIDsParams) error
 	UpdateWalletCachedBalance(ctx context.Context, arg UpdateWalletCachedBalanceParams) error
+	UpdateWalletFreeze(ctx context.Context, arg UpdateWalletFreezeParams) error
 	UpdateWalletStatus(ctx context.Context, arg UpdateWalletStatusParams) error
+	UpsertBankBalance(ctx context.Context, arg UpsertBankBalanceParams) (BankBalance, error)
+	UpsertLiquidityAlert(ctx context.Context, arg UpsertLiquidityAlertParams) (LiquidityAlert, error)
+	UpsertLiquidityTopUp(ctx context.Context, arg UpsertLiquidityTopUpParams) (LiquidityTopUp, error)
+	UpsertRegionalSettlement(ctx context.Context, arg UpsertRegionalSettlementParams) (RegionalSettlement, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,23 @@
+-- Records a callback. A callback already recorded for the rail and event
+-- returns no row.
+-- name: CreateRailCallback :one
+INSERT INTO rail_callbacks (
+    rail, event_id, transfer_id, rail_reference, status, return_code, reason, outcome,
+    payload, occurred_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (rail, event_id) DO NOTHING
+RETURNING id, rail, event_id, transfer_id, rail_reference, status, return_code, reason, outcome,
+    payload, occurred_at, received_at;
+
+-- name: GetRailCallbackByEvent :one
+SELECT id, rail, event_id, transfer_id, rail_reference, status, return_code, reason, outcome,
+    payload, occurred_at, received_at
+FROM rail_callbacks
+WHERE rail = $1 AND event_id = $2;
+
+-- name: ListRailCallbacksByTransfer :many
+SELECT id, rail, event_id, transfer_id, rail_reference, status, return_code, reason, outcome,
+    payload, occurred_at, received_at
+FROM rail_callbacks
+WHERE transfer_id = $1
+ORDER BY received_at;
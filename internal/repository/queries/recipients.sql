@@ -0,0 +1,16 @@
+-- name: CreateRecipient :one
+INSERT INTO recipients (tenant_id, name, country, account_number, bank_code, metadata)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, tenant_id, name, country, account_number, bank_code, metadata, updated_at;
+
+-- name: GetRecipientByID :one
+SELECT id, tenant_id, name, country, account_number, bank_code, metadata, updated_at
+FROM recipients
+WHERE id = $1;
+
+-- name: ListRecipientsByTenant :many
+SELECT id, tenant_id, name, country, account_number, bank_code, metadata, updated_at
+FROM recipients
+WHERE tenant_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
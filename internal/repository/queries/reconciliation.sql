@@ -0,0 +1,73 @@
+-- name: CreateReconciliationDiscrepancy :exec
+INSERT INTO reconciliation_discrepancies (
+    report_id, kind, legal_entity_id, currency, transfer_id,
+    ledger_amount, bank_amount, difference, bank_balance_as_of, detail
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: CreateReconciliationReport :one
+INSERT INTO reconciliation_reports (business_date, balances_checked, transfers_checked, discrepancy_count)
+VALUES ($1, $2, $3, $4)
+RETURNING id, business_date, status, balances_checked, transfers_checked, discrepancy_count,
+    signed_off_by, sign_off_note, signed_off_at, created_at;
+
+-- name: GetReconciliationReport :one
+SELECT id, business_date, status, balances_checked, transfers_checked, discrepancy_count,
+    signed_off_by, sign_off_note, signed_off_at, created_at
+FROM reconciliation_reports
+WHERE id = $1;
+
+-- name: GetReconciliationReportForUpdate :one
+SELECT id, business_date, status, balances_checked, transfers_checked, discrepancy_count,
+    signed_off_by, sign_off_note, signed_off_at, created_at
+FROM reconciliation_reports
+WHERE id = $1
+FOR UPDATE;
+
+-- The closing balance of each bank account and currency on a date.
+-- name: ListBankBalancesAsOf :many
+SELECT id, legal_entity_id, account_kind, currency, balance, as_of, source, recorded_by, created_at
+FROM bank_balances
+WHERE as_of = $1
+ORDER BY legal_entity_id, account_kind, currency;
+
+-- name: ListReconciliationDiscrepancies :many
+SELECT id, report_id, kind, legal_entity_id, currency, transfer_id,
+    ledger_amount, bank_amount, difference, bank_balance_as_of, detail, created_at
+FROM reconciliation_discrepancies
+WHERE report_id = $1
+ORDER BY kind, legal_entity_id, currency, transfer_id;
+
+-- name: ListReconciliationReports :many
+SELECT id, business_date, status, balances_checked, transfers_checked, discrepancy_count,
+    signed_off_by, sign_off_note, signed_off_at, created_at
+FROM reconciliation_reports
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY business_date DESC, created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- Transfers that reference ledger transfers, in id order after the given id.
+-- name: ListTransferLedgerIDs :many
+SELECT id, tb_transfer_ids
+FROM transfers
+WHERE id > $1 AND tb_transfer_ids IS NOT NULL
+ORDER BY id
+LIMIT $2;
+
+-- Every wallet's ledger account with the legal entity holding its funds.
+-- name: ListWalletLedgerAccounts :many
+SELECT w.tb_account_id, w.currency, t.legal_entity_id
+FROM wallets w
+JOIN tenants t ON t.id = w.tenant_id
+ORDER BY t.legal_entity_id, w.currency;
+
+-- name: SignOffReconciliationReport :exec
+UPDATE reconciliation_reports
+SET status = 'signed_off', signed_off_by = $2, sign_off_note = $3, signed_off_at = NOW()
+WHERE id = $1;
+
+-- name: UpsertBankBalance :one
+INSERT INTO bank_balances (legal_entity_id, account_kind, currency, balance, as_of, source, recorded_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (legal_entity_id, account_kind, currency, as_of) DO UPDATE
+SET balance = EXCLUDED.balance, source = EXCLUDED.source, recorded_by = EXCLUDED.recorded_by, created_at = NOW()
+RETURNING id, legal_entity_id, account_kind, currency, balance, as_of, source, recorded_by, created_at;
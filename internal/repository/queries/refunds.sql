@@ -0,0 +1,39 @@
+-- name: CreateRefund :one
+INSERT INTO refunds (
+    transfer_id, tenant_id, seq, currency, amount, fee_amount, dest_currency, dest_amount,
+    reason, return_code, idempotency_key
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, transfer_id, tenant_id, seq, currency, amount, fee_amount, dest_currency, dest_amount,
+    reason, return_code, idempotency_key, status, created_at, posted_at;
+
+-- name: GetRefund :one
+SELECT id, transfer_id, tenant_id, seq, currency, amount, fee_amount, dest_currency, dest_amount,
+    reason, return_code, idempotency_key, status, created_at, posted_at
+FROM refunds
+WHERE id = $1;
+
+-- name: GetRefundByIdempotencyKey :one
+SELECT id, transfer_id, tenant_id, seq, currency, amount, fee_amount, dest_currency, dest_amount,
+    reason, return_code, idempotency_key, status, created_at, posted_at
+FROM refunds
+WHERE transfer_id = $1 AND idempotency_key = $2;
+
+-- How much of a transfer has been refunded so far.
+-- name: GetRefundTotals :one
+SELECT COUNT(*)::int AS refunds,
+    COALESCE(SUM(amount), 0)::numeric AS amount,
+    COALESCE(SUM(fee_amount), 0)::numeric AS fee_amount
+FROM refunds
+WHERE transfer_id = $1;
+
+-- name: ListRefundsByTransfer :many
+SELECT id, transfer_id, tenant_id, seq, currency, amount, fee_amount, dest_currency, dest_amount,
+    reason, return_code, idempotency_key, status, created_at, posted_at
+FROM refunds
+WHERE transfer_id = $1
+ORDER BY seq;
+
+-- name: MarkRefundPosted :execrows
+UPDATE refunds
+SET status = 'posted', posted_at = NOW()
+WHERE id = $1 AND status = 'pending';
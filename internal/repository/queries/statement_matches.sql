@@ -0,0 +1,26 @@
+-- name: CreateStatementMatch :one
+INSERT INTO statement_matches (
+    entry_id, transfer_id, expected_deposit_id, amount, rule, matched_by
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT DO NOTHING
+RETURNING id, entry_id, transfer_id, expected_deposit_id, amount, rule, matched_by, created_at;
+
+-- Unmatched payouts to a legal entity settled in a time range, the
+-- candidates for its Nostro debits.
+-- name: ListMatchCandidateTransfers :many
+SELECT t.id, t.batch_id, t.netting_group_id, t.to_amount, t.rail_reference,
+    COALESCE(t.completed_at, t.updated_at)::timestamptz AS settled_at
+FROM transfers t
+WHERE t.dest_legal_entity_id = $1
+  AND t.to_currency = $2
+  AND t.status IN ('processing', 'completed')
+  AND COALESCE(t.completed_at, t.updated_at) >= sqlc.arg('settled_from')
+  AND COALESCE(t.completed_at, t.updated_at) < sqlc.arg('settled_to')
+  AND NOT EXISTS (SELECT 1 FROM statement_matches m WHERE m.transfer_id = t.id)
+ORDER BY t.id;
+
+-- name: ListStatementMatches :many
+SELECT id, entry_id, transfer_id, expected_deposit_id, amount, rule, matched_by, created_at
+FROM statement_matches
+WHERE entry_id = $1
+ORDER BY created_at, id;
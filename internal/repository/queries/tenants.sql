@@ -3,15 +3,47 @@ INSERT INTO tenants (display_name, legal_name, country, tenant_kind, parent_tena
 VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at;
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at;
 
 -- name: GetTenantByID :one
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE id = $1;
 
+-- name: GetTenantByIDForUpdate :one
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetTenantByAPIKeyHash :one
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE api_key_hash = $1;
+
+-- name: ListTenantsWithUnsealedWebhookKey :many
+-- Tenants whose webhook signing key is stored in plaintext, from before keys
+-- were sealed. Sealed keys start with their format version.
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE webhook_signing_key IS NOT NULL AND webhook_signing_key NOT LIKE 'v1:%'
+ORDER BY id;
+
+-- name: SealTenantWebhookSigningKey :exec
+-- Replaces a plaintext signing key with its sealed form, unless the key was
+-- rotated meanwhile.
+UPDATE tenants
+SET webhook_signing_key = sqlc.arg('sealed_key'), updated_at = NOW()
+WHERE id = sqlc.arg('id') AND webhook_signing_key = sqlc.arg('plain_key');
+
 -- name: UpdateTenant :one
 UPDATE tenants SET
     display_name = COALESCE(sqlc.narg('display_name'), display_name),
@@ -26,20 +58,25 @@ UPDATE tenants SET
 WHERE id = sqlc.arg('id')
 RETURNING id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at;
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at;
 
+-- Lists the tenants of a legal entity newest first, starting after the cursor ID.
 -- name: ListTenantsByLegalEntity :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE legal_entity_id = $1
-ORDER BY id DESC;
+    AND (sqlc.narg('after')::uuid IS NULL OR id < sqlc.narg('after'))
+    AND (sqlc.narg('tenant_status')::tenant_status_enum IS NULL OR tenant_status = sqlc.narg('tenant_status'))
+    AND (sqlc.narg('country')::text IS NULL OR country = sqlc.narg('country'))
+ORDER BY id DESC
+LIMIT $2;
 
 -- name: ListTenantsByParent :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE parent_tenant_id = $1
 ORDER BY id DESC;
@@ -47,8 +84,13 @@ ORDER BY id DESC;
 -- name: ListActiveTenants :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE tenant_status = 'active'
 ORDER BY id DESC
 LIMIT $1 OFFSET $2;
+
+-- name: UpdateTenantWebhookSigningKey :exec
+UPDATE tenants
+SET webhook_signing_key = $2, updated_at = NOW()
+WHERE id = $1;
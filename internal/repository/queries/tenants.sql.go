@@ -17,7 +17,7 @@ INSERT INTO tenants (display_name, legal_name, country, tenant_kind, parent_tena
 VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 `
 
 type CreateTenantParams struct {
@@ -35,7 +35,6 @@ func (q *Queries) CreateTenant(ctx context.Context, arg CreateTenantParams) (Ten
 		arg.DisplayName,
 		arg.LegalName,
 		arg.Country,
-		arg.TenantKind,
 		arg.ParentTenantID,
 		arg.LegalEntityID,
 		arg.Metadata,
@@ -55,7 +54,39 @@ func (q *Queries) CreateTenant(ctx context.Context, arg CreateTenantParams) (Ten
 		&i.NettingWindowMinutes,
 		&i.ApiKeyHash,
 		&i.WebhookUrl,
-		&i.WebhookSecretHash,
+		&i.WebhookSigningKey,
+		&i.Metadata,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantByAPIKeyHash = `-- name: GetTenantByAPIKeyHash :one
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE api_key_hash = $1
+`
+
+func (q *Queries) GetTenantByAPIKeyHash(ctx context.Context, apiKeyHash pgtype.Text) (Tenant, error) {
+	row := q.db.QueryRow(ctx, getTenantByAPIKeyHash, apiKeyHash)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.LegalName,
+		&i.Country,
+		&i.TenantKind,
+		&i.ParentTenantID,
+		&i.LegalEntityID,
+		&i.TenantStatus,
+		&i.KycLevel,
+		&i.NettingEnabled,
+		&i.NettingWindowMinutes,
+		&i.ApiKeyHash,
+		&i.WebhookUrl,
+		&i.WebhookSigningKey,
 		&i.Metadata,
 		&i.UpdatedAt,
 	)
@@ -65,7 +96,7 @@ func (q *Queries) CreateTenant(ctx context.Context, arg CreateTenantParams) (Ten
 const getTenantByID = `-- name: GetTenantByID :one
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE id = $1
 `
@@ -87,7 +118,40 @@ func (q *Queries) GetTenantByID(ctx context.Context, id uuid.UUID) (Tenant, erro
 		&i.NettingWindowMinutes,
 		&i.ApiKeyHash,
 		&i.WebhookUrl,
-		&i.WebhookSecretHash,
+		&i.WebhookSigningKey,
+		&i.Metadata,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTenantByIDForUpdate = `-- name: GetTenantByIDForUpdate :one
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetTenantByIDForUpdate(ctx context.Context, id uuid.UUID) (Tenant, error) {
+	row := q.db.QueryRow(ctx, getTenantByIDForUpdate, id)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.LegalName,
+		&i.Country,
+		&i.TenantKind,
+		&i.ParentTenantID,
+		&i.LegalEntityID,
+		&i.TenantStatus,
+		&i.KycLevel,
+		&i.NettingEnabled,
+		&i.NettingWindowMinutes,
+		&i.ApiKeyHash,
+		&i.WebhookUrl,
+		&i.WebhookSigningKey,
 		&i.Metadata,
 		&i.UpdatedAt,
 	)
@@ -97,7 +161,7 @@ func (q *Queries) GetTenantByID(ctx context.Context, id uuid.UUID) (Tenant, erro
 const listActiveTenants = `-- name: ListActiveTenants :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE tenant_status = 'active'
 ORDER BY id DESC
@@ -132,7 +196,7 @@ func (q *Queries) ListActiveTenants(ctx context.Context, arg ListActiveTenantsPa
 			&i.NettingWindowMinutes,
 			&i.ApiKeyHash,
 			&i.WebhookUrl,
-			&i.WebhookSecretHash,
+			&i.WebhookSigningKey,
 			&i.Metadata,
 			&i.UpdatedAt,
 		); err != nil {
@@ -149,14 +213,33 @@ func (q *Queries) ListActiveTenants(ctx context.Context, arg ListActiveTenantsPa
 const listTenantsByLegalEntity = `-- name: ListTenantsByLegalEntity :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE legal_entity_id = $1
+    AND ($3::uuid IS NULL OR id < $3)
+    AND ($4::tenant_status_enum IS NULL OR tenant_status = $4)
+    AND ($5::text IS NULL OR country = $5)
 ORDER BY id DESC
+LIMIT $2
 `
 
-func (q *Queries) ListTenantsByLegalEntity(ctx context.Context, legalEntityID uuid.UUID) ([]Tenant, error) {
-	rows, err := q.db.Query(ctx, listTenantsByLegalEntity, legalEntityID)
+type ListTenantsByLegalEntityParams struct {
+	LegalEntityID uuid.UUID            `json:"legal_entity_id"`
+	Limit         int32                `json:"limit"`
+	After         pgtype.UUID          `json:"after"`
+	TenantStatus  NullTenantStatusEnum `json:"tenant_status"`
+	Country       pgtype.Text          `json:"country"`
+}
+
+// Lists the tenants of a legal entity newest first, starting after the cursor ID.
+func (q *Queries) ListTenantsByLegalEntity(ctx context.Context, arg ListTenantsByLegalEntityParams) ([]Tenant, error) {
+	rows, err := q.db.Query(ctx, listTenantsByLegalEntity,
+		arg.LegalEntityID,
+		arg.Limit,
+		arg.After,
+		arg.TenantStatus,
+		arg.Country,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +261,7 @@ func (q *Queries) ListTenantsByLegalEntity(ctx context.Context, legalEntityID uu
 			&i.NettingWindowMinutes,
 			&i.ApiKeyHash,
 			&i.WebhookUrl,
-			&i.WebhookSecretHash,
+			&i.WebhookSigningKey,
 			&i.Metadata,
 			&i.UpdatedAt,
 		); err != nil {
@@ -195,7 +278,7 @@ func (q *Queries) ListTenantsByLegalEntity(ctx context.Context, legalEntityID uu
 const listTenantsByParent = `-- name: ListTenantsByParent :many
 SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 FROM tenants
 WHERE parent_tenant_id = $1
 ORDER BY id DESC
@@ -224,7 +307,7 @@ func (q *Queries) ListTenantsByParent(ctx context.Context, parentTenantID pgtype
 			&i.NettingWindowMinutes,
 			&i.ApiKeyHash,
 			&i.WebhookUrl,
-			&i.WebhookSecretHash,
+			&i.WebhookSigningKey,
 			&i.Metadata,
 			&i.UpdatedAt,
 		); err != nil {
@@ -238,6 +321,73 @@ func (q *Queries) ListTenantsByParent(ctx context.Context, parentTenantID pgtype
 	return items, nil
 }
 
+const listTenantsWithUnsealedWebhookKey = `-- name: ListTenantsWithUnsealedWebhookKey :many
+SELECT id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
+    tenant_status, kyc_level, netting_enabled, netting_window_minutes,
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
+FROM tenants
+WHERE webhook_signing_key IS NOT NULL AND webhook_signing_key NOT LIKE 'v1:%'
+ORDER BY id
+`
+
+// Tenants whose webhook signing key is stored in plaintext, from before keys
+// were sealed. Sealed keys start with their format version.
+func (q *Queries) ListTenantsWithUnsealedWebhookKey(ctx context.Context) ([]Tenant, error) {
+	rows, err := q.db.Query(ctx, listTenantsWithUnsealedWebhookKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tenant{}
+	for rows.Next() {
+		var i Tenant
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisplayName,
+			&i.LegalName,
+			&i.Country,
+			&i.TenantKind,
+			&i.ParentTenantID,
+			&i.LegalEntityID,
+			&i.TenantStatus,
+			&i.KycLevel,
+			&i.NettingEnabled,
+			&i.NettingWindowMinutes,
+			&i.ApiKeyHash,
+			&i.WebhookUrl,
+			&i.WebhookSigningKey,
+			&i.Metadata,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const sealTenantWebhookSigningKey = `-- name: SealTenantWebhookSigningKey :exec
+UPDATE tenants
+SET webhook_signing_key = $1, updated_at = NOW()
+WHERE id = $2 AND webhook_signing_key = $3
+`
+
+type SealTenantWebhookSigningKeyParams struct {
+	SealedKey pgtype.Text `json:"sealed_key"`
+	ID        uuid.UUID   `json:"id"`
+	PlainKey  pgtype.Text `json:"plain_key"`
+}
+
+// Replaces a plaintext signing key with its sealed form, unless the key was
+// rotated meanwhile.
+func (q *Queries) SealTenantWebhookSigningKey(ctx context.Context, arg SealTenantWebhookSigningKeyParams) error {
+	_, err := q.db.Exec(ctx, sealTenantWebhookSigningKey, arg.SealedKey, arg.ID, arg.PlainKey)
+	return err
+}
+
 const updateTenant = `-- name: UpdateTenant :one
 UPDATE tenants SET
     display_name = COALESCE($1, display_name),
@@ -252,7 +402,7 @@ UPDATE tenants SET
 WHERE id = $9
 RETURNING id, display_name, legal_name, country, tenant_kind, parent_tenant_id, legal_entity_id,
     tenant_status, kyc_level, netting_enabled, netting_window_minutes,
-    api_key_hash, webhook_url, webhook_secret_hash, metadata, updated_at
+    api_key_hash, webhook_url, webhook_signing_key, metadata, updated_at
 `
 
 type UpdateTenantParams struct {
@@ -294,9 +444,25 @@ func (q *Queries) UpdateTenant(ctx context.Context, arg UpdateTenantParams) (Ten
 		&i.NettingWindowMinutes,
 		&i.ApiKeyHash,
 		&i.WebhookUrl,
-		&i.WebhookSecretHash,
+		&i.WebhookSigningKey,
 		&i.Metadata,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
+
+const updateTenantWebhookSigningKey = `-- name: UpdateTenantWebhookSigningKey :exec
+UPDATE tenants
+SET webhook_signing_key = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateTenantWebhookSigningKeyParams struct {
+	ID                uuid.UUID   `json:"id"`
+	WebhookSigningKey pgtype.Text `json:"webhook_signing_key"`
+}
+
+func (q *Queries) UpdateTenantWebhookSigningKey(ctx context.Context, arg UpdateTenantWebhookSigningKeyParams) error {
+	_, err := q.db.Exec(ctx, updateTenantWebhookSigningKey, arg.ID, arg.WebhookSigningKey)
+	return err
+}
@@ -8,14 +8,14 @@ RETURNING id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at;
+    updated_at, completed_at, rail_acknowledged_at;
 
 -- name: GetTransferByID :one
 SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE id = $1;
 
@@ -24,10 +24,44 @@ SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, ba
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1 AND idempotency_key = $2;
 
+-- Finds and locks the transfer a rail knows by its reference.
+-- name: GetTransferByRailReferenceForUpdate :one
+SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
+    idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
+    status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
+    tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
+    updated_at, completed_at, rail_acknowledged_at
+FROM transfers
+WHERE rail = $1 AND rail_reference = $2
+FOR UPDATE;
+
+-- Locks a transfer against concurrent changes of its state.
+-- name: GetTransferForUpdate :one
+SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
+    idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
+    status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
+    tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
+    updated_at, completed_at, rail_acknowledged_at
+FROM transfers
+WHERE id = $1
+FOR UPDATE;
+
+-- Finds the compliance region of a transfer, to route its queries to the
+-- database holding it.
+-- name: GetTransferRegion :one
+SELECT compliance_region
+FROM transfers
+WHERE id = $1;
+
+-- name: GetTransferRegionByRailReference :one
+SELECT compliance_region
+FROM transfers
+WHERE rail = $1 AND rail_reference = $2;
+
 -- name: UpdateTransferStatus :exec
 UPDATE transfers
 SET status = $2, failure_reason = $3, updated_at = NOW(),
@@ -39,34 +73,51 @@ UPDATE transfers
 SET tb_transfer_ids = $2, updated_at = NOW()
 WHERE id = $1;
 
+-- Lists a tenant's transfers newest first, starting after the cursor ID.
+-- Creation time bounds are ID bounds, as IDs are UUIDv7.
 -- name: ListTransfersByTenant :many
 SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1
+    AND (sqlc.narg('after')::uuid IS NULL OR id < sqlc.narg('after'))
+    AND (sqlc.narg('created_from')::timestamptz IS NULL OR id >= ts_to_uuid_min(sqlc.narg('created_from')))
+    AND (sqlc.narg('created_to')::timestamptz IS NULL OR id <= ts_to_uuid_max(sqlc.narg('created_to')))
     AND (sqlc.narg('status')::transfer_status_enum IS NULL OR status = sqlc.narg('status'))
     AND (sqlc.narg('from_currency')::text IS NULL OR from_currency = sqlc.narg('from_currency'))
     AND (sqlc.narg('to_currency')::text IS NULL OR to_currency = sqlc.narg('to_currency'))
     AND (sqlc.narg('compliance_region')::text IS NULL OR compliance_region = sqlc.narg('compliance_region'))
+    AND (sqlc.narg('compliance_status')::text IS NULL OR compliance_status = sqlc.narg('compliance_status'))
+    AND (sqlc.narg('rail')::rail_enum IS NULL OR rail = sqlc.narg('rail'))
+    AND (sqlc.narg('recipient_id')::uuid IS NULL OR recipient_id = sqlc.narg('recipient_id'))
+    AND (sqlc.narg('batch_id')::uuid IS NULL OR batch_id = sqlc.narg('batch_id'))
+    AND (sqlc.narg('min_amount')::numeric IS NULL OR from_amount >= sqlc.narg('min_amount'))
+    AND (sqlc.narg('max_amount')::numeric IS NULL OR from_amount <= sqlc.narg('max_amount'))
     AND (sqlc.narg('updated_after')::timestamptz IS NULL OR updated_at >= sqlc.narg('updated_after'))
     AND (sqlc.narg('updated_before')::timestamptz IS NULL OR updated_at < sqlc.narg('updated_before'))
-ORDER BY updated_at DESC
-LIMIT $2 OFFSET $3;
+ORDER BY id DESC
+LIMIT $2;
 
 -- name: ListTransfersByTenantAndStatus :many
 SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1 AND status = $2
 ORDER BY updated_at DESC
 LIMIT $3 OFFSET $4;
 
+-- Records that the rail accepted the payout of a transfer, once.
+-- name: AcknowledgeTransferRail :exec
+UPDATE transfers
+SET rail_acknowledged_at = COALESCE(rail_acknowledged_at, NOW()), updated_at = NOW()
+WHERE id = $1;
+
 -- name: UpdateTransferRailReference :exec
 UPDATE transfers
 SET rail_reference = $2, updated_at = NOW()
@@ -81,3 +132,10 @@ WHERE id = $1;
 UPDATE transfers
 SET netting_group_id = $2, is_netted = $3, updated_at = NOW()
 WHERE id = $1;
+
+-- Recent transfers of a tenant for transaction monitoring, by UUIDv7 time range.
+-- name: ListTransferActivity :many
+SELECT id, recipient_id, from_currency, from_amount
+FROM transfers
+WHERE tenant_id = $1 AND id >= ts_to_uuid_min($2)
+ORDER BY id DESC;
@@ -12,6 +12,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const acknowledgeTransferRail = `-- name: AcknowledgeTransferRail :exec
+UPDATE transfers
+SET rail_acknowledged_at = COALESCE(rail_acknowledged_at, NOW()), updated_at = NOW()
+WHERE id = $1
+`
+
+// Records that the rail accepted the payout of a transfer, once.
+func (q *Queries) AcknowledgeTransferRail(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, acknowledgeTransferRail, id)
+	return err
+}
+
 const createTransfer = `-- name: CreateTransfer :one
 INSERT INTO transfers (
     tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, recipient_id,
@@ -22,7 +34,7 @@ RETURNING id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 `
 
 type CreateTransferParams struct {
@@ -86,6 +98,7 @@ func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams)
 		&i.ComplianceRegion,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.RailAcknowledgedAt,
 	)
 	return i, err
 }
@@ -95,7 +108,7 @@ SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, ba
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE id = $1
 `
@@ -131,6 +144,7 @@ func (q *Queries) GetTransferByID(ctx context.Context, id uuid.UUID) (Transfer,
 		&i.ComplianceRegion,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.RailAcknowledgedAt,
 	)
 	return i, err
 }
@@ -140,7 +154,7 @@ SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, ba
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1 AND idempotency_key = $2
 `
@@ -181,49 +195,256 @@ func (q *Queries) GetTransferByIdempotencyKey(ctx context.Context, arg GetTransf
 		&i.ComplianceRegion,
 		&i.UpdatedAt,
 		&i.CompletedAt,
+		&i.RailAcknowledgedAt,
+	)
+	return i, err
+}
+
+const getTransferByRailReferenceForUpdate = `-- name: GetTransferByRailReferenceForUpdate :one
+SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
+    idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
+    status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
+    tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
+    updated_at, completed_at, rail_acknowledged_at
+FROM transfers
+WHERE rail = $1 AND rail_reference = $2
+FOR UPDATE
+`
+
+type GetTransferByRailReferenceForUpdateParams struct {
+	Rail          NullRailEnum `json:"rail"`
+	RailReference pgtype.Text  `json:"rail_reference"`
+}
+
+// Finds and locks the transfer a rail knows by its reference.
+func (q *Queries) GetTransferByRailReferenceForUpdate(ctx context.Context, arg GetTransferByRailReferenceForUpdateParams) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferByRailReferenceForUpdate, arg.Rail, arg.RailReference)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.SourceLegalEntityID,
+		&i.DestLegalEntityID,
+		&i.QuoteID,
+		&i.BatchID,
+		&i.RecipientID,
+		&i.IdempotencyKey,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.TotalFee,
+		&i.Status,
+		&i.FailureReason,
+		&i.Rail,
+		&i.RailReference,
+		&i.NettingGroupID,
+		&i.IsNetted,
+		&i.TbTransferIds,
+		&i.RiskScore,
+		&i.ComplianceStatus,
+		&i.ScreenedAt,
+		&i.ComplianceRegion,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.RailAcknowledgedAt,
+	)
+	return i, err
+}
+
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
+    idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
+    status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
+    tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
+    updated_at, completed_at, rail_acknowledged_at
+FROM transfers
+WHERE id = $1
+FOR UPDATE
+`
+
+// Locks a transfer against concurrent changes of its state.
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id uuid.UUID) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.TenantID,
+		&i.SourceLegalEntityID,
+		&i.DestLegalEntityID,
+		&i.QuoteID,
+		&i.BatchID,
+		&i.RecipientID,
+		&i.IdempotencyKey,
+		&i.FromCurrency,
+		&i.ToCurrency,
+		&i.FromAmount,
+		&i.ToAmount,
+		&i.FxRate,
+		&i.TotalFee,
+		&i.Status,
+		&i.FailureReason,
+		&i.Rail,
+		&i.RailReference,
+		&i.NettingGroupID,
+		&i.IsNetted,
+		&i.TbTransferIds,
+		&i.RiskScore,
+		&i.ComplianceStatus,
+		&i.ScreenedAt,
+		&i.ComplianceRegion,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.RailAcknowledgedAt,
 	)
 	return i, err
 }
 
+const getTransferRegion = `-- name: GetTransferRegion :one
+SELECT compliance_region
+FROM transfers
+WHERE id = $1
+`
+
+// Finds the compliance region of a transfer, to route its queries to the
+// database holding it.
+func (q *Queries) GetTransferRegion(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getTransferRegion, id)
+	var compliance_region string
+	err := row.Scan(&compliance_region)
+	return compliance_region, err
+}
+
+const getTransferRegionByRailReference = `-- name: GetTransferRegionByRailReference :one
+SELECT compliance_region
+FROM transfers
+WHERE rail = $1 AND rail_reference = $2
+`
+
+type GetTransferRegionByRailReferenceParams struct {
+	Rail          NullRailEnum `json:"rail"`
+	RailReference pgtype.Text  `json:"rail_reference"`
+}
+
+func (q *Queries) GetTransferRegionByRailReference(ctx context.Context, arg GetTransferRegionByRailReferenceParams) (string, error) {
+	row := q.db.QueryRow(ctx, getTransferRegionByRailReference, arg.Rail, arg.RailReference)
+	var compliance_region string
+	err := row.Scan(&compliance_region)
+	return compliance_region, err
+}
+
+const listTransferActivity = `-- name: ListTransferActivity :many
+SELECT id, recipient_id, from_currency, from_amount
+FROM transfers
+WHERE tenant_id = $1 AND id >= ts_to_uuid_min($2)
+ORDER BY id DESC
+`
+
+type ListTransferActivityParams struct {
+	TenantID uuid.UUID          `json:"tenant_id"`
+	Since    pgtype.Timestamptz `json:"since"`
+}
+
+type ListTransferActivityRow struct {
+	ID           uuid.UUID      `json:"id"`
+	RecipientID  pgtype.UUID    `json:"recipient_id"`
+	FromCurrency string         `json:"from_currency"`
+	FromAmount   pgtype.Numeric `json:"from_amount"`
+}
+
+// Recent transfers of a tenant for transaction monitoring, by UUIDv7 time range.
+func (q *Queries) ListTransferActivity(ctx context.Context, arg ListTransferActivityParams) ([]ListTransferActivityRow, error) {
+	rows, err := q.db.Query(ctx, listTransferActivity, arg.TenantID, arg.Since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransferActivityRow{}
+	for rows.Next() {
+		var i ListTransferActivityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RecipientID,
+			&i.FromCurrency,
+			&i.FromAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransfersByTenant = `-- name: ListTransfersByTenant :many
 SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, batch_id, recipient_id,
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1
-    AND ($4::transfer_status_enum IS NULL OR status = $4)
-    AND ($5::text IS NULL OR from_currency = $5)
-    AND ($6::text IS NULL OR to_currency = $6)
-    AND ($7::text IS NULL OR compliance_region = $7)
-    AND ($8::timestamptz IS NULL OR updated_at >= $8)
-    AND ($9::timestamptz IS NULL OR updated_at < $9)
-ORDER BY updated_at DESC
-LIMIT $2 OFFSET $3
+    AND ($3::uuid IS NULL OR id < $3)
+    AND ($4::timestamptz IS NULL OR id >= ts_to_uuid_min($4))
+    AND ($5::timestamptz IS NULL OR id <= ts_to_uuid_max($5))
+    AND ($6::transfer_status_enum IS NULL OR status = $6)
+    AND ($7::text IS NULL OR from_currency = $7)
+    AND ($8::text IS NULL OR to_currency = $8)
+    AND ($9::text IS NULL OR compliance_region = $9)
+    AND ($10::text IS NULL OR compliance_status = $10)
+    AND ($11::rail_enum IS NULL OR rail = $11)
+    AND ($12::uuid IS NULL OR recipient_id = $12)
+    AND ($13::uuid IS NULL OR batch_id = $13)
+    AND ($14::numeric IS NULL OR from_amount >= $14)
+    AND ($15::numeric IS NULL OR from_amount <= $15)
+    AND ($16::timestamptz IS NULL OR updated_at >= $16)
+    AND ($17::timestamptz IS NULL OR updated_at < $17)
+ORDER BY id DESC
+LIMIT $2
 `
 
 type ListTransfersByTenantParams struct {
 	TenantID         uuid.UUID              `json:"tenant_id"`
 	Limit            int32                  `json:"limit"`
-	Offset           int32                  `json:"offset"`
+	After            pgtype.UUID            `json:"after"`
+	CreatedFrom      pgtype.Timestamptz     `json:"created_from"`
+	CreatedTo        pgtype.Timestamptz     `json:"created_to"`
 	Status           NullTransferStatusEnum `json:"status"`
 	FromCurrency     pgtype.Text            `json:"from_currency"`
 	ToCurrency       pgtype.Text            `json:"to_currency"`
 	ComplianceRegion pgtype.Text            `json:"compliance_region"`
+	ComplianceStatus pgtype.Text            `json:"compliance_status"`
+	Rail             NullRailEnum           `json:"rail"`
+	RecipientID      pgtype.UUID            `json:"recipient_id"`
+	BatchID          pgtype.UUID            `json:"batch_id"`
+	MinAmount        pgtype.Numeric         `json:"min_amount"`
+	MaxAmount        pgtype.Numeric         `json:"max_amount"`
 	UpdatedAfter     pgtype.Timestamptz     `json:"updated_after"`
 	UpdatedBefore    pgtype.Timestamptz     `json:"updated_before"`
 }
 
+// Lists a tenant's transfers newest first, starting after the cursor ID.
+// Creation time bounds are ID bounds, as IDs are UUIDv7.
 func (q *Queries) ListTransfersByTenant(ctx context.Context, arg ListTransfersByTenantParams) ([]Transfer, error) {
 	rows, err := q.db.Query(ctx, listTransfersByTenant,
 		arg.TenantID,
 		arg.Limit,
-		arg.Offset,
+		arg.After,
+		arg.CreatedFrom,
+		arg.CreatedTo,
 		arg.Status,
 		arg.FromCurrency,
 		arg.ToCurrency,
 		arg.ComplianceRegion,
+		arg.ComplianceStatus,
+		arg.Rail,
+		arg.RecipientID,
+		arg.BatchID,
+		arg.MinAmount,
+		arg.MaxAmount,
 		arg.UpdatedAfter,
 		arg.UpdatedBefore,
 	)
@@ -262,6 +483,7 @@ func (q *Queries) ListTransfersByTenant(ctx context.Context, arg ListTransfersBy
 			&i.ComplianceRegion,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.RailAcknowledgedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -278,7 +500,7 @@ SELECT id, tenant_id, source_legal_entity_id, dest_legal_entity_id, quote_id, ba
     idempotency_key, from_currency, to_currency, from_amount, to_amount, fx_rate, total_fee,
     status, failure_reason, rail, rail_reference, netting_group_id, is_netted,
     tb_transfer_ids, risk_score, compliance_status, screened_at, compliance_region,
-    updated_at, completed_at
+    updated_at, completed_at, rail_acknowledged_at
 FROM transfers
 WHERE tenant_id = $1 AND status = $2
 ORDER BY updated_at DESC
@@ -334,6 +556,7 @@ func (q *Queries) ListTransfersByTenantAndStatus(ctx context.Context, arg ListTr
 			&i.ComplianceRegion,
 			&i.UpdatedAt,
 			&i.CompletedAt,
+			&i.RailAcknowledgedAt,
 		); err != nil {
 			return nil, err
 		}
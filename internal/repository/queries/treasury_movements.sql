@@ -0,0 +1,64 @@
+-- name: CreateTreasuryMovement :one
+INSERT INTO treasury_movements (
+    kind, reason, counterparty, deal_reference, requested_by
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at;
+
+-- Adds a leg to a movement at the current USD reference rate of its
+-- currency.
+-- name: CreateTreasuryMovementLeg :one
+INSERT INTO treasury_movement_legs (
+    movement_id, seq, tb_transfer_id, currency, debit_account, credit_account, amount, usd_rate
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7,
+    (SELECT r.usd_rate FROM usd_reference_rates r WHERE r.currency = $4)
+)
+RETURNING movement_id, seq, tb_transfer_id, currency, debit_account, credit_account, amount, usd_rate;
+
+-- name: GetTreasuryMovement :one
+SELECT id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at
+FROM treasury_movements
+WHERE id = $1;
+
+-- The live booking of an FX deal, if it was booked before.
+-- name: GetTreasuryMovementByDeal :one
+SELECT id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at
+FROM treasury_movements
+WHERE kind = 'fx_deal' AND counterparty = $1 AND deal_reference = $2 AND status <> 'rejected';
+
+-- name: GetTreasuryMovementForUpdate :one
+SELECT id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at
+FROM treasury_movements
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListTreasuryMovements :many
+SELECT id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at
+FROM treasury_movements
+WHERE (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('kind')::text IS NULL OR kind = sqlc.narg('kind'))
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListTreasuryMovementLegs :many
+SELECT movement_id, seq, tb_transfer_id, currency, debit_account, credit_account, amount, usd_rate
+FROM treasury_movement_legs
+WHERE movement_id = $1
+ORDER BY seq;
+
+-- name: DecideTreasuryMovement :one
+UPDATE treasury_movements
+SET status = $2, decided_by = $3, decision_reason = $4, decided_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING id, kind, status, reason, counterparty, deal_reference, requested_by,
+    decided_by, decision_reason, created_at, updated_at, decided_at, posted_at;
+
+-- name: MarkTreasuryMovementPosted :exec
+UPDATE treasury_movements
+SET status = 'posted', posted_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'approved';
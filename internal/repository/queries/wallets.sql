@@ -1,24 +1,35 @@
 -- name: CreateWallet :one
 INSERT INTO wallets (tenant_id, currency, tb_account_id)
 VALUES ($1, $2, $3)
-RETURNING id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at;
+RETURNING id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id;
 
 -- name: GetWalletByID :one
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE id = $1;
 
 -- name: GetWalletByTenantAndCurrency :one
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE tenant_id = $1 AND currency = $2;
 
 -- name: ListWalletsByTenant :many
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE tenant_id = $1
 ORDER BY currency;
 
+-- Lists a tenant's wallets newest first, starting after the cursor ID.
+-- name: ListWalletsByTenantPage :many
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
+FROM wallets
+WHERE tenant_id = $1
+    AND (sqlc.narg('after')::uuid IS NULL OR id < sqlc.narg('after'))
+    AND (sqlc.narg('currency')::text IS NULL OR currency = sqlc.narg('currency'))
+    AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY id DESC
+LIMIT $2;
+
 -- name: UpdateWalletCachedBalance :exec
 UPDATE wallets
 SET cached_balance = $2, cached_pending = $3, cached_at = NOW(), updated_at = NOW()
@@ -28,3 +39,8 @@ WHERE id = $1;
 UPDATE wallets
 SET status = $2, updated_at = NOW()
 WHERE id = $1;
+
+-- name: UpdateWalletFreeze :exec
+UPDATE wallets
+SET status = $2, ledger_freeze_id = $3, updated_at = NOW()
+WHERE id = $1;
@@ -15,7 +15,7 @@ import (
 const createWallet = `-- name: CreateWallet :one
 INSERT INTO wallets (tenant_id, currency, tb_account_id)
 VALUES ($1, $2, $3)
-RETURNING id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+RETURNING id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 `
 
 type CreateWalletParams struct {
@@ -37,12 +37,13 @@ func (q *Queries) CreateWallet(ctx context.Context, arg CreateWalletParams) (Wal
 		&i.CachedAt,
 		&i.Status,
 		&i.UpdatedAt,
+		&i.LedgerFreezeID,
 	)
 	return i, err
 }
 
 const getWalletByID = `-- name: GetWalletByID :one
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE id = $1
 `
@@ -60,12 +61,13 @@ func (q *Queries) GetWalletByID(ctx context.Context, id uuid.UUID) (Wallet, erro
 		&i.CachedAt,
 		&i.Status,
 		&i.UpdatedAt,
+		&i.LedgerFreezeID,
 	)
 	return i, err
 }
 
 const getWalletByTenantAndCurrency = `-- name: GetWalletByTenantAndCurrency :one
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE tenant_id = $1 AND currency = $2
 `
@@ -88,12 +90,13 @@ func (q *Queries) GetWalletByTenantAndCurrency(ctx context.Context, arg GetWalle
 		&i.CachedAt,
 		&i.Status,
 		&i.UpdatedAt,
+		&i.LedgerFreezeID,
 	)
 	return i, err
 }
 
 const listWalletsByTenant = `-- name: ListWalletsByTenant :many
-SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
 FROM wallets
 WHERE tenant_id = $1
 ORDER BY currency
@@ -118,6 +121,64 @@ func (q *Queries) ListWalletsByTenant(ctx context.Context, tenantID uuid.UUID) (
 			&i.CachedAt,
 			&i.Status,
 			&i.UpdatedAt,
+			&i.LedgerFreezeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWalletsByTenantPage = `-- name: ListWalletsByTenantPage :many
+SELECT id, tenant_id, currency, tb_account_id, cached_balance, cached_pending, cached_at, status, updated_at, ledger_freeze_id
+FROM wallets
+WHERE tenant_id = $1
+    AND ($3::uuid IS NULL OR id < $3)
+    AND ($4::text IS NULL OR currency = $4)
+    AND ($5::text IS NULL OR status = $5)
+ORDER BY id DESC
+LIMIT $2
+`
+
+type ListWalletsByTenantPageParams struct {
+	TenantID uuid.UUID   `json:"tenant_id"`
+	Limit    int32       `json:"limit"`
+	After    pgtype.UUID `json:"after"`
+	Currency pgtype.Text `json:"currency"`
+	Status   pgtype.Text `json:"status"`
+}
+
+// Lists a tenant's wallets newest first, starting after the cursor ID.
+func (q *Queries) ListWalletsByTenantPage(ctx context.Context, arg ListWalletsByTenantPageParams) ([]Wallet, error) {
+	rows, err := q.db.Query(ctx, listWalletsByTenantPage,
+		arg.TenantID,
+		arg.Limit,
+		arg.After,
+		arg.Currency,
+		arg.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Wallet{}
+	for rows.Next() {
+		var i Wallet
+		if err := rows.Scan(
+			&i.ID,
+			&i.TenantID,
+			&i.Currency,
+			&i.TbAccountID,
+			&i.CachedBalance,
+			&i.CachedPending,
+			&i.CachedAt,
+			&i.Status,
+			&i.UpdatedAt,
+			&i.LedgerFreezeID,
 		); err != nil {
 			return nil, err
 		}
@@ -146,6 +207,23 @@ func (q *Queries) UpdateWalletCachedBalance(ctx context.Context, arg UpdateWalle
 	return err
 }
 
+const updateWalletFreeze = `-- name: UpdateWalletFreeze :exec
+UPDATE wallets
+SET status = $2, ledger_freeze_id = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateWalletFreezeParams struct {
+	ID             uuid.UUID   `json:"id"`
+	Status         string      `json:"status"`
+	LedgerFreezeID pgtype.UUID `json:"ledger_freeze_id"`
+}
+
+func (q *Queries) UpdateWalletFreeze(ctx context.Context, arg UpdateWalletFreezeParams) error {
+	_, err := q.db.Exec(ctx, updateWalletFreeze, arg.ID, arg.Status, arg.LedgerFreezeID)
+	return err
+}
+
 const updateWalletStatus = `-- name: UpdateWalletStatus :exec
 UPDATE wallets
 SET status = $2, updated_at = NOW()
@@ -0,0 +1,67 @@
+-- name: CountPendingWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE status = 'pending';
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (tenant_id, event_id, event_type, url, payload, trace_context)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (tenant_id, event_id) DO NOTHING;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT id, tenant_id, event_id, event_type, url, payload, status, attempts, next_attempt_at,
+    locked_until, last_status_code, last_error, delivered_at, updated_at, trace_context
+FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: ListWebhookDeliveriesByTenant :many
+SELECT id, tenant_id, event_id, event_type, url, payload, status, attempts, next_attempt_at,
+    locked_until, last_status_code, last_error, delivered_at, updated_at, trace_context
+FROM webhook_deliveries
+WHERE tenant_id = $1
+    AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY id DESC
+LIMIT $2;
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries
+SET locked_until = NOW() + (sqlc.arg('lease_seconds')::int * INTERVAL '1 second')
+WHERE id IN (
+    SELECT wd.id FROM webhook_deliveries wd
+    WHERE wd.status = 'pending'
+        AND wd.next_attempt_at <= NOW()
+        AND (wd.locked_until IS NULL OR wd.locked_until < NOW())
+    ORDER BY wd.next_attempt_at
+    LIMIT sqlc.arg('batch_size')
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, tenant_id, event_id, event_type, url, payload, status, attempts, next_attempt_at,
+    locked_until, last_status_code, last_error, delivered_at, updated_at, trace_context;
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', attempts = $2, last_status_code = $3, last_error = NULL,
+    locked_until = NULL, delivered_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6,
+    locked_until = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ReplayWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending', next_attempt_at = NOW(), locked_until = NULL, updated_at = NOW()
+WHERE id = $1
+RETURNING id, tenant_id, event_id, event_type, url, payload, status, attempts, next_attempt_at,
+    locked_until, last_status_code, last_error, delivered_at, updated_at, trace_context;
+
+-- name: CreateWebhookDeliveryAttempt :exec
+INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ListWebhookDeliveryAttempts :many
+SELECT id, delivery_id, attempt, status_code, error, duration_ms, attempted_at
+FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY attempt;
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// RailCallbackRepository handles status callbacks received from the rails.
+type RailCallbackRepository struct {
+	q *queries.Queries
+}
+
+// NewRailCallbackRepository creates a new rail callback repository.
+func NewRailCallbackRepository(pool *pgxpool.Pool) *RailCallbackRepository {
+	return &RailCallbackRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *RailCallbackRepository) WithTx(tx pgx.Tx) *RailCallbackRepository {
+	return &RailCallbackRepository{q: r.q.WithTx(tx)}
+}
+
+// Create records a callback. It returns nil if the rail's event has already
+// been recorded.
+func (r *RailCallbackRepository) Create(ctx context.Context, params models.CreateRailCallbackParams) (*models.RailCallback, error) {
+	row, err := r.q.CreateRailCallback(ctx, queries.CreateRailCallbackParams{
+		Rail:          queries.RailEnum(params.Rail),
+		EventID:       params.EventID,
+		TransferID:    params.TransferID,
+		RailReference: stringPtrToNullable(params.RailReference),
+		Status:        string(params.Status),
+		ReturnCode:    stringPtrToNullable(params.ReturnCode),
+		Reason:        stringPtrToNullable(params.Reason),
+		Outcome:       string(params.Outcome),
+		Payload:       params.Payload,
+		OccurredAt:    timeToNullable(params.OccurredAt),
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return railCallbackToModel(row), nil
+}
+
+// GetByEvent retrieves the callback a rail sent with the given event ID.
+func (r *RailCallbackRepository) GetByEvent(ctx context.Context, rail models.Rail, eventID string) (*models.RailCallback, error) {
+	row, err := r.q.GetRailCallbackByEvent(ctx, queries.GetRailCallbackByEventParams{
+		Rail:    queries.RailEnum(rail),
+		EventID: eventID,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return railCallbackToModel(row), nil
+}
+
+// ListByTransfer returns the callbacks received for a transfer, oldest
+// first.
+func (r *RailCallbackRepository) ListByTransfer(ctx context.Context, transferID uuid.UUID) ([]*models.RailCallback, error) {
+	rows, err := r.q.ListRailCallbacksByTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.RailCallback, len(rows))
+	for i, row := range rows {
+		result[i] = railCallbackToModel(row)
+	}
+	return result, nil
+}
+
+func railCallbackToModel(row queries.RailCallback) *models.RailCallback {
+	cb := &models.RailCallback{
+		ID:         row.ID,
+		Rail:       models.Rail(row.Rail),
+		EventID:    row.EventID,
+		TransferID: row.TransferID,
+		Status:     models.RailCallbackStatus(row.Status),
+		Outcome:    models.RailCallbackOutcome(row.Outcome),
+		Payload:    row.Payload,
+		ReceivedAt: row.ReceivedAt,
+	}
+	if row.RailReference.Valid {
+		cb.RailReference = &row.RailReference.String
+	}
+	if row.ReturnCode.Valid {
+		cb.ReturnCode = &row.ReturnCode.String
+	}
+	if row.Reason.Valid {
+		cb.Reason = &row.Reason.String
+	}
+	if row.OccurredAt.Valid {
+		cb.OccurredAt = &row.OccurredAt.Time
+	}
+	return cb
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// RecipientRepository handles recipient data access.
+type RecipientRepository struct {
+	q *queries.Queries
+}
+
+// NewRecipientRepository creates a new recipient repository.
+func NewRecipientRepository(pool *pgxpool.Pool) *RecipientRepository {
+	return &RecipientRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *RecipientRepository) WithTx(tx pgx.Tx) *RecipientRepository {
+	return &RecipientRepository{q: r.q.WithTx(tx)}
+}
+
+// Create creates a new recipient.
+func (r *RecipientRepository) Create(ctx context.Context, params models.CreateRecipientParams) (*models.Recipient, error) {
+	metadata := params.Metadata
+	if metadata == nil {
+		metadata = []byte("{}")
+	}
+
+	row, err := r.q.CreateRecipient(ctx, queries.CreateRecipientParams{
+		TenantID:      params.TenantID,
+		Name:          params.Name,
+		Country:       params.Country,
+		AccountNumber: stringToNullable(params.AccountNumber),
+		BankCode:      stringToNullable(params.BankCode),
+		Metadata:      metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByID retrieves a recipient by ID.
+func (r *RecipientRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Recipient, error) {
+	row, err := r.q.GetRecipientByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// ListByTenant retrieves recipients for a tenant.
+func (r *RecipientRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, limit, offset int) ([]*models.Recipient, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := r.q.ListRecipientsByTenant(ctx, queries.ListRecipientsByTenantParams{
+		TenantID: tenantID,
+		Limit:    int32(limit),
+		Offset:   int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Recipient, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result, nil
+}
+
+func (r *RecipientRepository) toModel(row queries.Recipient) *models.Recipient {
+	rec := &models.Recipient{
+		ID:        row.ID,
+		TenantID:  row.TenantID,
+		Name:      row.Name,
+		Country:   row.Country,
+		Metadata:  json.RawMessage(row.Metadata),
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if row.AccountNumber.Valid {
+		rec.AccountNumber = &row.AccountNumber.String
+	}
+	if row.BankCode.Valid {
+		rec.BankCode = &row.BankCode.String
+	}
+
+	return rec
+}
@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// ReconciliationRepository handles bank balances and reconciliation reports.
+type ReconciliationRepository struct {
+	q *queries.Queries
+}
+
+// NewReconciliationRepository creates a new reconciliation repository.
+func NewReconciliationRepository(pool *pgxpool.Pool) *ReconciliationRepository {
+	return &ReconciliationRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *ReconciliationRepository) WithTx(tx pgx.Tx) *ReconciliationRepository {
+	return &ReconciliationRepository{q: r.q.WithTx(tx)}
+}
+
+// RecordBankBalance stores the closing balance of a bank account, replacing
+// any balance recorded for the same account, currency and date.
+func (r *ReconciliationRepository) RecordBankBalance(ctx context.Context, params models.CreateBankBalanceParams) (*models.BankBalance, error) {
+	source := params.Source
+	if source == "" {
+		source = "manual"
+	}
+
+	row, err := r.q.UpsertBankBalance(ctx, queries.UpsertBankBalanceParams{
+		LegalEntityID: params.LegalEntityID,
+		AccountKind:   string(params.AccountKind),
+		Currency:      params.Currency,
+		Balance:       decimalToNumeric(params.Balance),
+		AsOf:          dateToPg(params.AsOf),
+		Source:        source,
+		RecordedBy:    params.RecordedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bankBalanceToModel(row), nil
+}
+
+// ListBankBalancesAsOf returns the closing balance of each bank account and
+// currency on a date.
+func (r *ReconciliationRepository) ListBankBalancesAsOf(ctx context.Context, asOf time.Time) ([]*models.BankBalance, error) {
+	rows, err := r.q.ListBankBalancesAsOf(ctx, dateToPg(asOf))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.BankBalance, len(rows))
+	for i, row := range rows {
+		result[i] = bankBalanceToModel(row)
+	}
+	return result, nil
+}
+
+// ListWalletLedgerAccounts returns every wallet's ledger account with the
+// legal entity holding its funds.
+func (r *ReconciliationRepository) ListWalletLedgerAccounts(ctx context.Context) ([]*models.WalletLedgerAccount, error) {
+	rows, err := r.q.ListWalletLedgerAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.WalletLedgerAccount, len(rows))
+	for i, row := range rows {
+		result[i] = &models.WalletLedgerAccount{
+			TBAccountID:   numericToBigInt(row.TbAccountID),
+			Currency:      row.Currency,
+			LegalEntityID: row.LegalEntityID,
+		}
+	}
+	return result, nil
+}
+
+// ListTransferLedgerIDs returns up to limit transfers that reference ledger
+// transfers, in ID order after afterID.
+func (r *ReconciliationRepository) ListTransferLedgerIDs(ctx context.Context, afterID uuid.UUID, limit int) ([]*models.TransferLedgerIDs, error) {
+	rows, err := r.q.ListTransferLedgerIDs(ctx, queries.ListTransferLedgerIDsParams{
+		ID:    afterID,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.TransferLedgerIDs, len(rows))
+	for i, row := range rows {
+		ids := make([]*big.Int, len(row.TbTransferIds))
+		for j, n := range row.TbTransferIds {
+			ids[j] = numericToBigInt(n)
+		}
+		result[i] = &models.TransferLedgerIDs{TransferID: row.ID, TBTransferIDs: ids}
+	}
+	return result, nil
+}
+
+// CreateReport stores a finished reconciliation run and its discrepancies.
+// Call it in a transaction.
+func (r *ReconciliationRepository) CreateReport(ctx context.Context, params models.CreateReconciliationReportParams) (*models.ReconciliationReport, error) {
+	row, err := r.q.CreateReconciliationReport(ctx, queries.CreateReconciliationReportParams{
+		BusinessDate:     dateToPg(params.BusinessDate),
+		BalancesChecked:  int32(params.BalancesChecked),
+		TransfersChecked: int32(params.TransfersChecked),
+		DiscrepancyCount: int32(len(params.Discrepancies)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range params.Discrepancies {
+		err := r.q.CreateReconciliationDiscrepancy(ctx, queries.CreateReconciliationDiscrepancyParams{
+			ReportID:        row.ID,
+			Kind:            string(d.Kind),
+			LegalEntityID:   uuidToNullable(d.LegalEntityID),
+			Currency:        stringPtrToNullable(d.Currency),
+			TransferID:      uuidToNullable(d.TransferID),
+			LedgerAmount:    decimalPtrToNumeric(d.LedgerAmount),
+			BankAmount:      decimalPtrToNumeric(d.BankAmount),
+			Difference:      decimalPtrToNumeric(d.Difference),
+			BankBalanceAsOf: datePtrToPg(d.BankBalanceAsOf),
+			Detail:          d.Detail,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reportToModel(row), nil
+}
+
+// GetReport retrieves a report by ID.
+func (r *ReconciliationRepository) GetReport(ctx context.Context, id uuid.UUID) (*models.ReconciliationReport, error) {
+	row, err := r.q.GetReconciliationReport(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reportToModel(row), nil
+}
+
+// GetReportForUpdate retrieves a report by ID and locks it until the
+// transaction ends.
+func (r *ReconciliationRepository) GetReportForUpdate(ctx context.Context, id uuid.UUID) (*models.ReconciliationReport, error) {
+	row, err := r.q.GetReconciliationReportForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reportToModel(row), nil
+}
+
+// ListReports returns reports, newest business date first.
+func (r *ReconciliationRepository) ListReports(ctx context.Context, status *models.ReconciliationStatus, limit, offset int) ([]*models.ReconciliationReport, error) {
+	var st pgtype.Text
+	if status != nil {
+		st = pgtype.Text{String: string(*status), Valid: true}
+	}
+
+	rows, err := r.q.ListReconciliationReports(ctx, queries.ListReconciliationReportsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+		Status: st,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.ReconciliationReport, len(rows))
+	for i, row := range rows {
+		result[i] = reportToModel(row)
+	}
+	return result, nil
+}
+
+// ListDiscrepancies returns the discrepancies of a report.
+func (r *ReconciliationRepository) ListDiscrepancies(ctx context.Context, reportID uuid.UUID) ([]*models.ReconciliationDiscrepancy, error) {
+	rows, err := r.q.ListReconciliationDiscrepancies(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*models.ReconciliationDiscrepancy, len(rows))
+	for i, row := range rows {
+		result[i] = discrepancyToModel(row)
+	}
+	return result, nil
+}
+
+// SignOff marks a report as reviewed.
+func (r *ReconciliationRepository) SignOff(ctx context.Context, id uuid.UUID, signedOffBy string, note *string) error {
+	return r.q.SignOffReconciliationReport(ctx, queries.SignOffReconciliationReportParams{
+		ID:          id,
+		SignedOffBy: pgtype.Text{String: signedOffBy, Valid: true},
+		SignOffNote: stringPtrToNullable(note),
+	})
+}
+
+func bankBalanceToModel(row queries.BankBalance) *models.BankBalance {
+	return &models.BankBalance{
+		ID:            row.ID,
+		LegalEntityID: row.LegalEntityID,
+		AccountKind:   models.BankAccountKind(row.AccountKind),
+		Currency:      row.Currency,
+		Balance:       numericToDecimal(row.Balance),
+		AsOf:          row.AsOf.Time,
+		Source:        row.Source,
+		RecordedBy:    row.RecordedBy,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func reportToModel(row queries.ReconciliationReport) *models.ReconciliationReport {
+	report := &models.ReconciliationReport{
+		ID:               row.ID,
+		BusinessDate:     row.BusinessDate.Time,
+		Status:           models.ReconciliationStatus(row.Status),
+		BalancesChecked:  int(row.BalancesChecked),
+		TransfersChecked: int(row.TransfersChecked),
+		DiscrepancyCount: int(row.DiscrepancyCount),
+		CreatedAt:        row.CreatedAt,
+	}
+	if row.SignedOffBy.Valid {
+		report.SignedOffBy = &row.SignedOffBy.String
+	}
+	if row.SignOffNote.Valid {
+		report.SignOffNote = &row.SignOffNote.String
+	}
+	if row.SignedOffAt.Valid {
+		report.SignedOffAt = &row.SignedOffAt.Time
+	}
+	return report
+}
+
+func discrepancyToModel(row queries.ReconciliationDiscrepancy) *models.ReconciliationDiscrepancy {
+	d := &models.ReconciliationDiscrepancy{
+		ID:           row.ID,
+		ReportID:     row.ReportID,
+		Kind:         models.DiscrepancyKind(row.Kind),
+		LedgerAmount: numericToDecimalPtr(row.LedgerAmount),
+		BankAmount:   numericToDecimalPtr(row.BankAmount),
+		Difference:   numericToDecimalPtr(row.Difference),
+		Detail:       row.Detail,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.LegalEntityID.Valid {
+		id := uuid.UUID(row.LegalEntityID.Bytes)
+		d.LegalEntityID = &id
+	}
+	if row.Currency.Valid {
+		d.Currency = &row.Currency.String
+	}
+	if row.TransferID.Valid {
+		id := uuid.UUID(row.TransferID.Bytes)
+		d.TransferID = &id
+	}
+	if row.BankBalanceAsOf.Valid {
+		d.BankBalanceAsOf = &row.BankBalanceAsOf.Time
+	}
+	return d
+}
+
+func decimalPtrToNumeric(d *decimal.Decimal) pgtype.Numeric {
+	if d == nil {
+		return pgtype.Numeric{}
+	}
+	return decimalToNumeric(*d)
+}
+
+func dateToPg(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+func datePtrToPg(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return dateToPg(*t)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// RefundRepository handles refunds of transfers.
+type RefundRepository struct {
+	q *queries.Queries
+}
+
+// NewRefundRepository creates a new refund repository.
+func NewRefundRepository(pool *pgxpool.Pool) *RefundRepository {
+	return &RefundRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *RefundRepository) WithTx(tx pgx.Tx) *RefundRepository {
+	return &RefundRepository{q: r.q.WithTx(tx)}
+}
+
+// Create records a pending refund.
+func (r *RefundRepository) Create(ctx context.Context, params models.CreateRefundParams) (*models.Refund, error) {
+	row, err := r.q.CreateRefund(ctx, queries.CreateRefundParams{
+		TransferID:     params.TransferID,
+		TenantID:       params.TenantID,
+		Seq:            int32(params.Seq),
+		Currency:       params.Currency,
+		Amount:         decimalToNumeric(params.Amount),
+		FeeAmount:      decimalToNumeric(params.FeeAmount),
+		DestCurrency:   params.DestCurrency,
+		DestAmount:     decimalToNumeric(params.DestAmount),
+		Reason:         params.Reason,
+		ReturnCode:     stringPtrToNullable(params.ReturnCode),
+		IdempotencyKey: stringPtrToNullable(params.IdempotencyKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return refundToModel(row), nil
+}
+
+// Get retrieves a refund by ID.
+func (r *RefundRepository) Get(ctx context.Context, id uuid.UUID) (*models.Refund, error) {
+	row, err := r.q.GetRefund(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return refundToModel(row), nil
+}
+
+// GetByIdempotencyKey retrieves the refund of a transfer with the given
+// idempotency key.
+func (r *RefundRepository) GetByIdempotencyKey(ctx context.Context, transferID uuid.UUID, key string) (*models.Refund, error) {
+	row, err := r.q.GetRefundByIdempotencyKey(ctx, queries.GetRefundByIdempotencyKeyParams{
+		TransferID:     transferID,
+		IdempotencyKey: pgtype.Text{String: key, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return refundToModel(row), nil
+}
+
+// Totals returns how much of a transfer has been refunded so far.
+func (r *RefundRepository) Totals(ctx context.Context, transferID uuid.UUID) (models.RefundTotals, error) {
+	row, err := r.q.GetRefundTotals(ctx, transferID)
+	if err != nil {
+		return models.RefundTotals{}, err
+	}
+	return models.RefundTotals{
+		Refunds:   int(row.Refunds),
+		Amount:    numericToDecimal(row.Amount),
+		FeeAmount: numericToDecimal(row.FeeAmount),
+	}, nil
+}
+
+// ListByTransfer returns the refunds of a transfer in order.
+func (r *RefundRepository) ListByTransfer(ctx context.Context, transferID uuid.UUID) ([]*models.Refund, error) {
+	rows, err := r.q.ListRefundsByTransfer(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Refund, len(rows))
+	for i, row := range rows {
+		result[i] = refundToModel(row)
+	}
+	return result, nil
+}
+
+// MarkPosted marks a pending refund posted. It returns false if the refund
+// was already posted.
+func (r *RefundRepository) MarkPosted(ctx context.Context, id uuid.UUID) (bool, error) {
+	n, err := r.q.MarkRefundPosted(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func refundToModel(row queries.Refund) *models.Refund {
+	rf := &models.Refund{
+		ID:           row.ID,
+		TransferID:   row.TransferID,
+		TenantID:     row.TenantID,
+		Seq:          int(row.Seq),
+		Currency:     row.Currency,
+		Amount:       numericToDecimal(row.Amount),
+		FeeAmount:    numericToDecimal(row.FeeAmount),
+		DestCurrency: row.DestCurrency,
+		DestAmount:   numericToDecimal(row.DestAmount),
+		Reason:       row.Reason,
+		Status:       models.RefundStatus(row.Status),
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.ReturnCode.Valid {
+		rf.ReturnCode = &row.ReturnCode.String
+	}
+	if row.IdempotencyKey.Valid {
+		rf.IdempotencyKey = &row.IdempotencyKey.String
+	}
+	if row.PostedAt.Valid {
+		rf.PostedAt = &row.PostedAt.Time
+	}
+	return rf
+}
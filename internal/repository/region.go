@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/db"
+	"kovra/internal/repository/queries"
+)
+
+// newQueries returns the queries of a repository over pool. Queries of a
+// context confined to a compliance region run as the region's database
+// role, so row level security hides the transfers of other regions.
+func newQueries(pool *pgxpool.Pool) *queries.Queries {
+	return queries.New(db.NewRegionPool(pool))
+}
@@ -20,7 +20,12 @@ type TenantRepository struct {
 
 // NewTenantRepository creates a new tenant repository.
 func NewTenantRepository(pool *pgxpool.Pool) *TenantRepository {
-	return &TenantRepository{q: queries.New(pool)}
+	return &TenantRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *TenantRepository) WithTx(tx pgx.Tx) *TenantRepository {
+	return &TenantRepository{q: r.q.WithTx(tx)}
 }
 
 // Create creates a new tenant.
@@ -58,6 +63,30 @@ func (r *TenantRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.T
 	return r.toModel(row), nil
 }
 
+// GetByIDForUpdate retrieves a tenant and locks it until the transaction ends.
+func (r *TenantRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Tenant, error) {
+	row, err := r.q.GetTenantByIDForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByAPIKeyHash retrieves the tenant that owns an API key.
+func (r *TenantRepository) GetByAPIKeyHash(ctx context.Context, apiKeyHash string) (*models.Tenant, error) {
+	row, err := r.q.GetTenantByAPIKeyHash(ctx, pgtype.Text{String: apiKeyHash, Valid: true})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
 // Update updates a tenant.
 func (r *TenantRepository) Update(ctx context.Context, id uuid.UUID, params models.UpdateTenantParams) (*models.Tenant, error) {
 	row, err := r.q.UpdateTenant(ctx, queries.UpdateTenantParams{
@@ -78,15 +107,52 @@ func (r *TenantRepository) Update(ctx context.Context, id uuid.UUID, params mode
 	return r.toModel(row), nil
 }
 
-// ListByLegalEntity retrieves tenants by legal entity.
-func (r *TenantRepository) ListByLegalEntity(ctx context.Context, legalEntityID uuid.UUID) ([]*models.Tenant, error) {
-	rows, err := r.q.ListTenantsByLegalEntity(ctx, legalEntityID)
+// SetWebhookSigningKey stores a tenant's sealed webhook signing key.
+func (r *TenantRepository) SetWebhookSigningKey(ctx context.Context, id uuid.UUID, sealedKey string) error {
+	return r.q.UpdateTenantWebhookSigningKey(ctx, queries.UpdateTenantWebhookSigningKeyParams{
+		ID:                id,
+		WebhookSigningKey: pgtype.Text{String: sealedKey, Valid: true},
+	})
+}
+
+// SealWebhookSigningKey replaces a tenant's plaintext webhook signing key
+// with its sealed form, unless the key was rotated meanwhile.
+func (r *TenantRepository) SealWebhookSigningKey(ctx context.Context, id uuid.UUID, plainKey, sealedKey string) error {
+	return r.q.SealTenantWebhookSigningKey(ctx, queries.SealTenantWebhookSigningKeyParams{
+		SealedKey: pgtype.Text{String: sealedKey, Valid: true},
+		ID:        id,
+		PlainKey:  pgtype.Text{String: plainKey, Valid: true},
+	})
+}
+
+// ListWithUnsealedWebhookKey retrieves the tenants whose webhook signing key
+// is still stored in plaintext.
+func (r *TenantRepository) ListWithUnsealedWebhookKey(ctx context.Context) ([]*models.Tenant, error) {
+	rows, err := r.q.ListTenantsWithUnsealedWebhookKey(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return r.toModels(rows), nil
 }
 
+// ListByLegalEntity retrieves a page of the tenants of a legal entity, newest
+// first.
+func (r *TenantRepository) ListByLegalEntity(ctx context.Context, legalEntityID uuid.UUID, filter models.TenantFilter) (models.Page[*models.Tenant], error) {
+	limit := pageLimit(filter.Limit)
+
+	rows, err := r.q.ListTenantsByLegalEntity(ctx, queries.ListTenantsByLegalEntityParams{
+		LegalEntityID: legalEntityID,
+		Limit:         int32(limit + 1),
+		After:         uuidToNullable(filter.After),
+		TenantStatus:  toNullTenantStatus(filter.Status),
+		Country:       stringPtrToNullable(filter.Country),
+	})
+	if err != nil {
+		return models.Page[*models.Tenant]{}, err
+	}
+	return newPage(r.toModels(rows), limit, func(t *models.Tenant) uuid.UUID { return t.ID }), nil
+}
+
 // ListByParent retrieves child tenants.
 func (r *TenantRepository) ListByParent(ctx context.Context, parentID uuid.UUID) ([]*models.Tenant, error) {
 	rows, err := r.q.ListTenantsByParent(ctx, pgtype.UUID{Bytes: parentID, Valid: true})
@@ -134,8 +200,8 @@ func (r *TenantRepository) toModel(row queries.Tenant) *models.Tenant {
 	if row.WebhookUrl.Valid {
 		t.WebhookURL = &row.WebhookUrl.String
 	}
-	if row.WebhookSecretHash.Valid {
-		t.WebhookSecretHash = &row.WebhookSecretHash.String
+	if row.WebhookSigningKey.Valid {
+		t.WebhookSigningKey = &row.WebhookSigningKey.String
 	}
 
 	return t
@@ -21,7 +21,12 @@ type TransferRepository struct {
 
 // NewTransferRepository creates a new transfer repository.
 func NewTransferRepository(pool *pgxpool.Pool) *TransferRepository {
-	return &TransferRepository{q: queries.New(pool)}
+	return &TransferRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *TransferRepository) WithTx(tx pgx.Tx) *TransferRepository {
+	return &TransferRepository{q: r.q.WithTx(tx)}
 }
 
 // Create creates a new transfer.
@@ -75,6 +80,66 @@ func (r *TransferRepository) GetByIdempotencyKey(ctx context.Context, tenantID u
 	return r.toModel(row), nil
 }
 
+// GetForUpdate retrieves a transfer and locks it until the transaction
+// ends.
+func (r *TransferRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*models.Transfer, error) {
+	row, err := r.q.GetTransferForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetByRailReferenceForUpdate retrieves the transfer a rail knows by the
+// given reference and locks it until the transaction ends.
+func (r *TransferRepository) GetByRailReferenceForUpdate(ctx context.Context, rail models.Rail, reference string) (*models.Transfer, error) {
+	row, err := r.q.GetTransferByRailReferenceForUpdate(ctx, queries.GetTransferByRailReferenceForUpdateParams{
+		Rail:          railToNullable(&rail),
+		RailReference: pgtype.Text{String: reference, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// GetRegion returns the compliance region of a transfer, or nil if the
+// database holds no such transfer.
+func (r *TransferRepository) GetRegion(ctx context.Context, id uuid.UUID) (*models.ComplianceRegion, error) {
+	region, err := r.q.GetTransferRegion(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cr := models.ComplianceRegion(region)
+	return &cr, nil
+}
+
+// GetRegionByRailReference returns the compliance region of the transfer a
+// rail knows by the given reference, or nil if the database holds none.
+func (r *TransferRepository) GetRegionByRailReference(ctx context.Context, rail models.Rail, reference string) (*models.ComplianceRegion, error) {
+	region, err := r.q.GetTransferRegionByRailReference(ctx, queries.GetTransferRegionByRailReferenceParams{
+		Rail:          railToNullable(&rail),
+		RailReference: pgtype.Text{String: reference, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cr := models.ComplianceRegion(region)
+	return &cr, nil
+}
+
 // UpdateStatus updates the transfer status.
 func (r *TransferRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransferStatus, failureReason *string) error {
 	return r.q.UpdateTransferStatus(ctx, queries.UpdateTransferStatusParams{
@@ -84,6 +149,30 @@ func (r *TransferRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 	})
 }
 
+// UpdateComplianceStatus records the screening outcome of a transfer.
+func (r *TransferRepository) UpdateComplianceStatus(ctx context.Context, id uuid.UUID, complianceStatus string, riskScore *int) error {
+	return r.q.UpdateTransferComplianceStatus(ctx, queries.UpdateTransferComplianceStatusParams{
+		ID:               id,
+		ComplianceStatus: complianceStatus,
+		RiskScore:        intToNullable(riskScore),
+	})
+}
+
+// UpdateRailReference records the reference the rail assigned to the
+// transfer's payout.
+func (r *TransferRepository) UpdateRailReference(ctx context.Context, id uuid.UUID, reference string) error {
+	return r.q.UpdateTransferRailReference(ctx, queries.UpdateTransferRailReferenceParams{
+		ID:            id,
+		RailReference: pgtype.Text{String: reference, Valid: true},
+	})
+}
+
+// AcknowledgeRail records that the rail accepted the transfer's payout. It
+// keeps the time of the first acknowledgement.
+func (r *TransferRepository) AcknowledgeRail(ctx context.Context, id uuid.UUID) error {
+	return r.q.AcknowledgeTransferRail(ctx, id)
+}
+
 // UpdateTBTransferIDs updates the TigerBeetle transfer IDs.
 func (r *TransferRepository) UpdateTBTransferIDs(ctx context.Context, id uuid.UUID, tbIDs []*big.Int) error {
 	numericIDs := make([]pgtype.Numeric, len(tbIDs))
@@ -97,33 +186,73 @@ func (r *TransferRepository) UpdateTBTransferIDs(ctx context.Context, id uuid.UU
 	})
 }
 
-// ListByTenant retrieves transfers for a tenant with filters.
-func (r *TransferRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, filter models.TransferFilter) ([]*models.Transfer, error) {
-	limit := filter.Limit
-	if limit <= 0 {
-		limit = 100
-	}
-	offset := filter.Offset
-	if offset < 0 {
-		offset = 0
-	}
+// RecordQuoteMargin records the margin on the quote of an FX transfer
+// against the current USD reference rates. Single-currency transfers record
+// nothing.
+func (r *TransferRepository) RecordQuoteMargin(ctx context.Context, id uuid.UUID) error {
+	return r.q.RecordFXQuoteMargin(ctx, id)
+}
+
+// ReleaseQuoteMargin drops the quote margin of a transfer cancelled before
+// it was paid out, so it counts towards no P&L.
+func (r *TransferRepository) ReleaseQuoteMargin(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteFXQuoteMargin(ctx, id)
+}
+
+// ListByTenant retrieves a page of a tenant's transfers, newest first.
+func (r *TransferRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, filter models.TransferFilter) (models.Page[*models.Transfer], error) {
+	limit := pageLimit(filter.Limit)
 
 	rows, err := r.q.ListTransfersByTenant(ctx, queries.ListTransfersByTenantParams{
 		TenantID:         tenantID,
-		Limit:            int32(limit),
-		Offset:           int32(offset),
+		Limit:            int32(limit + 1),
+		After:            uuidToNullable(filter.After),
+		CreatedFrom:      timeToNullable(filter.CreatedFrom),
+		CreatedTo:        timeToNullable(filter.CreatedTo),
 		Status:           transferStatusToNullable(filter.Status),
 		FromCurrency:     stringPtrToNullable(filter.FromCurrency),
 		ToCurrency:       stringPtrToNullable(filter.ToCurrency),
 		ComplianceRegion: complianceRegionToNullable(filter.ComplianceRegion),
+		ComplianceStatus: stringPtrToNullable(filter.ComplianceStatus),
+		Rail:             railToNullable(filter.Rail),
+		RecipientID:      uuidToNullable(filter.RecipientID),
+		BatchID:          uuidToNullable(filter.BatchID),
+		MinAmount:        decimalPtrToNumeric(filter.MinAmount),
+		MaxAmount:        decimalPtrToNumeric(filter.MaxAmount),
 		UpdatedAfter:     timeToNullable(filter.UpdatedAfter),
 		UpdatedBefore:    timeToNullable(filter.UpdatedBefore),
 	})
+	if err != nil {
+		return models.Page[*models.Transfer]{}, err
+	}
+
+	return newPage(r.toModels(rows), limit, func(t *models.Transfer) uuid.UUID { return t.ID }), nil
+}
+
+// ListActivitySince retrieves a tenant's transfers created since the given time, newest first.
+func (r *TransferRepository) ListActivitySince(ctx context.Context, tenantID uuid.UUID, since time.Time) ([]models.TransferActivity, error) {
+	rows, err := r.q.ListTransferActivity(ctx, queries.ListTransferActivityParams{
+		TenantID: tenantID,
+		Since:    pgtype.Timestamptz{Time: since, Valid: true},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.toModels(rows), nil
+	result := make([]models.TransferActivity, len(rows))
+	for i, row := range rows {
+		result[i] = models.TransferActivity{
+			ID:        row.ID,
+			Currency:  row.FromCurrency,
+			Amount:    numericToDecimal(row.FromAmount),
+			CreatedAt: uuidTime(row.ID),
+		}
+		if row.RecipientID.Valid {
+			id := uuid.UUID(row.RecipientID.Bytes)
+			result[i].RecipientID = &id
+		}
+	}
+	return result, nil
 }
 
 func (r *TransferRepository) toModel(row queries.Transfer) *models.Transfer {
@@ -176,6 +305,9 @@ func (r *TransferRepository) toModel(row queries.Transfer) *models.Transfer {
 	if row.RailReference.Valid {
 		t.RailReference = &row.RailReference.String
 	}
+	if row.RailAcknowledgedAt.Valid {
+		t.RailAcknowledgedAt = &row.RailAcknowledgedAt.Time
+	}
 	if row.NettingGroupID.Valid {
 		id := uuid.UUID(row.NettingGroupID.Bytes)
 		t.NettingGroupID = &id
@@ -252,3 +384,9 @@ func timeToNullable(t *time.Time) pgtype.Timestamptz {
 	}
 	return pgtype.Timestamptz{Time: *t, Valid: true}
 }
+
+// uuidTime returns the creation time encoded in a UUIDv7.
+func uuidTime(id uuid.UUID) time.Time {
+	sec, nsec := id.Time().UnixTime()
+	return time.Unix(sec, nsec).UTC()
+}
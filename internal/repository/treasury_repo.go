@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+)
+
+// TreasuryRepository handles treasury movements between system accounts.
+type TreasuryRepository struct {
+	q *queries.Queries
+}
+
+// NewTreasuryRepository creates a new treasury repository.
+func NewTreasuryRepository(pool *pgxpool.Pool) *TreasuryRepository {
+	return &TreasuryRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *TreasuryRepository) WithTx(tx pgx.Tx) *TreasuryRepository {
+	return &TreasuryRepository{q: r.q.WithTx(tx)}
+}
+
+// CreateMovement records a pending movement and its legs, each at the
+// current USD reference rate of its currency. It must run in a transaction.
+func (r *TreasuryRepository) CreateMovement(ctx context.Context, params models.CreateTreasuryMovementParams) (*models.TreasuryMovement, error) {
+	row, err := r.q.CreateTreasuryMovement(ctx, queries.CreateTreasuryMovementParams{
+		Kind:          string(params.Kind),
+		Reason:        params.Reason,
+		Counterparty:  stringPtrToNullable(params.Counterparty),
+		DealReference: stringPtrToNullable(params.DealReference),
+		RequestedBy:   params.RequestedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m := movementToModel(row)
+
+	for _, leg := range params.Legs {
+		legRow, err := r.q.CreateTreasuryMovementLeg(ctx, queries.CreateTreasuryMovementLegParams{
+			MovementID:    m.ID,
+			Seq:           int32(leg.Seq),
+			TbTransferID:  leg.TBTransferID,
+			Currency:      leg.Currency,
+			DebitAccount:  leg.DebitAccount,
+			CreditAccount: leg.CreditAccount,
+			Amount:        decimalToNumeric(leg.Amount),
+		})
+		if err != nil {
+			return nil, err
+		}
+		m.Legs = append(m.Legs, legToModel(legRow))
+	}
+
+	return m, nil
+}
+
+// GetMovement retrieves a movement with its legs.
+func (r *TreasuryRepository) GetMovement(ctx context.Context, id uuid.UUID) (*models.TreasuryMovement, error) {
+	row, err := r.q.GetTreasuryMovement(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withLegs(ctx, movementToModel(row))
+}
+
+// GetMovementForUpdate retrieves a movement with its legs and locks it
+// until the transaction ends.
+func (r *TreasuryRepository) GetMovementForUpdate(ctx context.Context, id uuid.UUID) (*models.TreasuryMovement, error) {
+	row, err := r.q.GetTreasuryMovementForUpdate(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.withLegs(ctx, movementToModel(row))
+}
+
+// GetMovementByDeal retrieves the booking of an FX deal, unless it was
+// rejected.
+func (r *TreasuryRepository) GetMovementByDeal(ctx context.Context, counterparty, dealReference string) (*models.TreasuryMovement, error) {
+	row, err := r.q.GetTreasuryMovementByDeal(ctx, queries.GetTreasuryMovementByDealParams{
+		Counterparty:  pgtype.Text{String: counterparty, Valid: true},
+		DealReference: pgtype.Text{String: dealReference, Valid: true},
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return movementToModel(row), nil
+}
+
+// ListMovements returns movements without their legs, newest first.
+func (r *TreasuryRepository) ListMovements(ctx context.Context, filter models.TreasuryMovementFilter) ([]*models.TreasuryMovement, error) {
+	params := queries.ListTreasuryMovementsParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+	}
+	if filter.Status != nil {
+		params.Status = pgtype.Text{String: string(*filter.Status), Valid: true}
+	}
+	if filter.Kind != nil {
+		params.Kind = pgtype.Text{String: string(*filter.Kind), Valid: true}
+	}
+
+	rows, err := r.q.ListTreasuryMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.TreasuryMovement, len(rows))
+	for i, row := range rows {
+		result[i] = movementToModel(row)
+	}
+	return result, nil
+}
+
+// DecideMovement records the approval or rejection of a movement.
+func (r *TreasuryRepository) DecideMovement(ctx context.Context, id uuid.UUID, status models.TreasuryMovementStatus, decidedBy string, reason *string) (*models.TreasuryMovement, error) {
+	row, err := r.q.DecideTreasuryMovement(ctx, queries.DecideTreasuryMovementParams{
+		ID:             id,
+		Status:         string(status),
+		DecidedBy:      pgtype.Text{String: decidedBy, Valid: true},
+		DecisionReason: stringPtrToNullable(reason),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withLegs(ctx, movementToModel(row))
+}
+
+// MarkPosted marks an approved movement as posted to the ledger.
+func (r *TreasuryRepository) MarkPosted(ctx context.Context, id uuid.UUID) error {
+	return r.q.MarkTreasuryMovementPosted(ctx, id)
+}
+
+func (r *TreasuryRepository) withLegs(ctx context.Context, m *models.TreasuryMovement) (*models.TreasuryMovement, error) {
+	rows, err := r.q.ListTreasuryMovementLegs(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Legs = make([]models.TreasuryMovementLeg, len(rows))
+	for i, row := range rows {
+		m.Legs[i] = legToModel(row)
+	}
+	return m, nil
+}
+
+func movementToModel(row queries.TreasuryMovement) *models.TreasuryMovement {
+	m := &models.TreasuryMovement{
+		ID:          row.ID,
+		Kind:        models.TreasuryMovementKind(row.Kind),
+		Status:      models.TreasuryMovementStatus(row.Status),
+		Reason:      row.Reason,
+		RequestedBy: row.RequestedBy,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+
+	if row.Counterparty.Valid {
+		m.Counterparty = &row.Counterparty.String
+	}
+	if row.DealReference.Valid {
+		m.DealReference = &row.DealReference.String
+	}
+	if row.DecidedBy.Valid {
+		m.DecidedBy = &row.DecidedBy.String
+	}
+	if row.DecisionReason.Valid {
+		m.DecisionReason = &row.DecisionReason.String
+	}
+	if row.DecidedAt.Valid {
+		m.DecidedAt = &row.DecidedAt.Time
+	}
+	if row.PostedAt.Valid {
+		m.PostedAt = &row.PostedAt.Time
+	}
+
+	return m
+}
+
+func legToModel(row queries.TreasuryMovementLeg) models.TreasuryMovementLeg {
+	return models.TreasuryMovementLeg{
+		Seq:           int(row.Seq),
+		TBTransferID:  row.TbTransferID,
+		Currency:      row.Currency,
+		DebitAccount:  row.DebitAccount,
+		CreditAccount: row.CreditAccount,
+		Amount:        numericToDecimal(row.Amount),
+		USDRate:       numericToDecimalPtr(row.UsdRate),
+	}
+}
@@ -21,7 +21,12 @@ type WalletRepository struct {
 
 // NewWalletRepository creates a new wallet repository.
 func NewWalletRepository(pool *pgxpool.Pool) *WalletRepository {
-	return &WalletRepository{q: queries.New(pool)}
+	return &WalletRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *WalletRepository) WithTx(tx pgx.Tx) *WalletRepository {
+	return &WalletRepository{q: r.q.WithTx(tx)}
 }
 
 // Create creates a new wallet.
@@ -74,6 +79,23 @@ func (r *WalletRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID)
 	return r.toModels(rows), nil
 }
 
+// ListPageByTenant retrieves a page of a tenant's wallets, newest first.
+func (r *WalletRepository) ListPageByTenant(ctx context.Context, tenantID uuid.UUID, filter models.WalletFilter) (models.Page[*models.Wallet], error) {
+	limit := pageLimit(filter.Limit)
+
+	rows, err := r.q.ListWalletsByTenantPage(ctx, queries.ListWalletsByTenantPageParams{
+		TenantID: tenantID,
+		Limit:    int32(limit + 1),
+		After:    uuidToNullable(filter.After),
+		Currency: stringPtrToNullable(filter.Currency),
+		Status:   stringPtrToNullable(filter.Status),
+	})
+	if err != nil {
+		return models.Page[*models.Wallet]{}, err
+	}
+	return newPage(r.toModels(rows), limit, func(w *models.Wallet) uuid.UUID { return w.ID }), nil
+}
+
 // UpdateCachedBalance updates the cached balance from TigerBeetle.
 func (r *WalletRepository) UpdateCachedBalance(ctx context.Context, id uuid.UUID, balance, pending decimal.Decimal) error {
 	return r.q.UpdateWalletCachedBalance(ctx, queries.UpdateWalletCachedBalanceParams{
@@ -91,6 +113,15 @@ func (r *WalletRepository) UpdateStatus(ctx context.Context, id uuid.UUID, statu
 	})
 }
 
+// UpdateFreeze sets the wallet status together with its ledger freeze transfer.
+func (r *WalletRepository) UpdateFreeze(ctx context.Context, id uuid.UUID, status string, freezeID *uuid.UUID) error {
+	return r.q.UpdateWalletFreeze(ctx, queries.UpdateWalletFreezeParams{
+		ID:             id,
+		Status:         status,
+		LedgerFreezeID: uuidToNullable(freezeID),
+	})
+}
+
 func (r *WalletRepository) toModel(row queries.Wallet) *models.Wallet {
 	w := &models.Wallet{
 		ID:            row.ID,
@@ -100,9 +131,15 @@ func (r *WalletRepository) toModel(row queries.Wallet) *models.Wallet {
 		CachedPending: numericToDecimal(row.CachedPending),
 		CachedAt:      row.CachedAt,
 		Status:        row.Status,
+		CreatedAt:     uuidTime(row.ID),
 		UpdatedAt:     row.UpdatedAt,
 	}
 
+	if row.LedgerFreezeID.Valid {
+		id := uuid.UUID(row.LedgerFreezeID.Bytes)
+		w.LedgerFreezeID = &id
+	}
+
 	// Convert TBAccountID
 	w.TBAccountID = numericToBigInt(row.TbAccountID)
 
@@ -150,6 +187,14 @@ func numericToDecimal(n pgtype.Numeric) decimal.Decimal {
 	return d
 }
 
+func numericToDecimalPtr(n pgtype.Numeric) *decimal.Decimal {
+	if !n.Valid {
+		return nil
+	}
+	d := numericToDecimal(n)
+	return &d
+}
+
 func numericToString(n pgtype.Numeric) string {
 	if !n.Valid {
 		return "0"
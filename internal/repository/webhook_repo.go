@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"kovra/internal/models"
+	"kovra/internal/repository/queries"
+	"kovra/internal/tracing"
+)
+
+// WebhookDeliveryRepository handles webhook delivery data access.
+type WebhookDeliveryRepository struct {
+	q *queries.Queries
+}
+
+// NewWebhookDeliveryRepository creates a new webhook delivery repository.
+func NewWebhookDeliveryRepository(pool *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{q: newQueries(pool)}
+}
+
+// WithTx returns a repository bound to the given transaction.
+func (r *WebhookDeliveryRepository) WithTx(tx pgx.Tx) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{q: r.q.WithTx(tx)}
+}
+
+// Create enqueues a webhook delivery, with the trace context of ctx for the
+// dispatcher to deliver it in. Duplicate events for a tenant are ignored.
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, params models.CreateWebhookDeliveryParams) error {
+	return r.q.CreateWebhookDelivery(ctx, queries.CreateWebhookDeliveryParams{
+		TenantID:     params.TenantID,
+		EventID:      params.EventID,
+		EventType:    string(params.EventType),
+		Url:          params.URL,
+		Payload:      params.Payload,
+		TraceContext: tracing.Inject(ctx),
+	})
+}
+
+// GetByID retrieves a webhook delivery by ID.
+func (r *WebhookDeliveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	row, err := r.q.GetWebhookDeliveryByID(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// ListByTenant retrieves the most recent webhook deliveries for a tenant.
+func (r *WebhookDeliveryRepository) ListByTenant(ctx context.Context, tenantID uuid.UUID, status *models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	var statusText pgtype.Text
+	if status != nil {
+		statusText = pgtype.Text{String: string(*status), Valid: true}
+	}
+
+	rows, err := r.q.ListWebhookDeliveriesByTenant(ctx, queries.ListWebhookDeliveriesByTenantParams{
+		TenantID: tenantID,
+		Limit:    int32(limit),
+		Status:   statusText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// ClaimDue leases up to batchSize deliveries that are due for an attempt.
+// Leased rows are invisible to other dispatchers until the lease expires.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, batchSize int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	rows, err := r.q.ClaimDueWebhookDeliveries(ctx, queries.ClaimDueWebhookDeliveriesParams{
+		LeaseSeconds: int32(lease.Seconds()),
+		BatchSize:    int32(batchSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toModels(rows), nil
+}
+
+// MarkDelivered marks a delivery as successfully delivered.
+func (r *WebhookDeliveryRepository) MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, statusCode int) error {
+	return r.q.MarkWebhookDeliveryDelivered(ctx, queries.MarkWebhookDeliveryDeliveredParams{
+		ID:             id,
+		Attempts:       int32(attempts),
+		LastStatusCode: pgtype.Int4{Int32: int32(statusCode), Valid: true},
+	})
+}
+
+// MarkFailed records a failed attempt and schedules the next one (or dead-letters it).
+func (r *WebhookDeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, status models.WebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, statusCode *int, lastError string) error {
+	return r.q.MarkWebhookDeliveryFailed(ctx, queries.MarkWebhookDeliveryFailedParams{
+		ID:             id,
+		Status:         string(status),
+		Attempts:       int32(attempts),
+		NextAttemptAt:  nextAttemptAt,
+		LastStatusCode: intToNullable(statusCode),
+		LastError:      pgtype.Text{String: lastError, Valid: lastError != ""},
+	})
+}
+
+// Replay re-queues a delivery for immediate redelivery.
+func (r *WebhookDeliveryRepository) Replay(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
+	row, err := r.q.ReplayWebhookDelivery(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toModel(row), nil
+}
+
+// CountPending counts the deliveries waiting for their next attempt.
+func (r *WebhookDeliveryRepository) CountPending(ctx context.Context) (int64, error) {
+	return r.q.CountPendingWebhookDeliveries(ctx)
+}
+
+// RecordAttempt appends an entry to the delivery log.
+func (r *WebhookDeliveryRepository) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt int, statusCode *int, errMsg string, duration time.Duration) error {
+	return r.q.CreateWebhookDeliveryAttempt(ctx, queries.CreateWebhookDeliveryAttemptParams{
+		DeliveryID: deliveryID,
+		Attempt:    int32(attempt),
+		StatusCode: intToNullable(statusCode),
+		Error:      pgtype.Text{String: errMsg, Valid: errMsg != ""},
+		DurationMs: int32(duration.Milliseconds()),
+	})
+}
+
+// ListAttempts retrieves the delivery log for a webhook delivery.
+func (r *WebhookDeliveryRepository) ListAttempts(ctx context.Context, deliveryID uuid.UUID) ([]*models.WebhookDeliveryAttempt, error) {
+	rows, err := r.q.ListWebhookDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.WebhookDeliveryAttempt, len(rows))
+	for i, row := range rows {
+		a := &models.WebhookDeliveryAttempt{
+			ID:          row.ID,
+			DeliveryID:  row.DeliveryID,
+			Attempt:     int(row.Attempt),
+			DurationMs:  int(row.DurationMs),
+			AttemptedAt: row.AttemptedAt,
+		}
+		if row.StatusCode.Valid {
+			code := int(row.StatusCode.Int32)
+			a.StatusCode = &code
+		}
+		if row.Error.Valid {
+			a.Error = &row.Error.String
+		}
+		result[i] = a
+	}
+	return result, nil
+}
+
+func (r *WebhookDeliveryRepository) toModel(row queries.WebhookDelivery) *models.WebhookDelivery {
+	d := &models.WebhookDelivery{
+		ID:            row.ID,
+		TenantID:      row.TenantID,
+		EventID:       row.EventID,
+		EventType:     models.WebhookEventType(row.EventType),
+		URL:           row.Url,
+		Payload:       row.Payload,
+		Status:        models.WebhookDeliveryStatus(row.Status),
+		Attempts:      int(row.Attempts),
+		NextAttemptAt: row.NextAttemptAt,
+		UpdatedAt:     row.UpdatedAt,
+		TraceContext:  row.TraceContext,
+	}
+
+	if row.LastStatusCode.Valid {
+		code := int(row.LastStatusCode.Int32)
+		d.LastStatusCode = &code
+	}
+	if row.LastError.Valid {
+		d.LastError = &row.LastError.String
+	}
+	if row.DeliveredAt.Valid {
+		d.DeliveredAt = &row.DeliveredAt.Time
+	}
+
+	return d
+}
+
+func (r *WebhookDeliveryRepository) toModels(rows []queries.WebhookDelivery) []*models.WebhookDelivery {
+	result := make([]*models.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		result[i] = r.toModel(row)
+	}
+	return result
+}
+
+func intToNullable(i *int) pgtype.Int4 {
+	if i == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*i), Valid: true}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"kovra/internal/metrics"
+)
+
+// Admin serves the operational endpoints, the Prometheus metrics, on a port
+// of their own, out of reach of API clients.
+type Admin struct {
+	httpServer *http.Server
+	logger     *zap.Logger
+}
+
+// NewAdmin creates a new admin HTTP server.
+func NewAdmin(port int, logger *zap.Logger) *Admin {
+	r := chi.NewRouter()
+	r.Handle("/metrics", metrics.Handler())
+
+	return &Admin{
+		httpServer: &http.Server{
+			Addr:         fmt.Sprintf(":%d", port),
+			Handler:      r,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Start starts the admin HTTP server.
+func (a *Admin) Start() error {
+	a.logger.Info("starting admin HTTP server", zap.String("addr", a.httpServer.Addr))
+	if err := a.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown gracefully shuts down the admin server.
+func (a *Admin) Shutdown(ctx context.Context) error {
+	return a.httpServer.Shutdown(ctx)
+}
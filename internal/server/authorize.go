@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+	"kovra/internal/handler"
+	"kovra/internal/repository"
+)
+
+// operatorsOnly is a middleware that serves a request only to operators.
+// Anonymous callers get a 401 and tenants a 403.
+func operatorsOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch auth.ActorFromContext(r.Context()).Type {
+		case auth.ActorTypeOperator:
+			next.ServeHTTP(w, r)
+		case auth.ActorTypeAnonymous:
+			handler.Unauthorized(w, "authentication required")
+		default:
+			handler.Forbidden(w, "only operators may perform this action")
+		}
+	})
+}
+
+// ownTenant is a middleware that serves a request about the tenant named by
+// the URL parameter param to operators and to that tenant only. The handler
+// reports an invalid ID.
+func ownTenant(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !permitsTenant(w, auth.ActorFromContext(r.Context()), id) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ownTransfer is a middleware that serves a request about the transfer named
+// by the URL parameter param to operators and to the transfer's tenant only.
+// It follows routeTransfer, so that the transfer is read from the database
+// holding it. The handler reports a missing transfer or an invalid ID.
+func ownTransfer(transferRepo *repository.TransferRepository, param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor := auth.ActorFromContext(r.Context())
+			id, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil || actor.Type == auth.ActorTypeOperator {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			transfer, err := transferRepo.GetByID(r.Context(), id)
+			if err != nil {
+				handler.InternalError(w, "failed to get transfer")
+				return
+			}
+			if transfer != nil && !permitsTenant(w, actor, transfer.TenantID) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// permitsTenant reports whether actor may act on the resources of a tenant,
+// and writes a 401 or 403 if not.
+func permitsTenant(w http.ResponseWriter, actor auth.Actor, tenantID uuid.UUID) bool {
+	switch {
+	case actor.Type == auth.ActorTypeOperator:
+		return true
+	case actor.Type == auth.ActorTypeAnonymous:
+		handler.Unauthorized(w, "authentication required")
+	case actor.Type == auth.ActorTypeTenant && actor.ID == tenantID.String():
+		return true
+	default:
+		handler.Forbidden(w, "not permitted for this tenant")
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"kovra/internal/audit"
+	"kovra/internal/auth"
+	"kovra/internal/handler"
+	"kovra/internal/repository"
+)
+
+// identify is a middleware that resolves the caller of a request for the
+// audit trail and for the checks of who may do what. Every request must carry
+// an "Authorization: Bearer <api key>" header, and acts as the tenant or
+// operator owning the key; a request without one, or with an unknown key, is
+// rejected. A regional operator's region is kept on the actor, for the
+// transfer-scoped routes to confine their queries to it. The client IP
+// is taken from RemoteAddr, which middleware.RealIP has already rewritten.
+func identify(tenantRepo *repository.TenantRepository, operatorRepo *repository.OperatorRepository) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				handler.Unauthorized(w, "missing authorization header")
+				return
+			}
+			key, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || strings.TrimSpace(key) == "" {
+				handler.Unauthorized(w, "invalid authorization header")
+				return
+			}
+
+			actor, err := authenticate(ctx, tenantRepo, operatorRepo, auth.HashAPIKey(strings.TrimSpace(key)))
+			if err != nil {
+				handler.InternalError(w, "failed to authenticate request")
+				return
+			}
+			if actor == nil {
+				handler.Unauthorized(w, "invalid API key")
+				return
+			}
+			ctx = auth.WithActor(ctx, *actor)
+
+			if ip, ok := clientIP(r.RemoteAddr); ok {
+				ctx = audit.WithClientIP(ctx, ip)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// authenticate returns the tenant or operator owning an API key, or nil if
+// the key is unknown.
+func authenticate(ctx context.Context, tenantRepo *repository.TenantRepository, operatorRepo *repository.OperatorRepository, keyHash string) (*auth.Actor, error) {
+	tenant, err := tenantRepo.GetByAPIKeyHash(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	if tenant != nil {
+		return &auth.Actor{Type: auth.ActorTypeTenant, ID: tenant.ID.String()}, nil
+	}
+
+	operator, err := operatorRepo.GetByAPIKeyHash(ctx, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	if operator == nil {
+		return nil, nil
+	}
+	actor := &auth.Actor{Type: auth.ActorTypeOperator, ID: operator.ID.String()}
+	if operator.ComplianceRegion != nil {
+		actor.Region = *operator.ComplianceRegion
+	}
+	return actor, nil
+}
+
+// clientIP parses RemoteAddr, which is host:port unless RealIP replaced it
+// with a bare address.
+func clientIP(remoteAddr string) (netip.Addr, bool) {
+	if addrPort, err := netip.ParseAddrPort(remoteAddr); err == nil {
+		return addrPort.Addr().Unmap(), true
+	}
+	if addr, err := netip.ParseAddr(remoteAddr); err == nil {
+		return addr.Unmap(), true
+	}
+	return netip.Addr{}, false
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"kovra/internal/auth"
+	"kovra/internal/db"
+	"kovra/internal/handler"
+	"kovra/internal/repository"
+)
+
+// routeTransfer is a middleware that routes the queries of a request about
+// the transfer named by the URL parameter param to the database holding it.
+// Those of a regional operator are confined to their region instead, so a
+// transfer of another region is not found. The handler reports a transfer
+// found in no database, or an invalid ID, as it would with a single
+// database.
+func routeTransfer(database *db.DB, transferRepo *repository.TransferRepository, param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if region := auth.ActorFromContext(r.Context()).Region; region != "" {
+				next.ServeHTTP(w, r.WithContext(database.Scope(r.Context(), region)))
+				return
+			}
+
+			id, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, err := database.Locate(r.Context(), func(ctx context.Context) (bool, error) {
+				region, err := transferRepo.GetRegion(ctx, id)
+				return region != nil, err
+			})
+			if err != nil {
+				handler.InternalError(w, "failed to locate transfer")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
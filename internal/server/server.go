@@ -2,47 +2,81 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"kovra/internal/audit"
 	"kovra/internal/cache"
+	"kovra/internal/compliance"
+	"kovra/internal/db"
+	"kovra/internal/export"
 	"kovra/internal/handler"
+	"kovra/internal/health"
+	"kovra/internal/jobs"
+	"kovra/internal/kyc"
 	"kovra/internal/ledger"
+	"kovra/internal/liquidity"
+	"kovra/internal/matching"
+	"kovra/internal/metrics"
+	"kovra/internal/rail"
+	"kovra/internal/reconciliation"
+	"kovra/internal/refund"
 	"kovra/internal/repository"
+	"kovra/internal/statement"
+	"kovra/internal/tracing"
+	"kovra/internal/treasury"
+	"kovra/internal/webhook"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Server represents the HTTP server.
 type Server struct {
-	httpServer   *http.Server
-	logger       *zap.Logger
-	pool         *pgxpool.Pool
-	ledgerClient *ledger.Client
-	cacheClient  *cache.Client
+	httpServer *http.Server
+	logger     *zap.Logger
+	health     *health.Checker
 }
 
 // Config holds server configuration.
 type Config struct {
-	Port         int
-	Pool         *pgxpool.Pool
-	LedgerClient *ledger.Client
-	CacheClient  *cache.Client
-	Logger       *zap.Logger
+	Port           int
+	DB             *db.DB
+	Pool           *pgxpool.Pool
+	LedgerClient   *ledger.Client
+	CacheClient    *cache.Client
+	Screener       *compliance.Screener
+	Cases          *compliance.CaseService
+	KYC            *kyc.Service
+	Trail          *audit.Trail
+	Reconciliation *reconciliation.Service
+	Statements     *statement.Importer
+	Matching       *matching.Service
+	Liquidity      *liquidity.Service
+	Treasury       *treasury.Service
+	Refunds        *refund.Service
+	Rails          *rail.Service
+	Exporter       *export.Exporter
+	WebhookKeys    *webhook.KeyCipher
+	Jobs           *jobs.Client
+	Health         *health.Checker
+	Logger         *zap.Logger
 }
 
 // New creates a new HTTP server.
 func New(cfg Config) *Server {
 	s := &Server{
-		logger:       cfg.Logger,
-		pool:         cfg.Pool,
-		ledgerClient: cfg.LedgerClient,
-		cacheClient:  cfg.CacheClient,
+		logger: cfg.Logger,
+		health: cfg.Health,
 	}
 
 	// Create repositories
@@ -50,12 +84,44 @@ func New(cfg Config) *Server {
 	tenantRepo := repository.NewTenantRepository(cfg.Pool)
 	walletRepo := repository.NewWalletRepository(cfg.Pool)
 	transferRepo := repository.NewTransferRepository(cfg.Pool)
+	webhookRepo := repository.NewWebhookDeliveryRepository(cfg.Pool)
+	outboxRepo := repository.NewOutboxRepository(cfg.Pool)
+	recipientRepo := repository.NewRecipientRepository(cfg.Pool)
+	complianceLogRepo := repository.NewComplianceLogRepository(cfg.Pool)
+	complianceCaseRepo := repository.NewComplianceCaseRepository(cfg.Pool)
+	monitoringAlertRepo := repository.NewMonitoringAlertRepository(cfg.Pool)
+	kycRepo := repository.NewKYCRepository(cfg.Pool)
+	auditRepo := repository.NewAuditRepository(cfg.Pool)
+	reconciliationRepo := repository.NewReconciliationRepository(cfg.Pool)
+	bankStatementRepo := repository.NewBankStatementRepository(cfg.Pool)
+	expectedDepositRepo := repository.NewExpectedDepositRepository(cfg.Pool)
+	liquidityRepo := repository.NewLiquidityRepository(cfg.Pool)
+	fxExposureRepo := repository.NewFXExposureRepository(cfg.Pool)
+	operatorRepo := repository.NewOperatorRepository(cfg.Pool)
 
 	// Create handlers
 	legalEntityHandler := handler.NewLegalEntityHandler(legalEntityRepo)
-	tenantHandler := handler.NewTenantHandler(tenantRepo)
-	walletHandler := handler.NewWalletHandler(walletRepo, cfg.LedgerClient)
-	transferHandler := handler.NewTransferHandler(transferRepo, walletRepo)
+	tenantHandler := handler.NewTenantHandler(cfg.DB, tenantRepo, cfg.Trail)
+	walletHandler := handler.NewWalletHandler(cfg.DB, walletRepo, cfg.LedgerClient, cfg.KYC, cfg.Trail)
+	transferHandler := handler.NewTransferHandler(cfg.DB, transferRepo, walletRepo, outboxRepo, liquidityRepo, cfg.Jobs, cfg.KYC, cfg.Trail)
+	webhookHandler := handler.NewWebhookHandler(cfg.DB, webhookRepo, tenantRepo, cfg.WebhookKeys, cfg.Trail)
+	outboxHandler := handler.NewOutboxHandler(cfg.DB, outboxRepo)
+	recipientHandler := handler.NewRecipientHandler(cfg.DB, recipientRepo, cfg.Trail)
+	complianceHandler := handler.NewComplianceHandler(cfg.DB, complianceLogRepo, cfg.Screener, cfg.Trail)
+	complianceCaseHandler := handler.NewComplianceCaseHandler(cfg.DB, cfg.Cases, complianceCaseRepo, complianceLogRepo)
+	monitoringHandler := handler.NewMonitoringHandler(monitoringAlertRepo)
+	kycHandler := handler.NewKYCHandler(cfg.KYC, kycRepo)
+	auditHandler := handler.NewAuditHandler(cfg.DB, auditRepo)
+	reconciliationHandler := handler.NewReconciliationHandler(cfg.Reconciliation, reconciliationRepo, cfg.Jobs)
+	bankStatementHandler := handler.NewBankStatementHandler(cfg.Statements, bankStatementRepo)
+	statementMatchHandler := handler.NewStatementMatchHandler(cfg.Matching, bankStatementRepo, cfg.Jobs)
+	depositHandler := handler.NewDepositHandler(cfg.Matching, expectedDepositRepo)
+	liquidityHandler := handler.NewLiquidityHandler(cfg.Liquidity, liquidityRepo, cfg.Jobs)
+	fxExposureHandler := handler.NewFXExposureHandler(cfg.Treasury, fxExposureRepo)
+	treasuryHandler := handler.NewTreasuryHandler(cfg.Treasury)
+	refundHandler := handler.NewRefundHandler(cfg.Refunds, transferRepo)
+	railHandler := handler.NewRailHandler(cfg.Rails)
+	exportHandler := handler.NewExportHandler(cfg.Exporter)
 
 	// Setup chi router
 	r := chi.NewRouter()
@@ -63,37 +129,146 @@ func New(cfg Config) *Server {
 	// Middleware
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(traceRequests)
 	r.Use(s.zapLogger)
+	r.Use(instrument)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(30 * time.Second))
+	r.Use(timeout(30 * time.Second))
 
 	// Health check endpoints
 	r.Get("/health", s.healthCheck)
+	r.Get("/live", s.liveCheck)
 	r.Get("/ready", s.readyCheck)
 
 	// API v1 routes
 	r.Route("/api/v1", func(r chi.Router) {
-		// Legal Entities (read-only)
-		r.Get("/legal-entities", legalEntityHandler.List)
-		r.Get("/legal-entities/{id}", legalEntityHandler.Get)
-		r.Get("/legal-entities/code/{code}", legalEntityHandler.GetByCode)
-		r.Get("/legal-entities/{id}/tenants", tenantHandler.ListByLegalEntity)
-
-		// Tenants
-		r.Post("/tenants", tenantHandler.Create)
-		r.Get("/tenants/{id}", tenantHandler.Get)
-		r.Patch("/tenants/{id}", tenantHandler.Update)
-		r.Get("/tenants/{id}/wallets", walletHandler.ListByTenant)
-		r.Get("/tenants/{id}/transfers", transferHandler.ListByTenant)
-
-		// Wallets
-		r.Post("/wallets", walletHandler.Create)
-		r.Get("/wallets/{id}", walletHandler.Get)
-		r.Get("/wallets/{id}/balance", walletHandler.GetBalance)
-
-		// Transfers
-		r.Post("/transfers", transferHandler.Create)
-		r.Get("/transfers/{id}", transferHandler.Get)
+		// Payout status callbacks from the rails, which carry no API key and
+		// are authenticated by their signature instead. This is the only
+		// route under /api/v1 served without credentials.
+		r.Post("/rails/{rail}/callbacks", railHandler.Callback)
+
+		r.Group(func(r chi.Router) {
+			r.Use(identify(tenantRepo, operatorRepo))
+
+			// Legal Entities (read-only)
+			r.Get("/legal-entities", legalEntityHandler.List)
+			r.Get("/legal-entities/{id}", legalEntityHandler.Get)
+			r.Get("/legal-entities/code/{code}", legalEntityHandler.GetByCode)
+
+			// Tenants, served to operators and to the tenant itself
+			r.With(ownTenant("id")).Route("/tenants/{id}", func(r chi.Router) {
+				r.Get("/", tenantHandler.Get)
+				r.Patch("/", tenantHandler.Update)
+				r.Get("/wallets", walletHandler.ListByTenant)
+				r.Get("/transfers", transferHandler.ListByTenant)
+				r.Get("/webhook-deliveries", webhookHandler.ListByTenant)
+				r.Post("/webhook-secret", webhookHandler.RotateSecret)
+				r.Get("/recipients", recipientHandler.ListByTenant)
+				r.Get("/deposits", depositHandler.ListByTenant)
+				r.Post("/status", kycHandler.SetStatus)
+				r.Get("/status-history", kycHandler.ListStatusChanges)
+				r.Post("/kyc/submissions", kycHandler.Submit)
+				r.Get("/kyc/submissions", kycHandler.ListByTenant)
+			})
+
+			// KYC review
+			r.Get("/kyc/submissions/{id}", kycHandler.Get)
+			r.Post("/kyc/submissions/{id}/decision", kycHandler.Decide)
+			r.Get("/kyc/tier-limits", kycHandler.ListTierLimits)
+
+			// Wallets
+			r.Post("/wallets", walletHandler.Create)
+			r.Get("/wallets/{id}", walletHandler.Get)
+			r.Get("/wallets/{id}/balance", walletHandler.GetBalance)
+
+			// Transfers
+			r.Post("/transfers", transferHandler.Create)
+			r.Get("/transfers/export", exportHandler.Transfers)
+			r.With(routeTransfer(cfg.DB, transferRepo, "id"), ownTransfer(transferRepo, "id")).Route("/transfers/{id}", func(r chi.Router) {
+				r.Get("/", transferHandler.Get)
+				r.Post("/cancel", transferHandler.Cancel)
+				r.With(operatorsOnly).Get("/compliance-logs", complianceHandler.ListTransferLogs)
+				r.Post("/refunds", refundHandler.Create)
+				r.Get("/refunds", refundHandler.ListByTransfer)
+				r.Get("/rail-callbacks", railHandler.ListByTransfer)
+			})
+
+			// Recipients
+			r.Post("/recipients", recipientHandler.Create)
+			r.Get("/recipients/{id}", recipientHandler.Get)
+
+			// Webhook deliveries
+			r.Get("/webhook-deliveries/{id}", webhookHandler.Get)
+			r.Post("/webhook-deliveries/{id}/replay", webhookHandler.Replay)
+
+			// Expected deposits
+			r.Post("/deposits", depositHandler.Create)
+			r.Get("/deposits/{id}", depositHandler.Get)
+			r.Post("/deposits/{id}/cancel", depositHandler.Cancel)
+
+			// Operator routes
+			r.Group(func(r chi.Router) {
+				r.Use(operatorsOnly)
+
+				r.Post("/tenants", tenantHandler.Create)
+				r.Get("/legal-entities/{id}/tenants", tenantHandler.ListByLegalEntity)
+
+				// Compliance review queue
+				r.Get("/compliance/cases", complianceCaseHandler.List)
+				r.Get("/compliance/cases/{id}", complianceCaseHandler.Get)
+				r.Post("/compliance/cases/{id}/assign", complianceCaseHandler.Assign)
+				r.Post("/compliance/cases/{id}/notes", complianceCaseHandler.AddNote)
+				r.Post("/compliance/cases/{id}/decision", complianceCaseHandler.Decide)
+				r.Get("/compliance/alerts", monitoringHandler.ListAlerts)
+
+				// Admin
+				r.Get("/admin/outbox/stats", outboxHandler.Stats)
+				r.Get("/admin/sanctions/lists", complianceHandler.ListSanctionsLists)
+				r.Post("/admin/sanctions/reload", complianceHandler.ReloadSanctionsLists)
+
+				// Audit trail
+				r.Get("/audit/entries", auditHandler.List)
+				r.Get("/audit/chains", auditHandler.ListChains)
+				r.Get("/audit/chains/{region}/verify", auditHandler.Verify)
+
+				// Ledger reconciliation
+				r.Get("/reconciliation/reports", reconciliationHandler.ListReports)
+				r.Post("/reconciliation/reports", reconciliationHandler.RunReport)
+				r.Get("/reconciliation/reports/{id}", reconciliationHandler.GetReport)
+				r.Post("/reconciliation/reports/{id}/sign-off", reconciliationHandler.SignOffReport)
+				r.Post("/reconciliation/bank-balances", reconciliationHandler.RecordBankBalance)
+				r.Post("/reconciliation/statements", bankStatementHandler.Upload)
+				r.Get("/reconciliation/statements", bankStatementHandler.List)
+				r.Get("/reconciliation/statements/{id}", bankStatementHandler.Get)
+				r.Get("/reconciliation/statement-entries", statementMatchHandler.ListQueue)
+				r.Post("/reconciliation/statement-entries/match", statementMatchHandler.RunMatching)
+				r.Get("/reconciliation/statement-entries/{id}", statementMatchHandler.GetEntry)
+				r.Post("/reconciliation/statement-entries/{id}/match", statementMatchHandler.MatchEntry)
+
+				// Settlement liquidity
+				r.Get("/liquidity/positions", liquidityHandler.ListPositions)
+				r.Post("/liquidity/settlements", liquidityHandler.ConfigureSettlement)
+				r.Get("/liquidity/alerts", liquidityHandler.ListAlerts)
+				r.Get("/liquidity/top-ups", liquidityHandler.ListTopUps)
+				r.Post("/liquidity/top-ups/{id}/decision", liquidityHandler.DecideTopUp)
+				r.Get("/liquidity/holds", liquidityHandler.ListHolds)
+				r.Post("/liquidity/check", liquidityHandler.RunCheck)
+
+				// FX exposure
+				r.Get("/fx/exposure", fxExposureHandler.Get)
+				r.Get("/fx/exposure/snapshots", fxExposureHandler.ListSnapshots)
+				r.Post("/fx/exposure/snapshots", fxExposureHandler.TakeSnapshot)
+				r.Get("/fx/exposure/snapshots/{id}", fxExposureHandler.GetSnapshot)
+				r.With(routeTransfer(cfg.DB, transferRepo, "transferID")).Get("/fx/quote-margins/{transferID}", fxExposureHandler.GetQuoteMargin)
+
+				// Treasury movements between system accounts
+				r.Post("/treasury/rebalances", treasuryHandler.RequestRebalance)
+				r.Post("/treasury/fx-deals", treasuryHandler.RequestFXDeal)
+				r.Get("/treasury/movements", treasuryHandler.ListMovements)
+				r.Get("/treasury/movements/{id}", treasuryHandler.GetMovement)
+				r.Post("/treasury/movements/{id}/decision", treasuryHandler.DecideMovement)
+			})
+		})
 	})
 
 	s.httpServer = &http.Server{
@@ -116,6 +291,11 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// Handler returns the server's HTTP handler, for serving requests in tests.
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
 // Shutdown gracefully shuts down the server.
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("shutting down HTTP server")
@@ -129,31 +309,87 @@ func (s *Server) healthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(`{"status":"healthy"}`))
 }
 
-// readyCheck returns readiness status (all dependencies available).
+// liveCheck reports that the process is serving requests, without checking
+// its dependencies, so that an outage of one does not get it restarted.
+func (s *Server) liveCheck(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"status":"alive"}`))
+}
+
+// readyCheck reports the status and latency of each dependency, and is
+// unavailable while a critical one is down. A degraded service stays ready.
 func (s *Server) readyCheck(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	// Check PostgreSQL
-	if err := s.pool.Ping(ctx); err != nil {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusServiceUnavailable)
-		w.Write([]byte(`{"status":"not ready","reason":"database unavailable"}`))
-		return
+	report := s.health.Check(r.Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
 	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
 
-	// Check Redis
-	if s.cacheClient != nil {
-		if err := s.cacheClient.Ping(ctx); err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"not ready","reason":"cache unavailable"}`))
-			return
-		}
+// timeout cancels the context of a request after d, as middleware.Timeout
+// does, except for exports, which stream for as long as the client keeps
+// reading.
+func timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		limited := middleware.Timeout(d)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/export") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			limited.ServeHTTP(w, r)
+		})
 	}
+}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"status":"ready"}`))
+// traceRequests is a middleware that serves each request in a span,
+// continuing the trace of the caller's traceparent header if any. The span is
+// named by route pattern once the route is matched.
+func traceRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tracing.ExtractHeaders(r.Context(), r.Header)
+		ctx, span := tracing.Tracer().Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)),
+		)
+		defer span.End()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			span.SetName(r.Method + " " + rctx.RoutePattern())
+			span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(ww.Status()))
+		if ww.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(ww.Status()))
+		}
+	})
+}
+
+// instrument is a middleware that records the rate, errors and duration of
+// requests by route pattern. Requests matching no route share the pattern
+// "unmatched".
+func instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		endpoint := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			endpoint = rctx.RoutePattern()
+		}
+		metrics.ObserveHTTPRequest(endpoint, r.Method, ww.Status(), time.Since(start))
+	})
 }
 
 // zapLogger is a middleware that logs requests using zap.
@@ -164,6 +400,7 @@ func (s *Server) zapLogger(next http.Handler) http.Handler {
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 		next.ServeHTTP(ww, r)
 
+		sc := trace.SpanContextFromContext(r.Context())
 		s.logger.Info("request",
 			zap.String("method", r.Method),
 			zap.String("path", r.URL.Path),
@@ -171,6 +408,8 @@ func (s *Server) zapLogger(next http.Handler) http.Handler {
 			zap.Int("bytes", ww.BytesWritten()),
 			zap.Duration("duration", time.Since(start)),
 			zap.String("request_id", middleware.GetReqID(r.Context())),
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
 		)
 	})
 }
@@ -0,0 +1,30 @@
+package statement
+
+import (
+	"kovra/internal/models"
+)
+
+// MatchAccount finds the FBO or Nostro account a statement is for, by IBAN
+// or, when the statement has none, by account number.
+func MatchAccount(entities []*models.LegalEntity, st *Statement) (*models.LegalEntity, models.BankAccountKind, bool) {
+	for _, le := range entities {
+		fbo, nostro := le.FBOAccountNumber, le.NostroAccountNumber
+		account := st.AccountNumber
+		if st.AccountIBAN != "" {
+			fbo, nostro = le.FBOAccountIBAN, le.NostroAccountIBAN
+			account = st.AccountIBAN
+		}
+
+		switch {
+		case sameAccount(account, fbo):
+			return le, models.BankAccountFBO, true
+		case sameAccount(account, nostro):
+			return le, models.BankAccountNostro, true
+		}
+	}
+	return nil, "", false
+}
+
+func sameAccount(account string, configured *string) bool {
+	return account != "" && configured != nil && normaliseAccount(*configured) == account
+}
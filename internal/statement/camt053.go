@@ -0,0 +1,273 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// camt.053 elements read by the parser. Namespaces are ignored so that any
+// version of the message (camt.053.001.02 onwards) parses.
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	ID      string `xml:"Id"`
+	Created string `xml:"CreDtTm"`
+	FromTo  struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account struct {
+		IBAN     string `xml:"Id>IBAN"`
+		Other    string `xml:"Id>Othr>Id"`
+		Currency string `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []camtBalance `xml:"Bal"`
+	Entries  []camtEntry   `xml:"Ntry"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+// camtStatus is the entry status: a code in camt.053.001.02, a Cd element
+// from .08 on.
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtEntry struct {
+	Amount        camtAmount `xml:"Amt"`
+	Indicator     string     `xml:"CdtDbtInd"`
+	Status        camtStatus `xml:"Sts"`
+	BookingDate   camtDate   `xml:"BookgDt"`
+	ValueDate     camtDate   `xml:"ValDt"`
+	BankReference string     `xml:"AcctSvcrRef"`
+	Info          string     `xml:"AddtlNtryInf"`
+	Details       []camtTx   `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTx struct {
+	EndToEndID string   `xml:"Refs>EndToEndId"`
+	Remittance []string `xml:"RmtInf>Ustrd"`
+	// Party names moved under Pty in camt.053.001.08
+	DebtorName    string `xml:"RltdPties>Dbtr>Nm"`
+	DebtorPtyName string `xml:"RltdPties>Dbtr>Pty>Nm"`
+	CreditorName  string `xml:"RltdPties>Cdtr>Nm"`
+	CreditorPty   string `xml:"RltdPties>Cdtr>Pty>Nm"`
+}
+
+// ParseCAMT053 parses an ISO 20022 camt.053 bank-to-customer statement.
+// Only booked entries are returned; pending entries have not moved money.
+func ParseCAMT053(data []byte) ([]*Statement, error) {
+	var doc camtDocument
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, invalid("camt.053: %v", err)
+	}
+	if len(doc.Statements) == 0 {
+		return nil, invalid("camt.053: no statements")
+	}
+
+	out := make([]*Statement, 0, len(doc.Statements))
+	for i, s := range doc.Statements {
+		st, err := s.toStatement()
+		if err != nil {
+			return nil, invalid("camt.053 statement %d: %v", i+1, err)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+func (s camtStatement) toStatement() (*Statement, error) {
+	st := &Statement{
+		ID:          strings.TrimSpace(s.ID),
+		AccountIBAN: normaliseAccount(s.Account.IBAN),
+		Currency:    strings.TrimSpace(s.Account.Currency),
+	}
+	if st.AccountIBAN == "" {
+		st.AccountNumber = normaliseAccount(s.Account.Other)
+	}
+	if st.Account() == "" {
+		return nil, errors.New("no account")
+	}
+
+	for _, b := range s.Balances {
+		amount, err := signedAmount(b.Amount.Value, b.Indicator)
+		if err != nil {
+			return nil, err
+		}
+		if st.Currency == "" {
+			st.Currency = b.Amount.Currency
+		}
+		date, err := b.Date.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		switch b.Type {
+		case "OPBD", "PRCD":
+			st.OpeningBalance = &amount
+			if st.From.IsZero() && !date.IsZero() {
+				st.From = date
+			}
+		case "CLBD":
+			st.ClosingBalance = &amount
+			st.To = date
+		}
+	}
+
+	if st.From.IsZero() {
+		st.From, _ = parseISODate(s.FromTo.From)
+	}
+	if st.To.IsZero() {
+		st.To, _ = parseISODate(s.FromTo.To)
+	}
+	if st.To.IsZero() {
+		st.To, _ = parseISODate(s.Created)
+	}
+	if st.To.IsZero() {
+		return nil, errors.New("no statement date")
+	}
+	if st.Currency == "" {
+		return nil, errors.New("no currency")
+	}
+
+	for j, e := range s.Entries {
+		status := strings.TrimSpace(e.Status.Code)
+		if status == "" {
+			status = strings.TrimSpace(e.Status.Text)
+		}
+		if status != "" && status != "BOOK" {
+			continue
+		}
+
+		entry, err := e.toEntry(st.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %v", j+1, err)
+		}
+		st.Entries = append(st.Entries, entry)
+	}
+	return st, nil
+}
+
+func (e camtEntry) toEntry(currency string) (Entry, error) {
+	amount, err := signedAmount(e.Amount.Value, e.Indicator)
+	if err != nil {
+		return Entry{}, err
+	}
+	if e.Amount.Currency != "" {
+		currency = e.Amount.Currency
+	}
+
+	booking, err := e.BookingDate.parse()
+	if err != nil {
+		return Entry{}, err
+	}
+	value, err := e.ValueDate.parse()
+	if err != nil {
+		return Entry{}, err
+	}
+	if value.IsZero() {
+		value = booking
+	}
+	if value.IsZero() {
+		return Entry{}, errors.New("no value or booking date")
+	}
+
+	entry := Entry{
+		Amount:        amount,
+		Currency:      currency,
+		ValueDate:     value,
+		BookingDate:   booking,
+		BankReference: strings.TrimSpace(e.BankReference),
+		Reference:     strings.TrimSpace(e.Info),
+	}
+
+	// Batch bookings carry several transactions; only a single one says who
+	// the counterparty is.
+	if len(e.Details) == 1 {
+		tx := e.Details[0]
+		switch {
+		case tx.EndToEndID != "" && tx.EndToEndID != "NOTPROVIDED":
+			entry.Reference = strings.TrimSpace(tx.EndToEndID)
+		case len(tx.Remittance) > 0:
+			entry.Reference = strings.TrimSpace(strings.Join(tx.Remittance, " "))
+		}
+
+		// The counterparty of a credit is the debtor, of a debit the creditor
+		if amount.IsPositive() {
+			entry.Counterparty = firstNonEmpty(tx.DebtorName, tx.DebtorPtyName)
+		} else {
+			entry.Counterparty = firstNonEmpty(tx.CreditorName, tx.CreditorPty)
+		}
+	}
+	return entry, nil
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	s := firstNonEmpty(d.Date, d.DateTime)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := parseISODate(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q", s)
+	}
+	return t, nil
+}
+
+// parseISODate parses the date part of an ISO 8601 date or date-time.
+func parseISODate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) > 10 {
+		s = s[:10]
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// signedAmount parses an unsigned amount and applies a CRDT/DBIT indicator.
+func signedAmount(value, indicator string) (decimal.Decimal, error) {
+	amount, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("amount %q", value)
+	}
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return amount, nil
+	case "DBIT":
+		return amount.Neg(), nil
+	default:
+		return decimal.Zero, fmt.Errorf("credit/debit indicator %q", indicator)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,317 @@
+package statement
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed csv_layouts.yaml
+var defaultLayouts []byte
+
+// Layout describes the columns of a bank's CSV export.
+type Layout struct {
+	Delimiter    string  `yaml:"delimiter"`
+	SkipRows     int     `yaml:"skip_rows"`
+	DateFormat   string  `yaml:"date_format"`
+	DecimalComma bool    `yaml:"decimal_comma"`
+	NewestFirst  bool    `yaml:"newest_first"`
+	Currency     string  `yaml:"currency"`
+	Columns      Columns `yaml:"columns"`
+}
+
+// Columns maps statement fields to CSV header names.
+type Columns struct {
+	ValueDate     string `yaml:"value_date"`
+	BookingDate   string `yaml:"booking_date"`
+	Amount        string `yaml:"amount"`
+	Credit        string `yaml:"credit"`
+	Debit         string `yaml:"debit"`
+	Currency      string `yaml:"currency"`
+	Reference     string `yaml:"reference"`
+	Counterparty  string `yaml:"counterparty"`
+	BankReference string `yaml:"bank_reference"`
+	Account       string `yaml:"account"`
+	Balance       string `yaml:"balance"`
+}
+
+// Layouts are the configured CSV layouts by name.
+type Layouts struct {
+	Layouts map[string]*Layout `yaml:"layouts"`
+}
+
+// Get returns a layout by name.
+func (l *Layouts) Get(name string) (*Layout, bool) {
+	layout, ok := l.Layouts[name]
+	return layout, ok
+}
+
+// DefaultLayouts returns the built-in layouts.
+func DefaultLayouts() (*Layouts, error) {
+	return ParseLayouts(defaultLayouts)
+}
+
+// LoadLayouts reads layouts from a YAML file, or the built-in layouts if
+// path is empty.
+func LoadLayouts(path string) (*Layouts, error) {
+	if path == "" {
+		return DefaultLayouts()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open csv layouts: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read csv layouts: %w", err)
+	}
+	return ParseLayouts(data)
+}
+
+// ParseLayouts parses and validates YAML layouts.
+func ParseLayouts(data []byte) (*Layouts, error) {
+	var layouts Layouts
+	if err := yaml.Unmarshal(data, &layouts); err != nil {
+		return nil, fmt.Errorf("parse csv layouts: %w", err)
+	}
+
+	var errs []error
+	for name, l := range layouts.Layouts {
+		if err := l.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("layout %s: %w", name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return &layouts, nil
+}
+
+func (l *Layout) validate() error {
+	if l.Delimiter == "" {
+		l.Delimiter = ","
+	}
+	if l.DateFormat == "" {
+		l.DateFormat = time.DateOnly
+	}
+
+	switch {
+	case len([]rune(l.Delimiter)) != 1:
+		return errors.New("delimiter must be a single character")
+	case l.Columns.ValueDate == "":
+		return errors.New("value_date column is required")
+	case l.Columns.Amount == "" && (l.Columns.Credit == "" || l.Columns.Debit == ""):
+		return errors.New("amount column, or credit and debit columns, are required")
+	case l.Columns.Currency == "" && l.Currency == "":
+		return errors.New("currency column or currency is required")
+	}
+	return nil
+}
+
+// ParseCSV parses a CSV statement export. The file is one statement. When
+// the layout has no account column, the account is given on import.
+func ParseCSV(data []byte, layout *Layout) ([]*Statement, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = []rune(layout.Delimiter)[0]
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, invalid("csv: %v", err)
+	}
+	if len(records) <= layout.SkipRows {
+		return nil, invalid("csv: no header row")
+	}
+
+	header := make(map[string]int)
+	for i, name := range records[layout.SkipRows] {
+		header[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	cols, err := layout.Columns.resolve(header)
+	if err != nil {
+		return nil, invalid("csv: %v", err)
+	}
+
+	st := &Statement{}
+
+	var closing *decimal.Decimal
+	for n, record := range records[layout.SkipRows+1:] {
+		if isBlank(record) {
+			continue
+		}
+		line := layout.SkipRows + n + 2
+
+		entry, balance, account, err := layout.parseRow(cols, record)
+		if err != nil {
+			return nil, invalid("csv line %d: %v", line, err)
+		}
+
+		if st.Currency == "" {
+			st.Currency = entry.Currency
+		} else if entry.Currency != st.Currency {
+			return nil, invalid("csv line %d: currency %s differs from %s", line, entry.Currency, st.Currency)
+		}
+		if account != "" {
+			if isIBAN(account) {
+				st.AccountIBAN = account
+			} else {
+				st.AccountNumber = account
+			}
+		}
+
+		if st.From.IsZero() || entry.ValueDate.Before(st.From) {
+			st.From = entry.ValueDate
+		}
+		if entry.ValueDate.After(st.To) {
+			st.To = entry.ValueDate
+		}
+		if balance != nil && (closing == nil || !layout.NewestFirst) {
+			closing = balance
+		}
+		st.Entries = append(st.Entries, entry)
+	}
+
+	if len(st.Entries) == 0 {
+		return nil, invalid("csv: no entries")
+	}
+	st.ClosingBalance = closing
+	return []*Statement{st}, nil
+}
+
+// csvColumns are the indexes of the layout's columns, -1 when absent.
+type csvColumns struct {
+	valueDate, bookingDate, amount, credit, debit, currency  int
+	reference, counterparty, bankReference, account, balance int
+}
+
+func (c Columns) resolve(header map[string]int) (csvColumns, error) {
+	var missing []string
+	index := func(name string) int {
+		if name == "" {
+			return -1
+		}
+		i, ok := header[name]
+		if !ok {
+			missing = append(missing, name)
+			return -1
+		}
+		return i
+	}
+
+	cols := csvColumns{
+		valueDate:     index(c.ValueDate),
+		bookingDate:   index(c.BookingDate),
+		amount:        index(c.Amount),
+		credit:        index(c.Credit),
+		debit:         index(c.Debit),
+		currency:      index(c.Currency),
+		reference:     index(c.Reference),
+		counterparty:  index(c.Counterparty),
+		bankReference: index(c.BankReference),
+		account:       index(c.Account),
+		balance:       index(c.Balance),
+	}
+	if len(missing) > 0 {
+		return cols, fmt.Errorf("missing columns: %s", strings.Join(missing, ", "))
+	}
+	return cols, nil
+}
+
+func (l *Layout) parseRow(cols csvColumns, record []string) (Entry, *decimal.Decimal, string, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var entry Entry
+	var err error
+
+	if entry.ValueDate, err = time.Parse(l.DateFormat, field(cols.valueDate)); err != nil {
+		return Entry{}, nil, "", fmt.Errorf("value date %q", field(cols.valueDate))
+	}
+	if s := field(cols.bookingDate); s != "" {
+		if entry.BookingDate, err = time.Parse(l.DateFormat, s); err != nil {
+			return Entry{}, nil, "", fmt.Errorf("booking date %q", s)
+		}
+	}
+
+	if cols.amount >= 0 {
+		if entry.Amount, err = l.parseAmount(field(cols.amount)); err != nil {
+			return Entry{}, nil, "", err
+		}
+	} else {
+		credit, err := l.parseOptionalAmount(field(cols.credit))
+		if err != nil {
+			return Entry{}, nil, "", err
+		}
+		debit, err := l.parseOptionalAmount(field(cols.debit))
+		if err != nil {
+			return Entry{}, nil, "", err
+		}
+		entry.Amount = credit.Sub(debit.Abs())
+	}
+
+	entry.Currency = strings.ToUpper(field(cols.currency))
+	if entry.Currency == "" {
+		entry.Currency = l.Currency
+	}
+	entry.Reference = field(cols.reference)
+	entry.Counterparty = field(cols.counterparty)
+	entry.BankReference = field(cols.bankReference)
+
+	var balance *decimal.Decimal
+	if s := field(cols.balance); s != "" {
+		b, err := l.parseAmount(s)
+		if err != nil {
+			return Entry{}, nil, "", err
+		}
+		balance = &b
+	}
+	return entry, balance, normaliseAccount(field(cols.account)), nil
+}
+
+func (l *Layout) parseAmount(s string) (decimal.Decimal, error) {
+	v := strings.ReplaceAll(s, " ", "")
+	if l.DecimalComma {
+		v = strings.ReplaceAll(v, ".", "")
+		v = strings.Replace(v, ",", ".", 1)
+	} else {
+		v = strings.ReplaceAll(v, ",", "")
+	}
+	amount, err := decimal.NewFromString(v)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("amount %q", s)
+	}
+	return amount, nil
+}
+
+func (l *Layout) parseOptionalAmount(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	return l.parseAmount(s)
+}
+
+func isBlank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
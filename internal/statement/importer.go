@@ -0,0 +1,211 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/matching"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrUnknownLayout        = errors.New("unknown csv layout")
+	ErrUnknownAccount       = errors.New("statement account is not an FBO or Nostro account")
+	ErrCurrencyNotSupported = errors.New("legal entity does not support the statement currency")
+	ErrDuplicateStatement   = errors.New("statement was already imported")
+)
+
+// DefaultLayout is the CSV layout used when none is given.
+const DefaultLayout = "default"
+
+// ImportParams contains a statement file to import.
+type ImportParams struct {
+	Format Format
+	// Layout is the CSV layout name; DefaultLayout if empty.
+	Layout string
+	// Account is the IBAN or account number of the statement, for CSV
+	// layouts without an account column.
+	Account    string
+	ImportedBy string
+	Data       []byte
+}
+
+// Importer imports bank statements. A statement's closing balance becomes
+// the bank balance of its account for reconciliation, and its entries are
+// queued for matching.
+type Importer struct {
+	db                 *db.DB
+	repo               *repository.BankStatementRepository
+	reconciliationRepo *repository.ReconciliationRepository
+	legalEntityRepo    *repository.LegalEntityRepository
+	layouts            *Layouts
+	jobClient          *jobs.Client
+	trail              *audit.Trail
+}
+
+// NewImporter creates a new statement importer.
+func NewImporter(
+	database *db.DB,
+	repo *repository.BankStatementRepository,
+	reconciliationRepo *repository.ReconciliationRepository,
+	legalEntityRepo *repository.LegalEntityRepository,
+	layouts *Layouts,
+	jobClient *jobs.Client,
+	trail *audit.Trail,
+) *Importer {
+	return &Importer{
+		db:                 database,
+		repo:               repo,
+		reconciliationRepo: reconciliationRepo,
+		legalEntityRepo:    legalEntityRepo,
+		layouts:            layouts,
+		jobClient:          jobClient,
+		trail:              trail,
+	}
+}
+
+// Import parses a statement file and stores its statements. Either every
+// statement in the file is imported or none is.
+func (i *Importer) Import(ctx context.Context, params ImportParams) ([]*models.BankStatement, error) {
+	var layout *Layout
+	if params.Format == FormatCSV {
+		name := params.Layout
+		if name == "" {
+			name = DefaultLayout
+		}
+		var ok bool
+		if layout, ok = i.layouts.Get(name); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownLayout, name)
+		}
+	}
+
+	statements, err := Parse(params.Format, params.Data, layout)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := i.legalEntityRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list legal entities: %w", err)
+	}
+
+	creates := make([]models.CreateBankStatementParams, len(statements))
+	owners := make([]*models.LegalEntity, len(statements))
+	for n, st := range statements {
+		if st.Account() == "" {
+			if account := normaliseAccount(params.Account); isIBAN(account) {
+				st.AccountIBAN = account
+			} else {
+				st.AccountNumber = account
+			}
+		}
+
+		le, kind, ok := MatchAccount(entities, st)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownAccount, st.Account())
+		}
+		if !le.SupportsCurrency(st.Currency) {
+			return nil, fmt.Errorf("%w: %s %s", ErrCurrencyNotSupported, le.Code, st.Currency)
+		}
+
+		creates[n] = toCreateParams(st, params, le, kind)
+		owners[n] = le
+	}
+
+	return db.WithTxResult(ctx, i.db, func(tx pgx.Tx) ([]*models.BankStatement, error) {
+		repo := i.repo.WithTx(tx)
+		reconciliationRepo := i.reconciliationRepo.WithTx(tx)
+
+		imported := make([]*models.BankStatement, 0, len(creates))
+		for n, create := range creates {
+			stmt, err := repo.Create(ctx, create)
+			if err != nil {
+				return nil, fmt.Errorf("create statement: %w", err)
+			}
+			if stmt == nil {
+				return nil, fmt.Errorf("%w: %s %s", ErrDuplicateStatement, create.AccountIdentifier, create.ExternalID)
+			}
+
+			if stmt.ClosingBalance != nil {
+				_, err := reconciliationRepo.RecordBankBalance(ctx, models.CreateBankBalanceParams{
+					LegalEntityID: stmt.LegalEntityID,
+					AccountKind:   stmt.AccountKind,
+					Currency:      stmt.Currency,
+					Balance:       *stmt.ClosingBalance,
+					AsOf:          stmt.ToDate,
+					Source:        stmt.Format,
+					RecordedBy:    stmt.ImportedBy,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("record closing balance: %w", err)
+				}
+			}
+
+			if err := i.trail.RecordTx(ctx, tx, audit.Entry{
+				Region:       models.ComplianceRegionForJurisdiction(owners[n].Jurisdiction),
+				ResourceType: audit.ResourceBankStatement,
+				ResourceID:   stmt.ID.String(),
+				Action:       audit.ActionCreate,
+				After:        stmt,
+			}); err != nil {
+				return nil, err
+			}
+			imported = append(imported, stmt)
+		}
+
+		if _, err := i.jobClient.InsertTx(ctx, tx, matching.RunArgs{}, nil); err != nil {
+			return nil, fmt.Errorf("enqueue matching: %w", err)
+		}
+		return imported, nil
+	})
+}
+
+func toCreateParams(st *Statement, params ImportParams, le *models.LegalEntity, kind models.BankAccountKind) models.CreateBankStatementParams {
+	create := models.CreateBankStatementParams{
+		LegalEntityID:     le.ID,
+		AccountKind:       kind,
+		AccountIdentifier: st.Account(),
+		Currency:          st.Currency,
+		Format:            string(params.Format),
+		ExternalID:        st.ID,
+		OpeningBalance:    st.OpeningBalance,
+		ClosingBalance:    st.ClosingBalance,
+		ToDate:            st.To,
+		ImportedBy:        params.ImportedBy,
+		Entries:           make([]models.CreateBankStatementEntryParams, len(st.Entries)),
+	}
+	if !st.From.IsZero() {
+		from := st.From
+		create.FromDate = &from
+	}
+
+	for n, e := range st.Entries {
+		entry := models.CreateBankStatementEntryParams{
+			Amount:    e.Amount,
+			Currency:  e.Currency,
+			ValueDate: e.ValueDate,
+			Reference: e.Reference,
+		}
+		if !e.BookingDate.IsZero() {
+			booking := e.BookingDate
+			entry.BookingDate = &booking
+		}
+		if e.Counterparty != "" {
+			counterparty := e.Counterparty
+			entry.Counterparty = &counterparty
+		}
+		if e.BankReference != "" {
+			ref := e.BankReference
+			entry.BankReference = &ref
+		}
+		create.Entries[n] = entry
+	}
+	return create
+}
@@ -0,0 +1,303 @@
+package statement
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// :nn: or :nna: at the start of a line
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	// Statement line: value date, optional entry date, debit/credit mark,
+	// optional funds code, amount, transaction type, customer reference,
+	// optional bank reference.
+	mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NSF][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?(?:\n(.*))?$`)
+	// Balance: debit/credit mark, date, currency, amount
+	mt940Balance = regexp.MustCompile(`^(C|D)(\d{6})([A-Z]{3})(\d+,\d*)$`)
+)
+
+type mt940Field struct {
+	tag   string
+	value string
+}
+
+// ParseMT940 parses SWIFT MT940 customer statements. A file may hold several
+// messages, with or without the SWIFT block envelope.
+func ParseMT940(data []byte) ([]*Statement, error) {
+	messages, err := splitMT940(data)
+	if err != nil {
+		return nil, invalid("mt940: %v", err)
+	}
+	if len(messages) == 0 {
+		return nil, invalid("mt940: no statements")
+	}
+
+	out := make([]*Statement, 0, len(messages))
+	for i, fields := range messages {
+		st, err := parseMT940Message(fields)
+		if err != nil {
+			return nil, invalid("mt940 statement %d: %v", i+1, err)
+		}
+		out = append(out, st)
+	}
+	return out, nil
+}
+
+// splitMT940 splits a file into messages of tagged fields. Continuation
+// lines are joined to their field with a newline.
+func splitMT940(data []byte) ([][]mt940Field, error) {
+	var messages [][]mt940Field
+	var current []mt940Field
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		// Envelope: {1:...}{2:...}{4: opens the text block, -} closes it
+		if i := strings.Index(line, "{4:"); i >= 0 {
+			line = line[i+3:]
+		}
+		if line == "-}" || line == "-" || strings.HasPrefix(line, "-}{") {
+			continue
+		}
+		if line == "" {
+			continue
+		}
+
+		m := mt940Tag.FindStringSubmatch(line)
+		if m == nil {
+			if len(current) == 0 {
+				continue
+			}
+			current[len(current)-1].value += "\n" + line
+			continue
+		}
+
+		field := mt940Field{tag: m[1], value: line[len(m[0]):]}
+		if field.tag == "20" && len(current) > 0 {
+			messages = append(messages, current)
+			current = nil
+		}
+		current = append(current, field)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(current) > 0 {
+		messages = append(messages, current)
+	}
+	return messages, nil
+}
+
+func parseMT940Message(fields []mt940Field) (*Statement, error) {
+	st := &Statement{}
+	var seq string
+
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		switch f.tag {
+		case "20":
+			st.ID = strings.TrimSpace(f.value)
+		case "25":
+			setMT940Account(st, f.value)
+		case "28C", "28":
+			seq = strings.TrimSpace(f.value)
+		case "60F", "60M":
+			amount, date, currency, err := parseMT940Balance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf(":%s: %w", f.tag, err)
+			}
+			st.OpeningBalance, st.From, st.Currency = &amount, date, currency
+		case "62F", "62M":
+			amount, date, currency, err := parseMT940Balance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf(":%s: %w", f.tag, err)
+			}
+			st.ClosingBalance, st.To = &amount, date
+			if st.Currency == "" {
+				st.Currency = currency
+			}
+		case "61":
+			var info string
+			if i+1 < len(fields) && fields[i+1].tag == "86" {
+				info = fields[i+1].value
+				i++
+			}
+			entry, err := parseMT940Line(f.value, info)
+			if err != nil {
+				return nil, fmt.Errorf(":61: %w", err)
+			}
+			st.Entries = append(st.Entries, entry)
+		}
+	}
+
+	if st.Account() == "" {
+		return nil, errors.New("no account (:25:)")
+	}
+	if st.Currency == "" {
+		return nil, errors.New("no opening or closing balance")
+	}
+	if st.To.IsZero() {
+		st.To = st.From
+	}
+	if seq != "" {
+		st.ID += "/" + seq
+	}
+	for i := range st.Entries {
+		st.Entries[i].Currency = st.Currency
+	}
+	return st, nil
+}
+
+// setMT940Account reads the account identification: an IBAN, or an account
+// number optionally prefixed by a BIC or sort code and a slash.
+func setMT940Account(st *Statement, value string) {
+	account := normaliseAccount(value)
+	if i := strings.LastIndex(account, "/"); i >= 0 {
+		account = account[i+1:]
+	}
+	if isIBAN(account) {
+		st.AccountIBAN = account
+	} else {
+		st.AccountNumber = account
+	}
+}
+
+func parseMT940Balance(value string) (decimal.Decimal, time.Time, string, error) {
+	m := mt940Balance.FindStringSubmatch(strings.TrimSpace(value))
+	if m == nil {
+		return decimal.Zero, time.Time{}, "", fmt.Errorf("malformed balance %q", value)
+	}
+	date, err := parseMT940Date(m[2])
+	if err != nil {
+		return decimal.Zero, time.Time{}, "", err
+	}
+	amount, err := parseMT940Amount(m[4])
+	if err != nil {
+		return decimal.Zero, time.Time{}, "", err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, date, m[3], nil
+}
+
+func parseMT940Line(value, info string) (Entry, error) {
+	m := mt940Line.FindStringSubmatch(value)
+	if m == nil {
+		return Entry{}, fmt.Errorf("malformed statement line %q", value)
+	}
+
+	valueDate, err := parseMT940Date(m[1])
+	if err != nil {
+		return Entry{}, err
+	}
+	bookingDate := valueDate
+	if m[2] != "" {
+		// The entry date has no year; it is close to the value date
+		t, err := time.Parse("0102", m[2])
+		if err != nil {
+			return Entry{}, fmt.Errorf("entry date %q", m[2])
+		}
+		bookingDate = time.Date(valueDate.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		switch {
+		case bookingDate.Sub(valueDate) > 180*24*time.Hour:
+			bookingDate = bookingDate.AddDate(-1, 0, 0)
+		case valueDate.Sub(bookingDate) > 180*24*time.Hour:
+			bookingDate = bookingDate.AddDate(1, 0, 0)
+		}
+	}
+
+	amount, err := parseMT940Amount(m[5])
+	if err != nil {
+		return Entry{}, err
+	}
+	// D and RC (reversal of a credit) take money out
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+
+	entry := Entry{
+		Amount:        amount,
+		ValueDate:     valueDate,
+		BookingDate:   bookingDate,
+		BankReference: strings.TrimSpace(m[8]),
+	}
+	if ref := strings.TrimSpace(m[7]); ref != "NONREF" {
+		entry.Reference = ref
+	}
+
+	remittance, counterparty := parseMT940Info(info)
+	if entry.Reference == "" {
+		entry.Reference = remittance
+	}
+	entry.Counterparty = counterparty
+	return entry, nil
+}
+
+// parseMT940Info reads the remittance information and counterparty name from
+// a :86: field. Banks use ?nn subfields (?20-?29 remittance, ?32-?33 name) or
+// /CODE/ segments (/REMI/, /NAME/); anything else is remittance text.
+func parseMT940Info(info string) (remittance, counterparty string) {
+	info = strings.ReplaceAll(info, "\n", "")
+	if info == "" {
+		return "", ""
+	}
+
+	switch {
+	case strings.Contains(info, "?2"):
+		var rem, name []string
+		for _, part := range strings.Split(info, "?")[1:] {
+			if len(part) < 2 {
+				continue
+			}
+			code, text := part[:2], part[2:]
+			switch {
+			case code >= "20" && code <= "29":
+				rem = append(rem, text)
+			case code == "32" || code == "33":
+				name = append(name, text)
+			}
+		}
+		return strings.TrimSpace(strings.Join(rem, "")), strings.TrimSpace(strings.Join(name, ""))
+
+	case strings.HasPrefix(info, "/"):
+		segments := strings.Split(info, "/")
+		var rem string
+		for i := 1; i+1 < len(segments); i += 2 {
+			switch segments[i] {
+			case "REMI":
+				rem = segments[i+1]
+			case "NAME":
+				counterparty = strings.TrimSpace(segments[i+1])
+			}
+		}
+		return strings.TrimSpace(rem), counterparty
+	}
+	return strings.TrimSpace(info), ""
+}
+
+func parseMT940Date(s string) (time.Time, error) {
+	t, err := time.Parse("060102", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("date %q", s)
+	}
+	return t, nil
+}
+
+func parseMT940Amount(s string) (decimal.Decimal, error) {
+	s = strings.Replace(s, ",", ".", 1)
+	s = strings.TrimSuffix(s, ".")
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("amount %q", s)
+	}
+	return amount, nil
+}
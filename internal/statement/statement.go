@@ -0,0 +1,138 @@
+// Package statement parses bank statements of the legal entities' FBO and
+// Nostro accounts and imports them for reconciliation.
+//
+// ISO 20022 camt.053, SWIFT MT940 and CSV exports (see csv_layouts.yaml) are
+// normalised to the same Statement: the account it belongs to, its opening
+// and closing balances and its booked entries.
+package statement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidStatement is returned when a statement cannot be parsed.
+var ErrInvalidStatement = errors.New("invalid statement")
+
+// Format is a bank statement file format.
+type Format string
+
+const (
+	FormatCAMT053 Format = "camt053"
+	FormatMT940   Format = "mt940"
+	FormatCSV     Format = "csv"
+)
+
+// IsValid returns true if the format is supported.
+func (f Format) IsValid() bool {
+	return f == FormatCAMT053 || f == FormatMT940 || f == FormatCSV
+}
+
+// Statement is a bank statement for one account and currency.
+type Statement struct {
+	// ID is the bank's statement reference, if the format has one.
+	ID string
+	// The account is identified by IBAN, account number or both.
+	AccountIBAN    string
+	AccountNumber  string
+	Currency       string
+	OpeningBalance *decimal.Decimal
+	ClosingBalance *decimal.Decimal
+	// From is zero when the statement does not say when it starts. To is
+	// the date of the closing balance.
+	From    time.Time
+	To      time.Time
+	Entries []Entry
+}
+
+// Account returns the identifier the statement gives for its account.
+func (s *Statement) Account() string {
+	if s.AccountIBAN != "" {
+		return s.AccountIBAN
+	}
+	return s.AccountNumber
+}
+
+// Entry is a booked statement entry.
+type Entry struct {
+	// Amount is signed: credits are positive, debits negative.
+	Amount      decimal.Decimal
+	Currency    string
+	ValueDate   time.Time
+	BookingDate time.Time
+	// Reference is the payment reference or remittance information.
+	Reference    string
+	Counterparty string
+	// BankReference is the bank's own reference for the entry.
+	BankReference string
+}
+
+// Parse parses a statement file. A file may hold several statements. The
+// layout is only used for CSV.
+//
+// Statements without a bank reference (all CSV exports) are identified by a
+// hash of the file, so that importing the same file twice is detected.
+func Parse(format Format, data []byte, layout *Layout) ([]*Statement, error) {
+	var statements []*Statement
+	var err error
+
+	switch format {
+	case FormatCAMT053:
+		statements, err = ParseCAMT053(data)
+	case FormatMT940:
+		statements, err = ParseMT940(data)
+	case FormatCSV:
+		if layout == nil {
+			return nil, errors.New("csv statements need a layout")
+		}
+		statements, err = ParseCSV(data, layout)
+	default:
+		return nil, fmt.Errorf("unknown statement format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	for i, st := range statements {
+		if st.ID == "" {
+			st.ID = fmt.Sprintf("sha256:%s/%d", hex.EncodeToString(sum[:16]), i+1)
+		}
+	}
+	return statements, nil
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidStatement, fmt.Sprintf(format, args...))
+}
+
+// normaliseAccount strips spaces from an IBAN or account number and
+// upper-cases it.
+func normaliseAccount(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// isIBAN reports whether s looks like an IBAN: a country code, two check
+// digits and up to 30 letters or digits.
+func isIBAN(s string) bool {
+	if len(s) < 15 || len(s) > 34 {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case i < 2 && (r < 'A' || r > 'Z'):
+			return false
+		case i >= 2 && i < 4 && (r < '0' || r > '9'):
+			return false
+		case (r < 'A' || r > 'Z') && (r < '0' || r > '9'):
+			return false
+		}
+	}
+	return true
+}
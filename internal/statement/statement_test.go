@@ -0,0 +1,174 @@
+package statement
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/models"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseCAMT053(t *testing.T) {
+	statements, err := Parse(FormatCAMT053, readFixture(t, "camt053.xml"), nil)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	st := statements[0]
+	assert.Equal(t, "STMT-20260302-001", st.ID)
+	assert.Equal(t, "DE89370400440532013000", st.AccountIBAN)
+	assert.Equal(t, "EUR", st.Currency)
+	assert.Equal(t, "10000.00", st.OpeningBalance.StringFixed(2))
+	assert.Equal(t, "10750.50", st.ClosingBalance.StringFixed(2))
+	assert.Equal(t, date("2026-03-01"), st.From)
+	assert.Equal(t, date("2026-03-02"), st.To)
+
+	// The pending entry is skipped
+	require.Len(t, st.Entries, 2)
+
+	credit := st.Entries[0]
+	assert.Equal(t, "1000.5", credit.Amount.String())
+	assert.Equal(t, "EUR", credit.Currency)
+	assert.Equal(t, date("2026-03-02"), credit.ValueDate)
+	assert.Equal(t, "E2E-TOPUP-42", credit.Reference)
+	assert.Equal(t, "Acme GmbH", credit.Counterparty)
+	assert.Equal(t, "BANK-0001", credit.BankReference)
+
+	debit := st.Entries[1]
+	assert.Equal(t, "-250", debit.Amount.String())
+	assert.Equal(t, date("2026-03-03"), debit.ValueDate)
+	assert.Equal(t, date("2026-03-02"), debit.BookingDate)
+	assert.Equal(t, "Invoice 2026-118", debit.Reference)
+	assert.Equal(t, "Beta SARL", debit.Counterparty)
+}
+
+func TestParseMT940(t *testing.T) {
+	statements, err := Parse(FormatMT940, readFixture(t, "mt940.txt"), nil)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	st := statements[0]
+	assert.Equal(t, "STMT260302/58/1", st.ID)
+	assert.Equal(t, "31926819", st.AccountNumber)
+	assert.Equal(t, "GBP", st.Currency)
+	assert.Equal(t, "5000.00", st.OpeningBalance.StringFixed(2))
+	assert.Equal(t, "5899.50", st.ClosingBalance.StringFixed(2))
+	assert.Equal(t, date("2026-03-02"), st.To)
+	require.Len(t, st.Entries, 2)
+
+	credit := st.Entries[0]
+	assert.Equal(t, "1200", credit.Amount.String())
+	assert.Equal(t, "GBP", credit.Currency)
+	assert.Equal(t, "TOPUP-7", credit.Reference)
+	assert.Equal(t, "ACME LTD", credit.Counterparty)
+	assert.Equal(t, "BR-1", credit.BankReference)
+
+	debit := st.Entries[1]
+	assert.Equal(t, "-300.5", debit.Amount.String())
+	assert.Equal(t, date("2026-03-03"), debit.ValueDate)
+	assert.Equal(t, date("2026-03-02"), debit.BookingDate)
+	assert.Equal(t, "Invoice INV-9", debit.Reference)
+	assert.Equal(t, "Beta Ltd", debit.Counterparty)
+}
+
+func TestParseCSVLayout(t *testing.T) {
+	layouts, err := DefaultLayouts()
+	require.NoError(t, err)
+	layout, ok := layouts.Get("id-fbo")
+	require.True(t, ok)
+
+	data := readFixture(t, "id-fbo.csv")
+	statements, err := Parse(FormatCSV, data, layout)
+	require.NoError(t, err)
+	require.Len(t, statements, 1)
+
+	st := statements[0]
+	assert.Equal(t, "IDR", st.Currency)
+	assert.Empty(t, st.Account())
+	assert.Equal(t, "11250000.00", st.ClosingBalance.StringFixed(2))
+	assert.Equal(t, date("2026-03-02"), st.From)
+	assert.Equal(t, date("2026-03-03"), st.To)
+	require.Len(t, st.Entries, 2)
+
+	assert.Equal(t, "1500000", st.Entries[0].Amount.String())
+	assert.Equal(t, "PT Maju", st.Entries[0].Counterparty)
+	assert.Equal(t, "-250000", st.Entries[1].Amount.String())
+	assert.Equal(t, "PAYOUT 100", st.Entries[1].Reference)
+
+	// The same file gets the same ID, so it is only imported once
+	again, err := Parse(FormatCSV, data, layout)
+	require.NoError(t, err)
+	assert.NotEmpty(t, st.ID)
+	assert.Equal(t, st.ID, again[0].ID)
+}
+
+func TestParseCSVMissingColumn(t *testing.T) {
+	layouts, err := DefaultLayouts()
+	require.NoError(t, err)
+	layout, _ := layouts.Get(DefaultLayout)
+
+	_, err = Parse(FormatCSV, []byte("value_date,amount\n2026-03-02,10.00\n"), layout)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+	assert.Contains(t, err.Error(), "currency")
+}
+
+func TestParseLayoutsValidates(t *testing.T) {
+	_, err := ParseLayouts([]byte(`
+layouts:
+  broken:
+    columns:
+      value_date: date
+      credit: in
+`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "layout broken")
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(FormatCAMT053, []byte("<Document><BkToCstmrStmt/></Document>"), nil)
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+
+	_, err = Parse(FormatMT940, []byte(":20:X\n:25:123\n:61:garbage\n"), nil)
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+}
+
+func TestMatchAccount(t *testing.T) {
+	fboIBAN := "DE89 3704 0044 0532 0130 00"
+	nostroNumber := "31926819"
+	eu := &models.LegalEntity{ID: uuid.New(), FBOAccountIBAN: &fboIBAN}
+	uk := &models.LegalEntity{ID: uuid.New(), NostroAccountNumber: &nostroNumber}
+	entities := []*models.LegalEntity{eu, uk}
+
+	le, kind, ok := MatchAccount(entities, &Statement{AccountIBAN: "DE89370400440532013000"})
+	require.True(t, ok)
+	assert.Equal(t, eu.ID, le.ID)
+	assert.Equal(t, models.BankAccountFBO, kind)
+
+	le, kind, ok = MatchAccount(entities, &Statement{AccountNumber: "31926819"})
+	require.True(t, ok)
+	assert.Equal(t, uk.ID, le.ID)
+	assert.Equal(t, models.BankAccountNostro, kind)
+
+	_, _, ok = MatchAccount(entities, &Statement{AccountNumber: "00000000"})
+	assert.False(t, ok)
+}
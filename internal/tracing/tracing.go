@@ -0,0 +1,105 @@
+// Package tracing sets up OpenTelemetry tracing, exported over OTLP, and
+// carries W3C trace context across the outbox, webhooks and background jobs,
+// so that the work a request causes joins its trace.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"kovra/internal/config"
+)
+
+// instrumentationName names the tracer of the service's spans.
+const instrumentationName = "kovra"
+
+// propagator reads and writes the traceparent, tracestate and baggage
+// headers.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Setup installs the global tracer provider, exporting spans to the
+// configured collector, and returns the function flushing and stopping it.
+// Without an endpoint no spans are recorded, though trace context received
+// from callers is still passed on.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagator)
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the service's spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject returns the trace context of ctx encoded for storing alongside a
+// row, or nil when ctx is not part of a trace.
+func Inject(ctx context.Context) []byte {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	data, err := json.Marshal(carrier)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// Extract returns ctx continuing the trace context stored by Inject. A
+// missing or unreadable carrier leaves ctx as it is.
+func Extract(ctx context.Context, data []byte) context.Context {
+	if len(data) == 0 {
+		return ctx
+	}
+	var carrier propagation.MapCarrier
+	if err := json.Unmarshal(data, &carrier); err != nil {
+		return ctx
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+// InjectHeaders sets the traceparent and tracestate headers of an outgoing
+// request from ctx.
+func InjectHeaders(ctx context.Context, h http.Header) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// ExtractHeaders returns ctx continuing the trace of an incoming request.
+func ExtractHeaders(ctx context.Context, h http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(h))
+}
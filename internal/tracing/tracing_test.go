@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectExtract(t *testing.T) {
+	assert.Nil(t, Inject(context.Background()), "an untraced operation stores no trace context")
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	data := Inject(ctx)
+	require.NotNil(t, data)
+
+	sc := trace.SpanContextFromContext(Extract(context.Background(), data))
+	assert.Equal(t, traceID, sc.TraceID())
+	assert.Equal(t, spanID, sc.SpanID())
+	assert.True(t, sc.IsSampled())
+	assert.True(t, sc.IsRemote())
+
+	h := http.Header{}
+	InjectHeaders(ctx, h)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", h.Get("traceparent"))
+	assert.Equal(t, traceID, trace.SpanContextFromContext(ExtractHeaders(context.Background(), h)).TraceID())
+
+	// Unreadable carriers are ignored
+	assert.False(t, trace.SpanContextFromContext(Extract(context.Background(), []byte("{"))).IsValid())
+}
@@ -0,0 +1,77 @@
+package treasury
+
+import (
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+// buildExposure values the FX_SETTLEMENT positions at the given USD rates.
+// The platform buys the source currency of each FX transfer into the
+// account and sells the destination currency out of it, so a position is
+// the account's credits less its debits. Each quote's margin is realised
+// when the transfer posts, as is the gain or loss on each FX deal covering
+// the positions: the sum of the USD cost of every position. Unrealised P&L
+// is what the open positions have moved since.
+func buildExposure(balances map[string]ledger.Balance, costs []models.FXCostBasis, rates map[string]decimal.Decimal, eurRate decimal.Decimal, takenAt time.Time) *models.FXExposure {
+	costByCurrency := make(map[string]decimal.Decimal, len(costs))
+	for _, c := range costs {
+		costByCurrency[c.Currency] = c.CostUSD
+	}
+
+	currencies := make([]string, 0, len(balances))
+	for c := range balances {
+		currencies = append(currencies, c)
+	}
+	for c := range costByCurrency {
+		if _, ok := balances[c]; !ok {
+			currencies = append(currencies, c)
+		}
+	}
+	sort.Strings(currencies)
+
+	e := &models.FXExposure{
+		TakenAt:          takenAt,
+		EURUSDRate:       eurRate,
+		TotalValueUSD:    decimal.Zero,
+		TotalValueEUR:    decimal.Zero,
+		RealisedPnLUSD:   decimal.Zero,
+		UnrealisedPnLUSD: decimal.Zero,
+		Positions:        []models.FXPosition{},
+	}
+
+	for _, c := range currencies {
+		b := balances[c]
+		p := models.FXPosition{
+			Currency: c,
+			Position: decimal.New(int64(b.Credits)-int64(b.Debits), -2),
+			CostUSD:  costByCurrency[c].Round(2),
+		}
+		if p.Position.IsZero() && p.CostUSD.IsZero() {
+			continue
+		}
+		e.RealisedPnLUSD = e.RealisedPnLUSD.Add(p.CostUSD)
+
+		if rate, ok := rates[c]; ok {
+			valueUSD := p.Position.Mul(rate).Round(2)
+			valueEUR := p.Position.Mul(rate).Div(eurRate).Round(2)
+			unrealised := valueUSD.Sub(p.CostUSD)
+			p.USDRate = &rate
+			p.ValueUSD = &valueUSD
+			p.ValueEUR = &valueEUR
+			p.UnrealisedPnLUSD = &unrealised
+
+			e.TotalValueUSD = e.TotalValueUSD.Add(valueUSD)
+			e.TotalValueEUR = e.TotalValueEUR.Add(valueEUR)
+			e.UnrealisedPnLUSD = e.UnrealisedPnLUSD.Add(unrealised)
+		}
+
+		e.Positions = append(e.Positions, p)
+	}
+
+	return e
+}
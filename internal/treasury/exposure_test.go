@@ -0,0 +1,81 @@
+package treasury
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+func TestBuildExposure(t *testing.T) {
+	// One EUR->GBP transfer of 1,000.00 EUR for 850.00 GBP, quoted with
+	// EUR at 1.10 USD and GBP at 1.28 USD: cost 1,100.00 - 1,088.00 USD.
+	balances := map[string]ledger.Balance{
+		"EUR": {Credits: 100000},
+		"GBP": {Debits: 85000},
+	}
+	costs := []models.FXCostBasis{
+		{Currency: "EUR", CostUSD: d("1100")},
+		{Currency: "GBP", CostUSD: d("-1088")},
+	}
+	// GBP has since strengthened
+	rates := map[string]decimal.Decimal{
+		"EUR": d("1.10"),
+		"GBP": d("1.30"),
+		"USD": d("1"),
+	}
+
+	e := buildExposure(balances, costs, rates, d("1.10"), time.Now())
+
+	require.Len(t, e.Positions, 2)
+	eur, gbp := e.Positions[0], e.Positions[1]
+	assert.Equal(t, "EUR", eur.Currency)
+	assert.True(t, eur.Position.Equal(d("1000")))
+	assert.True(t, eur.ValueUSD.Equal(d("1100")))
+	assert.True(t, eur.ValueEUR.Equal(d("1000")))
+	assert.True(t, eur.UnrealisedPnLUSD.IsZero())
+
+	assert.Equal(t, "GBP", gbp.Currency)
+	assert.True(t, gbp.Position.Equal(d("-850")))
+	assert.True(t, gbp.ValueUSD.Equal(d("-1105")))
+	assert.True(t, gbp.UnrealisedPnLUSD.Equal(d("-17")), gbp.UnrealisedPnLUSD.String())
+
+	assert.True(t, e.RealisedPnLUSD.Equal(d("12")), e.RealisedPnLUSD.String())
+	assert.True(t, e.UnrealisedPnLUSD.Equal(d("-17")))
+	assert.True(t, e.TotalValueUSD.Equal(d("-5")))
+}
+
+func TestBuildExposureWithoutRate(t *testing.T) {
+	balances := map[string]ledger.Balance{
+		"EUR": {Credits: 100000},
+		"SEK": {Debits: 1150000},
+		"DKK": {},
+	}
+	costs := []models.FXCostBasis{
+		{Currency: "EUR", CostUSD: d("1100")},
+		{Currency: "SEK", CostUSD: d("-1090")},
+	}
+	rates := map[string]decimal.Decimal{"EUR": d("1.10")}
+
+	e := buildExposure(balances, costs, rates, d("1.10"), time.Now())
+
+	// DKK has no position and is left out; SEK is reported but not valued
+	require.Len(t, e.Positions, 2)
+	sek := e.Positions[1]
+	assert.Equal(t, "SEK", sek.Currency)
+	assert.Nil(t, sek.USDRate)
+	assert.Nil(t, sek.ValueUSD)
+
+	assert.True(t, e.RealisedPnLUSD.Equal(d("10")))
+	assert.True(t, e.TotalValueUSD.Equal(d("1100")))
+	assert.True(t, e.UnrealisedPnLUSD.IsZero())
+}
@@ -0,0 +1,87 @@
+package treasury
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/jobs"
+)
+
+// SnapshotArgs are the arguments of the periodic FX exposure snapshot.
+type SnapshotArgs struct{}
+
+// Kind returns the job kind.
+func (SnapshotArgs) Kind() string { return "treasury.fx_exposure_snapshot" }
+
+// InsertOpts returns the default insert options.
+func (SnapshotArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: "maintenance", MaxAttempts: 3, UniqueKey: "treasury.fx_exposure_snapshot"}
+}
+
+// SnapshotWorker takes FX exposure snapshots.
+type SnapshotWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewSnapshotWorker creates a new snapshot worker.
+func NewSnapshotWorker(service *Service, logger *zap.Logger) *SnapshotWorker {
+	return &SnapshotWorker{service: service, logger: logger}
+}
+
+// Work takes a snapshot and logs its totals.
+func (w *SnapshotWorker) Work(ctx context.Context, _ *jobs.Job[SnapshotArgs]) error {
+	snapshot, err := w.service.Snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("fx exposure snapshot taken",
+		zap.String("total_value_usd", snapshot.TotalValueUSD.String()),
+		zap.String("realised_pnl_usd", snapshot.RealisedPnLUSD.String()),
+		zap.String("unrealised_pnl_usd", snapshot.UnrealisedPnLUSD.String()),
+	)
+	return nil
+}
+
+// PostMovementArgs are the arguments of the job that posts an approved
+// treasury movement to the ledger.
+type PostMovementArgs struct {
+	MovementID uuid.UUID `json:"movement_id"`
+}
+
+// Kind returns the job kind.
+func (PostMovementArgs) Kind() string { return "treasury.post_movement" }
+
+// InsertOpts returns the default insert options.
+func (PostMovementArgs) InsertOpts() jobs.InsertOpts {
+	return jobs.InsertOpts{Queue: jobs.DefaultQueue, MaxAttempts: 10}
+}
+
+// PostMovementWorker posts approved treasury movements.
+type PostMovementWorker struct {
+	service *Service
+	logger  *zap.Logger
+}
+
+// NewPostMovementWorker creates a new movement posting worker.
+func NewPostMovementWorker(service *Service, logger *zap.Logger) *PostMovementWorker {
+	return &PostMovementWorker{service: service, logger: logger}
+}
+
+// Work posts the movement.
+func (w *PostMovementWorker) Work(ctx context.Context, job *jobs.Job[PostMovementArgs]) error {
+	err := w.service.Post(ctx, job.Args.MovementID)
+	if errors.Is(err, ErrMovementNotFound) || errors.Is(err, ErrMovementNotApproved) {
+		return jobs.Cancel(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.logger.Info("treasury movement posted", zap.String("movement_id", job.Args.MovementID.String()))
+	return nil
+}
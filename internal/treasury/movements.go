@@ -0,0 +1,288 @@
+package treasury
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+)
+
+var (
+	ErrMovementNotFound    = errors.New("treasury movement not found")
+	ErrMovementNotPending  = errors.New("treasury movement is not pending")
+	ErrMovementNotApproved = errors.New("treasury movement is not approved")
+	ErrSelfApproval        = errors.New("a treasury movement must be decided by someone other than its requester")
+	ErrDuplicateDeal       = errors.New("fx deal is already booked")
+	ErrInvalidMovement     = errors.New("invalid treasury movement")
+)
+
+// movementAccounts are the system accounts treasury moves funds between.
+var movementAccounts = map[string]ledger.AccountType{
+	ledger.AccountTypeFeeRevenue.String():         ledger.AccountTypeFeeRevenue,
+	ledger.AccountTypeFXSettlement.String():       ledger.AccountTypeFXSettlement,
+	ledger.AccountTypeRegionalSettlement.String(): ledger.AccountTypeRegionalSettlement,
+}
+
+// FXDeal is an external FX deal covering FX_SETTLEMENT positions: treasury
+// sells one currency to the counterparty for another, paying out of and
+// receiving into the settlement accounts of the two currencies.
+type FXDeal struct {
+	Counterparty  string
+	DealReference string
+	SellCurrency  string
+	SellAmount    decimal.Decimal
+	BuyCurrency   string
+	BuyAmount     decimal.Decimal
+	Reason        string
+	RequestedBy   string
+}
+
+// RequestRebalance records a pending movement of funds between system
+// accounts. Each leg debits one account and credits another in the same
+// currency; the legs of a currency are posted together or not at all.
+func (s *Service) RequestRebalance(ctx context.Context, reason, requestedBy string, legs []models.TreasuryMovementLeg) (*models.TreasuryMovement, error) {
+	if len(legs) == 0 {
+		return nil, fmt.Errorf("%w: at least one leg is required", ErrInvalidMovement)
+	}
+	for i, leg := range legs {
+		if err := validateLeg(leg); err != nil {
+			return nil, fmt.Errorf("%w: leg %d: %s", ErrInvalidMovement, i+1, err)
+		}
+	}
+
+	return s.request(ctx, models.CreateTreasuryMovementParams{
+		Kind:        models.TreasuryMovementRebalance,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		Legs:        legs,
+	})
+}
+
+// RequestFXDeal records a pending booking of an FX deal. The sold currency
+// leaves its FX_SETTLEMENT position through its settlement account and the
+// bought currency enters its position the same way.
+func (s *Service) RequestFXDeal(ctx context.Context, deal FXDeal) (*models.TreasuryMovement, error) {
+	if strings.TrimSpace(deal.Counterparty) == "" || strings.TrimSpace(deal.DealReference) == "" {
+		return nil, fmt.Errorf("%w: counterparty and deal_reference are required", ErrInvalidMovement)
+	}
+	if deal.SellCurrency == deal.BuyCurrency {
+		return nil, fmt.Errorf("%w: sell and buy currencies must differ", ErrInvalidMovement)
+	}
+
+	legs := []models.TreasuryMovementLeg{
+		{
+			Currency:      deal.SellCurrency,
+			DebitAccount:  ledger.AccountTypeFXSettlement.String(),
+			CreditAccount: ledger.AccountTypeRegionalSettlement.String(),
+			Amount:        deal.SellAmount,
+		},
+		{
+			Currency:      deal.BuyCurrency,
+			DebitAccount:  ledger.AccountTypeRegionalSettlement.String(),
+			CreditAccount: ledger.AccountTypeFXSettlement.String(),
+			Amount:        deal.BuyAmount,
+		},
+	}
+	if err := validateLeg(legs[0]); err != nil {
+		return nil, fmt.Errorf("%w: sell leg: %s", ErrInvalidMovement, err)
+	}
+	if err := validateLeg(legs[1]); err != nil {
+		return nil, fmt.Errorf("%w: buy leg: %s", ErrInvalidMovement, err)
+	}
+
+	return s.request(ctx, models.CreateTreasuryMovementParams{
+		Kind:          models.TreasuryMovementFXDeal,
+		Reason:        deal.Reason,
+		Counterparty:  &deal.Counterparty,
+		DealReference: &deal.DealReference,
+		RequestedBy:   deal.RequestedBy,
+		Legs:          legs,
+	})
+}
+
+func (s *Service) request(ctx context.Context, params models.CreateTreasuryMovementParams) (*models.TreasuryMovement, error) {
+	if strings.TrimSpace(params.Reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidMovement)
+	}
+	if params.RequestedBy == "" {
+		return nil, errors.New("treasury movement has no requester")
+	}
+
+	for i := range params.Legs {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return nil, fmt.Errorf("generate transfer id: %w", err)
+		}
+		params.Legs[i].Seq = i + 1
+		params.Legs[i].TBTransferID = id
+	}
+
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.TreasuryMovement, error) {
+		repo := s.movementRepo.WithTx(tx)
+
+		if params.Kind == models.TreasuryMovementFXDeal {
+			existing, err := repo.GetMovementByDeal(ctx, *params.Counterparty, *params.DealReference)
+			if err != nil {
+				return nil, fmt.Errorf("get movement by deal: %w", err)
+			}
+			if existing != nil {
+				return nil, ErrDuplicateDeal
+			}
+		}
+
+		movement, err := repo.CreateMovement(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("create movement: %w", err)
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			ResourceType: audit.ResourceTreasuryMovement,
+			ResourceID:   movement.ID.String(),
+			Action:       audit.ActionCreate,
+			After:        movement,
+		}); err != nil {
+			return nil, err
+		}
+		return movement, nil
+	})
+}
+
+// Decide approves or rejects a pending movement. It must be decided by
+// someone other than its requester; both are the operators authenticated
+// for the requests. An approved movement is posted to the ledger in the
+// background.
+func (s *Service) Decide(ctx context.Context, id uuid.UUID, status models.TreasuryMovementStatus, decidedBy string, reason *string) (*models.TreasuryMovement, error) {
+	return db.WithTxResult(ctx, s.db, func(tx pgx.Tx) (*models.TreasuryMovement, error) {
+		repo := s.movementRepo.WithTx(tx)
+
+		before, err := repo.GetMovementForUpdate(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get movement: %w", err)
+		}
+		if before == nil {
+			return nil, ErrMovementNotFound
+		}
+		if before.Status != models.TreasuryMovementPending {
+			return nil, ErrMovementNotPending
+		}
+		if before.RequestedBy == decidedBy {
+			return nil, ErrSelfApproval
+		}
+
+		movement, err := repo.DecideMovement(ctx, id, status, decidedBy, reason)
+		if err != nil {
+			return nil, fmt.Errorf("decide movement: %w", err)
+		}
+
+		if status == models.TreasuryMovementApproved {
+			if _, err := s.jobClient.InsertTx(ctx, tx, PostMovementArgs{MovementID: id}, nil); err != nil {
+				return nil, fmt.Errorf("enqueue posting: %w", err)
+			}
+		}
+
+		if err := s.trail.RecordTx(ctx, tx, audit.Entry{
+			ResourceType: audit.ResourceTreasuryMovement,
+			ResourceID:   id.String(),
+			Action:       audit.ActionDecide,
+			Before:       before,
+			After:        movement,
+		}); err != nil {
+			return nil, err
+		}
+		return movement, nil
+	})
+}
+
+// Post posts an approved movement to the ledger and marks it posted. The
+// leg transfer IDs were fixed when it was requested, so posting again is
+// harmless.
+func (s *Service) Post(ctx context.Context, id uuid.UUID) error {
+	movement, err := s.movementRepo.GetMovement(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get movement: %w", err)
+	}
+	if movement == nil {
+		return ErrMovementNotFound
+	}
+	if movement.Status == models.TreasuryMovementPosted {
+		return nil
+	}
+	if movement.Status != models.TreasuryMovementApproved {
+		return ErrMovementNotApproved
+	}
+
+	legs := make([]ledger.TreasuryLeg, len(movement.Legs))
+	for i, leg := range movement.Legs {
+		legs[i] = ledger.TreasuryLeg{
+			TransferID: leg.TBTransferID,
+			Seq:        uint32(leg.Seq),
+			Debit:      movementAccounts[leg.DebitAccount],
+			Credit:     movementAccounts[leg.CreditAccount],
+			Currency:   ledger.CurrencyFromString(leg.Currency),
+			Amount:     uint64(leg.Amount.Shift(2).IntPart()),
+		}
+	}
+
+	code := ledger.CodeRebalance
+	if movement.Kind == models.TreasuryMovementFXDeal {
+		code = ledger.CodeFXDeal
+	}
+	if err := s.ledger.PostTreasuryMovement(ctx, movement.ID, code, legs); err != nil {
+		return fmt.Errorf("post movement: %w", err)
+	}
+
+	if err := s.movementRepo.MarkPosted(ctx, movement.ID); err != nil {
+		return fmt.Errorf("mark movement posted: %w", err)
+	}
+	return nil
+}
+
+// GetMovement retrieves a movement with its legs.
+func (s *Service) GetMovement(ctx context.Context, id uuid.UUID) (*models.TreasuryMovement, error) {
+	movement, err := s.movementRepo.GetMovement(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get movement: %w", err)
+	}
+	if movement == nil {
+		return nil, ErrMovementNotFound
+	}
+	return movement, nil
+}
+
+// ListMovements returns movements, newest first.
+func (s *Service) ListMovements(ctx context.Context, filter models.TreasuryMovementFilter) ([]*models.TreasuryMovement, error) {
+	return s.movementRepo.ListMovements(ctx, filter)
+}
+
+// validateLeg checks that a leg moves a positive amount in a ledger
+// currency between two different treasury accounts.
+func validateLeg(leg models.TreasuryMovementLeg) error {
+	if ledger.CurrencyFromString(leg.Currency) == 0 {
+		return fmt.Errorf("unsupported currency %q", leg.Currency)
+	}
+	if _, ok := movementAccounts[leg.DebitAccount]; !ok {
+		return fmt.Errorf("debit_account must be one of FEE_REVENUE, FX_SETTLEMENT, REGIONAL_SETTLEMENT")
+	}
+	if _, ok := movementAccounts[leg.CreditAccount]; !ok {
+		return fmt.Errorf("credit_account must be one of FEE_REVENUE, FX_SETTLEMENT, REGIONAL_SETTLEMENT")
+	}
+	if leg.DebitAccount == leg.CreditAccount {
+		return fmt.Errorf("debit_account and credit_account must differ")
+	}
+	if !leg.Amount.IsPositive() {
+		return fmt.Errorf("amount must be positive")
+	}
+	if !leg.Amount.Equal(leg.Amount.Round(2)) {
+		return fmt.Errorf("amount must have at most 2 decimal places")
+	}
+	return nil
+}
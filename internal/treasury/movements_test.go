@@ -0,0 +1,37 @@
+package treasury
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"kovra/internal/models"
+)
+
+func TestValidateLeg(t *testing.T) {
+	valid := models.TreasuryMovementLeg{
+		Currency:      "EUR",
+		DebitAccount:  "FX_SETTLEMENT",
+		CreditAccount: "REGIONAL_SETTLEMENT",
+		Amount:        d("2500.50"),
+	}
+	assert.NoError(t, validateLeg(valid))
+
+	tests := []struct {
+		name   string
+		modify func(*models.TreasuryMovementLeg)
+	}{
+		{"unsupported currency", func(l *models.TreasuryMovementLeg) { l.Currency = "JPY" }},
+		{"wallet account", func(l *models.TreasuryMovementLeg) { l.DebitAccount = "TENANT_WALLET" }},
+		{"same account", func(l *models.TreasuryMovementLeg) { l.CreditAccount = "FX_SETTLEMENT" }},
+		{"zero amount", func(l *models.TreasuryMovementLeg) { l.Amount = d("0") }},
+		{"sub-cent amount", func(l *models.TreasuryMovementLeg) { l.Amount = d("10.005") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leg := valid
+			tt.modify(&leg)
+			assert.Error(t, validateLeg(leg))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Package treasury manages the platform's own positions. It values the
+// FX_SETTLEMENT account of every currency in USD and EUR at the current
+// reference rates, splits P&L into the margin realised on each quote and
+// the unrealised revaluation of the open positions, and keeps hourly
+// snapshots of the report. Treasury rebalances system accounts and books
+// the FX deals covering the positions as movements that one operator
+// requests and another approves before they are posted to the ledger.
+package treasury
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/audit"
+	"kovra/internal/db"
+	"kovra/internal/jobs"
+	"kovra/internal/ledger"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+var (
+	ErrNoEURRate        = errors.New("no USD reference rate for EUR")
+	ErrSnapshotNotFound = errors.New("exposure snapshot not found")
+)
+
+// Ledger is the part of the ledger client treasury uses.
+type Ledger interface {
+	GetBalances(ctx context.Context, ids []ledger.AccountID) (map[ledger.AccountID]ledger.Balance, error)
+	PostTreasuryMovement(ctx context.Context, movementID uuid.UUID, code ledger.TransferCode, legs []ledger.TreasuryLeg) error
+}
+
+// Service reports FX exposure and books treasury movements.
+type Service struct {
+	db           *db.DB
+	repo         *repository.FXExposureRepository
+	movementRepo *repository.TreasuryRepository
+	ledger       Ledger
+	jobClient    *jobs.Client
+	trail        *audit.Trail
+	logger       *zap.Logger
+}
+
+// NewService creates a new treasury service.
+func NewService(
+	database *db.DB,
+	repo *repository.FXExposureRepository,
+	movementRepo *repository.TreasuryRepository,
+	ledgerClient Ledger,
+	jobClient *jobs.Client,
+	trail *audit.Trail,
+	logger *zap.Logger,
+) *Service {
+	return &Service{
+		db:           database,
+		repo:         repo,
+		movementRepo: movementRepo,
+		ledger:       ledgerClient,
+		jobClient:    jobClient,
+		trail:        trail,
+		logger:       logger,
+	}
+}
+
+// Exposure returns the FX exposure as of now.
+func (s *Service) Exposure(ctx context.Context) (*models.FXExposure, error) {
+	rates, err := s.repo.ListUSDRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list usd rates: %w", err)
+	}
+	eurRate, ok := rates["EUR"]
+	if !ok || !eurRate.IsPositive() {
+		return nil, ErrNoEURRate
+	}
+
+	costs, err := s.repo.ListCostBasis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list cost basis: %w", err)
+	}
+
+	// Every ledger currency that has a rate or has been traded
+	codes := make(map[string]ledger.Currency)
+	for c := range rates {
+		if code := ledger.CurrencyFromString(c); code != 0 {
+			codes[c] = code
+		}
+	}
+	for _, c := range costs {
+		if code := ledger.CurrencyFromString(c.Currency); code != 0 {
+			codes[c.Currency] = code
+		}
+	}
+
+	ids := make([]ledger.AccountID, 0, len(codes))
+	for _, code := range codes {
+		ids = append(ids, ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeFXSettlement, code))
+	}
+	found, err := s.ledger.GetBalances(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get fx settlement balances: %w", err)
+	}
+
+	balances := make(map[string]ledger.Balance, len(codes))
+	for c, code := range codes {
+		balances[c] = found[ledger.NewAccountID(ledger.SystemTenantID, ledger.AccountTypeFXSettlement, code)]
+	}
+
+	return buildExposure(balances, costs, rates, eurRate, time.Now()), nil
+}
+
+// Snapshot stores the FX exposure as of now.
+func (s *Service) Snapshot(ctx context.Context) (*models.FXExposure, error) {
+	exposure, err := s.Exposure(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.repo.CreateSnapshot(ctx, exposure)
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// GetSnapshot retrieves an exposure snapshot.
+func (s *Service) GetSnapshot(ctx context.Context, id uuid.UUID) (*models.FXExposure, error) {
+	snapshot, err := s.repo.GetSnapshot(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+	if snapshot == nil {
+		return nil, ErrSnapshotNotFound
+	}
+	return snapshot, nil
+}
+
+// ListSnapshots returns exposure snapshots, newest first.
+func (s *Service) ListSnapshots(ctx context.Context, filter models.FXExposureFilter) ([]*models.FXExposure, error) {
+	return s.repo.ListSnapshots(ctx, filter)
+}
@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"kovra/internal/metrics"
+	"kovra/internal/models"
+	"kovra/internal/repository"
+	"kovra/internal/tracing"
+)
+
+// Retry schedule: 1s, 2s, 4s, ... capped at maxBackoff.
+const (
+	baseBackoff = time.Second
+	maxBackoff  = 32 * time.Second
+)
+
+var errNoSigningSecret = errors.New("tenant has no webhook signing secret")
+
+// Config holds dispatcher configuration.
+type Config struct {
+	MaxAttempts    int
+	BatchSize      int
+	PollInterval   time.Duration
+	Lease          time.Duration
+	RequestTimeout time.Duration
+}
+
+// Store is the persistence used by the dispatcher.
+type Store interface {
+	ClaimDue(ctx context.Context, batchSize int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, statusCode int) error
+	MarkFailed(ctx context.Context, id uuid.UUID, status models.WebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, statusCode *int, lastError string) error
+	RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt int, statusCode *int, errMsg string, duration time.Duration) error
+}
+
+// KeyLookup resolves the HMAC key for a tenant.
+type KeyLookup func(ctx context.Context, tenantID uuid.UUID) (string, error)
+
+// Dispatcher polls pending webhook deliveries and sends them.
+type Dispatcher struct {
+	store  Store
+	keys   KeyLookup
+	sender *Sender
+	cfg    Config
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+// NewDispatcher creates a new webhook dispatcher.
+func NewDispatcher(store Store, keys KeyLookup, cfg Config, logger *zap.Logger) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = 10 * time.Second
+	}
+	if cfg.Lease <= cfg.RequestTimeout {
+		cfg.Lease = 2 * cfg.RequestTimeout
+	}
+
+	return &Dispatcher{
+		store:  store,
+		keys:   keys,
+		sender: NewSender(cfg.RequestTimeout),
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// TenantKeyLookup resolves signing keys from tenants.webhook_signing_key,
+// unsealing them with keys.
+func TenantKeyLookup(tenantRepo *repository.TenantRepository, keys *KeyCipher) KeyLookup {
+	return func(ctx context.Context, tenantID uuid.UUID) (string, error) {
+		tenant, err := tenantRepo.GetByID(ctx, tenantID)
+		if err != nil {
+			return "", err
+		}
+		if tenant == nil || tenant.WebhookSigningKey == nil {
+			return "", errNoSigningSecret
+		}
+		return keys.Open(tenant.ID, *tenant.WebhookSigningKey)
+	}
+}
+
+// Backoff returns the delay before the next attempt after the given
+// number of failed attempts.
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	if attempts > 6 {
+		return maxBackoff
+	}
+	d := baseBackoff << (attempts - 1)
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+// Run polls for due deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	d.logger.Info("starting webhook dispatcher",
+		zap.Duration("poll_interval", d.cfg.PollInterval),
+		zap.Int("max_attempts", d.cfg.MaxAttempts),
+	)
+
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain full batches before waiting for the next tick
+		for {
+			n, err := d.DispatchOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				d.logger.Error("webhook dispatch failed", zap.Error(err))
+			}
+			if err != nil || n < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logger.Info("webhook dispatcher stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce claims one batch of due deliveries and attempts each of them.
+// It returns the number of deliveries claimed.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimDue(ctx, d.cfg.BatchSize, d.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.deliver(ctx, delivery); err != nil {
+			d.logger.Error("failed to record webhook delivery",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// deliver makes an attempt at a delivery in a span of the trace that created
+// it, and records the outcome.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	attempt := delivery.Attempts + 1
+	ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, delivery.TraceContext), "webhook deliver "+string(delivery.EventType),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("webhook.delivery_id", delivery.ID.String()),
+			attribute.String("webhook.event_id", delivery.EventID.String()),
+			attribute.Int("webhook.attempt", attempt),
+		),
+	)
+	defer span.End()
+	start := d.now()
+
+	var statusCode int
+	key, err := d.keys(ctx, delivery.TenantID)
+	if err == nil {
+		statusCode, err = d.sender.Send(ctx, Request{
+			URL:       delivery.URL,
+			Key:       key,
+			EventID:   delivery.EventID,
+			EventType: delivery.EventType,
+			Attempt:   attempt,
+			Payload:   delivery.Payload,
+		})
+	}
+	duration := d.now().Sub(start)
+
+	var code *int
+	if statusCode != 0 {
+		code = &statusCode
+	}
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+		span.SetStatus(codes.Error, errMsg)
+	}
+	if code != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+
+	if recErr := d.store.RecordAttempt(ctx, delivery.ID, attempt, code, errMsg, duration); recErr != nil {
+		return recErr
+	}
+
+	if err == nil {
+		metrics.WebhookDelivered(string(models.WebhookDeliveryStatusDelivered))
+		return d.store.MarkDelivered(ctx, delivery.ID, attempt, statusCode)
+	}
+
+	status := models.WebhookDeliveryStatusPending
+	if attempt >= d.cfg.MaxAttempts {
+		status = models.WebhookDeliveryStatusDead
+		d.logger.Warn("webhook delivery dead-lettered",
+			zap.String("delivery_id", delivery.ID.String()),
+			zap.String("tenant_id", delivery.TenantID.String()),
+			zap.Int("attempts", attempt),
+			zap.Error(err),
+		)
+	}
+	metrics.WebhookDelivered(string(status))
+
+	return d.store.MarkFailed(ctx, delivery.ID, status, attempt, d.now().Add(Backoff(attempt)), code, errMsg)
+}
@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"kovra/internal/models"
+)
+
+// memoryStore is an in-memory Store for dispatcher tests.
+type memoryStore struct {
+	mu         sync.Mutex
+	deliveries map[uuid.UUID]*models.WebhookDelivery
+	attempts   []int
+}
+
+func newMemoryStore(deliveries ...*models.WebhookDelivery) *memoryStore {
+	s := &memoryStore{deliveries: make(map[uuid.UUID]*models.WebhookDelivery)}
+	for _, d := range deliveries {
+		s.deliveries[d.ID] = d
+	}
+	return s
+}
+
+func (s *memoryStore) ClaimDue(ctx context.Context, batchSize int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []*models.WebhookDelivery
+	for _, d := range s.deliveries {
+		if d.Status == models.WebhookDeliveryStatusPending && len(due) < batchSize {
+			copied := *d
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (s *memoryStore) MarkDelivered(ctx context.Context, id uuid.UUID, attempts int, statusCode int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.deliveries[id]
+	d.Status = models.WebhookDeliveryStatusDelivered
+	d.Attempts = attempts
+	d.LastStatusCode = &statusCode
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(ctx context.Context, id uuid.UUID, status models.WebhookDeliveryStatus, attempts int, nextAttemptAt time.Time, statusCode *int, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := s.deliveries[id]
+	d.Status = status
+	d.Attempts = attempts
+	d.NextAttemptAt = nextAttemptAt
+	d.LastStatusCode = statusCode
+	d.LastError = &lastError
+	return nil
+}
+
+func (s *memoryStore) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt int, statusCode *int, errMsg string, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts = append(s.attempts, attempt)
+	return nil
+}
+
+func newTestDelivery(t *testing.T, url string) *models.WebhookDelivery {
+	t.Helper()
+
+	eventID := uuid.New()
+	payload, err := NewEnvelope(eventID, models.WebhookEventTransferStatusChanged, time.Now().UTC(), TransferStatusChanged{
+		TransferID: uuid.New(),
+		Status:     models.TransferStatusCreated,
+	})
+	require.NoError(t, err)
+
+	return &models.WebhookDelivery{
+		ID:        uuid.New(),
+		TenantID:  uuid.New(),
+		EventID:   eventID,
+		EventType: models.WebhookEventTransferStatusChanged,
+		URL:       url,
+		Payload:   payload,
+		Status:    models.WebhookDeliveryStatusPending,
+	}
+}
+
+func TestDispatcherDeliversSignedPayload(t *testing.T) {
+	key := SigningKey("whsec_test")
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery := newTestDelivery(t, receiver.URL)
+	store := newMemoryStore(delivery)
+	keys := func(ctx context.Context, tenantID uuid.UUID) (string, error) { return key, nil }
+
+	d := NewDispatcher(store, keys, Config{}, zap.NewNop())
+	n, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	require.NotNil(t, received)
+	assert.Equal(t, delivery.EventID.String(), received.Header.Get(HeaderEventID))
+	assert.Equal(t, "1", received.Header.Get(HeaderAttempt))
+	assert.NoError(t, Verify(key, received.Header.Get(HeaderSignature), body, 5*time.Minute, time.Now()))
+	assert.ErrorIs(t, Verify("wrong", received.Header.Get(HeaderSignature), body, 0, time.Now()), ErrSignatureMismatch)
+
+	assert.Equal(t, models.WebhookDeliveryStatusDelivered, store.deliveries[delivery.ID].Status)
+	assert.Equal(t, []int{1}, store.attempts)
+}
+
+func TestDispatcherDeadLettersAfterMaxAttempts(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	delivery := newTestDelivery(t, receiver.URL)
+	store := newMemoryStore(delivery)
+	keys := func(ctx context.Context, tenantID uuid.UUID) (string, error) { return "key", nil }
+
+	d := NewDispatcher(store, keys, Config{MaxAttempts: 3}, zap.NewNop())
+	for i := 0; i < 5; i++ {
+		_, err := d.DispatchOnce(context.Background())
+		require.NoError(t, err)
+	}
+
+	got := store.deliveries[delivery.ID]
+	assert.True(t, got.IsDead())
+	assert.Equal(t, 3, got.Attempts)
+	require.NotNil(t, got.LastStatusCode)
+	assert.Equal(t, http.StatusInternalServerError, *got.LastStatusCode)
+	assert.Equal(t, []int{1, 2, 3}, store.attempts)
+}
+
+func TestBackoff(t *testing.T) {
+	expected := []time.Duration{
+		1 * time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		32 * time.Second,
+		32 * time.Second,
+		32 * time.Second,
+	}
+	for i, want := range expected {
+		assert.Equal(t, want, Backoff(i+1), "attempt %d", i+1)
+	}
+}
+
+func TestDispatcherPropagatesTraceContext(t *testing.T) {
+	var received *http.Request
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	delivery := newTestDelivery(t, receiver.URL)
+	delivery.TraceContext = []byte(`{"traceparent":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}`)
+	keys := func(ctx context.Context, tenantID uuid.UUID) (string, error) { return SigningKey("whsec_test"), nil }
+
+	d := NewDispatcher(newMemoryStore(delivery), keys, Config{}, zap.NewNop())
+	_, err := d.DispatchOnce(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, received)
+	assert.Contains(t, received.Header.Get("traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736",
+		"the delivery continues the trace that created it")
+}
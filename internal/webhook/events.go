@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"kovra/internal/models"
+)
+
+// TransferStatusChanged is the data of a transfer.status_changed event.
+type TransferStatusChanged struct {
+	TransferID       uuid.UUID              `json:"transfer_id"`
+	Status           models.TransferStatus  `json:"status"`
+	PreviousStatus   *models.TransferStatus `json:"previous_status,omitempty"`
+	FailureReason    *string                `json:"failure_reason,omitempty"`
+	ComplianceStatus string                 `json:"compliance_status,omitempty"`
+	FromCurrency     string                 `json:"from_currency"`
+	ToCurrency       string                 `json:"to_currency"`
+	FromAmount       decimal.Decimal        `json:"from_amount"`
+	ToAmount         decimal.Decimal        `json:"to_amount"`
+	Rail             *models.Rail           `json:"rail,omitempty"`
+}
+
+// NewTransferStatusChanged builds the event data for a transfer that moved
+// to status from previous. previous is nil for newly created transfers.
+func NewTransferStatusChanged(t *models.Transfer, status models.TransferStatus, previous *models.TransferStatus, failureReason *string) TransferStatusChanged {
+	return TransferStatusChanged{
+		TransferID:       t.ID,
+		Status:           status,
+		PreviousStatus:   previous,
+		FailureReason:    failureReason,
+		ComplianceStatus: t.ComplianceStatus,
+		FromCurrency:     t.FromCurrency,
+		ToCurrency:       t.ToCurrency,
+		FromAmount:       t.FromAmount,
+		ToAmount:         t.ToAmount,
+		Rail:             t.Rail,
+	}
+}
+
+// Sources of a wallet credit.
+const (
+	CreditSourceDeposit = "deposit"
+	CreditSourceRefund  = "refund"
+)
+
+// WalletCredited is the data of a wallet.credited event, sent when an
+// inbound payment matched to an expected deposit, or a refund of a payout,
+// is credited to a wallet.
+type WalletCredited struct {
+	WalletID          uuid.UUID       `json:"wallet_id"`
+	Currency          string          `json:"currency"`
+	Amount            decimal.Decimal `json:"amount"`
+	Source            string          `json:"source"`
+	Reference         string          `json:"reference,omitempty"`
+	ExpectedDepositID *uuid.UUID      `json:"expected_deposit_id,omitempty"`
+	TransferID        *uuid.UUID      `json:"transfer_id,omitempty"`
+	RefundID          *uuid.UUID      `json:"refund_id,omitempty"`
+}
+
+// IsWebhookEvent returns true if events of this type are delivered to tenants.
+func IsWebhookEvent(eventType string) bool {
+	switch models.WebhookEventType(eventType) {
+	case models.WebhookEventTransferStatusChanged,
+		models.WebhookEventWalletCredited:
+		return true
+	}
+	return false
+}
+
+// NewEnvelope marshals an event envelope for delivery.
+func NewEnvelope(eventID uuid.UUID, eventType models.WebhookEventType, createdAt time.Time, data any) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event data: %w", err)
+	}
+
+	payload, err := json.Marshal(models.WebhookEvent{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: createdAt,
+		Data:      raw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal event envelope: %w", err)
+	}
+	return payload, nil
+}
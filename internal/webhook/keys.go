@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"kovra/internal/repository"
+)
+
+// sealedPrefix marks a sealed signing key and the version of its format:
+// base64 of the GCM nonce followed by the ciphertext.
+const sealedPrefix = "v1:"
+
+var ErrUnsealKey = errors.New("cannot unseal webhook signing key")
+
+// KeyCipher seals the webhook signing keys of tenants for storage, with
+// AES-256-GCM under the key encryption key of the service. The tenant ID is
+// authenticated with the key, so a sealed key copied to another tenant
+// does not unseal.
+type KeyCipher struct {
+	aead cipher.AEAD
+}
+
+// NewKeyCipher returns a cipher for the hex encoded 32 byte key encryption
+// key.
+func NewKeyCipher(hexKey string) (*KeyCipher, error) {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode key encryption key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyCipher{aead: aead}, nil
+}
+
+// Seal encrypts a tenant's signing key for storage.
+func (c *KeyCipher) Seal(tenantID uuid.UUID, key string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := c.aead.Seal(nonce, nonce, []byte(key), tenantID[:])
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a tenant's sealed signing key.
+func (c *KeyCipher) Open(tenantID uuid.UUID, sealed string) (string, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return "", fmt.Errorf("%w: unknown format", ErrUnsealKey)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) < c.aead.NonceSize() {
+		return "", fmt.Errorf("%w: malformed", ErrUnsealKey)
+	}
+	nonce, ciphertext := raw[:c.aead.NonceSize()], raw[c.aead.NonceSize():]
+	key, err := c.aead.Open(nil, nonce, ciphertext, tenantID[:])
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnsealKey, err)
+	}
+	return string(key), nil
+}
+
+// SealStoredKeys seals the signing keys stored in plaintext before keys were
+// sealed. It is idempotent, and runs at startup.
+func SealStoredKeys(ctx context.Context, tenantRepo *repository.TenantRepository, keys *KeyCipher, logger *zap.Logger) error {
+	tenants, err := tenantRepo.ListWithUnsealedWebhookKey(ctx)
+	if err != nil {
+		return fmt.Errorf("list unsealed webhook keys: %w", err)
+	}
+	for _, tenant := range tenants {
+		sealed, err := keys.Seal(tenant.ID, *tenant.WebhookSigningKey)
+		if err != nil {
+			return err
+		}
+		if err := tenantRepo.SealWebhookSigningKey(ctx, tenant.ID, *tenant.WebhookSigningKey, sealed); err != nil {
+			return fmt.Errorf("seal webhook key of tenant %s: %w", tenant.ID, err)
+		}
+	}
+	if len(tenants) > 0 {
+		logger.Info("sealed stored webhook signing keys", zap.Int("tenants", len(tenants)))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKEK = "00112233445566778899aabbccddeeff00112233445566778899aabbccddeeff"
+
+func TestKeyCipherRoundTrip(t *testing.T) {
+	keys, err := NewKeyCipher(testKEK)
+	require.NoError(t, err)
+
+	tenantID := uuid.New()
+	key := SigningKey("whsec_test")
+	sealed, err := keys.Seal(tenantID, key)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, sealedPrefix))
+	assert.NotContains(t, sealed, key, "the key is not stored in the clear")
+
+	opened, err := keys.Open(tenantID, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, key, opened)
+
+	again, err := keys.Seal(tenantID, key)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, again, "each seal uses a fresh nonce")
+}
+
+func TestKeyCipherRejects(t *testing.T) {
+	keys, err := NewKeyCipher(testKEK)
+	require.NoError(t, err)
+
+	tenantID := uuid.New()
+	key := SigningKey("whsec_test")
+	sealed, err := keys.Seal(tenantID, key)
+	require.NoError(t, err)
+
+	_, err = keys.Open(uuid.New(), sealed)
+	assert.ErrorIs(t, err, ErrUnsealKey, "a key copied to another tenant")
+
+	other, err := NewKeyCipher(strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	_, err = other.Open(tenantID, sealed)
+	assert.ErrorIs(t, err, ErrUnsealKey, "another key encryption key")
+
+	_, err = keys.Open(tenantID, key)
+	assert.ErrorIs(t, err, ErrUnsealKey, "a plaintext key")
+
+	_, err = NewKeyCipher("")
+	assert.Error(t, err)
+	_, err = NewKeyCipher("0011")
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"kovra/internal/models"
+	"kovra/internal/tracing"
+)
+
+// Request is a single signed webhook HTTP call.
+type Request struct {
+	URL       string
+	Key       string // HMAC key, unsealed from tenants.webhook_signing_key
+	EventID   uuid.UUID
+	EventType models.WebhookEventType
+	Attempt   int
+	Payload   []byte
+}
+
+// Sender posts signed webhook payloads to tenant endpoints.
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender creates a new sender with the given per-request timeout.
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// Send delivers the request and returns the HTTP status code, passing on
+// the trace context of ctx in the traceparent header.
+// Any non-2xx response is returned as an error together with its status code.
+func (s *Sender) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "Kovra-Webhooks/1.0")
+	httpReq.Header.Set(HeaderSignature, Sign(req.Key, s.now(), req.Payload))
+	httpReq.Header.Set(HeaderEventID, req.EventID.String())
+	httpReq.Header.Set(HeaderEventType, string(req.EventType))
+	httpReq.Header.Set(HeaderAttempt, strconv.Itoa(req.Attempt))
+	tracing.InjectHeaders(ctx, httpReq.Header)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set on every webhook request.
+const (
+	HeaderSignature = "Kovra-Signature"
+	HeaderEventID   = "Kovra-Event-Id"
+	HeaderEventType = "Kovra-Event-Type"
+	HeaderAttempt   = "Kovra-Delivery-Attempt"
+)
+
+// secretPrefix marks webhook signing secrets handed out to tenants.
+const secretPrefix = "whsec_"
+
+var (
+	ErrInvalidSignatureHeader = errors.New("invalid signature header")
+	ErrSignatureMismatch      = errors.New("signature mismatch")
+	ErrSignatureExpired       = errors.New("signature timestamp outside tolerance")
+)
+
+// GenerateSecret creates a new random webhook signing secret.
+// The secret is shown to the tenant once; only the signing key derived from
+// it is stored, sealed.
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// SigningKey returns the HMAC key of a secret: its hex SHA-256, which
+// receivers derive the same way from the secret they were given.
+//
+// The key signs webhooks on its own, so whoever holds it can forge them: it
+// is as sensitive as the secret, and is stored sealed with a KeyCipher.
+func SigningKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sign computes the signature header value for a payload.
+// Format: t=<unix seconds>,v1=<hex HMAC-SHA256(key, "<t>.<body>")>
+func Sign(key string, ts time.Time, body []byte) string {
+	t := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", t, computeMAC(key, t, body))
+}
+
+// Verify checks a signature header against a payload.
+// A zero tolerance disables the timestamp check.
+func Verify(key string, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return ErrInvalidSignatureHeader
+		}
+		switch k {
+		case "t":
+			t = v
+		case "v1":
+			v1 = v
+		}
+	}
+	if t == "" || v1 == "" {
+		return ErrInvalidSignatureHeader
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return ErrInvalidSignatureHeader
+	}
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(unix, 0))
+		if age > tolerance || age < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+
+	expected := computeMAC(key, t, body)
+	if !hmac.Equal([]byte(expected), []byte(v1)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+func computeMAC(key, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+
+	"kovra/internal/models"
+	"kovra/internal/repository"
+)
+
+// Sink is an outbox sink that enqueues webhook deliveries for tenant-facing events.
+// The outbox event ID becomes the webhook event ID, so re-publishing an event
+// does not enqueue a second delivery.
+type Sink struct {
+	repo       *repository.WebhookDeliveryRepository
+	tenantRepo *repository.TenantRepository
+}
+
+// NewSink creates a new webhook outbox sink.
+func NewSink(repo *repository.WebhookDeliveryRepository, tenantRepo *repository.TenantRepository) *Sink {
+	return &Sink{
+		repo:       repo,
+		tenantRepo: tenantRepo,
+	}
+}
+
+// Name returns the sink name.
+func (s *Sink) Name() string {
+	return "webhook"
+}
+
+// Publish enqueues a delivery within tx.
+// Tenants without a webhook URL or signing secret are skipped.
+func (s *Sink) Publish(ctx context.Context, tx pgx.Tx, event *models.OutboxEvent) error {
+	if !IsWebhookEvent(event.EventType) || event.TenantID == nil {
+		return nil
+	}
+
+	tenant, err := s.tenantRepo.WithTx(tx).GetByID(ctx, *event.TenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil || tenant.WebhookURL == nil || *tenant.WebhookURL == "" || tenant.WebhookSigningKey == nil {
+		return nil
+	}
+
+	eventType := models.WebhookEventType(event.EventType)
+	payload, err := NewEnvelope(event.ID, eventType, event.CreatedAt, json.RawMessage(event.Payload))
+	if err != nil {
+		return err
+	}
+
+	return s.repo.WithTx(tx).Create(ctx, models.CreateWebhookDeliveryParams{
+		TenantID:  tenant.ID,
+		EventID:   event.ID,
+		EventType: eventType,
+		URL:       *tenant.WebhookURL,
+		Payload:   payload,
+	})
+}
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Webhook deliveries act as the delivery outbox: a row is inserted in the same
+-- transaction as the state change, and the dispatcher picks it up afterwards.
+-- status: pending → delivered | dead (after max attempts)
+CREATE TABLE webhook_deliveries (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    -- Event identity (stable across retries and replays)
+    event_id                UUID NOT NULL,
+    event_type              VARCHAR(50) NOT NULL,
+    -- Target URL snapshot at enqueue time
+    url                     VARCHAR(500) NOT NULL,
+    -- Signed event envelope {id, type, created_at, data}
+    payload                 JSONB NOT NULL,
+    -- Delivery state
+    status                  VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts                INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Lease held by a dispatcher while the HTTP call is in flight
+    locked_until            TIMESTAMPTZ,
+    last_status_code        INTEGER,
+    last_error              TEXT,
+    delivered_at            TIMESTAMPTZ,
+    -- Timestamps
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- One delivery per event per tenant
+    CONSTRAINT unique_webhook_event UNIQUE (tenant_id, event_id),
+    CONSTRAINT chk_webhook_status CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+-- Delivery log: one row per HTTP attempt
+CREATE TABLE webhook_delivery_attempts (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    delivery_id             UUID NOT NULL REFERENCES webhook_deliveries(id) ON DELETE CASCADE,
+    attempt                 INTEGER NOT NULL,
+    status_code             INTEGER,
+    error                   TEXT,
+    duration_ms             INTEGER NOT NULL,
+    attempted_at            TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_tenant ON webhook_deliveries(tenant_id, id DESC);
+CREATE INDEX idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id, attempt);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+-- +goose StatementEnd
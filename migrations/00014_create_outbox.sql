@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Transactional outbox: domain events are inserted in the same transaction as
+-- the state change that produced them, then published by the relay.
+-- Delivery is at-least-once and ordered per aggregate (by seq).
+CREATE TABLE outbox (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    -- Commit-independent ordering key within an aggregate
+    seq                     BIGINT GENERATED ALWAYS AS IDENTITY,
+    -- Aggregate the event belongs to (e.g. 'transfer', <transfer id>)
+    aggregate_type          VARCHAR(50) NOT NULL,
+    aggregate_id            UUID NOT NULL,
+    tenant_id               UUID REFERENCES tenants(id) ON DELETE CASCADE,
+    event_type              VARCHAR(50) NOT NULL,
+    payload                 JSONB NOT NULL,
+    -- Relay state
+    attempts                INTEGER NOT NULL DEFAULT 0,
+    available_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error              TEXT,
+    published_at            TIMESTAMPTZ,
+    -- Timestamps
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE UNIQUE INDEX idx_outbox_seq ON outbox(seq);
+CREATE INDEX idx_outbox_unpublished ON outbox(aggregate_type, aggregate_id, seq)
+    WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_published_at ON outbox(published_at)
+    WHERE published_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS outbox;
+
+-- +goose StatementEnd
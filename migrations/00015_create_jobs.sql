@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Background job queue, consumed with SELECT ... FOR UPDATE SKIP LOCKED.
+-- state: available → running → completed | discarded
+--        running → available (retry with backoff, or rescued after lease expiry)
+CREATE TABLE jobs (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    kind                    VARCHAR(100) NOT NULL,
+    queue                   VARCHAR(50) NOT NULL DEFAULT 'default',
+    args                    JSONB NOT NULL DEFAULT '{}',
+    priority                SMALLINT NOT NULL DEFAULT 1,
+    -- Execution state
+    state                   VARCHAR(20) NOT NULL DEFAULT 'available',
+    attempt                 INTEGER NOT NULL DEFAULT 0,
+    max_attempts            INTEGER NOT NULL DEFAULT 25,
+    scheduled_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempted_at            TIMESTAMPTZ,
+    -- Lease held by the worker running the job
+    locked_until            TIMESTAMPTZ,
+    finalized_at            TIMESTAMPTZ,
+    last_error              TEXT,
+    -- Deduplication key: at most one unfinished job per (kind, unique_key)
+    unique_key              VARCHAR(255),
+    -- Timestamps
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_job_state CHECK (state IN ('available', 'running', 'completed', 'discarded')),
+    CONSTRAINT chk_job_priority CHECK (priority BETWEEN 1 AND 4)
+);
+
+-- Indexes
+CREATE INDEX idx_jobs_fetch ON jobs(queue, priority, scheduled_at)
+    WHERE state = 'available';
+CREATE INDEX idx_jobs_running ON jobs(locked_until)
+    WHERE state = 'running';
+CREATE INDEX idx_jobs_finalized ON jobs(finalized_at)
+    WHERE finalized_at IS NOT NULL;
+CREATE UNIQUE INDEX idx_jobs_unique ON jobs(kind, unique_key)
+    WHERE unique_key IS NOT NULL AND state IN ('available', 'running');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS jobs;
+
+-- +goose StatementEnd
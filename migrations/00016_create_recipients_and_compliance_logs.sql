@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Recipients are the beneficiaries of a tenant's transfers (transfers.recipient_id)
+CREATE TABLE recipients (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    -- Beneficiary identity (screened against sanctions lists)
+    name                    VARCHAR(200) NOT NULL,
+    country                 CHAR(2) NOT NULL,
+    -- Beneficiary account
+    account_number          VARCHAR(50),
+    bank_code               VARCHAR(20),
+    -- Metadata
+    metadata                JSONB NOT NULL DEFAULT '{}',
+    -- Timestamps
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Compliance logs: one row per screening run of a transfer
+-- result: clear | hit
+CREATE TABLE compliance_logs (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    screening_type          VARCHAR(30) NOT NULL,
+    result                  VARCHAR(20) NOT NULL,
+    risk_score              INTEGER,
+    -- Matches and list versions used for the decision
+    raw_response            JSONB,
+    screened_by             VARCHAR(100) NOT NULL DEFAULT 'system',
+    screened_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_recipients_tenant ON recipients(tenant_id);
+CREATE INDEX idx_compliance_logs_transfer ON compliance_logs(transfer_id, screened_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS compliance_logs;
+DROP TABLE IF EXISTS recipients;
+
+-- +goose StatementEnd
@@ -0,0 +1,158 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Manual review cases for transfers held by compliance checks
+-- status: open → pending_approval (four-eyes) → approved | rejected
+--         open | pending_approval → escalated → ...
+CREATE TABLE compliance_cases (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    compliance_region       TEXT NOT NULL DEFAULT 'UNKNOWN',
+    reason                  VARCHAR(50) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'open',
+    priority                VARCHAR(10) NOT NULL DEFAULT 'normal',
+    risk_score              INTEGER,
+    -- Review
+    assigned_to             VARCHAR(100),
+    decision                VARCHAR(20),
+    decision_reason         TEXT,
+    decided_by              VARCHAR(100),
+    -- Four-eyes: approval by decided_by must be confirmed by a second reviewer
+    requires_second_approval BOOLEAN NOT NULL DEFAULT FALSE,
+    approved_by             VARCHAR(100),
+    -- SLA
+    due_at                  TIMESTAMPTZ NOT NULL,
+    sla_breached_at         TIMESTAMPTZ,
+    closed_at               TIMESTAMPTZ,
+    -- Timestamps
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_case_status CHECK (status IN ('open', 'escalated', 'pending_approval', 'approved', 'rejected')),
+    CONSTRAINT chk_case_priority CHECK (priority IN ('normal', 'high')),
+    CONSTRAINT chk_case_second_approver CHECK (approved_by IS NULL OR approved_by <> decided_by)
+);
+
+CREATE TABLE compliance_case_notes (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    case_id                 UUID NOT NULL REFERENCES compliance_cases(id) ON DELETE CASCADE,
+    author                  VARCHAR(100) NOT NULL,
+    body                    TEXT NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Compliance logs hold screening runs and every case action. They are
+-- geo-partitioned by compliance_region like transfers, for data residency.
+ALTER TABLE compliance_logs RENAME TO compliance_logs_unpartitioned;
+ALTER TABLE compliance_logs_unpartitioned RENAME CONSTRAINT compliance_logs_pkey TO compliance_logs_unpartitioned_pkey;
+
+CREATE TABLE compliance_logs (
+    id                      UUID NOT NULL DEFAULT uuidv7(),
+    compliance_region       TEXT NOT NULL DEFAULT 'UNKNOWN',
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    case_id                 UUID,
+    screening_type          VARCHAR(30) NOT NULL,
+    result                  VARCHAR(30) NOT NULL,
+    risk_score              INTEGER,
+    -- Matches and list versions, or the details of a case action
+    raw_response            JSONB,
+    screened_by             VARCHAR(100) NOT NULL DEFAULT 'system',
+    screened_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (id, compliance_region)
+) PARTITION BY LIST (compliance_region);
+
+CREATE TABLE compliance_logs_id PARTITION OF compliance_logs FOR VALUES IN ('ID');
+CREATE TABLE compliance_logs_eu PARTITION OF compliance_logs FOR VALUES IN ('EU');
+CREATE TABLE compliance_logs_uk PARTITION OF compliance_logs FOR VALUES IN ('UK');
+CREATE TABLE compliance_logs_unknown PARTITION OF compliance_logs FOR VALUES IN ('UNKNOWN');
+
+INSERT INTO compliance_logs (id, compliance_region, transfer_id, tenant_id, screening_type, result,
+    risk_score, raw_response, screened_by, screened_at)
+SELECT l.id, COALESCE(t.compliance_region, 'UNKNOWN'), l.transfer_id, l.tenant_id, l.screening_type, l.result,
+    l.risk_score, l.raw_response, l.screened_by, l.screened_at
+FROM compliance_logs_unpartitioned l
+LEFT JOIN transfers t ON t.id = l.transfer_id;
+
+DROP TABLE compliance_logs_unpartitioned;
+
+-- Same residency policies as the transfer partitions
+ALTER TABLE compliance_logs_id ENABLE ROW LEVEL SECURITY;
+ALTER TABLE compliance_logs_eu ENABLE ROW LEVEL SECURITY;
+ALTER TABLE compliance_logs_uk ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY ojk_data_residency ON compliance_logs_id
+    FOR ALL
+    TO PUBLIC
+    USING (
+        pg_has_role(current_user, 'kovra_id_region', 'MEMBER') OR
+        pg_has_role(current_user, 'kovra_global', 'MEMBER') OR
+        current_user = 'kovra'
+    );
+
+CREATE POLICY gdpr_data_residency ON compliance_logs_eu
+    FOR ALL
+    TO PUBLIC
+    USING (
+        pg_has_role(current_user, 'kovra_eu_region', 'MEMBER') OR
+        pg_has_role(current_user, 'kovra_global', 'MEMBER') OR
+        current_user = 'kovra'
+    );
+
+CREATE POLICY fca_data_residency ON compliance_logs_uk
+    FOR ALL
+    TO PUBLIC
+    USING (
+        pg_has_role(current_user, 'kovra_uk_region', 'MEMBER') OR
+        pg_has_role(current_user, 'kovra_global', 'MEMBER') OR
+        current_user = 'kovra'
+    );
+
+-- Indexes
+CREATE UNIQUE INDEX idx_compliance_cases_active ON compliance_cases(transfer_id)
+    WHERE closed_at IS NULL;
+CREATE INDEX idx_compliance_cases_queue ON compliance_cases(status, due_at)
+    WHERE closed_at IS NULL;
+CREATE INDEX idx_compliance_cases_assignee ON compliance_cases(assigned_to)
+    WHERE closed_at IS NULL;
+CREATE INDEX idx_compliance_case_notes_case ON compliance_case_notes(case_id, created_at);
+CREATE INDEX idx_compliance_logs_transfer ON compliance_logs(transfer_id, screened_at);
+CREATE INDEX idx_compliance_logs_case ON compliance_logs(case_id, screened_at)
+    WHERE case_id IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE compliance_logs RENAME TO compliance_logs_partitioned;
+ALTER TABLE compliance_logs_partitioned RENAME CONSTRAINT compliance_logs_pkey TO compliance_logs_partitioned_pkey;
+
+CREATE TABLE compliance_logs (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    screening_type          VARCHAR(30) NOT NULL,
+    result                  VARCHAR(20) NOT NULL,
+    risk_score              INTEGER,
+    raw_response            JSONB,
+    screened_by             VARCHAR(100) NOT NULL DEFAULT 'system',
+    screened_at             TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO compliance_logs (id, transfer_id, tenant_id, screening_type, result,
+    risk_score, raw_response, screened_by, screened_at)
+SELECT id, transfer_id, tenant_id, screening_type, result,
+    risk_score, raw_response, screened_by, screened_at
+FROM compliance_logs_partitioned
+WHERE case_id IS NULL;
+
+DROP TABLE compliance_logs_partitioned;
+
+CREATE INDEX idx_compliance_logs_transfer ON compliance_logs(transfer_id, screened_at);
+
+DROP TABLE IF EXISTS compliance_case_notes;
+DROP TABLE IF EXISTS compliance_cases;
+
+-- +goose StatementEnd
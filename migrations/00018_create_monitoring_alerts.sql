@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Transaction monitoring alerts: one row per rule matched by a transfer
+CREATE TABLE monitoring_alerts (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    compliance_region       TEXT NOT NULL DEFAULT 'UNKNOWN',
+    rule_id                 VARCHAR(100) NOT NULL,
+    rule_type               VARCHAR(30) NOT NULL,
+    score                   INTEGER NOT NULL,
+    -- Window totals and counts that triggered the rule
+    details                 JSONB NOT NULL DEFAULT '{}',
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_monitoring_alerts_transfer ON monitoring_alerts(transfer_id);
+CREATE INDEX idx_monitoring_alerts_tenant ON monitoring_alerts(tenant_id, created_at DESC);
+CREATE INDEX idx_monitoring_alerts_rule ON monitoring_alerts(rule_id, created_at DESC);
+
+-- Monitoring reads a tenant's recent transfers by id (UUIDv7) range
+CREATE INDEX idx_transfers_tenant_id_range ON transfers(tenant_id, id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transfers_tenant_id_range;
+DROP TABLE IF EXISTS monitoring_alerts;
+
+-- +goose StatementEnd
@@ -0,0 +1,93 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- KYC submissions: document metadata sent for review and the reviewer decision.
+-- Approval raises the tenant to requested_level; files live outside the database.
+-- status: pending → approved | rejected
+CREATE TABLE kyc_submissions (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    requested_level         kyc_level_enum NOT NULL,
+    -- [{"type": "certificate_of_incorporation", "reference": "...", "sha256": "...", "issued_country": "ID"}]
+    documents               JSONB NOT NULL DEFAULT '[]',
+    submitted_by            VARCHAR(100) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'pending',
+    -- Review
+    reviewed_by             VARCHAR(100),
+    review_reason           TEXT,
+    reviewed_at             TIMESTAMPTZ,
+    -- Timestamps
+    submitted_at            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_kyc_submission_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT chk_kyc_submission_reviewer CHECK (reviewed_by IS NULL OR reviewed_by <> submitted_by)
+);
+
+-- One submission under review per tenant
+CREATE UNIQUE INDEX idx_kyc_submissions_pending ON kyc_submissions(tenant_id) WHERE status = 'pending';
+CREATE INDEX idx_kyc_submissions_tenant ON kyc_submissions(tenant_id, submitted_at DESC);
+
+-- Lifecycle history of tenants: pending_kyc → active ⇄ suspended → closed
+CREATE TABLE tenant_status_changes (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id               UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    from_status             tenant_status_enum NOT NULL,
+    to_status               tenant_status_enum NOT NULL,
+    reason                  TEXT NOT NULL,
+    changed_by              VARCHAR(100) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tenant_status_changes_tenant ON tenant_status_changes(tenant_id, created_at DESC);
+
+-- Transaction caps per KYC tier (USD equivalent). The effective cap is the
+-- lower of the tier cap and the tenant's limit policy; NULL means no tier cap.
+CREATE TABLE kyc_tier_limits (
+    kyc_level               kyc_level_enum PRIMARY KEY,
+    per_transfer_limit_usd  NUMERIC(15,2),
+    daily_limit_usd         NUMERIC(15,2),
+    monthly_limit_usd       NUMERIC(15,2),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO kyc_tier_limits (kyc_level, per_transfer_limit_usd, daily_limit_usd, monthly_limit_usd) VALUES
+    ('basic', 0, 0, 0),
+    ('standard', 25000, 100000, 1000000),
+    ('enhanced', NULL, NULL, NULL);
+
+-- Reference rates for valuing amounts in USD against limits
+CREATE TABLE usd_reference_rates (
+    currency                CHAR(3) PRIMARY KEY,
+    -- USD per one unit of currency
+    usd_rate                NUMERIC(20,10) NOT NULL,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_usd_rate_positive CHECK (usd_rate > 0)
+);
+
+INSERT INTO usd_reference_rates (currency, usd_rate) VALUES
+    ('USD', 1),
+    ('EUR', 1.08),
+    ('GBP', 1.27),
+    ('IDR', 0.0000625),
+    ('SEK', 0.095),
+    ('DKK', 0.145);
+
+-- Wallets are frozen in the ledger with a pending closing transfer, voided
+-- to unfreeze and posted to close the account for good
+ALTER TABLE wallets ADD COLUMN ledger_freeze_id UUID;
+ALTER TABLE wallets ADD CONSTRAINT chk_wallet_status CHECK (status IN ('active', 'frozen', 'closed'));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallet_status;
+ALTER TABLE wallets DROP COLUMN IF EXISTS ledger_freeze_id;
+DROP TABLE IF EXISTS usd_reference_rates;
+DROP TABLE IF EXISTS kyc_tier_limits;
+DROP TABLE IF EXISTS tenant_status_changes;
+DROP TABLE IF EXISTS kyc_submissions;
+
+-- +goose StatementEnd
@@ -0,0 +1,92 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Audit trail: one row per mutating API call, written in the same transaction
+-- as the change. Rows of a compliance region form a hash chain: row_hash is
+-- SHA-256 over prev_hash and the row's fields, so modifying or deleting a row
+-- breaks the chain (see cmd/audit-verify).
+CREATE TABLE audit_trail (
+    id                      UUID NOT NULL,
+    compliance_region       TEXT NOT NULL,
+    -- Position in the region's chain, starting at 1 with no gaps
+    seq                     BIGINT NOT NULL,
+    tenant_id               UUID,
+    legal_entity_id         UUID,
+    resource_type           VARCHAR(50) NOT NULL,
+    resource_id             VARCHAR(100) NOT NULL,
+    action                  VARCHAR(50) NOT NULL,
+    actor_type              VARCHAR(20) NOT NULL,
+    actor_id                VARCHAR(100) NOT NULL,
+    before_state            JSONB,
+    after_state             JSONB,
+    ip_address              INET,
+    request_id              VARCHAR(100),
+    created_at              TIMESTAMPTZ NOT NULL,
+    -- Chain
+    prev_hash               BYTEA NOT NULL,
+    row_hash                BYTEA NOT NULL,
+
+    PRIMARY KEY (compliance_region, seq)
+) PARTITION BY LIST (compliance_region);
+
+CREATE TABLE audit_trail_id PARTITION OF audit_trail FOR VALUES IN ('ID');
+CREATE TABLE audit_trail_eu PARTITION OF audit_trail FOR VALUES IN ('EU');
+CREATE TABLE audit_trail_uk PARTITION OF audit_trail FOR VALUES IN ('UK');
+CREATE TABLE audit_trail_unknown PARTITION OF audit_trail FOR VALUES IN ('UNKNOWN');
+
+CREATE INDEX idx_audit_trail_tenant ON audit_trail(tenant_id, created_at DESC);
+CREATE INDEX idx_audit_trail_resource ON audit_trail(resource_type, resource_id, created_at DESC);
+CREATE INDEX idx_audit_trail_request ON audit_trail(request_id) WHERE request_id IS NOT NULL;
+
+-- Head of each region's chain. Appending locks the head row, which orders
+-- the writers of a region; it also records the last row so deleting the
+-- tail of a chain is detected.
+CREATE TABLE audit_chain_heads (
+    compliance_region       TEXT PRIMARY KEY,
+    seq                     BIGINT NOT NULL DEFAULT 0,
+    row_hash                BYTEA NOT NULL,
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Chains start from an all-zero hash
+INSERT INTO audit_chain_heads (compliance_region, row_hash) VALUES
+    ('ID', decode(repeat('00', 32), 'hex')),
+    ('EU', decode(repeat('00', 32), 'hex')),
+    ('UK', decode(repeat('00', 32), 'hex')),
+    ('UNKNOWN', decode(repeat('00', 32), 'hex'));
+
+-- Callers authenticate with a tenant API key, looked up by its hash
+CREATE UNIQUE INDEX idx_tenants_api_key_hash ON tenants(api_key_hash) WHERE api_key_hash IS NOT NULL;
+
+-- Immutability
+CREATE OR REPLACE FUNCTION prevent_audit_modification() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'Audit trail records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_trail_immutable BEFORE UPDATE OR DELETE ON audit_trail
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_modification();
+
+CREATE TRIGGER audit_trail_no_truncate BEFORE TRUNCATE ON audit_trail
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_modification();
+CREATE TRIGGER audit_trail_id_no_truncate BEFORE TRUNCATE ON audit_trail_id
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_modification();
+CREATE TRIGGER audit_trail_eu_no_truncate BEFORE TRUNCATE ON audit_trail_eu
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_modification();
+CREATE TRIGGER audit_trail_uk_no_truncate BEFORE TRUNCATE ON audit_trail_uk
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_modification();
+CREATE TRIGGER audit_trail_unknown_no_truncate BEFORE TRUNCATE ON audit_trail_unknown
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_modification();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tenants_api_key_hash;
+DROP TABLE IF EXISTS audit_chain_heads;
+DROP TABLE IF EXISTS audit_trail;
+DROP FUNCTION IF EXISTS prevent_audit_modification();
+
+-- +goose StatementEnd
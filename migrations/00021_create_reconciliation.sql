@@ -0,0 +1,76 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Closing balances of the legal entities' bank accounts, one per account,
+-- currency and statement date. account_kind: fbo | nostro
+CREATE TABLE bank_balances (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    legal_entity_id         UUID NOT NULL REFERENCES legal_entities(id),
+    account_kind            VARCHAR(10) NOT NULL,
+    currency                CHAR(3) NOT NULL,
+    balance                 NUMERIC(20,2) NOT NULL,
+    as_of                   DATE NOT NULL,
+    -- manual, or the statement format it was read from
+    source                  VARCHAR(30) NOT NULL DEFAULT 'manual',
+    recorded_by             VARCHAR(100) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_bank_balance_account_kind CHECK (account_kind IN ('fbo', 'nostro')),
+    CONSTRAINT unique_bank_balance UNIQUE (legal_entity_id, account_kind, currency, as_of)
+);
+
+-- Daily reconciliation of the ledger against the bank and PostgreSQL.
+-- status: pending_review → signed_off
+CREATE TABLE reconciliation_reports (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    business_date           DATE NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'pending_review',
+    balances_checked        INTEGER NOT NULL DEFAULT 0,
+    transfers_checked       INTEGER NOT NULL DEFAULT 0,
+    discrepancy_count       INTEGER NOT NULL DEFAULT 0,
+    -- Sign-off
+    signed_off_by           VARCHAR(100),
+    sign_off_note           TEXT,
+    signed_off_at           TIMESTAMPTZ,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_reconciliation_report_status CHECK (status IN ('pending_review', 'signed_off'))
+);
+
+CREATE INDEX idx_reconciliation_reports_date ON reconciliation_reports(business_date DESC, created_at DESC);
+
+-- One row per discrepancy found by a report. Amounts are in major units;
+-- difference = ledger_amount - bank_amount.
+-- kind: fbo_mismatch | nostro_mismatch | missing_bank_balance | missing_ledger_account
+--       | missing_ledger_transfer
+CREATE TABLE reconciliation_discrepancies (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    report_id               UUID NOT NULL REFERENCES reconciliation_reports(id) ON DELETE CASCADE,
+    kind                    VARCHAR(30) NOT NULL,
+    legal_entity_id         UUID REFERENCES legal_entities(id),
+    currency                CHAR(3),
+    transfer_id             UUID,
+    ledger_amount           NUMERIC(20,2),
+    bank_amount             NUMERIC(20,2),
+    difference              NUMERIC(20,2),
+    bank_balance_as_of      DATE,
+    detail                  TEXT NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reconciliation_discrepancies_report ON reconciliation_discrepancies(report_id);
+
+-- Reconciliation walks transfers that reference ledger transfers by id
+CREATE INDEX idx_transfers_tb_transfer_ids ON transfers(id) WHERE tb_transfer_ids IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transfers_tb_transfer_ids;
+DROP TABLE IF EXISTS reconciliation_discrepancies;
+DROP TABLE IF EXISTS reconciliation_reports;
+DROP TABLE IF EXISTS bank_balances;
+
+-- +goose StatementEnd
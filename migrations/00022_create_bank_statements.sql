@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Bank statements imported for the legal entities' FBO and Nostro accounts.
+-- format: camt053 | mt940 | csv
+-- external_id is the bank's statement reference, or a hash of the file when
+-- the format has none.
+CREATE TABLE bank_statements (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    legal_entity_id         UUID NOT NULL REFERENCES legal_entities(id),
+    account_kind            VARCHAR(10) NOT NULL,
+    account_identifier      VARCHAR(50) NOT NULL,
+    currency                CHAR(3) NOT NULL,
+    format                  VARCHAR(10) NOT NULL,
+    external_id             VARCHAR(100) NOT NULL,
+    opening_balance         NUMERIC(20,2),
+    closing_balance         NUMERIC(20,2),
+    from_date               DATE,
+    to_date                 DATE NOT NULL,
+    entry_count             INTEGER NOT NULL DEFAULT 0,
+    imported_by             VARCHAR(100) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_bank_statement_account_kind CHECK (account_kind IN ('fbo', 'nostro')),
+    CONSTRAINT chk_bank_statement_format CHECK (format IN ('camt053', 'mt940', 'csv')),
+    CONSTRAINT unique_bank_statement UNIQUE (legal_entity_id, account_kind, currency, external_id)
+);
+
+CREATE INDEX idx_bank_statements_account ON bank_statements(legal_entity_id, account_kind, to_date DESC);
+
+-- Booked entries of a statement. amount is signed: credits are positive,
+-- debits negative.
+CREATE TABLE bank_statement_entries (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    statement_id            UUID NOT NULL REFERENCES bank_statements(id) ON DELETE CASCADE,
+    legal_entity_id         UUID NOT NULL REFERENCES legal_entities(id),
+    account_kind            VARCHAR(10) NOT NULL,
+    amount                  NUMERIC(20,2) NOT NULL,
+    currency                CHAR(3) NOT NULL,
+    value_date              DATE NOT NULL,
+    booking_date            DATE,
+    reference               TEXT NOT NULL DEFAULT '',
+    counterparty            TEXT,
+    bank_reference          VARCHAR(100),
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_bank_statement_entries_statement ON bank_statement_entries(statement_id);
+CREATE INDEX idx_bank_statement_entries_account ON bank_statement_entries(legal_entity_id, account_kind, value_date);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bank_statement_entries;
+DROP TABLE IF EXISTS bank_statements;
+
+-- +goose StatementEnd
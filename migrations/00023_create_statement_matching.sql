@@ -0,0 +1,79 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Deposits a tenant announces before paying into the FBO account of its
+-- legal entity. A statement credit matched to one funds the tenant's wallet.
+-- status: pending | matched | cancelled
+CREATE TABLE expected_deposits (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    tenant_id               UUID NOT NULL REFERENCES tenants(id),
+    legal_entity_id         UUID NOT NULL REFERENCES legal_entities(id),
+    currency                CHAR(3) NOT NULL,
+    amount                  NUMERIC(20,2) NOT NULL,
+    reference               VARCHAR(100) NOT NULL,
+    expected_date           DATE NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_by              VARCHAR(100) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_expected_deposit_amount CHECK (amount > 0),
+    CONSTRAINT chk_expected_deposit_status CHECK (status IN ('pending', 'matched', 'cancelled')),
+    CONSTRAINT unique_expected_deposit_reference UNIQUE (tenant_id, reference)
+);
+
+CREATE INDEX idx_expected_deposits_pending ON expected_deposits(legal_entity_id, currency) WHERE status = 'pending';
+CREATE INDEX idx_expected_deposits_tenant ON expected_deposits(tenant_id, created_at DESC);
+
+-- match_status: unmatched | matched | suspense
+-- parked_at is set when an inbound credit is parked in the suspense account
+-- and kept once it is resolved.
+ALTER TABLE bank_statement_entries
+    ADD COLUMN match_status VARCHAR(20) NOT NULL DEFAULT 'unmatched',
+    ADD COLUMN parked_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_bank_statement_entry_match_status CHECK (match_status IN ('unmatched', 'matched', 'suspense'));
+
+CREATE INDEX idx_bank_statement_entries_open ON bank_statement_entries(legal_entity_id, account_kind, currency)
+    WHERE match_status <> 'matched';
+
+-- What a statement entry was matched to. A netted or batched entry has one
+-- row per transfer it settles. Transfers are partitioned, so transfer_id has
+-- no foreign key.
+-- rule: exact | fuzzy | manual
+CREATE TABLE statement_matches (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    entry_id                UUID NOT NULL REFERENCES bank_statement_entries(id) ON DELETE CASCADE,
+    transfer_id             UUID,
+    expected_deposit_id     UUID REFERENCES expected_deposits(id),
+    amount                  NUMERIC(20,2) NOT NULL,
+    rule                    VARCHAR(10) NOT NULL,
+    matched_by              VARCHAR(100) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_statement_match_target CHECK ((transfer_id IS NULL) <> (expected_deposit_id IS NULL)),
+    CONSTRAINT chk_statement_match_rule CHECK (rule IN ('exact', 'fuzzy', 'manual'))
+);
+
+CREATE INDEX idx_statement_matches_entry ON statement_matches(entry_id);
+CREATE UNIQUE INDEX idx_statement_matches_transfer ON statement_matches(transfer_id) WHERE transfer_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_statement_matches_deposit ON statement_matches(expected_deposit_id) WHERE expected_deposit_id IS NOT NULL;
+
+-- Payouts are matched against Nostro debits by destination legal entity
+CREATE INDEX idx_transfers_dest_legal_entity ON transfers(dest_legal_entity_id, to_currency)
+    WHERE status IN ('processing', 'completed');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transfers_dest_legal_entity;
+DROP TABLE IF EXISTS statement_matches;
+DROP INDEX IF EXISTS idx_bank_statement_entries_open;
+ALTER TABLE bank_statement_entries
+    DROP CONSTRAINT IF EXISTS chk_bank_statement_entry_match_status,
+    DROP COLUMN IF EXISTS parked_at,
+    DROP COLUMN IF EXISTS match_status;
+DROP TABLE IF EXISTS expected_deposits;
+
+-- +goose StatementEnd
@@ -0,0 +1,106 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Liquidity limits of each legal entity's pre-funded Nostro account. The
+-- balance is the REGIONAL_SETTLEMENT account of the currency in TigerBeetle,
+-- cached here on every liquidity check. Legal entities sharing a currency
+-- share that account, so tb_account_id is not unique.
+CREATE TABLE regional_settlements (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    legal_entity_id         UUID NOT NULL REFERENCES legal_entities(id),
+    currency                CHAR(3) NOT NULL,
+    tb_account_id           NUMERIC(39,0) NOT NULL,
+    cached_balance          NUMERIC(20,2) NOT NULL DEFAULT 0,
+    cached_at               TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    min_balance             NUMERIC(20,2) NOT NULL,
+    target_balance          NUMERIC(20,2) NOT NULL,
+    alert_threshold         NUMERIC(20,2) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_regional_settlement_limits CHECK (
+        min_balance >= 0 AND alert_threshold >= min_balance AND target_balance >= alert_threshold
+    ),
+    CONSTRAINT unique_entity_currency UNIQUE (legal_entity_id, currency)
+);
+
+-- Raised when the projected balance of a settlement account falls below its
+-- alert threshold (low) or its minimum balance (critical). A settlement has
+-- at most one open alert, escalated in place.
+-- level: low | critical
+-- status: open | resolved
+CREATE TABLE liquidity_alerts (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    settlement_id           UUID NOT NULL REFERENCES regional_settlements(id),
+    level                   VARCHAR(10) NOT NULL,
+    balance                 NUMERIC(20,2) NOT NULL,
+    queued_outflow          NUMERIC(20,2) NOT NULL,
+    projected_balance       NUMERIC(20,2) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at             TIMESTAMPTZ,
+
+    CONSTRAINT chk_liquidity_alert_level CHECK (level IN ('low', 'critical')),
+    CONSTRAINT chk_liquidity_alert_status CHECK (status IN ('open', 'resolved'))
+);
+
+CREATE UNIQUE INDEX idx_liquidity_alerts_open ON liquidity_alerts(settlement_id) WHERE status = 'open';
+CREATE INDEX idx_liquidity_alerts_created ON liquidity_alerts(created_at DESC);
+
+-- Instructions for treasury to pre-fund a settlement account back to its
+-- target balance. A settlement has at most one pending instruction, whose
+-- amount follows the latest shortfall.
+-- status: pending | completed | cancelled
+CREATE TABLE liquidity_top_ups (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    settlement_id           UUID NOT NULL REFERENCES regional_settlements(id),
+    amount                  NUMERIC(20,2) NOT NULL,
+    projected_balance       NUMERIC(20,2) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decided_by              VARCHAR(100),
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_liquidity_top_up_amount CHECK (amount > 0),
+    CONSTRAINT chk_liquidity_top_up_status CHECK (status IN ('pending', 'completed', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX idx_liquidity_top_ups_pending ON liquidity_top_ups(settlement_id) WHERE status = 'pending';
+CREATE INDEX idx_liquidity_top_ups_created ON liquidity_top_ups(created_at DESC);
+
+-- Payouts held because paying them would take a settlement account below
+-- its minimum balance. The transfer stays in validating until the hold is
+-- released. Transfers are partitioned, so transfer_id has no foreign key.
+-- status: held | released | cancelled
+CREATE TABLE liquidity_holds (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    settlement_id           UUID NOT NULL REFERENCES regional_settlements(id),
+    transfer_id             UUID NOT NULL,
+    amount                  NUMERIC(20,2) NOT NULL,
+    status                  VARCHAR(20) NOT NULL DEFAULT 'held',
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    released_at             TIMESTAMPTZ,
+
+    CONSTRAINT chk_liquidity_hold_status CHECK (status IN ('held', 'released', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX idx_liquidity_holds_transfer ON liquidity_holds(transfer_id) WHERE status = 'held';
+CREATE INDEX idx_liquidity_holds_settlement ON liquidity_holds(settlement_id, id) WHERE status = 'held';
+
+-- Queued payouts are summed per destination legal entity for the forecast
+CREATE INDEX idx_transfers_queued_payouts ON transfers(dest_legal_entity_id, to_currency)
+    WHERE status IN ('created', 'validating', 'processing');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transfers_queued_payouts;
+DROP TABLE IF EXISTS liquidity_holds;
+DROP TABLE IF EXISTS liquidity_top_ups;
+DROP TABLE IF EXISTS liquidity_alerts;
+DROP TABLE IF EXISTS regional_settlements;
+
+-- +goose StatementEnd
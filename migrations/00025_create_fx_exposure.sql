@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The margin captured on the quote of each FX transfer, against the mid
+-- rate implied by the USD reference rates when the transfer was created.
+-- The source amount is credited to FX_SETTLEMENT in the source currency and
+-- the destination amount debited in the destination currency, so the USD
+-- rates here are the cost of the position each transfer opens.
+-- margin is in the destination currency.
+CREATE TABLE fx_quote_margins (
+    transfer_id             UUID PRIMARY KEY,
+    from_currency           CHAR(3) NOT NULL,
+    to_currency             CHAR(3) NOT NULL,
+    from_amount             NUMERIC(20,2) NOT NULL,
+    to_amount               NUMERIC(20,2) NOT NULL,
+    fx_rate                 NUMERIC(20,10) NOT NULL,
+    mid_rate                NUMERIC(20,10) NOT NULL,
+    from_usd_rate           NUMERIC(20,10) NOT NULL,
+    to_usd_rate             NUMERIC(20,10) NOT NULL,
+    margin                  NUMERIC(20,2) NOT NULL,
+    margin_usd              NUMERIC(20,2) NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Point-in-time FX exposure reports. positions holds the net position of
+-- every currency with its valuation; totals are cumulative since the first
+-- FX transfer.
+CREATE TABLE fx_exposure_snapshots (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    taken_at                TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    eur_usd_rate            NUMERIC(20,10) NOT NULL,
+    total_value_usd         NUMERIC(20,2) NOT NULL,
+    total_value_eur         NUMERIC(20,2) NOT NULL,
+    realised_pnl_usd        NUMERIC(20,2) NOT NULL,
+    unrealised_pnl_usd      NUMERIC(20,2) NOT NULL,
+    positions               JSONB NOT NULL
+);
+
+CREATE INDEX idx_fx_exposure_snapshots_taken ON fx_exposure_snapshots(taken_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS fx_exposure_snapshots;
+DROP TABLE IF EXISTS fx_quote_margins;
+
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Movements of funds between system accounts booked by treasury: a
+-- rebalance between accounts, or an external FX deal covering FX_SETTLEMENT
+-- positions. A movement is requested by one operator and approved by
+-- another before it is posted to the ledger.
+CREATE TABLE treasury_movements (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    kind                    TEXT NOT NULL CHECK (kind IN ('rebalance', 'fx_deal')),
+    status                  TEXT NOT NULL DEFAULT 'pending'
+                                CHECK (status IN ('pending', 'approved', 'rejected', 'posted')),
+    reason                  TEXT NOT NULL,
+    -- The deal with the external counterparty, for FX deals
+    counterparty            TEXT,
+    deal_reference          TEXT,
+    requested_by            TEXT NOT NULL,
+    decided_by              TEXT,
+    decision_reason         TEXT,
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at              TIMESTAMPTZ,
+    posted_at               TIMESTAMPTZ,
+    CHECK (decided_by IS NULL OR decided_by <> requested_by),
+    CHECK (kind <> 'fx_deal' OR (counterparty IS NOT NULL AND deal_reference IS NOT NULL))
+);
+
+CREATE INDEX idx_treasury_movements_status ON treasury_movements(status, created_at DESC);
+
+-- A deal is booked once
+CREATE UNIQUE INDEX idx_treasury_movements_deal ON treasury_movements(counterparty, deal_reference)
+    WHERE kind = 'fx_deal' AND status <> 'rejected';
+
+-- The ledger transfers of a movement. Legs in one currency are posted as a
+-- linked chain. usd_rate is the reference rate when the movement was
+-- requested, the cost of what the leg moves in or out of FX_SETTLEMENT.
+CREATE TABLE treasury_movement_legs (
+    movement_id             UUID NOT NULL REFERENCES treasury_movements(id),
+    seq                     INT NOT NULL,
+    tb_transfer_id          UUID NOT NULL UNIQUE,
+    currency                CHAR(3) NOT NULL,
+    debit_account           TEXT NOT NULL,
+    credit_account          TEXT NOT NULL,
+    amount                  NUMERIC(20,2) NOT NULL CHECK (amount > 0),
+    usd_rate                NUMERIC(20,10),
+    PRIMARY KEY (movement_id, seq),
+    CHECK (debit_account <> credit_account)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS treasury_movement_legs;
+DROP TABLE IF EXISTS treasury_movements;
+
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Refunds of completed transfers, for customer requests and rail returns
+-- such as SEPA R-transactions. amount is the refunded part of the payout and
+-- fee_amount the reversed part of the fee, both in the source currency;
+-- dest_amount is the refunded payout in the destination currency at the
+-- rate of the transfer. The refunds of a transfer never exceed its payout
+-- and fee, which the service checks under a lock on the transfer.
+CREATE TABLE refunds (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    transfer_id             UUID NOT NULL,
+    tenant_id               UUID NOT NULL,
+    -- Position among the refunds of the transfer, from 1
+    seq                     INT NOT NULL CHECK (seq > 0),
+    currency                CHAR(3) NOT NULL,
+    amount                  NUMERIC(20,2) NOT NULL CHECK (amount >= 0),
+    fee_amount              NUMERIC(20,2) NOT NULL DEFAULT 0 CHECK (fee_amount >= 0),
+    dest_currency           CHAR(3) NOT NULL,
+    dest_amount             NUMERIC(20,2) NOT NULL CHECK (dest_amount >= 0),
+    reason                  TEXT NOT NULL,
+    -- The return reason code of the rail, e.g. AC04 for a SEPA return
+    return_code             TEXT,
+    idempotency_key         VARCHAR(64),
+    status                  TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'posted')),
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    posted_at               TIMESTAMPTZ,
+    UNIQUE (transfer_id, seq),
+    UNIQUE (transfer_id, idempotency_key),
+    CHECK (amount + fee_amount > 0)
+);
+
+CREATE INDEX idx_refunds_tenant ON refunds(tenant_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS refunds;
+
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Status callbacks received from the payment rails, one row per event.
+-- A rail retries a callback until it is acknowledged, so a repeated event_id
+-- is a duplicate and is not applied again. outcome records whether the
+-- callback moved its transfer on, or was ignored because the transfer had
+-- already moved past it, e.g. an acceptance arriving after the settlement.
+CREATE TABLE rail_callbacks (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    rail                    rail_enum NOT NULL,
+    event_id                TEXT NOT NULL,
+    transfer_id             UUID NOT NULL,
+    rail_reference          TEXT,
+    status                  TEXT NOT NULL CHECK (status IN ('accepted', 'settled', 'rejected', 'returned')),
+    return_code             TEXT,
+    reason                  TEXT,
+    outcome                 TEXT NOT NULL CHECK (outcome IN ('applied', 'ignored')),
+    payload                 JSONB NOT NULL,
+    occurred_at             TIMESTAMPTZ,
+    received_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (rail, event_id)
+);
+
+CREATE INDEX idx_rail_callbacks_transfer ON rail_callbacks(transfer_id, received_at);
+
+-- Callbacks find their transfer by the reference the rail assigned to it
+CREATE INDEX idx_transfers_rail_reference ON transfers(rail, rail_reference)
+    WHERE rail_reference IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_transfers_rail_reference;
+DROP TABLE IF EXISTS rail_callbacks;
+
+-- +goose StatementEnd
\ No newline at end of file
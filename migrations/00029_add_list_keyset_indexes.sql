@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keyset pagination walks a tenant's transfers and wallets, and a legal
+-- entity's tenants, backwards by their UUIDv7 id; created-time bounds are
+-- mapped onto the same id range with ts_to_uuid_min/ts_to_uuid_max.
+CREATE INDEX idx_transfers_tenant_id ON transfers(tenant_id, id DESC);
+CREATE INDEX idx_wallets_tenant_id ON wallets(tenant_id, id DESC);
+CREATE INDEX idx_tenants_legal_entity_id ON tenants(legal_entity_id, id DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_tenants_legal_entity_id;
+DROP INDEX IF EXISTS idx_wallets_tenant_id;
+DROP INDEX IF EXISTS idx_transfers_tenant_id;
+
+-- +goose StatementEnd
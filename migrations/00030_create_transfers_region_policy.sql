@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The data residency policies of 00009 sit on the partitions, but Postgres
+-- applies only the policies of the parent to a query of the parent table, so
+-- a regional role selecting from transfers saw every region. This policy
+-- applies the same residency rules on the parent.
+ALTER TABLE transfers ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY regional_data_residency ON transfers
+    FOR ALL
+    TO PUBLIC
+    USING (
+        (compliance_region = 'ID' AND pg_has_role(current_user, 'kovra_id_region', 'MEMBER')) OR
+        (compliance_region = 'EU' AND pg_has_role(current_user, 'kovra_eu_region', 'MEMBER')) OR
+        (compliance_region = 'UK' AND pg_has_role(current_user, 'kovra_uk_region', 'MEMBER')) OR
+        pg_has_role(current_user, 'kovra_global', 'MEMBER') OR
+        current_user = 'kovra'
+    );
+
+-- Exports read transfers as the role of their region, which the app user
+-- switches to with SET LOCAL ROLE
+GRANT SELECT ON transfers TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+GRANT kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global TO kovra;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+REVOKE kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global FROM kovra;
+REVOKE SELECT ON transfers FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+DROP POLICY IF EXISTS regional_data_residency ON transfers;
+ALTER TABLE transfers DISABLE ROW LEVEL SECURITY;
+
+-- +goose StatementEnd
@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Operators are staff calling the API with their own key. A regional
+-- operator's queries run as the role of their compliance region, so the
+-- data residency policies on transfers show them only that region's
+-- partition; an operator without a region reads every region.
+CREATE TABLE operators (
+    id                      UUID PRIMARY KEY DEFAULT uuidv7(),
+    name                    TEXT NOT NULL,
+    -- SHA-256 of the operator's API key, as for tenants
+    api_key_hash            VARCHAR(64) NOT NULL UNIQUE,
+    compliance_region       TEXT CHECK (compliance_region IN ('ID', 'EU', 'UK')),
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The regional roles run every query of a regional operator, so they need
+-- the privileges of the app user; row level security narrows what they see
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    REVOKE USAGE, SELECT ON SEQUENCES
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+REVOKE USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+REVOKE INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+REVOKE SELECT ON ALL TABLES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+-- Exports read transfers as the regional roles since 00030
+GRANT SELECT ON transfers TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+DROP TABLE IF EXISTS operators;
+
+-- +goose StatementEnd
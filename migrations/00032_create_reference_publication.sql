@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Regional databases hold the transfers of their compliance region and
+-- everything written with them. The reference data a transfer reads is
+-- written on the primary only and replicated to each regional database,
+-- which subscribes to this publication (see cmd/region-setup). The primary
+-- needs wal_level = logical.
+CREATE PUBLICATION kovra_reference FOR TABLE
+    legal_entities,
+    tenants,
+    tenant_capabilities,
+    wallets,
+    recipients,
+    kyc_tier_limits,
+    limit_policies,
+    pricing_policies,
+    usd_reference_rates,
+    regional_settlements;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP PUBLICATION IF EXISTS kovra_reference;
+
+-- +goose StatementEnd
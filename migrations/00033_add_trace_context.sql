@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The W3C trace context (traceparent, tracestate) of the operation that
+-- wrote the row, so the relay, dispatcher and job workers picking it up later
+-- continue the same trace. NULL when the writer was not traced.
+ALTER TABLE outbox ADD COLUMN trace_context JSONB;
+ALTER TABLE webhook_deliveries ADD COLUMN trace_context JSONB;
+ALTER TABLE jobs ADD COLUMN trace_context JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS trace_context;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS trace_context;
+ALTER TABLE outbox DROP COLUMN IF EXISTS trace_context;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Set, together with the wallet.credited event, once the matched deposit is
+-- credited to the tenant's wallet, so that a retried booking does not
+-- announce the credit twice.
+ALTER TABLE expected_deposits ADD COLUMN credited_at TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE expected_deposits DROP COLUMN IF EXISTS credited_at;
+
+-- +goose StatementEnd
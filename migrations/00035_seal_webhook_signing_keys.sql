@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The column holds the key webhooks are signed with, which is as good as the
+-- tenant's secret, not a hash of it. The service seals it with the key
+-- encryption key; plaintext keys stored before are sealed at startup.
+ALTER TABLE tenants RENAME COLUMN webhook_secret_hash TO webhook_signing_key;
+ALTER TABLE tenants ALTER COLUMN webhook_signing_key TYPE TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- Sealed keys cannot be unsealed here: their tenants must rotate the secret.
+UPDATE tenants SET webhook_signing_key = NULL WHERE webhook_signing_key LIKE 'v1:%';
+ALTER TABLE tenants ALTER COLUMN webhook_signing_key TYPE VARCHAR(64);
+ALTER TABLE tenants RENAME COLUMN webhook_signing_key TO webhook_secret_hash;
+
+-- +goose StatementEnd
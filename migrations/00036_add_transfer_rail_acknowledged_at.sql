@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Set when the rail accepts the payout of a transfer, after which it can no
+-- longer be cancelled. A rail may accept a payout without assigning it a
+-- reference, so the reference does not tell whether it was accepted.
+ALTER TABLE transfers ADD COLUMN rail_acknowledged_at TIMESTAMPTZ;
+
+-- Payouts accepted before the column existed
+UPDATE transfers t
+SET rail_acknowledged_at = c.received_at
+FROM (
+    SELECT transfer_id, MIN(received_at) AS received_at
+    FROM rail_callbacks
+    WHERE outcome = 'applied'
+    GROUP BY transfer_id
+) c
+WHERE t.id = c.transfer_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE transfers DROP COLUMN IF EXISTS rail_acknowledged_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,146 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- 00031 granted the regional roles every privilege of the app user on every
+-- table, though only transfers had a data residency policy. The roles now
+-- get what the queries confined to a region need, table by table, and the
+-- region-scoped tables among them get a policy like that of transfers.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    REVOKE USAGE, SELECT ON SEQUENCES
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+REVOKE ALL ON ALL TABLES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+-- Exports read transfers as the regional roles, or as the global role
+-- across regions
+GRANT SELECT ON transfers TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+-- Reference data, written on the primary by unconfined queries only
+GRANT SELECT ON legal_entities, tenants, tenant_capabilities, wallets, recipients,
+    kyc_tier_limits, limit_policies, pricing_policies, usd_reference_rates, regional_settlements
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+-- Checking a tenant's limits locks its row, which takes an UPDATE privilege
+GRANT UPDATE (updated_at) ON tenants TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+
+-- Transfers and what is written with them
+GRANT INSERT, UPDATE ON transfers TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT, INSERT ON refunds TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT, UPDATE ON liquidity_holds TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT, INSERT, DELETE ON fx_quote_margins TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT ON compliance_logs, compliance_cases TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT INSERT ON outbox TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT, INSERT ON jobs TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+
+-- The audit trail is only appended to. The regional roles may not update a
+-- chain head: lock_audit_chain_head locks it for them, and inserting the
+-- next entry advances it.
+GRANT SELECT, INSERT ON audit_trail TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+GRANT SELECT ON audit_chain_heads TO kovra_id_region, kovra_eu_region, kovra_uk_region;
+
+CREATE OR REPLACE FUNCTION lock_audit_chain_head(region TEXT) RETURNS SETOF audit_chain_heads
+SECURITY DEFINER SET search_path = public AS $$
+    SELECT * FROM audit_chain_heads WHERE compliance_region = region FOR UPDATE;
+$$ LANGUAGE sql;
+
+-- Moves the head of the chain to a new entry, which must follow it
+CREATE OR REPLACE FUNCTION advance_audit_chain_head() RETURNS TRIGGER
+SECURITY DEFINER SET search_path = public AS $$
+BEGIN
+    UPDATE audit_chain_heads
+    SET seq = NEW.seq, row_hash = NEW.row_hash, updated_at = NOW()
+    WHERE compliance_region = NEW.compliance_region
+        AND seq = NEW.seq - 1
+        AND row_hash = NEW.prev_hash;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'audit entry % does not follow the head of the % chain', NEW.seq, NEW.compliance_region;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_trail_advance_chain AFTER INSERT ON audit_trail
+    FOR EACH ROW EXECUTE FUNCTION advance_audit_chain_head();
+
+-- Data residency on the region-scoped tables the regional roles reach, with
+-- the rules of transfers. Tables without a region of their own follow the
+-- transfer they belong to, which the policy on transfers hides.
+CREATE OR REPLACE FUNCTION region_visible(region TEXT) RETURNS BOOLEAN AS $$
+    SELECT (region = 'ID' AND pg_has_role(current_user, 'kovra_id_region', 'MEMBER')) OR
+        (region = 'EU' AND pg_has_role(current_user, 'kovra_eu_region', 'MEMBER')) OR
+        (region = 'UK' AND pg_has_role(current_user, 'kovra_uk_region', 'MEMBER')) OR
+        pg_has_role(current_user, 'kovra_global', 'MEMBER') OR
+        current_user = 'kovra';
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE compliance_logs ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON compliance_logs
+    FOR ALL TO PUBLIC
+    USING (region_visible(compliance_region));
+
+ALTER TABLE compliance_cases ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON compliance_cases
+    FOR ALL TO PUBLIC
+    USING (region_visible(compliance_region));
+
+ALTER TABLE audit_trail ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON audit_trail
+    FOR ALL TO PUBLIC
+    USING (region_visible(compliance_region));
+
+ALTER TABLE refunds ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON refunds
+    FOR ALL TO PUBLIC
+    USING (EXISTS (SELECT 1 FROM transfers t WHERE t.id = refunds.transfer_id));
+
+ALTER TABLE liquidity_holds ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON liquidity_holds
+    FOR ALL TO PUBLIC
+    USING (EXISTS (SELECT 1 FROM transfers t WHERE t.id = liquidity_holds.transfer_id));
+
+ALTER TABLE fx_quote_margins ENABLE ROW LEVEL SECURITY;
+CREATE POLICY regional_data_residency ON fx_quote_margins
+    FOR ALL TO PUBLIC
+    USING (EXISTS (SELECT 1 FROM transfers t WHERE t.id = fx_quote_margins.transfer_id));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP POLICY IF EXISTS regional_data_residency ON fx_quote_margins;
+ALTER TABLE fx_quote_margins DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS regional_data_residency ON liquidity_holds;
+ALTER TABLE liquidity_holds DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS regional_data_residency ON refunds;
+ALTER TABLE refunds DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS regional_data_residency ON audit_trail;
+ALTER TABLE audit_trail DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS regional_data_residency ON compliance_cases;
+ALTER TABLE compliance_cases DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS regional_data_residency ON compliance_logs;
+ALTER TABLE compliance_logs DISABLE ROW LEVEL SECURITY;
+DROP FUNCTION IF EXISTS region_visible(TEXT);
+
+DROP TRIGGER IF EXISTS audit_trail_advance_chain ON audit_trail;
+DROP FUNCTION IF EXISTS advance_audit_chain_head();
+DROP FUNCTION IF EXISTS lock_audit_chain_head(TEXT);
+
+REVOKE ALL ON ALL TABLES IN SCHEMA public
+    FROM kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES
+    TO kovra_id_region, kovra_eu_region, kovra_uk_region, kovra_global;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+// Package migrations embeds the goose migrations of the database schema, so
+// the service can tell which schema version it expects.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Latest returns the version of the newest migration, from the numeric
+// prefix of its file name.
+func Latest() (int64, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("migration %s has no version prefix", name)
+		}
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: %w", name, err)
+		}
+		latest = max(latest, version)
+	}
+	return latest, nil
+}